@@ -7,7 +7,7 @@
 package adhocprofilesv1
 
 import (
-	_ "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	v1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -392,6 +392,433 @@ func (x *AdHocProfilesProfileMetadata) GetUploadedAt() int64 {
 	return 0
 }
 
+type AdHocProfilesDiffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier of the baseline profile.
+	LeftId string `protobuf:"bytes,1,opt,name=left_id,json=leftId,proto3" json:"left_id,omitempty"`
+	// The unique identifier of the profile compared to the baseline.
+	RightId string `protobuf:"bytes,2,opt,name=right_id,json=rightId,proto3" json:"right_id,omitempty"`
+	// The desired profile type of the baseline profile. If omitted the first profile is used.
+	LeftProfileType *string `protobuf:"bytes,3,opt,name=left_profile_type,json=leftProfileType,proto3,oneof" json:"left_profile_type,omitempty"`
+	// The desired profile type of the compared profile. If omitted the first profile is used.
+	RightProfileType *string `protobuf:"bytes,4,opt,name=right_profile_type,json=rightProfileType,proto3,oneof" json:"right_profile_type,omitempty"`
+	// Max nodes can be used to truncate the response.
+	MaxNodes *int64 `protobuf:"varint,5,opt,name=max_nodes,json=maxNodes,proto3,oneof" json:"max_nodes,omitempty"`
+}
+
+func (x *AdHocProfilesDiffRequest) Reset() {
+	*x = AdHocProfilesDiffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesDiffRequest) ProtoMessage() {}
+
+func (x *AdHocProfilesDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesDiffRequest.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesDiffRequest) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AdHocProfilesDiffRequest) GetLeftId() string {
+	if x != nil {
+		return x.LeftId
+	}
+	return ""
+}
+
+func (x *AdHocProfilesDiffRequest) GetRightId() string {
+	if x != nil {
+		return x.RightId
+	}
+	return ""
+}
+
+func (x *AdHocProfilesDiffRequest) GetLeftProfileType() string {
+	if x != nil && x.LeftProfileType != nil {
+		return *x.LeftProfileType
+	}
+	return ""
+}
+
+func (x *AdHocProfilesDiffRequest) GetRightProfileType() string {
+	if x != nil && x.RightProfileType != nil {
+		return *x.RightProfileType
+	}
+	return ""
+}
+
+func (x *AdHocProfilesDiffRequest) GetMaxNodes() int64 {
+	if x != nil && x.MaxNodes != nil {
+		return *x.MaxNodes
+	}
+	return 0
+}
+
+type AdHocProfilesDiffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FlamebearerProfile string `protobuf:"bytes,1,opt,name=flamebearer_profile,json=flamebearerProfile,proto3" json:"flamebearer_profile,omitempty"`
+}
+
+func (x *AdHocProfilesDiffResponse) Reset() {
+	*x = AdHocProfilesDiffResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesDiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesDiffResponse) ProtoMessage() {}
+
+func (x *AdHocProfilesDiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesDiffResponse.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesDiffResponse) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AdHocProfilesDiffResponse) GetFlamebearerProfile() string {
+	if x != nil {
+		return x.FlamebearerProfile
+	}
+	return ""
+}
+
+type AdHocProfilesDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier of the profile.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *AdHocProfilesDeleteRequest) Reset() {
+	*x = AdHocProfilesDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesDeleteRequest) ProtoMessage() {}
+
+func (x *AdHocProfilesDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesDeleteRequest.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AdHocProfilesDeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AdHocProfilesDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AdHocProfilesDeleteResponse) Reset() {
+	*x = AdHocProfilesDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesDeleteResponse) ProtoMessage() {}
+
+func (x *AdHocProfilesDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesDeleteResponse.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{9}
+}
+
+type AdHocProfilesRenameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier of the profile.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The new human readable name of the profile.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *AdHocProfilesRenameRequest) Reset() {
+	*x = AdHocProfilesRenameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesRenameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesRenameRequest) ProtoMessage() {}
+
+func (x *AdHocProfilesRenameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesRenameRequest.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesRenameRequest) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AdHocProfilesRenameRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdHocProfilesRenameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AdHocProfilesRenameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile *AdHocProfilesProfileMetadata `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *AdHocProfilesRenameResponse) Reset() {
+	*x = AdHocProfilesRenameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesRenameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesRenameResponse) ProtoMessage() {}
+
+func (x *AdHocProfilesRenameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesRenameResponse.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesRenameResponse) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AdHocProfilesRenameResponse) GetProfile() *AdHocProfilesProfileMetadata {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type AdHocProfilesImportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier of the profile.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The time of the imported profile in milliseconds. If omitted, the upload time of the profile is used. The time must
+	// be within the ingestion window of the tenant.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The labels of the imported profile series. The __name__ label is required (e.g., process_cpu, memory), and
+	// service_name should identify the service the profile belongs to.
+	Labels []*v1.LabelPair `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+}
+
+func (x *AdHocProfilesImportRequest) Reset() {
+	*x = AdHocProfilesImportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesImportRequest) ProtoMessage() {}
+
+func (x *AdHocProfilesImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesImportRequest.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesImportRequest) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AdHocProfilesImportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdHocProfilesImportRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AdHocProfilesImportRequest) GetLabels() []*v1.LabelPair {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type AdHocProfilesImportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AdHocProfilesImportResponse) Reset() {
+	*x = AdHocProfilesImportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdHocProfilesImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdHocProfilesImportResponse) ProtoMessage() {}
+
+func (x *AdHocProfilesImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdHocProfilesImportResponse.ProtoReflect.Descriptor instead.
+func (*AdHocProfilesImportResponse) Descriptor() ([]byte, []int) {
+	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP(), []int{13}
+}
+
 var File_adhocprofiles_v1_adhocprofiles_proto protoreflect.FileDescriptor
 
 var file_adhocprofiles_v1_adhocprofiles_proto_rawDesc = []byte{
@@ -445,7 +872,54 @@ var file_adhocprofiles_v1_adhocprofiles_proto_rawDesc = []byte{
 	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x5f,
 	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x65, 0x64, 0x41, 0x74, 0x32, 0xbe, 0x02, 0x0a, 0x13, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x8f, 0x02, 0x0a, 0x18, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x65, 0x66, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x11, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x0f, 0x6c, 0x65, 0x66, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x01, 0x52, 0x10, 0x72, 0x69, 0x67, 0x68, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x08,
+	0x6d, 0x61, 0x78, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42, 0x14, 0x0a, 0x12, 0x5f,
+	0x6c, 0x65, 0x66, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6d, 0x61, 0x78,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x4c, 0x0a, 0x19, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x66, 0x6c, 0x61, 0x6d, 0x65, 0x62, 0x65, 0x61, 0x72,
+	0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x12, 0x66, 0x6c, 0x61, 0x6d, 0x65, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0x2c, 0x0a, 0x1a, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x40, 0x0a, 0x1a, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x67, 0x0a, 0x1b, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x77, 0x0a, 0x1a,
+	0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2b, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x50, 0x61, 0x69, 0x72, 0x52, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0x1d, 0x0a, 0x1b, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0xdc, 0x05, 0x0a, 0x13, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72,
 	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x64, 0x0a, 0x06,
 	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2c, 0x2e, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72,
 	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50,
@@ -465,21 +939,47 @@ var file_adhocprofiles_v1_adhocprofiles_proto_rawDesc = []byte{
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72,
 	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50,
 	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0xdb, 0x01, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x64,
-	0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x12,
-	0x41, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x4e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x67, 0x72, 0x61, 0x66, 0x61, 0x6e, 0x61, 0x2f, 0x70, 0x79, 0x72, 0x6f, 0x73, 0x63, 0x6f,
-	0x70, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
-	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x73, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x58, 0x58, 0xaa, 0x02, 0x10, 0x41, 0x64, 0x68,
-	0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x10,
-	0x41, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5c, 0x56, 0x31,
-	0xe2, 0x02, 0x1c, 0x41, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
-	0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea,
-	0x02, 0x11, 0x41, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x3a,
-	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x04, 0x44, 0x69, 0x66, 0x66, 0x12, 0x2a, 0x2e,
+	0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x44, 0x69,
+	0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x61, 0x64, 0x68, 0x6f,
+	0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48,
+	0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x2c, 0x2e, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2d, 0x2e, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x67, 0x0a, 0x06, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x2e, 0x61, 0x64,
+	0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x61, 0x64, 0x68, 0x6f,
+	0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48,
+	0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x06, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x2c, 0x2e, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x48, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0xdb, 0x01, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x64, 0x68, 0x6f,
+	0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x12, 0x41, 0x64,
+	0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x50, 0x01, 0x5a, 0x4e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67,
+	0x72, 0x61, 0x66, 0x61, 0x6e, 0x61, 0x2f, 0x70, 0x79, 0x72, 0x6f, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
+	0x6f, 0x2f, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2f,
+	0x76, 0x31, 0x3b, 0x61, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x58, 0x58, 0xaa, 0x02, 0x10, 0x41, 0x64, 0x68, 0x6f, 0x63,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x10, 0x41, 0x64,
+	0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5c, 0x56, 0x31, 0xe2, 0x02,
+	0x1c, 0x41, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5c, 0x56,
+	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x11,
+	0x41, 0x64, 0x68, 0x6f, 0x63, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x3a, 0x3a, 0x56,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -494,7 +994,7 @@ func file_adhocprofiles_v1_adhocprofiles_proto_rawDescGZIP() []byte {
 	return file_adhocprofiles_v1_adhocprofiles_proto_rawDescData
 }
 
-var file_adhocprofiles_v1_adhocprofiles_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_adhocprofiles_v1_adhocprofiles_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_adhocprofiles_v1_adhocprofiles_proto_goTypes = []interface{}{
 	(*AdHocProfilesUploadRequest)(nil),   // 0: adhocprofiles.v1.AdHocProfilesUploadRequest
 	(*AdHocProfilesGetRequest)(nil),      // 1: adhocprofiles.v1.AdHocProfilesGetRequest
@@ -502,20 +1002,39 @@ var file_adhocprofiles_v1_adhocprofiles_proto_goTypes = []interface{}{
 	(*AdHocProfilesListRequest)(nil),     // 3: adhocprofiles.v1.AdHocProfilesListRequest
 	(*AdHocProfilesListResponse)(nil),    // 4: adhocprofiles.v1.AdHocProfilesListResponse
 	(*AdHocProfilesProfileMetadata)(nil), // 5: adhocprofiles.v1.AdHocProfilesProfileMetadata
+	(*AdHocProfilesDiffRequest)(nil),     // 6: adhocprofiles.v1.AdHocProfilesDiffRequest
+	(*AdHocProfilesDiffResponse)(nil),    // 7: adhocprofiles.v1.AdHocProfilesDiffResponse
+	(*AdHocProfilesDeleteRequest)(nil),   // 8: adhocprofiles.v1.AdHocProfilesDeleteRequest
+	(*AdHocProfilesDeleteResponse)(nil),  // 9: adhocprofiles.v1.AdHocProfilesDeleteResponse
+	(*AdHocProfilesRenameRequest)(nil),   // 10: adhocprofiles.v1.AdHocProfilesRenameRequest
+	(*AdHocProfilesRenameResponse)(nil),  // 11: adhocprofiles.v1.AdHocProfilesRenameResponse
+	(*AdHocProfilesImportRequest)(nil),   // 12: adhocprofiles.v1.AdHocProfilesImportRequest
+	(*AdHocProfilesImportResponse)(nil),  // 13: adhocprofiles.v1.AdHocProfilesImportResponse
+	(*v1.LabelPair)(nil),                 // 14: types.v1.LabelPair
 }
 var file_adhocprofiles_v1_adhocprofiles_proto_depIdxs = []int32{
-	5, // 0: adhocprofiles.v1.AdHocProfilesListResponse.profiles:type_name -> adhocprofiles.v1.AdHocProfilesProfileMetadata
-	0, // 1: adhocprofiles.v1.AdHocProfileService.Upload:input_type -> adhocprofiles.v1.AdHocProfilesUploadRequest
-	1, // 2: adhocprofiles.v1.AdHocProfileService.Get:input_type -> adhocprofiles.v1.AdHocProfilesGetRequest
-	3, // 3: adhocprofiles.v1.AdHocProfileService.List:input_type -> adhocprofiles.v1.AdHocProfilesListRequest
-	2, // 4: adhocprofiles.v1.AdHocProfileService.Upload:output_type -> adhocprofiles.v1.AdHocProfilesGetResponse
-	2, // 5: adhocprofiles.v1.AdHocProfileService.Get:output_type -> adhocprofiles.v1.AdHocProfilesGetResponse
-	4, // 6: adhocprofiles.v1.AdHocProfileService.List:output_type -> adhocprofiles.v1.AdHocProfilesListResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5,  // 0: adhocprofiles.v1.AdHocProfilesListResponse.profiles:type_name -> adhocprofiles.v1.AdHocProfilesProfileMetadata
+	5,  // 1: adhocprofiles.v1.AdHocProfilesRenameResponse.profile:type_name -> adhocprofiles.v1.AdHocProfilesProfileMetadata
+	14, // 2: adhocprofiles.v1.AdHocProfilesImportRequest.labels:type_name -> types.v1.LabelPair
+	0,  // 3: adhocprofiles.v1.AdHocProfileService.Upload:input_type -> adhocprofiles.v1.AdHocProfilesUploadRequest
+	1,  // 4: adhocprofiles.v1.AdHocProfileService.Get:input_type -> adhocprofiles.v1.AdHocProfilesGetRequest
+	3,  // 5: adhocprofiles.v1.AdHocProfileService.List:input_type -> adhocprofiles.v1.AdHocProfilesListRequest
+	6,  // 6: adhocprofiles.v1.AdHocProfileService.Diff:input_type -> adhocprofiles.v1.AdHocProfilesDiffRequest
+	8,  // 7: adhocprofiles.v1.AdHocProfileService.Delete:input_type -> adhocprofiles.v1.AdHocProfilesDeleteRequest
+	10, // 8: adhocprofiles.v1.AdHocProfileService.Rename:input_type -> adhocprofiles.v1.AdHocProfilesRenameRequest
+	12, // 9: adhocprofiles.v1.AdHocProfileService.Import:input_type -> adhocprofiles.v1.AdHocProfilesImportRequest
+	2,  // 10: adhocprofiles.v1.AdHocProfileService.Upload:output_type -> adhocprofiles.v1.AdHocProfilesGetResponse
+	2,  // 11: adhocprofiles.v1.AdHocProfileService.Get:output_type -> adhocprofiles.v1.AdHocProfilesGetResponse
+	4,  // 12: adhocprofiles.v1.AdHocProfileService.List:output_type -> adhocprofiles.v1.AdHocProfilesListResponse
+	7,  // 13: adhocprofiles.v1.AdHocProfileService.Diff:output_type -> adhocprofiles.v1.AdHocProfilesDiffResponse
+	9,  // 14: adhocprofiles.v1.AdHocProfileService.Delete:output_type -> adhocprofiles.v1.AdHocProfilesDeleteResponse
+	11, // 15: adhocprofiles.v1.AdHocProfileService.Rename:output_type -> adhocprofiles.v1.AdHocProfilesRenameResponse
+	13, // 16: adhocprofiles.v1.AdHocProfileService.Import:output_type -> adhocprofiles.v1.AdHocProfilesImportResponse
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_adhocprofiles_v1_adhocprofiles_proto_init() }
@@ -596,16 +1115,113 @@ func file_adhocprofiles_v1_adhocprofiles_proto_init() {
 				return nil
 			}
 		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesDiffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesDiffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesRenameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesRenameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesImportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdHocProfilesImportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_adhocprofiles_v1_adhocprofiles_proto_msgTypes[6].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_adhocprofiles_v1_adhocprofiles_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
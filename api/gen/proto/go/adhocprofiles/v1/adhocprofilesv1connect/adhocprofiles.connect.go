@@ -41,6 +41,18 @@ const (
 	// AdHocProfileServiceListProcedure is the fully-qualified name of the AdHocProfileService's List
 	// RPC.
 	AdHocProfileServiceListProcedure = "/adhocprofiles.v1.AdHocProfileService/List"
+	// AdHocProfileServiceDiffProcedure is the fully-qualified name of the AdHocProfileService's Diff
+	// RPC.
+	AdHocProfileServiceDiffProcedure = "/adhocprofiles.v1.AdHocProfileService/Diff"
+	// AdHocProfileServiceDeleteProcedure is the fully-qualified name of the AdHocProfileService's
+	// Delete RPC.
+	AdHocProfileServiceDeleteProcedure = "/adhocprofiles.v1.AdHocProfileService/Delete"
+	// AdHocProfileServiceRenameProcedure is the fully-qualified name of the AdHocProfileService's
+	// Rename RPC.
+	AdHocProfileServiceRenameProcedure = "/adhocprofiles.v1.AdHocProfileService/Rename"
+	// AdHocProfileServiceImportProcedure is the fully-qualified name of the AdHocProfileService's
+	// Import RPC.
+	AdHocProfileServiceImportProcedure = "/adhocprofiles.v1.AdHocProfileService/Import"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
@@ -49,6 +61,10 @@ var (
 	adHocProfileServiceUploadMethodDescriptor = adHocProfileServiceServiceDescriptor.Methods().ByName("Upload")
 	adHocProfileServiceGetMethodDescriptor    = adHocProfileServiceServiceDescriptor.Methods().ByName("Get")
 	adHocProfileServiceListMethodDescriptor   = adHocProfileServiceServiceDescriptor.Methods().ByName("List")
+	adHocProfileServiceDiffMethodDescriptor   = adHocProfileServiceServiceDescriptor.Methods().ByName("Diff")
+	adHocProfileServiceDeleteMethodDescriptor = adHocProfileServiceServiceDescriptor.Methods().ByName("Delete")
+	adHocProfileServiceRenameMethodDescriptor = adHocProfileServiceServiceDescriptor.Methods().ByName("Rename")
+	adHocProfileServiceImportMethodDescriptor = adHocProfileServiceServiceDescriptor.Methods().ByName("Import")
 )
 
 // AdHocProfileServiceClient is a client for the adhocprofiles.v1.AdHocProfileService service.
@@ -61,6 +77,16 @@ type AdHocProfileServiceClient interface {
 	Get(context.Context, *connect.Request[v1.AdHocProfilesGetRequest]) (*connect.Response[v1.AdHocProfilesGetResponse], error)
 	// Retrieves a list of profiles found in the underlying store.
 	List(context.Context, *connect.Request[v1.AdHocProfilesListRequest]) (*connect.Response[v1.AdHocProfilesListResponse], error)
+	// Computes the difference between two profiles found in the underlying store. The response contains a flame graph in
+	// the diff format, where the left profile is the baseline and the right profile is compared to it.
+	Diff(context.Context, *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error)
+	// Deletes a profile from the underlying store.
+	Delete(context.Context, *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error)
+	// Renames a profile. As the identifier of a profile contains its name, the response contains the new identifier.
+	Rename(context.Context, *connect.Request[v1.AdHocProfilesRenameRequest]) (*connect.Response[v1.AdHocProfilesRenameResponse], error)
+	// Imports a profile found in the underlying store into the tenant's time series storage. The profile is ingested
+	// with the given timestamp and labels, and becomes queryable like any other profile.
+	Import(context.Context, *connect.Request[v1.AdHocProfilesImportRequest]) (*connect.Response[v1.AdHocProfilesImportResponse], error)
 }
 
 // NewAdHocProfileServiceClient constructs a client for the adhocprofiles.v1.AdHocProfileService
@@ -91,14 +117,42 @@ func NewAdHocProfileServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(adHocProfileServiceListMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		diff: connect.NewClient[v1.AdHocProfilesDiffRequest, v1.AdHocProfilesDiffResponse](
+			httpClient,
+			baseURL+AdHocProfileServiceDiffProcedure,
+			connect.WithSchema(adHocProfileServiceDiffMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.AdHocProfilesDeleteRequest, v1.AdHocProfilesDeleteResponse](
+			httpClient,
+			baseURL+AdHocProfileServiceDeleteProcedure,
+			connect.WithSchema(adHocProfileServiceDeleteMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		rename: connect.NewClient[v1.AdHocProfilesRenameRequest, v1.AdHocProfilesRenameResponse](
+			httpClient,
+			baseURL+AdHocProfileServiceRenameProcedure,
+			connect.WithSchema(adHocProfileServiceRenameMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		_import: connect.NewClient[v1.AdHocProfilesImportRequest, v1.AdHocProfilesImportResponse](
+			httpClient,
+			baseURL+AdHocProfileServiceImportProcedure,
+			connect.WithSchema(adHocProfileServiceImportMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // adHocProfileServiceClient implements AdHocProfileServiceClient.
 type adHocProfileServiceClient struct {
-	upload *connect.Client[v1.AdHocProfilesUploadRequest, v1.AdHocProfilesGetResponse]
-	get    *connect.Client[v1.AdHocProfilesGetRequest, v1.AdHocProfilesGetResponse]
-	list   *connect.Client[v1.AdHocProfilesListRequest, v1.AdHocProfilesListResponse]
+	upload  *connect.Client[v1.AdHocProfilesUploadRequest, v1.AdHocProfilesGetResponse]
+	get     *connect.Client[v1.AdHocProfilesGetRequest, v1.AdHocProfilesGetResponse]
+	list    *connect.Client[v1.AdHocProfilesListRequest, v1.AdHocProfilesListResponse]
+	diff    *connect.Client[v1.AdHocProfilesDiffRequest, v1.AdHocProfilesDiffResponse]
+	delete  *connect.Client[v1.AdHocProfilesDeleteRequest, v1.AdHocProfilesDeleteResponse]
+	rename  *connect.Client[v1.AdHocProfilesRenameRequest, v1.AdHocProfilesRenameResponse]
+	_import *connect.Client[v1.AdHocProfilesImportRequest, v1.AdHocProfilesImportResponse]
 }
 
 // Upload calls adhocprofiles.v1.AdHocProfileService.Upload.
@@ -116,6 +170,26 @@ func (c *adHocProfileServiceClient) List(ctx context.Context, req *connect.Reque
 	return c.list.CallUnary(ctx, req)
 }
 
+// Diff calls adhocprofiles.v1.AdHocProfileService.Diff.
+func (c *adHocProfileServiceClient) Diff(ctx context.Context, req *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error) {
+	return c.diff.CallUnary(ctx, req)
+}
+
+// Delete calls adhocprofiles.v1.AdHocProfileService.Delete.
+func (c *adHocProfileServiceClient) Delete(ctx context.Context, req *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// Rename calls adhocprofiles.v1.AdHocProfileService.Rename.
+func (c *adHocProfileServiceClient) Rename(ctx context.Context, req *connect.Request[v1.AdHocProfilesRenameRequest]) (*connect.Response[v1.AdHocProfilesRenameResponse], error) {
+	return c.rename.CallUnary(ctx, req)
+}
+
+// Import calls adhocprofiles.v1.AdHocProfileService.Import.
+func (c *adHocProfileServiceClient) Import(ctx context.Context, req *connect.Request[v1.AdHocProfilesImportRequest]) (*connect.Response[v1.AdHocProfilesImportResponse], error) {
+	return c._import.CallUnary(ctx, req)
+}
+
 // AdHocProfileServiceHandler is an implementation of the adhocprofiles.v1.AdHocProfileService
 // service.
 type AdHocProfileServiceHandler interface {
@@ -127,6 +201,16 @@ type AdHocProfileServiceHandler interface {
 	Get(context.Context, *connect.Request[v1.AdHocProfilesGetRequest]) (*connect.Response[v1.AdHocProfilesGetResponse], error)
 	// Retrieves a list of profiles found in the underlying store.
 	List(context.Context, *connect.Request[v1.AdHocProfilesListRequest]) (*connect.Response[v1.AdHocProfilesListResponse], error)
+	// Computes the difference between two profiles found in the underlying store. The response contains a flame graph in
+	// the diff format, where the left profile is the baseline and the right profile is compared to it.
+	Diff(context.Context, *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error)
+	// Deletes a profile from the underlying store.
+	Delete(context.Context, *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error)
+	// Renames a profile. As the identifier of a profile contains its name, the response contains the new identifier.
+	Rename(context.Context, *connect.Request[v1.AdHocProfilesRenameRequest]) (*connect.Response[v1.AdHocProfilesRenameResponse], error)
+	// Imports a profile found in the underlying store into the tenant's time series storage. The profile is ingested
+	// with the given timestamp and labels, and becomes queryable like any other profile.
+	Import(context.Context, *connect.Request[v1.AdHocProfilesImportRequest]) (*connect.Response[v1.AdHocProfilesImportResponse], error)
 }
 
 // NewAdHocProfileServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -153,6 +237,30 @@ func NewAdHocProfileServiceHandler(svc AdHocProfileServiceHandler, opts ...conne
 		connect.WithSchema(adHocProfileServiceListMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	adHocProfileServiceDiffHandler := connect.NewUnaryHandler(
+		AdHocProfileServiceDiffProcedure,
+		svc.Diff,
+		connect.WithSchema(adHocProfileServiceDiffMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adHocProfileServiceDeleteHandler := connect.NewUnaryHandler(
+		AdHocProfileServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(adHocProfileServiceDeleteMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adHocProfileServiceRenameHandler := connect.NewUnaryHandler(
+		AdHocProfileServiceRenameProcedure,
+		svc.Rename,
+		connect.WithSchema(adHocProfileServiceRenameMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adHocProfileServiceImportHandler := connect.NewUnaryHandler(
+		AdHocProfileServiceImportProcedure,
+		svc.Import,
+		connect.WithSchema(adHocProfileServiceImportMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/adhocprofiles.v1.AdHocProfileService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AdHocProfileServiceUploadProcedure:
@@ -161,6 +269,14 @@ func NewAdHocProfileServiceHandler(svc AdHocProfileServiceHandler, opts ...conne
 			adHocProfileServiceGetHandler.ServeHTTP(w, r)
 		case AdHocProfileServiceListProcedure:
 			adHocProfileServiceListHandler.ServeHTTP(w, r)
+		case AdHocProfileServiceDiffProcedure:
+			adHocProfileServiceDiffHandler.ServeHTTP(w, r)
+		case AdHocProfileServiceDeleteProcedure:
+			adHocProfileServiceDeleteHandler.ServeHTTP(w, r)
+		case AdHocProfileServiceRenameProcedure:
+			adHocProfileServiceRenameHandler.ServeHTTP(w, r)
+		case AdHocProfileServiceImportProcedure:
+			adHocProfileServiceImportHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -181,3 +297,19 @@ func (UnimplementedAdHocProfileServiceHandler) Get(context.Context, *connect.Req
 func (UnimplementedAdHocProfileServiceHandler) List(context.Context, *connect.Request[v1.AdHocProfilesListRequest]) (*connect.Response[v1.AdHocProfilesListResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.List is not implemented"))
 }
+
+func (UnimplementedAdHocProfileServiceHandler) Diff(context.Context, *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.Diff is not implemented"))
+}
+
+func (UnimplementedAdHocProfileServiceHandler) Delete(context.Context, *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.Delete is not implemented"))
+}
+
+func (UnimplementedAdHocProfileServiceHandler) Rename(context.Context, *connect.Request[v1.AdHocProfilesRenameRequest]) (*connect.Response[v1.AdHocProfilesRenameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.Rename is not implemented"))
+}
+
+func (UnimplementedAdHocProfileServiceHandler) Import(context.Context, *connect.Request[v1.AdHocProfilesImportRequest]) (*connect.Response[v1.AdHocProfilesImportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.Import is not implemented"))
+}
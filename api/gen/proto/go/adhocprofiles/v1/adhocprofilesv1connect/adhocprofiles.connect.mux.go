@@ -34,4 +34,24 @@ func RegisterAdHocProfileServiceHandler(mux *mux.Router, svc AdHocProfileService
 		svc.List,
 		opts...,
 	))
+	mux.Handle("/adhocprofiles.v1.AdHocProfileService/Diff", connect.NewUnaryHandler(
+		"/adhocprofiles.v1.AdHocProfileService/Diff",
+		svc.Diff,
+		opts...,
+	))
+	mux.Handle("/adhocprofiles.v1.AdHocProfileService/Delete", connect.NewUnaryHandler(
+		"/adhocprofiles.v1.AdHocProfileService/Delete",
+		svc.Delete,
+		opts...,
+	))
+	mux.Handle("/adhocprofiles.v1.AdHocProfileService/Rename", connect.NewUnaryHandler(
+		"/adhocprofiles.v1.AdHocProfileService/Rename",
+		svc.Rename,
+		opts...,
+	))
+	mux.Handle("/adhocprofiles.v1.AdHocProfileService/Import", connect.NewUnaryHandler(
+		"/adhocprofiles.v1.AdHocProfileService/Import",
+		svc.Import,
+		opts...,
+	))
 }
@@ -0,0 +1,148 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: debuginfo/v1/debuginfo.proto
+
+package debuginfov1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/grafana/pyroscope/api/gen/proto/go/debuginfo/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// DebugInfoServiceName is the fully-qualified name of the DebugInfoService service.
+	DebugInfoServiceName = "debuginfo.v1.DebugInfoService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// DebugInfoServiceHasDebugInfoProcedure is the fully-qualified name of the DebugInfoService's
+	// HasDebugInfo RPC.
+	DebugInfoServiceHasDebugInfoProcedure = "/debuginfo.v1.DebugInfoService/HasDebugInfo"
+	// DebugInfoServiceUploadProcedure is the fully-qualified name of the DebugInfoService's Upload RPC.
+	DebugInfoServiceUploadProcedure = "/debuginfo.v1.DebugInfoService/Upload"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	debugInfoServiceServiceDescriptor            = v1.File_debuginfo_v1_debuginfo_proto.Services().ByName("DebugInfoService")
+	debugInfoServiceHasDebugInfoMethodDescriptor = debugInfoServiceServiceDescriptor.Methods().ByName("HasDebugInfo")
+	debugInfoServiceUploadMethodDescriptor       = debugInfoServiceServiceDescriptor.Methods().ByName("Upload")
+)
+
+// DebugInfoServiceClient is a client for the debuginfo.v1.DebugInfoService service.
+type DebugInfoServiceClient interface {
+	// Checks whether debug information for the given build ID has been uploaded.
+	HasDebugInfo(context.Context, *connect.Request[v1.HasDebugInfoRequest]) (*connect.Response[v1.HasDebugInfoResponse], error)
+	// Uploads debug information for the given build ID. The first message of the stream must contain the upload info,
+	// subsequent messages contain chunks of the ELF file. An existing debug info file is overwritten.
+	Upload(context.Context) *connect.ClientStreamForClient[v1.UploadRequest, v1.UploadResponse]
+}
+
+// NewDebugInfoServiceClient constructs a client for the debuginfo.v1.DebugInfoService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewDebugInfoServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) DebugInfoServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &debugInfoServiceClient{
+		hasDebugInfo: connect.NewClient[v1.HasDebugInfoRequest, v1.HasDebugInfoResponse](
+			httpClient,
+			baseURL+DebugInfoServiceHasDebugInfoProcedure,
+			connect.WithSchema(debugInfoServiceHasDebugInfoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		upload: connect.NewClient[v1.UploadRequest, v1.UploadResponse](
+			httpClient,
+			baseURL+DebugInfoServiceUploadProcedure,
+			connect.WithSchema(debugInfoServiceUploadMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// debugInfoServiceClient implements DebugInfoServiceClient.
+type debugInfoServiceClient struct {
+	hasDebugInfo *connect.Client[v1.HasDebugInfoRequest, v1.HasDebugInfoResponse]
+	upload       *connect.Client[v1.UploadRequest, v1.UploadResponse]
+}
+
+// HasDebugInfo calls debuginfo.v1.DebugInfoService.HasDebugInfo.
+func (c *debugInfoServiceClient) HasDebugInfo(ctx context.Context, req *connect.Request[v1.HasDebugInfoRequest]) (*connect.Response[v1.HasDebugInfoResponse], error) {
+	return c.hasDebugInfo.CallUnary(ctx, req)
+}
+
+// Upload calls debuginfo.v1.DebugInfoService.Upload.
+func (c *debugInfoServiceClient) Upload(ctx context.Context) *connect.ClientStreamForClient[v1.UploadRequest, v1.UploadResponse] {
+	return c.upload.CallClientStream(ctx)
+}
+
+// DebugInfoServiceHandler is an implementation of the debuginfo.v1.DebugInfoService service.
+type DebugInfoServiceHandler interface {
+	// Checks whether debug information for the given build ID has been uploaded.
+	HasDebugInfo(context.Context, *connect.Request[v1.HasDebugInfoRequest]) (*connect.Response[v1.HasDebugInfoResponse], error)
+	// Uploads debug information for the given build ID. The first message of the stream must contain the upload info,
+	// subsequent messages contain chunks of the ELF file. An existing debug info file is overwritten.
+	Upload(context.Context, *connect.ClientStream[v1.UploadRequest]) (*connect.Response[v1.UploadResponse], error)
+}
+
+// NewDebugInfoServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewDebugInfoServiceHandler(svc DebugInfoServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	debugInfoServiceHasDebugInfoHandler := connect.NewUnaryHandler(
+		DebugInfoServiceHasDebugInfoProcedure,
+		svc.HasDebugInfo,
+		connect.WithSchema(debugInfoServiceHasDebugInfoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	debugInfoServiceUploadHandler := connect.NewClientStreamHandler(
+		DebugInfoServiceUploadProcedure,
+		svc.Upload,
+		connect.WithSchema(debugInfoServiceUploadMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/debuginfo.v1.DebugInfoService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DebugInfoServiceHasDebugInfoProcedure:
+			debugInfoServiceHasDebugInfoHandler.ServeHTTP(w, r)
+		case DebugInfoServiceUploadProcedure:
+			debugInfoServiceUploadHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedDebugInfoServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedDebugInfoServiceHandler struct{}
+
+func (UnimplementedDebugInfoServiceHandler) HasDebugInfo(context.Context, *connect.Request[v1.HasDebugInfoRequest]) (*connect.Response[v1.HasDebugInfoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("debuginfo.v1.DebugInfoService.HasDebugInfo is not implemented"))
+}
+
+func (UnimplementedDebugInfoServiceHandler) Upload(context.Context, *connect.ClientStream[v1.UploadRequest]) (*connect.Response[v1.UploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("debuginfo.v1.DebugInfoService.Upload is not implemented"))
+}
@@ -0,0 +1,32 @@
+// Code generated by protoc-gen-connect-go-mux. DO NOT EDIT.
+//
+// Source: debuginfo/v1/debuginfo.proto
+
+package debuginfov1connect
+
+import (
+	connect "connectrpc.com/connect"
+	mux "github.com/gorilla/mux"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion0_1_0
+
+// RegisterDebugInfoServiceHandler register an HTTP handler to a mux.Router from the service
+// implementation.
+func RegisterDebugInfoServiceHandler(mux *mux.Router, svc DebugInfoServiceHandler, opts ...connect.HandlerOption) {
+	mux.Handle("/debuginfo.v1.DebugInfoService/HasDebugInfo", connect.NewUnaryHandler(
+		"/debuginfo.v1.DebugInfoService/HasDebugInfo",
+		svc.HasDebugInfo,
+		opts...,
+	))
+	mux.Handle("/debuginfo.v1.DebugInfoService/Upload", connect.NewClientStreamHandler(
+		"/debuginfo.v1.DebugInfoService/Upload",
+		svc.Upload,
+		opts...,
+	))
+}
@@ -164,10 +164,12 @@ func (m *SelectProfilesRequest) CloneVT() *SelectProfilesRequest {
 		return (*SelectProfilesRequest)(nil)
 	}
 	r := &SelectProfilesRequest{
-		LabelSelector: m.LabelSelector,
-		Start:         m.Start,
-		End:           m.End,
-		Hints:         m.Hints.CloneVT(),
+		LabelSelector:       m.LabelSelector,
+		Start:               m.Start,
+		End:                 m.End,
+		Hints:               m.Hints.CloneVT(),
+		SampleLabelSelector: m.SampleLabelSelector,
+		CollapseRecursion:   m.CollapseRecursion,
 	}
 	if rhs := m.Type; rhs != nil {
 		if vtpb, ok := interface{}(rhs).(interface{ CloneVT() *v1.ProfileType }); ok {
@@ -574,6 +576,11 @@ func (m *MergeProfilesPprofRequest) CloneVT() *MergeProfilesPprofRequest {
 			r.StackTraceSelector = proto.Clone(rhs).(*v1.StackTraceSelector)
 		}
 	}
+	if rhs := m.SampleLabels; rhs != nil {
+		tmpContainer := make([]string, len(rhs))
+		copy(tmpContainer, rhs)
+		r.SampleLabels = tmpContainer
+	}
 	if rhs := m.Profiles; rhs != nil {
 		tmpContainer := make([]bool, len(rhs))
 		copy(tmpContainer, rhs)
@@ -896,6 +903,12 @@ func (this *SelectProfilesRequest) EqualVT(that *SelectProfilesRequest) bool {
 	if p, q := this.Aggregation, that.Aggregation; (p == nil && q != nil) || (p != nil && (q == nil || *p != *q)) {
 		return false
 	}
+	if this.SampleLabelSelector != that.SampleLabelSelector {
+		return false
+	}
+	if this.CollapseRecursion != that.CollapseRecursion {
+		return false
+	}
 	return string(this.unknownFields) == string(that.unknownFields)
 }
 
@@ -1410,6 +1423,15 @@ func (this *MergeProfilesPprofRequest) EqualVT(that *MergeProfilesPprofRequest)
 	} else if !proto.Equal(this.StackTraceSelector, that.StackTraceSelector) {
 		return false
 	}
+	if len(this.SampleLabels) != len(that.SampleLabels) {
+		return false
+	}
+	for i, vx := range this.SampleLabels {
+		vy := that.SampleLabels[i]
+		if vx != vy {
+			return false
+		}
+	}
 	return string(this.unknownFields) == string(that.unknownFields)
 }
 
@@ -2447,6 +2469,18 @@ func (m *SelectProfilesRequest) MarshalToSizedBufferVT(dAtA []byte) (int, error)
 		i -= len(m.unknownFields)
 		copy(dAtA[i:], m.unknownFields)
 	}
+	if m.CollapseRecursion != 0 {
+		i = encodeVarint(dAtA, i, uint64(m.CollapseRecursion))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.SampleLabelSelector) > 0 {
+		i -= len(m.SampleLabelSelector)
+		copy(dAtA[i:], m.SampleLabelSelector)
+		i = encodeVarint(dAtA, i, uint64(len(m.SampleLabelSelector)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if m.Aggregation != nil {
 		i = encodeVarint(dAtA, i, uint64(*m.Aggregation))
 		i--
@@ -3387,6 +3421,15 @@ func (m *MergeProfilesPprofRequest) MarshalToSizedBufferVT(dAtA []byte) (int, er
 		i -= len(m.unknownFields)
 		copy(dAtA[i:], m.unknownFields)
 	}
+	if len(m.SampleLabels) > 0 {
+		for iNdEx := len(m.SampleLabels) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SampleLabels[iNdEx])
+			copy(dAtA[i:], m.SampleLabels[iNdEx])
+			i = encodeVarint(dAtA, i, uint64(len(m.SampleLabels[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if m.StackTraceSelector != nil {
 		if vtmsg, ok := interface{}(m.StackTraceSelector).(interface {
 			MarshalToSizedBufferVT([]byte) (int, error)
@@ -3837,6 +3880,13 @@ func (m *SelectProfilesRequest) SizeVT() (n int) {
 	if m.Aggregation != nil {
 		n += 1 + sov(uint64(*m.Aggregation))
 	}
+	l = len(m.SampleLabelSelector)
+	if l > 0 {
+		n += 1 + l + sov(uint64(l))
+	}
+	if m.CollapseRecursion != 0 {
+		n += 1 + sov(uint64(m.CollapseRecursion))
+	}
 	n += len(m.unknownFields)
 	return n
 }
@@ -4194,6 +4244,12 @@ func (m *MergeProfilesPprofRequest) SizeVT() (n int) {
 		}
 		n += 1 + l + sov(uint64(l))
 	}
+	if len(m.SampleLabels) > 0 {
+		for _, s := range m.SampleLabels {
+			l = len(s)
+			n += 1 + l + sov(uint64(l))
+		}
+	}
 	n += len(m.unknownFields)
 	return n
 }
@@ -5022,6 +5078,57 @@ func (m *SelectProfilesRequest) UnmarshalVT(dAtA []byte) error {
 				}
 			}
 			m.Aggregation = &v
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleLabelSelector", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLength
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLength
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SampleLabelSelector = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CollapseRecursion", wireType)
+			}
+			m.CollapseRecursion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CollapseRecursion |= v1.RecursionCollapse(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skip(dAtA[iNdEx:])
@@ -7277,6 +7384,38 @@ func (m *MergeProfilesPprofRequest) UnmarshalVT(dAtA []byte) error {
 				}
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleLabels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLength
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLength
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SampleLabels = append(m.SampleLabels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skip(dAtA[iNdEx:])
@@ -49,6 +49,9 @@ const (
 	// QuerierServiceSelectMergeStacktracesProcedure is the fully-qualified name of the QuerierService's
 	// SelectMergeStacktraces RPC.
 	QuerierServiceSelectMergeStacktracesProcedure = "/querier.v1.QuerierService/SelectMergeStacktraces"
+	// QuerierServiceSelectMergeStacktracesStreamProcedure is the fully-qualified name of the
+	// QuerierService's SelectMergeStacktracesStream RPC.
+	QuerierServiceSelectMergeStacktracesStreamProcedure = "/querier.v1.QuerierService/SelectMergeStacktracesStream"
 	// QuerierServiceSelectMergeSpanProfileProcedure is the fully-qualified name of the QuerierService's
 	// SelectMergeSpanProfile RPC.
 	QuerierServiceSelectMergeSpanProfileProcedure = "/querier.v1.QuerierService/SelectMergeSpanProfile"
@@ -60,20 +63,25 @@ const (
 	QuerierServiceSelectSeriesProcedure = "/querier.v1.QuerierService/SelectSeries"
 	// QuerierServiceDiffProcedure is the fully-qualified name of the QuerierService's Diff RPC.
 	QuerierServiceDiffProcedure = "/querier.v1.QuerierService/Diff"
+	// QuerierServiceSelectSourceLinesProcedure is the fully-qualified name of the QuerierService's
+	// SelectSourceLines RPC.
+	QuerierServiceSelectSourceLinesProcedure = "/querier.v1.QuerierService/SelectSourceLines"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	querierServiceServiceDescriptor                      = v1.File_querier_v1_querier_proto.Services().ByName("QuerierService")
-	querierServiceProfileTypesMethodDescriptor           = querierServiceServiceDescriptor.Methods().ByName("ProfileTypes")
-	querierServiceLabelValuesMethodDescriptor            = querierServiceServiceDescriptor.Methods().ByName("LabelValues")
-	querierServiceLabelNamesMethodDescriptor             = querierServiceServiceDescriptor.Methods().ByName("LabelNames")
-	querierServiceSeriesMethodDescriptor                 = querierServiceServiceDescriptor.Methods().ByName("Series")
-	querierServiceSelectMergeStacktracesMethodDescriptor = querierServiceServiceDescriptor.Methods().ByName("SelectMergeStacktraces")
-	querierServiceSelectMergeSpanProfileMethodDescriptor = querierServiceServiceDescriptor.Methods().ByName("SelectMergeSpanProfile")
-	querierServiceSelectMergeProfileMethodDescriptor     = querierServiceServiceDescriptor.Methods().ByName("SelectMergeProfile")
-	querierServiceSelectSeriesMethodDescriptor           = querierServiceServiceDescriptor.Methods().ByName("SelectSeries")
-	querierServiceDiffMethodDescriptor                   = querierServiceServiceDescriptor.Methods().ByName("Diff")
+	querierServiceServiceDescriptor                            = v1.File_querier_v1_querier_proto.Services().ByName("QuerierService")
+	querierServiceProfileTypesMethodDescriptor                 = querierServiceServiceDescriptor.Methods().ByName("ProfileTypes")
+	querierServiceLabelValuesMethodDescriptor                  = querierServiceServiceDescriptor.Methods().ByName("LabelValues")
+	querierServiceLabelNamesMethodDescriptor                   = querierServiceServiceDescriptor.Methods().ByName("LabelNames")
+	querierServiceSeriesMethodDescriptor                       = querierServiceServiceDescriptor.Methods().ByName("Series")
+	querierServiceSelectMergeStacktracesMethodDescriptor       = querierServiceServiceDescriptor.Methods().ByName("SelectMergeStacktraces")
+	querierServiceSelectMergeStacktracesStreamMethodDescriptor = querierServiceServiceDescriptor.Methods().ByName("SelectMergeStacktracesStream")
+	querierServiceSelectMergeSpanProfileMethodDescriptor       = querierServiceServiceDescriptor.Methods().ByName("SelectMergeSpanProfile")
+	querierServiceSelectMergeProfileMethodDescriptor           = querierServiceServiceDescriptor.Methods().ByName("SelectMergeProfile")
+	querierServiceSelectSeriesMethodDescriptor                 = querierServiceServiceDescriptor.Methods().ByName("SelectSeries")
+	querierServiceDiffMethodDescriptor                         = querierServiceServiceDescriptor.Methods().ByName("Diff")
+	querierServiceSelectSourceLinesMethodDescriptor            = querierServiceServiceDescriptor.Methods().ByName("SelectSourceLines")
 )
 
 // QuerierServiceClient is a client for the querier.v1.QuerierService service.
@@ -88,6 +96,8 @@ type QuerierServiceClient interface {
 	Series(context.Context, *connect.Request[v1.SeriesRequest]) (*connect.Response[v1.SeriesResponse], error)
 	// SelectMergeStacktraces returns matching profiles aggregated in a flamegraph format. It will combine samples from within the same callstack, with each element being grouped by its function name.
 	SelectMergeStacktraces(context.Context, *connect.Request[v1.SelectMergeStacktracesRequest]) (*connect.Response[v1.SelectMergeStacktracesResponse], error)
+	// SelectMergeStacktracesStream is the streaming variant of SelectMergeStacktraces. The merged flamegraph is sent after each completed part of the query, which allows to render the result progressively. The last message has the final flag set.
+	SelectMergeStacktracesStream(context.Context, *connect.Request[v1.SelectMergeStacktracesRequest]) (*connect.ServerStreamForClient[v1.SelectMergeStacktracesStreamResponse], error)
 	// SelectMergeSpans returns matching profiles aggregated in a flamegraph format. It will combine samples from within the same callstack, with each element being grouped by its function name.
 	SelectMergeSpanProfile(context.Context, *connect.Request[v1.SelectMergeSpanProfileRequest]) (*connect.Response[v1.SelectMergeSpanProfileResponse], error)
 	// SelectMergeProfile returns matching profiles aggregated in pprof format. It will contain all information stored (so including filenames and line number, if ingested).
@@ -95,6 +105,9 @@ type QuerierServiceClient interface {
 	// SelectSeries returns a time series for the total sum of the requested profiles.
 	SelectSeries(context.Context, *connect.Request[v1.SelectSeriesRequest]) (*connect.Response[v1.SelectSeriesResponse], error)
 	Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error)
+	// SelectSourceLines returns the self and total values of the source lines of a function or a file, aggregated from the matching profiles.
+	// It allows to annotate the source code, similarly to pprof -list.
+	SelectSourceLines(context.Context, *connect.Request[v1.SelectSourceLinesRequest]) (*connect.Response[v1.SelectSourceLinesResponse], error)
 }
 
 // NewQuerierServiceClient constructs a client for the querier.v1.QuerierService service. By
@@ -137,6 +150,12 @@ func NewQuerierServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(querierServiceSelectMergeStacktracesMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		selectMergeStacktracesStream: connect.NewClient[v1.SelectMergeStacktracesRequest, v1.SelectMergeStacktracesStreamResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectMergeStacktracesStreamProcedure,
+			connect.WithSchema(querierServiceSelectMergeStacktracesStreamMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		selectMergeSpanProfile: connect.NewClient[v1.SelectMergeSpanProfileRequest, v1.SelectMergeSpanProfileResponse](
 			httpClient,
 			baseURL+QuerierServiceSelectMergeSpanProfileProcedure,
@@ -161,20 +180,28 @@ func NewQuerierServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(querierServiceDiffMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		selectSourceLines: connect.NewClient[v1.SelectSourceLinesRequest, v1.SelectSourceLinesResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectSourceLinesProcedure,
+			connect.WithSchema(querierServiceSelectSourceLinesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // querierServiceClient implements QuerierServiceClient.
 type querierServiceClient struct {
-	profileTypes           *connect.Client[v1.ProfileTypesRequest, v1.ProfileTypesResponse]
-	labelValues            *connect.Client[v11.LabelValuesRequest, v11.LabelValuesResponse]
-	labelNames             *connect.Client[v11.LabelNamesRequest, v11.LabelNamesResponse]
-	series                 *connect.Client[v1.SeriesRequest, v1.SeriesResponse]
-	selectMergeStacktraces *connect.Client[v1.SelectMergeStacktracesRequest, v1.SelectMergeStacktracesResponse]
-	selectMergeSpanProfile *connect.Client[v1.SelectMergeSpanProfileRequest, v1.SelectMergeSpanProfileResponse]
-	selectMergeProfile     *connect.Client[v1.SelectMergeProfileRequest, v12.Profile]
-	selectSeries           *connect.Client[v1.SelectSeriesRequest, v1.SelectSeriesResponse]
-	diff                   *connect.Client[v1.DiffRequest, v1.DiffResponse]
+	profileTypes                 *connect.Client[v1.ProfileTypesRequest, v1.ProfileTypesResponse]
+	labelValues                  *connect.Client[v11.LabelValuesRequest, v11.LabelValuesResponse]
+	labelNames                   *connect.Client[v11.LabelNamesRequest, v11.LabelNamesResponse]
+	series                       *connect.Client[v1.SeriesRequest, v1.SeriesResponse]
+	selectMergeStacktraces       *connect.Client[v1.SelectMergeStacktracesRequest, v1.SelectMergeStacktracesResponse]
+	selectMergeStacktracesStream *connect.Client[v1.SelectMergeStacktracesRequest, v1.SelectMergeStacktracesStreamResponse]
+	selectMergeSpanProfile       *connect.Client[v1.SelectMergeSpanProfileRequest, v1.SelectMergeSpanProfileResponse]
+	selectMergeProfile           *connect.Client[v1.SelectMergeProfileRequest, v12.Profile]
+	selectSeries                 *connect.Client[v1.SelectSeriesRequest, v1.SelectSeriesResponse]
+	diff                         *connect.Client[v1.DiffRequest, v1.DiffResponse]
+	selectSourceLines            *connect.Client[v1.SelectSourceLinesRequest, v1.SelectSourceLinesResponse]
 }
 
 // ProfileTypes calls querier.v1.QuerierService.ProfileTypes.
@@ -202,6 +229,11 @@ func (c *querierServiceClient) SelectMergeStacktraces(ctx context.Context, req *
 	return c.selectMergeStacktraces.CallUnary(ctx, req)
 }
 
+// SelectMergeStacktracesStream calls querier.v1.QuerierService.SelectMergeStacktracesStream.
+func (c *querierServiceClient) SelectMergeStacktracesStream(ctx context.Context, req *connect.Request[v1.SelectMergeStacktracesRequest]) (*connect.ServerStreamForClient[v1.SelectMergeStacktracesStreamResponse], error) {
+	return c.selectMergeStacktracesStream.CallServerStream(ctx, req)
+}
+
 // SelectMergeSpanProfile calls querier.v1.QuerierService.SelectMergeSpanProfile.
 func (c *querierServiceClient) SelectMergeSpanProfile(ctx context.Context, req *connect.Request[v1.SelectMergeSpanProfileRequest]) (*connect.Response[v1.SelectMergeSpanProfileResponse], error) {
 	return c.selectMergeSpanProfile.CallUnary(ctx, req)
@@ -222,6 +254,11 @@ func (c *querierServiceClient) Diff(ctx context.Context, req *connect.Request[v1
 	return c.diff.CallUnary(ctx, req)
 }
 
+// SelectSourceLines calls querier.v1.QuerierService.SelectSourceLines.
+func (c *querierServiceClient) SelectSourceLines(ctx context.Context, req *connect.Request[v1.SelectSourceLinesRequest]) (*connect.Response[v1.SelectSourceLinesResponse], error) {
+	return c.selectSourceLines.CallUnary(ctx, req)
+}
+
 // QuerierServiceHandler is an implementation of the querier.v1.QuerierService service.
 type QuerierServiceHandler interface {
 	// ProfileType returns a list of the existing profile types.
@@ -234,6 +271,8 @@ type QuerierServiceHandler interface {
 	Series(context.Context, *connect.Request[v1.SeriesRequest]) (*connect.Response[v1.SeriesResponse], error)
 	// SelectMergeStacktraces returns matching profiles aggregated in a flamegraph format. It will combine samples from within the same callstack, with each element being grouped by its function name.
 	SelectMergeStacktraces(context.Context, *connect.Request[v1.SelectMergeStacktracesRequest]) (*connect.Response[v1.SelectMergeStacktracesResponse], error)
+	// SelectMergeStacktracesStream is the streaming variant of SelectMergeStacktraces. The merged flamegraph is sent after each completed part of the query, which allows to render the result progressively. The last message has the final flag set.
+	SelectMergeStacktracesStream(context.Context, *connect.Request[v1.SelectMergeStacktracesRequest], *connect.ServerStream[v1.SelectMergeStacktracesStreamResponse]) error
 	// SelectMergeSpans returns matching profiles aggregated in a flamegraph format. It will combine samples from within the same callstack, with each element being grouped by its function name.
 	SelectMergeSpanProfile(context.Context, *connect.Request[v1.SelectMergeSpanProfileRequest]) (*connect.Response[v1.SelectMergeSpanProfileResponse], error)
 	// SelectMergeProfile returns matching profiles aggregated in pprof format. It will contain all information stored (so including filenames and line number, if ingested).
@@ -241,6 +280,9 @@ type QuerierServiceHandler interface {
 	// SelectSeries returns a time series for the total sum of the requested profiles.
 	SelectSeries(context.Context, *connect.Request[v1.SelectSeriesRequest]) (*connect.Response[v1.SelectSeriesResponse], error)
 	Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error)
+	// SelectSourceLines returns the self and total values of the source lines of a function or a file, aggregated from the matching profiles.
+	// It allows to annotate the source code, similarly to pprof -list.
+	SelectSourceLines(context.Context, *connect.Request[v1.SelectSourceLinesRequest]) (*connect.Response[v1.SelectSourceLinesResponse], error)
 }
 
 // NewQuerierServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -279,6 +321,12 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 		connect.WithSchema(querierServiceSelectMergeStacktracesMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	querierServiceSelectMergeStacktracesStreamHandler := connect.NewServerStreamHandler(
+		QuerierServiceSelectMergeStacktracesStreamProcedure,
+		svc.SelectMergeStacktracesStream,
+		connect.WithSchema(querierServiceSelectMergeStacktracesStreamMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	querierServiceSelectMergeSpanProfileHandler := connect.NewUnaryHandler(
 		QuerierServiceSelectMergeSpanProfileProcedure,
 		svc.SelectMergeSpanProfile,
@@ -303,6 +351,12 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 		connect.WithSchema(querierServiceDiffMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	querierServiceSelectSourceLinesHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectSourceLinesProcedure,
+		svc.SelectSourceLines,
+		connect.WithSchema(querierServiceSelectSourceLinesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/querier.v1.QuerierService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case QuerierServiceProfileTypesProcedure:
@@ -315,6 +369,8 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 			querierServiceSeriesHandler.ServeHTTP(w, r)
 		case QuerierServiceSelectMergeStacktracesProcedure:
 			querierServiceSelectMergeStacktracesHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectMergeStacktracesStreamProcedure:
+			querierServiceSelectMergeStacktracesStreamHandler.ServeHTTP(w, r)
 		case QuerierServiceSelectMergeSpanProfileProcedure:
 			querierServiceSelectMergeSpanProfileHandler.ServeHTTP(w, r)
 		case QuerierServiceSelectMergeProfileProcedure:
@@ -323,6 +379,8 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 			querierServiceSelectSeriesHandler.ServeHTTP(w, r)
 		case QuerierServiceDiffProcedure:
 			querierServiceDiffHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectSourceLinesProcedure:
+			querierServiceSelectSourceLinesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -352,6 +410,10 @@ func (UnimplementedQuerierServiceHandler) SelectMergeStacktraces(context.Context
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectMergeStacktraces is not implemented"))
 }
 
+func (UnimplementedQuerierServiceHandler) SelectMergeStacktracesStream(context.Context, *connect.Request[v1.SelectMergeStacktracesRequest], *connect.ServerStream[v1.SelectMergeStacktracesStreamResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectMergeStacktracesStream is not implemented"))
+}
+
 func (UnimplementedQuerierServiceHandler) SelectMergeSpanProfile(context.Context, *connect.Request[v1.SelectMergeSpanProfileRequest]) (*connect.Response[v1.SelectMergeSpanProfileResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectMergeSpanProfile is not implemented"))
 }
@@ -367,3 +429,7 @@ func (UnimplementedQuerierServiceHandler) SelectSeries(context.Context, *connect
 func (UnimplementedQuerierServiceHandler) Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.Diff is not implemented"))
 }
+
+func (UnimplementedQuerierServiceHandler) SelectSourceLines(context.Context, *connect.Request[v1.SelectSourceLinesRequest]) (*connect.Response[v1.SelectSourceLinesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectSourceLines is not implemented"))
+}
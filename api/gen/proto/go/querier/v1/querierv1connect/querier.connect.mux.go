@@ -44,6 +44,11 @@ func RegisterQuerierServiceHandler(mux *mux.Router, svc QuerierServiceHandler, o
 		svc.SelectMergeStacktraces,
 		opts...,
 	))
+	mux.Handle("/querier.v1.QuerierService/SelectMergeStacktracesStream", connect.NewServerStreamHandler(
+		"/querier.v1.QuerierService/SelectMergeStacktracesStream",
+		svc.SelectMergeStacktracesStream,
+		opts...,
+	))
 	mux.Handle("/querier.v1.QuerierService/SelectMergeSpanProfile", connect.NewUnaryHandler(
 		"/querier.v1.QuerierService/SelectMergeSpanProfile",
 		svc.SelectMergeSpanProfile,
@@ -64,4 +69,9 @@ func RegisterQuerierServiceHandler(mux *mux.Router, svc QuerierServiceHandler, o
 		svc.Diff,
 		opts...,
 	))
+	mux.Handle("/querier.v1.QuerierService/SelectSourceLines", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectSourceLines",
+		svc.SelectSourceLines,
+		opts...,
+	))
 }
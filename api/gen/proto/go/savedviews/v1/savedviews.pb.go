@@ -0,0 +1,1075 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: savedviews/v1/savedviews.proto
+
+package savedviewsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ViewMode int32
+
+const (
+	ViewMode_VIEW_MODE_UNSPECIFIED ViewMode = 0
+	// A single flame graph of the query.
+	ViewMode_VIEW_MODE_SINGLE ViewMode = 1
+	// The flame graphs of the baseline and the query side by side.
+	ViewMode_VIEW_MODE_COMPARISON ViewMode = 2
+	// The diff flame graph of the baseline and the query.
+	ViewMode_VIEW_MODE_DIFF ViewMode = 3
+)
+
+// Enum value maps for ViewMode.
+var (
+	ViewMode_name = map[int32]string{
+		0: "VIEW_MODE_UNSPECIFIED",
+		1: "VIEW_MODE_SINGLE",
+		2: "VIEW_MODE_COMPARISON",
+		3: "VIEW_MODE_DIFF",
+	}
+	ViewMode_value = map[string]int32{
+		"VIEW_MODE_UNSPECIFIED": 0,
+		"VIEW_MODE_SINGLE":      1,
+		"VIEW_MODE_COMPARISON":  2,
+		"VIEW_MODE_DIFF":        3,
+	}
+)
+
+func (x ViewMode) Enum() *ViewMode {
+	p := new(ViewMode)
+	*p = x
+	return p
+}
+
+func (x ViewMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ViewMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_savedviews_v1_savedviews_proto_enumTypes[0].Descriptor()
+}
+
+func (ViewMode) Type() protoreflect.EnumType {
+	return &file_savedviews_v1_savedviews_proto_enumTypes[0]
+}
+
+func (x ViewMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ViewMode.Descriptor instead.
+func (ViewMode) EnumDescriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{0}
+}
+
+type ViewQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProfileTypeId string `protobuf:"bytes,1,opt,name=profile_type_id,json=profileTypeId,proto3" json:"profile_type_id,omitempty"`
+	LabelSelector string `protobuf:"bytes,2,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// The start and the end of the time range, in the format of the from and until parameters of /pyroscope/render,
+	// e.g. now-1h or a unix timestamp.
+	From  string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	Until string `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+}
+
+func (x *ViewQuery) Reset() {
+	*x = ViewQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewQuery) ProtoMessage() {}
+
+func (x *ViewQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewQuery.ProtoReflect.Descriptor instead.
+func (*ViewQuery) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ViewQuery) GetProfileTypeId() string {
+	if x != nil {
+		return x.ProfileTypeId
+	}
+	return ""
+}
+
+func (x *ViewQuery) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *ViewQuery) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ViewQuery) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+type SavedView struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string     `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Query       *ViewQuery `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	// The baseline the query is compared to in the comparison and diff modes.
+	Baseline *ViewQuery `protobuf:"bytes,5,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	Mode     ViewMode   `protobuf:"varint,6,opt,name=mode,proto3,enum=savedviews.v1.ViewMode" json:"mode,omitempty"`
+	// Milliseconds since epoch.
+	CreatedAt int64  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy string `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// Milliseconds since epoch.
+	ModifiedAt int64  `protobuf:"varint,9,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	ModifiedBy string `protobuf:"bytes,10,opt,name=modified_by,json=modifiedBy,proto3" json:"modified_by,omitempty"`
+}
+
+func (x *SavedView) Reset() {
+	*x = SavedView{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavedView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedView) ProtoMessage() {}
+
+func (x *SavedView) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedView.ProtoReflect.Descriptor instead.
+func (*SavedView) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SavedView) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SavedView) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedView) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SavedView) GetQuery() *ViewQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *SavedView) GetBaseline() *ViewQuery {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *SavedView) GetMode() ViewMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ViewMode_VIEW_MODE_UNSPECIFIED
+}
+
+func (x *SavedView) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SavedView) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *SavedView) GetModifiedAt() int64 {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return 0
+}
+
+func (x *SavedView) GetModifiedBy() string {
+	if x != nil {
+		return x.ModifiedBy
+	}
+	return ""
+}
+
+type CreateSavedViewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The identifier and the timestamps of the view are set by the server.
+	View *SavedView `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+}
+
+func (x *CreateSavedViewRequest) Reset() {
+	*x = CreateSavedViewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedViewRequest) ProtoMessage() {}
+
+func (x *CreateSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSavedViewRequest.ProtoReflect.Descriptor instead.
+func (*CreateSavedViewRequest) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateSavedViewRequest) GetView() *SavedView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type CreateSavedViewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View *SavedView `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+}
+
+func (x *CreateSavedViewResponse) Reset() {
+	*x = CreateSavedViewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSavedViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedViewResponse) ProtoMessage() {}
+
+func (x *CreateSavedViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSavedViewResponse.ProtoReflect.Descriptor instead.
+func (*CreateSavedViewResponse) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSavedViewResponse) GetView() *SavedView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type GetSavedViewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetSavedViewRequest) Reset() {
+	*x = GetSavedViewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedViewRequest) ProtoMessage() {}
+
+func (x *GetSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedViewRequest.ProtoReflect.Descriptor instead.
+func (*GetSavedViewRequest) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSavedViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetSavedViewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View *SavedView `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+}
+
+func (x *GetSavedViewResponse) Reset() {
+	*x = GetSavedViewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSavedViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedViewResponse) ProtoMessage() {}
+
+func (x *GetSavedViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedViewResponse.ProtoReflect.Descriptor instead.
+func (*GetSavedViewResponse) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSavedViewResponse) GetView() *SavedView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type ListSavedViewsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSavedViewsRequest) Reset() {
+	*x = ListSavedViewsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSavedViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedViewsRequest) ProtoMessage() {}
+
+func (x *ListSavedViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedViewsRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedViewsRequest) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{6}
+}
+
+type ListSavedViewsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Views []*SavedView `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+}
+
+func (x *ListSavedViewsResponse) Reset() {
+	*x = ListSavedViewsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSavedViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedViewsResponse) ProtoMessage() {}
+
+func (x *ListSavedViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedViewsResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedViewsResponse) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSavedViewsResponse) GetViews() []*SavedView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+type UpdateSavedViewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View *SavedView `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+}
+
+func (x *UpdateSavedViewRequest) Reset() {
+	*x = UpdateSavedViewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSavedViewRequest) ProtoMessage() {}
+
+func (x *UpdateSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSavedViewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSavedViewRequest) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateSavedViewRequest) GetView() *SavedView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type UpdateSavedViewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View *SavedView `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+}
+
+func (x *UpdateSavedViewResponse) Reset() {
+	*x = UpdateSavedViewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSavedViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSavedViewResponse) ProtoMessage() {}
+
+func (x *UpdateSavedViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSavedViewResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSavedViewResponse) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateSavedViewResponse) GetView() *SavedView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type DeleteSavedViewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteSavedViewRequest) Reset() {
+	*x = DeleteSavedViewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedViewRequest) ProtoMessage() {}
+
+func (x *DeleteSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedViewRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedViewRequest) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteSavedViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSavedViewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteSavedViewResponse) Reset() {
+	*x = DeleteSavedViewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_savedviews_v1_savedviews_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSavedViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedViewResponse) ProtoMessage() {}
+
+func (x *DeleteSavedViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_savedviews_v1_savedviews_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedViewResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSavedViewResponse) Descriptor() ([]byte, []int) {
+	return file_savedviews_v1_savedviews_proto_rawDescGZIP(), []int{11}
+}
+
+var File_savedviews_v1_savedviews_proto protoreflect.FileDescriptor
+
+var file_savedviews_v1_savedviews_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2f, 0x76, 0x31, 0x2f,
+	0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0d, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x22,
+	0x84, 0x01, 0x0a, 0x09, 0x56, 0x69, 0x65, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x26, 0x0a,
+	0x0f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0xe4, 0x02, 0x0a, 0x09, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x56, 0x69, 0x65, 0x77, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x61, 0x76, 0x65,
+	0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x08, 0x62, 0x61,
+	0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73,
+	0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x65,
+	0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x2b, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17,
+	0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x69, 0x65, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x42, 0x79, 0x22, 0x46, 0x0a,
+	0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65,
+	0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52,
+	0x04, 0x76, 0x69, 0x65, 0x77, 0x22, 0x47, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2c, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x22, 0x25,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x44, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x61, 0x76, 0x65,
+	0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x04, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x61,
+	0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x76, 0x65,
+	0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x22, 0x17, 0x0a, 0x15, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x48, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x76, 0x65,
+	0x64, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e,
+	0x0a, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x22, 0x46,
+	0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69,
+	0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77,
+	0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x22, 0x47, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2c, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x22,
+	0x28, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x19, 0x0a, 0x17, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x69, 0x0a, 0x08, 0x56, 0x69, 0x65, 0x77, 0x4d, 0x6f, 0x64, 0x65,
+	0x12, 0x19, 0x0a, 0x15, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x56,
+	0x49, 0x45, 0x57, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x10,
+	0x01, 0x12, 0x18, 0x0a, 0x14, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43,
+	0x4f, 0x4d, 0x50, 0x41, 0x52, 0x49, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x56,
+	0x49, 0x45, 0x57, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x49, 0x46, 0x46, 0x10, 0x03, 0x32,
+	0xcd, 0x03, 0x0a, 0x11, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x73, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12,
+	0x25, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69,
+	0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76,
+	0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x50, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x22, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76,
+	0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x73, 0x61,
+	0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x55, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x24, 0x2e, 0x73, 0x61, 0x76,
+	0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x25, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x06, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x25, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56,
+	0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x61, 0x76,
+	0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x25,
+	0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65,
+	0x77, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65,
+	0x64, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
+	0xc3, 0x01, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65,
+	0x77, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77,
+	0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x72, 0x61, 0x66, 0x61, 0x6e, 0x61, 0x2f, 0x70, 0x79, 0x72,
+	0x6f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65,
+	0x77, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77, 0x73,
+	0x76, 0x31, 0xa2, 0x02, 0x03, 0x53, 0x58, 0x58, 0xaa, 0x02, 0x0d, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0d, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x76, 0x69, 0x65, 0x77, 0x73, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x19, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x76, 0x69, 0x65, 0x77, 0x73, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x76, 0x69, 0x65, 0x77,
+	0x73, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_savedviews_v1_savedviews_proto_rawDescOnce sync.Once
+	file_savedviews_v1_savedviews_proto_rawDescData = file_savedviews_v1_savedviews_proto_rawDesc
+)
+
+func file_savedviews_v1_savedviews_proto_rawDescGZIP() []byte {
+	file_savedviews_v1_savedviews_proto_rawDescOnce.Do(func() {
+		file_savedviews_v1_savedviews_proto_rawDescData = protoimpl.X.CompressGZIP(file_savedviews_v1_savedviews_proto_rawDescData)
+	})
+	return file_savedviews_v1_savedviews_proto_rawDescData
+}
+
+var file_savedviews_v1_savedviews_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_savedviews_v1_savedviews_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_savedviews_v1_savedviews_proto_goTypes = []interface{}{
+	(ViewMode)(0),                   // 0: savedviews.v1.ViewMode
+	(*ViewQuery)(nil),               // 1: savedviews.v1.ViewQuery
+	(*SavedView)(nil),               // 2: savedviews.v1.SavedView
+	(*CreateSavedViewRequest)(nil),  // 3: savedviews.v1.CreateSavedViewRequest
+	(*CreateSavedViewResponse)(nil), // 4: savedviews.v1.CreateSavedViewResponse
+	(*GetSavedViewRequest)(nil),     // 5: savedviews.v1.GetSavedViewRequest
+	(*GetSavedViewResponse)(nil),    // 6: savedviews.v1.GetSavedViewResponse
+	(*ListSavedViewsRequest)(nil),   // 7: savedviews.v1.ListSavedViewsRequest
+	(*ListSavedViewsResponse)(nil),  // 8: savedviews.v1.ListSavedViewsResponse
+	(*UpdateSavedViewRequest)(nil),  // 9: savedviews.v1.UpdateSavedViewRequest
+	(*UpdateSavedViewResponse)(nil), // 10: savedviews.v1.UpdateSavedViewResponse
+	(*DeleteSavedViewRequest)(nil),  // 11: savedviews.v1.DeleteSavedViewRequest
+	(*DeleteSavedViewResponse)(nil), // 12: savedviews.v1.DeleteSavedViewResponse
+}
+var file_savedviews_v1_savedviews_proto_depIdxs = []int32{
+	1,  // 0: savedviews.v1.SavedView.query:type_name -> savedviews.v1.ViewQuery
+	1,  // 1: savedviews.v1.SavedView.baseline:type_name -> savedviews.v1.ViewQuery
+	0,  // 2: savedviews.v1.SavedView.mode:type_name -> savedviews.v1.ViewMode
+	2,  // 3: savedviews.v1.CreateSavedViewRequest.view:type_name -> savedviews.v1.SavedView
+	2,  // 4: savedviews.v1.CreateSavedViewResponse.view:type_name -> savedviews.v1.SavedView
+	2,  // 5: savedviews.v1.GetSavedViewResponse.view:type_name -> savedviews.v1.SavedView
+	2,  // 6: savedviews.v1.ListSavedViewsResponse.views:type_name -> savedviews.v1.SavedView
+	2,  // 7: savedviews.v1.UpdateSavedViewRequest.view:type_name -> savedviews.v1.SavedView
+	2,  // 8: savedviews.v1.UpdateSavedViewResponse.view:type_name -> savedviews.v1.SavedView
+	3,  // 9: savedviews.v1.SavedViewsService.Create:input_type -> savedviews.v1.CreateSavedViewRequest
+	5,  // 10: savedviews.v1.SavedViewsService.Get:input_type -> savedviews.v1.GetSavedViewRequest
+	7,  // 11: savedviews.v1.SavedViewsService.List:input_type -> savedviews.v1.ListSavedViewsRequest
+	9,  // 12: savedviews.v1.SavedViewsService.Update:input_type -> savedviews.v1.UpdateSavedViewRequest
+	11, // 13: savedviews.v1.SavedViewsService.Delete:input_type -> savedviews.v1.DeleteSavedViewRequest
+	4,  // 14: savedviews.v1.SavedViewsService.Create:output_type -> savedviews.v1.CreateSavedViewResponse
+	6,  // 15: savedviews.v1.SavedViewsService.Get:output_type -> savedviews.v1.GetSavedViewResponse
+	8,  // 16: savedviews.v1.SavedViewsService.List:output_type -> savedviews.v1.ListSavedViewsResponse
+	10, // 17: savedviews.v1.SavedViewsService.Update:output_type -> savedviews.v1.UpdateSavedViewResponse
+	12, // 18: savedviews.v1.SavedViewsService.Delete:output_type -> savedviews.v1.DeleteSavedViewResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_savedviews_v1_savedviews_proto_init() }
+func file_savedviews_v1_savedviews_proto_init() {
+	if File_savedviews_v1_savedviews_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_savedviews_v1_savedviews_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ViewQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SavedView); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSavedViewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSavedViewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSavedViewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSavedViewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSavedViewsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSavedViewsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSavedViewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSavedViewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSavedViewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_savedviews_v1_savedviews_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSavedViewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_savedviews_v1_savedviews_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_savedviews_v1_savedviews_proto_goTypes,
+		DependencyIndexes: file_savedviews_v1_savedviews_proto_depIdxs,
+		EnumInfos:         file_savedviews_v1_savedviews_proto_enumTypes,
+		MessageInfos:      file_savedviews_v1_savedviews_proto_msgTypes,
+	}.Build()
+	File_savedviews_v1_savedviews_proto = out.File
+	file_savedviews_v1_savedviews_proto_rawDesc = nil
+	file_savedviews_v1_savedviews_proto_goTypes = nil
+	file_savedviews_v1_savedviews_proto_depIdxs = nil
+}
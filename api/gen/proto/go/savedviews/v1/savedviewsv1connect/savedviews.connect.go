@@ -0,0 +1,237 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: savedviews/v1/savedviews.proto
+
+package savedviewsv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SavedViewsServiceName is the fully-qualified name of the SavedViewsService service.
+	SavedViewsServiceName = "savedviews.v1.SavedViewsService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SavedViewsServiceCreateProcedure is the fully-qualified name of the SavedViewsService's Create
+	// RPC.
+	SavedViewsServiceCreateProcedure = "/savedviews.v1.SavedViewsService/Create"
+	// SavedViewsServiceGetProcedure is the fully-qualified name of the SavedViewsService's Get RPC.
+	SavedViewsServiceGetProcedure = "/savedviews.v1.SavedViewsService/Get"
+	// SavedViewsServiceListProcedure is the fully-qualified name of the SavedViewsService's List RPC.
+	SavedViewsServiceListProcedure = "/savedviews.v1.SavedViewsService/List"
+	// SavedViewsServiceUpdateProcedure is the fully-qualified name of the SavedViewsService's Update
+	// RPC.
+	SavedViewsServiceUpdateProcedure = "/savedviews.v1.SavedViewsService/Update"
+	// SavedViewsServiceDeleteProcedure is the fully-qualified name of the SavedViewsService's Delete
+	// RPC.
+	SavedViewsServiceDeleteProcedure = "/savedviews.v1.SavedViewsService/Delete"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	savedViewsServiceServiceDescriptor      = v1.File_savedviews_v1_savedviews_proto.Services().ByName("SavedViewsService")
+	savedViewsServiceCreateMethodDescriptor = savedViewsServiceServiceDescriptor.Methods().ByName("Create")
+	savedViewsServiceGetMethodDescriptor    = savedViewsServiceServiceDescriptor.Methods().ByName("Get")
+	savedViewsServiceListMethodDescriptor   = savedViewsServiceServiceDescriptor.Methods().ByName("List")
+	savedViewsServiceUpdateMethodDescriptor = savedViewsServiceServiceDescriptor.Methods().ByName("Update")
+	savedViewsServiceDeleteMethodDescriptor = savedViewsServiceServiceDescriptor.Methods().ByName("Delete")
+)
+
+// SavedViewsServiceClient is a client for the savedviews.v1.SavedViewsService service.
+type SavedViewsServiceClient interface {
+	// Creates a view. The response contains the short identifier of the view, which can be passed as the view parameter
+	// of the /pyroscope/render and /pyroscope/render-diff endpoints.
+	Create(context.Context, *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.CreateSavedViewResponse], error)
+	Get(context.Context, *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.GetSavedViewResponse], error)
+	List(context.Context, *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error)
+	// Replaces a view. The identifier and the creation time of the view are kept.
+	Update(context.Context, *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.UpdateSavedViewResponse], error)
+	Delete(context.Context, *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[v1.DeleteSavedViewResponse], error)
+}
+
+// NewSavedViewsServiceClient constructs a client for the savedviews.v1.SavedViewsService service.
+// By default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped
+// responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSavedViewsServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SavedViewsServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &savedViewsServiceClient{
+		create: connect.NewClient[v1.CreateSavedViewRequest, v1.CreateSavedViewResponse](
+			httpClient,
+			baseURL+SavedViewsServiceCreateProcedure,
+			connect.WithSchema(savedViewsServiceCreateMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[v1.GetSavedViewRequest, v1.GetSavedViewResponse](
+			httpClient,
+			baseURL+SavedViewsServiceGetProcedure,
+			connect.WithSchema(savedViewsServiceGetMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[v1.ListSavedViewsRequest, v1.ListSavedViewsResponse](
+			httpClient,
+			baseURL+SavedViewsServiceListProcedure,
+			connect.WithSchema(savedViewsServiceListMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.UpdateSavedViewRequest, v1.UpdateSavedViewResponse](
+			httpClient,
+			baseURL+SavedViewsServiceUpdateProcedure,
+			connect.WithSchema(savedViewsServiceUpdateMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.DeleteSavedViewRequest, v1.DeleteSavedViewResponse](
+			httpClient,
+			baseURL+SavedViewsServiceDeleteProcedure,
+			connect.WithSchema(savedViewsServiceDeleteMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// savedViewsServiceClient implements SavedViewsServiceClient.
+type savedViewsServiceClient struct {
+	create *connect.Client[v1.CreateSavedViewRequest, v1.CreateSavedViewResponse]
+	get    *connect.Client[v1.GetSavedViewRequest, v1.GetSavedViewResponse]
+	list   *connect.Client[v1.ListSavedViewsRequest, v1.ListSavedViewsResponse]
+	update *connect.Client[v1.UpdateSavedViewRequest, v1.UpdateSavedViewResponse]
+	delete *connect.Client[v1.DeleteSavedViewRequest, v1.DeleteSavedViewResponse]
+}
+
+// Create calls savedviews.v1.SavedViewsService.Create.
+func (c *savedViewsServiceClient) Create(ctx context.Context, req *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.CreateSavedViewResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Get calls savedviews.v1.SavedViewsService.Get.
+func (c *savedViewsServiceClient) Get(ctx context.Context, req *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.GetSavedViewResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// List calls savedviews.v1.SavedViewsService.List.
+func (c *savedViewsServiceClient) List(ctx context.Context, req *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Update calls savedviews.v1.SavedViewsService.Update.
+func (c *savedViewsServiceClient) Update(ctx context.Context, req *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.UpdateSavedViewResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls savedviews.v1.SavedViewsService.Delete.
+func (c *savedViewsServiceClient) Delete(ctx context.Context, req *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[v1.DeleteSavedViewResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// SavedViewsServiceHandler is an implementation of the savedviews.v1.SavedViewsService service.
+type SavedViewsServiceHandler interface {
+	// Creates a view. The response contains the short identifier of the view, which can be passed as the view parameter
+	// of the /pyroscope/render and /pyroscope/render-diff endpoints.
+	Create(context.Context, *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.CreateSavedViewResponse], error)
+	Get(context.Context, *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.GetSavedViewResponse], error)
+	List(context.Context, *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error)
+	// Replaces a view. The identifier and the creation time of the view are kept.
+	Update(context.Context, *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.UpdateSavedViewResponse], error)
+	Delete(context.Context, *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[v1.DeleteSavedViewResponse], error)
+}
+
+// NewSavedViewsServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSavedViewsServiceHandler(svc SavedViewsServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	savedViewsServiceCreateHandler := connect.NewUnaryHandler(
+		SavedViewsServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(savedViewsServiceCreateMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedViewsServiceGetHandler := connect.NewUnaryHandler(
+		SavedViewsServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(savedViewsServiceGetMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedViewsServiceListHandler := connect.NewUnaryHandler(
+		SavedViewsServiceListProcedure,
+		svc.List,
+		connect.WithSchema(savedViewsServiceListMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedViewsServiceUpdateHandler := connect.NewUnaryHandler(
+		SavedViewsServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(savedViewsServiceUpdateMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	savedViewsServiceDeleteHandler := connect.NewUnaryHandler(
+		SavedViewsServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(savedViewsServiceDeleteMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/savedviews.v1.SavedViewsService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SavedViewsServiceCreateProcedure:
+			savedViewsServiceCreateHandler.ServeHTTP(w, r)
+		case SavedViewsServiceGetProcedure:
+			savedViewsServiceGetHandler.ServeHTTP(w, r)
+		case SavedViewsServiceListProcedure:
+			savedViewsServiceListHandler.ServeHTTP(w, r)
+		case SavedViewsServiceUpdateProcedure:
+			savedViewsServiceUpdateHandler.ServeHTTP(w, r)
+		case SavedViewsServiceDeleteProcedure:
+			savedViewsServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSavedViewsServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSavedViewsServiceHandler struct{}
+
+func (UnimplementedSavedViewsServiceHandler) Create(context.Context, *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.CreateSavedViewResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("savedviews.v1.SavedViewsService.Create is not implemented"))
+}
+
+func (UnimplementedSavedViewsServiceHandler) Get(context.Context, *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.GetSavedViewResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("savedviews.v1.SavedViewsService.Get is not implemented"))
+}
+
+func (UnimplementedSavedViewsServiceHandler) List(context.Context, *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("savedviews.v1.SavedViewsService.List is not implemented"))
+}
+
+func (UnimplementedSavedViewsServiceHandler) Update(context.Context, *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.UpdateSavedViewResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("savedviews.v1.SavedViewsService.Update is not implemented"))
+}
+
+func (UnimplementedSavedViewsServiceHandler) Delete(context.Context, *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[v1.DeleteSavedViewResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("savedviews.v1.SavedViewsService.Delete is not implemented"))
+}
@@ -0,0 +1,47 @@
+// Code generated by protoc-gen-connect-go-mux. DO NOT EDIT.
+//
+// Source: savedviews/v1/savedviews.proto
+
+package savedviewsv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	mux "github.com/gorilla/mux"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion0_1_0
+
+// RegisterSavedViewsServiceHandler register an HTTP handler to a mux.Router from the service
+// implementation.
+func RegisterSavedViewsServiceHandler(mux *mux.Router, svc SavedViewsServiceHandler, opts ...connect.HandlerOption) {
+	mux.Handle("/savedviews.v1.SavedViewsService/Create", connect.NewUnaryHandler(
+		"/savedviews.v1.SavedViewsService/Create",
+		svc.Create,
+		opts...,
+	))
+	mux.Handle("/savedviews.v1.SavedViewsService/Get", connect.NewUnaryHandler(
+		"/savedviews.v1.SavedViewsService/Get",
+		svc.Get,
+		opts...,
+	))
+	mux.Handle("/savedviews.v1.SavedViewsService/List", connect.NewUnaryHandler(
+		"/savedviews.v1.SavedViewsService/List",
+		svc.List,
+		opts...,
+	))
+	mux.Handle("/savedviews.v1.SavedViewsService/Update", connect.NewUnaryHandler(
+		"/savedviews.v1.SavedViewsService/Update",
+		svc.Update,
+		opts...,
+	))
+	mux.Handle("/savedviews.v1.SavedViewsService/Delete", connect.NewUnaryHandler(
+		"/savedviews.v1.SavedViewsService/Delete",
+		svc.Delete,
+		opts...,
+	))
+}
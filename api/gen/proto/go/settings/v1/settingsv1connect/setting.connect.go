@@ -37,19 +37,36 @@ const (
 	SettingsServiceGetProcedure = "/settings.v1.SettingsService/Get"
 	// SettingsServiceSetProcedure is the fully-qualified name of the SettingsService's Set RPC.
 	SettingsServiceSetProcedure = "/settings.v1.SettingsService/Set"
+	// SettingsServiceDeleteProcedure is the fully-qualified name of the SettingsService's Delete RPC.
+	SettingsServiceDeleteProcedure = "/settings.v1.SettingsService/Delete"
+	// SettingsServiceGetSchemaProcedure is the fully-qualified name of the SettingsService's GetSchema
+	// RPC.
+	SettingsServiceGetSchemaProcedure = "/settings.v1.SettingsService/GetSchema"
+	// SettingsServiceGetHistoryProcedure is the fully-qualified name of the SettingsService's
+	// GetHistory RPC.
+	SettingsServiceGetHistoryProcedure = "/settings.v1.SettingsService/GetHistory"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	settingsServiceServiceDescriptor   = v1.File_settings_v1_setting_proto.Services().ByName("SettingsService")
-	settingsServiceGetMethodDescriptor = settingsServiceServiceDescriptor.Methods().ByName("Get")
-	settingsServiceSetMethodDescriptor = settingsServiceServiceDescriptor.Methods().ByName("Set")
+	settingsServiceServiceDescriptor          = v1.File_settings_v1_setting_proto.Services().ByName("SettingsService")
+	settingsServiceGetMethodDescriptor        = settingsServiceServiceDescriptor.Methods().ByName("Get")
+	settingsServiceSetMethodDescriptor        = settingsServiceServiceDescriptor.Methods().ByName("Set")
+	settingsServiceDeleteMethodDescriptor     = settingsServiceServiceDescriptor.Methods().ByName("Delete")
+	settingsServiceGetSchemaMethodDescriptor  = settingsServiceServiceDescriptor.Methods().ByName("GetSchema")
+	settingsServiceGetHistoryMethodDescriptor = settingsServiceServiceDescriptor.Methods().ByName("GetHistory")
 )
 
 // SettingsServiceClient is a client for the settings.v1.SettingsService service.
 type SettingsServiceClient interface {
 	Get(context.Context, *connect.Request[v1.GetSettingsRequest]) (*connect.Response[v1.GetSettingsResponse], error)
 	Set(context.Context, *connect.Request[v1.SetSettingsRequest]) (*connect.Response[v1.SetSettingsResponse], error)
+	Delete(context.Context, *connect.Request[v1.DeleteSettingsRequest]) (*connect.Response[v1.DeleteSettingsResponse], error)
+	// GetSchema returns the settings which can be set, with their type and
+	// default value.
+	GetSchema(context.Context, *connect.Request[v1.GetSettingsSchemaRequest]) (*connect.Response[v1.GetSettingsSchemaResponse], error)
+	// GetHistory returns the changes made to the settings, oldest first.
+	GetHistory(context.Context, *connect.Request[v1.GetSettingsHistoryRequest]) (*connect.Response[v1.GetSettingsHistoryResponse], error)
 }
 
 // NewSettingsServiceClient constructs a client for the settings.v1.SettingsService service. By
@@ -74,13 +91,34 @@ func NewSettingsServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(settingsServiceSetMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		delete: connect.NewClient[v1.DeleteSettingsRequest, v1.DeleteSettingsResponse](
+			httpClient,
+			baseURL+SettingsServiceDeleteProcedure,
+			connect.WithSchema(settingsServiceDeleteMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getSchema: connect.NewClient[v1.GetSettingsSchemaRequest, v1.GetSettingsSchemaResponse](
+			httpClient,
+			baseURL+SettingsServiceGetSchemaProcedure,
+			connect.WithSchema(settingsServiceGetSchemaMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getHistory: connect.NewClient[v1.GetSettingsHistoryRequest, v1.GetSettingsHistoryResponse](
+			httpClient,
+			baseURL+SettingsServiceGetHistoryProcedure,
+			connect.WithSchema(settingsServiceGetHistoryMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // settingsServiceClient implements SettingsServiceClient.
 type settingsServiceClient struct {
-	get *connect.Client[v1.GetSettingsRequest, v1.GetSettingsResponse]
-	set *connect.Client[v1.SetSettingsRequest, v1.SetSettingsResponse]
+	get        *connect.Client[v1.GetSettingsRequest, v1.GetSettingsResponse]
+	set        *connect.Client[v1.SetSettingsRequest, v1.SetSettingsResponse]
+	delete     *connect.Client[v1.DeleteSettingsRequest, v1.DeleteSettingsResponse]
+	getSchema  *connect.Client[v1.GetSettingsSchemaRequest, v1.GetSettingsSchemaResponse]
+	getHistory *connect.Client[v1.GetSettingsHistoryRequest, v1.GetSettingsHistoryResponse]
 }
 
 // Get calls settings.v1.SettingsService.Get.
@@ -93,10 +131,31 @@ func (c *settingsServiceClient) Set(ctx context.Context, req *connect.Request[v1
 	return c.set.CallUnary(ctx, req)
 }
 
+// Delete calls settings.v1.SettingsService.Delete.
+func (c *settingsServiceClient) Delete(ctx context.Context, req *connect.Request[v1.DeleteSettingsRequest]) (*connect.Response[v1.DeleteSettingsResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// GetSchema calls settings.v1.SettingsService.GetSchema.
+func (c *settingsServiceClient) GetSchema(ctx context.Context, req *connect.Request[v1.GetSettingsSchemaRequest]) (*connect.Response[v1.GetSettingsSchemaResponse], error) {
+	return c.getSchema.CallUnary(ctx, req)
+}
+
+// GetHistory calls settings.v1.SettingsService.GetHistory.
+func (c *settingsServiceClient) GetHistory(ctx context.Context, req *connect.Request[v1.GetSettingsHistoryRequest]) (*connect.Response[v1.GetSettingsHistoryResponse], error) {
+	return c.getHistory.CallUnary(ctx, req)
+}
+
 // SettingsServiceHandler is an implementation of the settings.v1.SettingsService service.
 type SettingsServiceHandler interface {
 	Get(context.Context, *connect.Request[v1.GetSettingsRequest]) (*connect.Response[v1.GetSettingsResponse], error)
 	Set(context.Context, *connect.Request[v1.SetSettingsRequest]) (*connect.Response[v1.SetSettingsResponse], error)
+	Delete(context.Context, *connect.Request[v1.DeleteSettingsRequest]) (*connect.Response[v1.DeleteSettingsResponse], error)
+	// GetSchema returns the settings which can be set, with their type and
+	// default value.
+	GetSchema(context.Context, *connect.Request[v1.GetSettingsSchemaRequest]) (*connect.Response[v1.GetSettingsSchemaResponse], error)
+	// GetHistory returns the changes made to the settings, oldest first.
+	GetHistory(context.Context, *connect.Request[v1.GetSettingsHistoryRequest]) (*connect.Response[v1.GetSettingsHistoryResponse], error)
 }
 
 // NewSettingsServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -117,12 +176,36 @@ func NewSettingsServiceHandler(svc SettingsServiceHandler, opts ...connect.Handl
 		connect.WithSchema(settingsServiceSetMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	settingsServiceDeleteHandler := connect.NewUnaryHandler(
+		SettingsServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(settingsServiceDeleteMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceGetSchemaHandler := connect.NewUnaryHandler(
+		SettingsServiceGetSchemaProcedure,
+		svc.GetSchema,
+		connect.WithSchema(settingsServiceGetSchemaMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceGetHistoryHandler := connect.NewUnaryHandler(
+		SettingsServiceGetHistoryProcedure,
+		svc.GetHistory,
+		connect.WithSchema(settingsServiceGetHistoryMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/settings.v1.SettingsService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case SettingsServiceGetProcedure:
 			settingsServiceGetHandler.ServeHTTP(w, r)
 		case SettingsServiceSetProcedure:
 			settingsServiceSetHandler.ServeHTTP(w, r)
+		case SettingsServiceDeleteProcedure:
+			settingsServiceDeleteHandler.ServeHTTP(w, r)
+		case SettingsServiceGetSchemaProcedure:
+			settingsServiceGetSchemaHandler.ServeHTTP(w, r)
+		case SettingsServiceGetHistoryProcedure:
+			settingsServiceGetHistoryHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -139,3 +222,15 @@ func (UnimplementedSettingsServiceHandler) Get(context.Context, *connect.Request
 func (UnimplementedSettingsServiceHandler) Set(context.Context, *connect.Request[v1.SetSettingsRequest]) (*connect.Response[v1.SetSettingsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("settings.v1.SettingsService.Set is not implemented"))
 }
+
+func (UnimplementedSettingsServiceHandler) Delete(context.Context, *connect.Request[v1.DeleteSettingsRequest]) (*connect.Response[v1.DeleteSettingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("settings.v1.SettingsService.Delete is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) GetSchema(context.Context, *connect.Request[v1.GetSettingsSchemaRequest]) (*connect.Response[v1.GetSettingsSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("settings.v1.SettingsService.GetSchema is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) GetHistory(context.Context, *connect.Request[v1.GetSettingsHistoryRequest]) (*connect.Response[v1.GetSettingsHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("settings.v1.SettingsService.GetHistory is not implemented"))
+}
@@ -29,4 +29,19 @@ func RegisterSettingsServiceHandler(mux *mux.Router, svc SettingsServiceHandler,
 		svc.Set,
 		opts...,
 	))
+	mux.Handle("/settings.v1.SettingsService/Delete", connect.NewUnaryHandler(
+		"/settings.v1.SettingsService/Delete",
+		svc.Delete,
+		opts...,
+	))
+	mux.Handle("/settings.v1.SettingsService/GetSchema", connect.NewUnaryHandler(
+		"/settings.v1.SettingsService/GetSchema",
+		svc.GetSchema,
+		opts...,
+	))
+	mux.Handle("/settings.v1.SettingsService/GetHistory", connect.NewUnaryHandler(
+		"/settings.v1.SettingsService/GetHistory",
+		svc.GetHistory,
+		opts...,
+	))
 }
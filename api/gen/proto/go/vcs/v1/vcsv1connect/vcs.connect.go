@@ -37,6 +37,10 @@ const (
 	VCSServiceGithubAppProcedure = "/vcs.v1.VCSService/GithubApp"
 	// VCSServiceGithubLoginProcedure is the fully-qualified name of the VCSService's GithubLogin RPC.
 	VCSServiceGithubLoginProcedure = "/vcs.v1.VCSService/GithubLogin"
+	// VCSServiceGitlabAppProcedure is the fully-qualified name of the VCSService's GitlabApp RPC.
+	VCSServiceGitlabAppProcedure = "/vcs.v1.VCSService/GitlabApp"
+	// VCSServiceGitlabLoginProcedure is the fully-qualified name of the VCSService's GitlabLogin RPC.
+	VCSServiceGitlabLoginProcedure = "/vcs.v1.VCSService/GitlabLogin"
 	// VCSServiceGetFileProcedure is the fully-qualified name of the VCSService's GetFile RPC.
 	VCSServiceGetFileProcedure = "/vcs.v1.VCSService/GetFile"
 	// VCSServiceGetCommitProcedure is the fully-qualified name of the VCSService's GetCommit RPC.
@@ -48,6 +52,8 @@ var (
 	vCSServiceServiceDescriptor           = v1.File_vcs_v1_vcs_proto.Services().ByName("VCSService")
 	vCSServiceGithubAppMethodDescriptor   = vCSServiceServiceDescriptor.Methods().ByName("GithubApp")
 	vCSServiceGithubLoginMethodDescriptor = vCSServiceServiceDescriptor.Methods().ByName("GithubLogin")
+	vCSServiceGitlabAppMethodDescriptor   = vCSServiceServiceDescriptor.Methods().ByName("GitlabApp")
+	vCSServiceGitlabLoginMethodDescriptor = vCSServiceServiceDescriptor.Methods().ByName("GitlabLogin")
 	vCSServiceGetFileMethodDescriptor     = vCSServiceServiceDescriptor.Methods().ByName("GetFile")
 	vCSServiceGetCommitMethodDescriptor   = vCSServiceServiceDescriptor.Methods().ByName("GetCommit")
 )
@@ -56,6 +62,8 @@ var (
 type VCSServiceClient interface {
 	GithubApp(context.Context, *connect.Request[v1.GithubAppRequest]) (*connect.Response[v1.GithubAppResponse], error)
 	GithubLogin(context.Context, *connect.Request[v1.GithubLoginRequest]) (*connect.Response[v1.GithubLoginResponse], error)
+	GitlabApp(context.Context, *connect.Request[v1.GitlabAppRequest]) (*connect.Response[v1.GitlabAppResponse], error)
+	GitlabLogin(context.Context, *connect.Request[v1.GitlabLoginRequest]) (*connect.Response[v1.GitlabLoginResponse], error)
 	GetFile(context.Context, *connect.Request[v1.GetFileRequest]) (*connect.Response[v1.GetFileResponse], error)
 	GetCommit(context.Context, *connect.Request[v1.GetCommitRequest]) (*connect.Response[v1.GetCommitResponse], error)
 }
@@ -82,6 +90,18 @@ func NewVCSServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(vCSServiceGithubLoginMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		gitlabApp: connect.NewClient[v1.GitlabAppRequest, v1.GitlabAppResponse](
+			httpClient,
+			baseURL+VCSServiceGitlabAppProcedure,
+			connect.WithSchema(vCSServiceGitlabAppMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		gitlabLogin: connect.NewClient[v1.GitlabLoginRequest, v1.GitlabLoginResponse](
+			httpClient,
+			baseURL+VCSServiceGitlabLoginProcedure,
+			connect.WithSchema(vCSServiceGitlabLoginMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		getFile: connect.NewClient[v1.GetFileRequest, v1.GetFileResponse](
 			httpClient,
 			baseURL+VCSServiceGetFileProcedure,
@@ -101,6 +121,8 @@ func NewVCSServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 type vCSServiceClient struct {
 	githubApp   *connect.Client[v1.GithubAppRequest, v1.GithubAppResponse]
 	githubLogin *connect.Client[v1.GithubLoginRequest, v1.GithubLoginResponse]
+	gitlabApp   *connect.Client[v1.GitlabAppRequest, v1.GitlabAppResponse]
+	gitlabLogin *connect.Client[v1.GitlabLoginRequest, v1.GitlabLoginResponse]
 	getFile     *connect.Client[v1.GetFileRequest, v1.GetFileResponse]
 	getCommit   *connect.Client[v1.GetCommitRequest, v1.GetCommitResponse]
 }
@@ -115,6 +137,16 @@ func (c *vCSServiceClient) GithubLogin(ctx context.Context, req *connect.Request
 	return c.githubLogin.CallUnary(ctx, req)
 }
 
+// GitlabApp calls vcs.v1.VCSService.GitlabApp.
+func (c *vCSServiceClient) GitlabApp(ctx context.Context, req *connect.Request[v1.GitlabAppRequest]) (*connect.Response[v1.GitlabAppResponse], error) {
+	return c.gitlabApp.CallUnary(ctx, req)
+}
+
+// GitlabLogin calls vcs.v1.VCSService.GitlabLogin.
+func (c *vCSServiceClient) GitlabLogin(ctx context.Context, req *connect.Request[v1.GitlabLoginRequest]) (*connect.Response[v1.GitlabLoginResponse], error) {
+	return c.gitlabLogin.CallUnary(ctx, req)
+}
+
 // GetFile calls vcs.v1.VCSService.GetFile.
 func (c *vCSServiceClient) GetFile(ctx context.Context, req *connect.Request[v1.GetFileRequest]) (*connect.Response[v1.GetFileResponse], error) {
 	return c.getFile.CallUnary(ctx, req)
@@ -129,6 +161,8 @@ func (c *vCSServiceClient) GetCommit(ctx context.Context, req *connect.Request[v
 type VCSServiceHandler interface {
 	GithubApp(context.Context, *connect.Request[v1.GithubAppRequest]) (*connect.Response[v1.GithubAppResponse], error)
 	GithubLogin(context.Context, *connect.Request[v1.GithubLoginRequest]) (*connect.Response[v1.GithubLoginResponse], error)
+	GitlabApp(context.Context, *connect.Request[v1.GitlabAppRequest]) (*connect.Response[v1.GitlabAppResponse], error)
+	GitlabLogin(context.Context, *connect.Request[v1.GitlabLoginRequest]) (*connect.Response[v1.GitlabLoginResponse], error)
 	GetFile(context.Context, *connect.Request[v1.GetFileRequest]) (*connect.Response[v1.GetFileResponse], error)
 	GetCommit(context.Context, *connect.Request[v1.GetCommitRequest]) (*connect.Response[v1.GetCommitResponse], error)
 }
@@ -151,6 +185,18 @@ func NewVCSServiceHandler(svc VCSServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(vCSServiceGithubLoginMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	vCSServiceGitlabAppHandler := connect.NewUnaryHandler(
+		VCSServiceGitlabAppProcedure,
+		svc.GitlabApp,
+		connect.WithSchema(vCSServiceGitlabAppMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	vCSServiceGitlabLoginHandler := connect.NewUnaryHandler(
+		VCSServiceGitlabLoginProcedure,
+		svc.GitlabLogin,
+		connect.WithSchema(vCSServiceGitlabLoginMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	vCSServiceGetFileHandler := connect.NewUnaryHandler(
 		VCSServiceGetFileProcedure,
 		svc.GetFile,
@@ -169,6 +215,10 @@ func NewVCSServiceHandler(svc VCSServiceHandler, opts ...connect.HandlerOption)
 			vCSServiceGithubAppHandler.ServeHTTP(w, r)
 		case VCSServiceGithubLoginProcedure:
 			vCSServiceGithubLoginHandler.ServeHTTP(w, r)
+		case VCSServiceGitlabAppProcedure:
+			vCSServiceGitlabAppHandler.ServeHTTP(w, r)
+		case VCSServiceGitlabLoginProcedure:
+			vCSServiceGitlabLoginHandler.ServeHTTP(w, r)
 		case VCSServiceGetFileProcedure:
 			vCSServiceGetFileHandler.ServeHTTP(w, r)
 		case VCSServiceGetCommitProcedure:
@@ -190,6 +240,14 @@ func (UnimplementedVCSServiceHandler) GithubLogin(context.Context, *connect.Requ
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.GithubLogin is not implemented"))
 }
 
+func (UnimplementedVCSServiceHandler) GitlabApp(context.Context, *connect.Request[v1.GitlabAppRequest]) (*connect.Response[v1.GitlabAppResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.GitlabApp is not implemented"))
+}
+
+func (UnimplementedVCSServiceHandler) GitlabLogin(context.Context, *connect.Request[v1.GitlabLoginRequest]) (*connect.Response[v1.GitlabLoginResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.GitlabLogin is not implemented"))
+}
+
 func (UnimplementedVCSServiceHandler) GetFile(context.Context, *connect.Request[v1.GetFileRequest]) (*connect.Response[v1.GetFileResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.GetFile is not implemented"))
 }
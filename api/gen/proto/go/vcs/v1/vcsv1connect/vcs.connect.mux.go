@@ -29,6 +29,16 @@ func RegisterVCSServiceHandler(mux *mux.Router, svc VCSServiceHandler, opts ...c
 		svc.GithubLogin,
 		opts...,
 	))
+	mux.Handle("/vcs.v1.VCSService/GitlabApp", connect.NewUnaryHandler(
+		"/vcs.v1.VCSService/GitlabApp",
+		svc.GitlabApp,
+		opts...,
+	))
+	mux.Handle("/vcs.v1.VCSService/GitlabLogin", connect.NewUnaryHandler(
+		"/vcs.v1.VCSService/GitlabLogin",
+		svc.GitlabLogin,
+		opts...,
+	))
 	mux.Handle("/vcs.v1.VCSService/GetFile", connect.NewUnaryHandler(
 		"/vcs.v1.VCSService/GetFile",
 		svc.GetFile,
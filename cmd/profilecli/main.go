@@ -64,6 +64,8 @@ func main() {
 	queryMergeCmd := queryCmd.Command("merge", "Request merged profile.")
 	queryMergeOutput := queryMergeCmd.Flag("output", "How to output the result, examples: console, raw, pprof=./my.pprof").Default("console").String()
 	queryMergeParams := addQueryMergeParams(queryMergeCmd)
+	queryPGOCmd := queryCmd.Command("pgo", "Request merged CPU profile prepared for Go profile-guided optimization (default.pgo).")
+	queryPGOParams := addQueryPGOParams(queryPGOCmd)
 	querySeriesCmd := queryCmd.Command("series", "Request series labels.")
 	querySeriesParams := addQuerySeriesParams(querySeriesCmd)
 
@@ -101,6 +103,10 @@ func main() {
 		if err := queryMerge(ctx, queryMergeParams, *queryMergeOutput); err != nil {
 			os.Exit(checkError(err))
 		}
+	case queryPGOCmd.FullCommand():
+		if err := queryPGO(ctx, queryPGOParams); err != nil {
+			os.Exit(checkError(err))
+		}
 	case querySeriesCmd.FullCommand():
 		if err := querySeries(ctx, querySeriesParams); err != nil {
 			os.Exit(checkError(err))
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/runutil"
+	"github.com/pkg/errors"
+)
+
+type queryPGOParams struct {
+	*queryParams
+	ProfileType     string
+	MinEdgeFraction float64
+	Output          string
+}
+
+func addQueryPGOParams(queryCmd commander) *queryPGOParams {
+	params := new(queryPGOParams)
+	params.queryParams = addQueryParams(queryCmd)
+	queryCmd.Flag("profile-type", "CPU profile type to query.").Default("process_cpu:cpu:nanoseconds:cpu:nanoseconds").StringVar(&params.ProfileType)
+	queryCmd.Flag("min-edge-fraction", "Prune call edges with the weight below the fraction of the profile total, e.g. 0.001. Zero disables pruning.").Default("0").Float64Var(&params.MinEdgeFraction)
+	queryCmd.Flag("output", "Path of the PGO profile file. An existing file is overwritten.").Default("default.pgo").StringVar(&params.Output)
+	return params
+}
+
+func queryPGO(ctx context.Context, params *queryPGOParams) (err error) {
+	from, to, err := params.parseFromTo()
+	if err != nil {
+		return err
+	}
+
+	level.Info(logger).Log("msg", "query PGO profile from profile store", "url", params.URL, "from", from, "to", to, "query", params.Query, "type", params.ProfileType)
+
+	values := url.Values{
+		"query":           []string{params.ProfileType + params.Query},
+		"from":            []string{strconv.FormatInt(from.UnixMilli(), 10)},
+		"until":           []string{strconv.FormatInt(to.UnixMilli(), 10)},
+		"minEdgeFraction": []string{strconv.FormatFloat(params.MinEdgeFraction, 'f', -1, 64)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(params.URL, "/")+"/pyroscope/pgo?"+values.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := params.httpClient().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to query")
+	}
+	defer runutil.CloseWithErrCapture(&err, resp.Body, "failed to close response body")
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return fmt.Errorf("failed to query: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	f, err := os.Create(params.Output)
+	if err != nil {
+		return errors.Wrap(err, "failed to create PGO profile file")
+	}
+	defer runutil.CloseWithErrCapture(&err, f, "failed to close PGO profile file")
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		return errors.Wrap(err, "failed to write PGO profile")
+	}
+	level.Info(logger).Log("msg", "PGO profile written", "path", params.Output)
+	return nil
+}
@@ -90,13 +90,15 @@ func addQueryParams(queryCmd commander) *queryParams {
 
 type queryMergeParams struct {
 	*queryParams
-	ProfileType string
+	ProfileType  string
+	SampleLabels []string
 }
 
 func addQueryMergeParams(queryCmd commander) *queryMergeParams {
 	params := new(queryMergeParams)
 	params.queryParams = addQueryParams(queryCmd)
 	queryCmd.Flag("profile-type", "Profile type to query.").Default("process_cpu:cpu:nanoseconds:cpu:nanoseconds").StringVar(&params.ProfileType)
+	queryCmd.Flag("sample-label", "Sample label to retain in the profile, e.g. for use with pprof -tagfocus. Use span_id to retain the span IDs of samples. Can be specified multiple times.").StringsVar(&params.SampleLabels)
 	return params
 }
 
@@ -115,6 +117,7 @@ func queryMerge(ctx context.Context, params *queryMergeParams, outputFlag string
 		Start:         from.UnixMilli(),
 		End:           to.UnixMilli(),
 		LabelSelector: params.Query,
+		SampleLabels:  params.SampleLabels,
 	}))
 
 	if err != nil {
@@ -43,7 +43,8 @@ int do_perf_event(struct bpf_perf_event_data *ctx) {
                 .type = PROFILING_TYPE_UNKNOWN,
                 .collect_kernel = 0,
                 .collect_user = 0,
-                .padding_ = 0
+                .off_cpu = 0,
+                .sample_drop_threshold = 0
         };
         if (bpf_map_update_elem(&pids, &tgid, &unknown, BPF_NOEXIST)) {
             bpf_dbg_printk("failed to update pids map. probably concurrent update\n");
@@ -61,6 +62,12 @@ int do_perf_event(struct bpf_perf_event_data *ctx) {
         return 0;
     }
 
+    // The perf events are shared by all the processes,
+    // lower sample rates are implemented by dropping samples.
+    if (config->sample_drop_threshold && bpf_get_prandom_u32() < config->sample_drop_threshold) {
+        return 0;
+    }
+
     if (config->type == PROFILING_TYPE_PYTHON) {
         bpf_tail_call(ctx, &progs, PROG_IDX_PYTHON);
         return 0;
@@ -87,6 +94,55 @@ int do_perf_event(struct bpf_perf_event_data *ctx) {
     return 0;
 }
 
+// Measures the time the threads spend off CPU, including the time spent
+// waiting to be scheduled, for the processes with off_cpu enabled.
+SEC("tracepoint/sched/sched_switch")
+int off_cpu(struct trace_event_raw_sched_switch *ctx) {
+    // The current thread is switched off CPU.
+    u32 tid = (u32) bpf_get_current_pid_tgid();
+    if (tid != 0) {
+        u32 tgid = 0;
+        current_pid(global_config.ns_pid_ino, &tgid);
+        struct pid_config *config = NULL;
+        if (tgid != 0) {
+            config = bpf_map_lookup_elem(&pids, &tgid);
+        }
+        if (config != NULL && config->off_cpu) {
+            struct off_cpu_start start = {};
+            start.key.pid = tgid;
+            start.key.kern_stack = -1;
+            start.key.user_stack = -1;
+            if (config->collect_kernel) {
+                start.key.kern_stack = bpf_get_stackid(ctx, &off_cpu_stacks, KERN_STACKID_FLAGS);
+            }
+            if (config->collect_user) {
+                start.key.user_stack = bpf_get_stackid(ctx, &off_cpu_stacks, USER_STACKID_FLAGS);
+            }
+            start.time = bpf_ktime_get_ns();
+            bpf_map_update_elem(&off_cpu_starts, &tid, &start, BPF_ANY);
+        }
+    }
+
+    // The next thread is switched in, account the time it spent off CPU.
+    u32 next_tid = ctx->next_pid;
+    if (next_tid == 0) {
+        return 0;
+    }
+    struct off_cpu_start *start = bpf_map_lookup_elem(&off_cpu_starts, &next_tid);
+    if (start == NULL) {
+        return 0;
+    }
+    u64 delta = bpf_ktime_get_ns() - start->time;
+    struct sample_key key = start->key;
+    bpf_map_delete_elem(&off_cpu_starts, &next_tid);
+
+    u64 *val = bpf_map_lookup_elem(&off_cpu_counts, &key);
+    if (val)
+        __sync_fetch_and_add(val, delta);
+    else
+        bpf_map_update_elem(&off_cpu_counts, &key, &delta, BPF_NOEXIST);
+    return 0;
+}
 
 SEC("kprobe/disassociate_ctty")
 int BPF_KPROBE(disassociate_ctty, int on_exit) {
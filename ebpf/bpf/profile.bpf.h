@@ -14,7 +14,9 @@ struct pid_config {
     uint8_t type;
     uint8_t collect_user;
     uint8_t collect_kernel;
-    uint8_t padding_;
+    uint8_t off_cpu;
+    // The probability of dropping a CPU sample, scaled to the uint32 range.
+    uint32_t sample_drop_threshold;
 };
 
 #define OP_REQUEST_UNKNOWN_PROCESS_INFO 1
@@ -58,6 +60,34 @@ struct {
 
 #include "stacks.h"
 
+struct off_cpu_start {
+    uint64_t time;
+    struct sample_key key;
+};
+
+// The time and the stack of the threads switched off CPU, keyed by the thread id.
+struct {
+    __uint(type, BPF_MAP_TYPE_LRU_HASH);
+    __type(key, u32);
+    __type(value, struct off_cpu_start);
+    __uint(max_entries, PROFILE_MAPS_SIZE);
+} off_cpu_starts SEC(".maps");
+
+struct {
+    __uint(type, BPF_MAP_TYPE_STACK_TRACE);
+    __uint(key_size, sizeof(u32));
+    __uint(value_size, PERF_MAX_STACK_DEPTH * sizeof(u64));
+    __uint(max_entries, PROFILE_MAPS_SIZE);
+} off_cpu_stacks SEC(".maps");
+
+// The nanoseconds spent off CPU by the stacks.
+struct {
+    __uint(type, BPF_MAP_TYPE_HASH);
+    __type(key, struct sample_key);
+    __type(value, u64);
+    __uint(max_entries, PROFILE_MAPS_SIZE);
+} off_cpu_counts SEC(".maps");
+
 
 
 #endif // PROFILE_BPF_H
\ No newline at end of file
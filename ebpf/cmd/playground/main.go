@@ -9,7 +9,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 	"time"
 
 	"connectrpc.com/connect"
@@ -27,6 +29,7 @@ import (
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	ebpfspy "github.com/grafana/pyroscope/ebpf"
 	"github.com/grafana/pyroscope/ebpf/pprof"
+	"github.com/grafana/pyroscope/ebpf/replay"
 	"github.com/grafana/pyroscope/ebpf/sd"
 	"github.com/grafana/pyroscope/ebpf/symtab"
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,6 +46,9 @@ var collectFreq = flag.Duration("collect.freq",
 	15*time.Second,
 	"")
 
+var recordDir = flag.String("record", "",
+	"directory to record the collected samples to, for replaying them offline. The recording is written when the playground is stopped")
+
 var (
 	config  *Config
 	logger  log.Logger
@@ -100,6 +106,8 @@ func main() {
 
 	discoverTicker := time.NewTicker(*discoverFreq)
 	collectTicker := time.NewTicker(*collectFreq)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
 	for {
 		select {
@@ -107,6 +115,10 @@ func main() {
 			session.UpdateTargets(convertTargetOptions())
 		case <-collectTicker.C:
 			collectProfiles(profiles)
+		case <-stop:
+			// Stopping the session flushes the recording.
+			session.Stop()
+			return
 		}
 	}
 }
@@ -188,6 +200,7 @@ func convertSessionOptions() ebpfspy.SessionOptions {
 	return ebpfspy.SessionOptions{
 		CollectUser:               config.CollectUser,
 		CollectKernel:             config.CollectKernel,
+		OffCPUEnabled:             config.OffCPUEnabled,
 		SampleRate:                config.SampleRate,
 		UnknownSymbolAddress:      config.UnknownSymbolAddress,
 		UnknownSymbolModuleOffset: config.UnknownSymbolModuleOffset,
@@ -197,7 +210,15 @@ func convertSessionOptions() ebpfspy.SessionOptions {
 		VerifierLogSize:           1024 * 1024 * 20,
 		PythonBPFErrorLogEnabled:  config.PythonBPFLogErr,
 		PythonBPFDebugLogEnabled:  config.PythonBPFLogDebug,
+		Recorder:                  newRecorder(),
+	}
+}
+
+func newRecorder() *replay.Recorder {
+	if *recordDir == "" {
+		return nil
 	}
+	return replay.NewRecorder(*recordDir)
 }
 
 func getConfig() *Config {
@@ -257,6 +278,7 @@ var defaultConfig = Config{
 type Config struct {
 	CollectUser               bool
 	CollectKernel             bool
+	OffCPUEnabled             bool
 	UnknownSymbolModuleOffset bool
 	UnknownSymbolAddress      bool
 	PythonEnabled             bool
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 	"unsafe"
@@ -11,6 +13,7 @@ import (
 	"github.com/cespare/xxhash/v2"
 	"github.com/google/pprof/profile"
 	"github.com/grafana/pyroscope/ebpf/sd"
+	"github.com/grafana/pyroscope/ebpf/symtab/elf"
 	"github.com/klauspost/compress/gzip"
 	"github.com/prometheus/prometheus/model/labels"
 )
@@ -32,6 +35,12 @@ type SampleType uint32
 var SampleTypeCpu = SampleType(0)
 var SampleTypeMem = SampleType(1)
 
+// SampleTypeOffCpu samples measure the time threads spend off CPU,
+// the value is the duration in nanoseconds.
+var SampleTypeOffCpu = SampleType(2)
+
+const offCpuMetricName = "process_off_cpu"
+
 type SampleAggregation bool
 
 var (
@@ -51,8 +60,14 @@ type ProfileSample struct {
 	SampleType  SampleType
 	Aggregation SampleAggregation
 	Stack       []string
-	Value       uint64
-	Value2      uint64
+	// Frames optionally holds source code locations of the Stack entries.
+	// If not empty, it is of the same length as Stack.
+	Frames [][]elf.Frame
+	Value  uint64
+	Value2 uint64
+	// SampleRate overrides the sample rate of the
+	// builders options for CPU samples if not zero.
+	SampleRate int64
 }
 
 type BuildersOptions struct {
@@ -105,11 +120,22 @@ func (b *ProfileBuilders) BuilderForSample(sample *ProfileSample) *ProfileBuilde
 	var sampleType []*profile.ValueType
 	var periodType *profile.ValueType
 	var period int64
-	if sample.SampleType == SampleTypeCpu {
+	switch sample.SampleType {
+	case SampleTypeCpu:
 		sampleType = []*profile.ValueType{{Type: "cpu", Unit: "nanoseconds"}}
 		periodType = &profile.ValueType{Type: "cpu", Unit: "nanoseconds"}
-		period = time.Second.Nanoseconds() / b.opt.SampleRate
-	} else {
+		sampleRate := b.opt.SampleRate
+		if sample.SampleRate != 0 {
+			sampleRate = sample.SampleRate
+		}
+		period = time.Second.Nanoseconds() / sampleRate
+	case SampleTypeOffCpu:
+		sampleType = []*profile.ValueType{{Type: "off_cpu", Unit: "nanoseconds"}}
+		periodType = &profile.ValueType{Type: "off_cpu", Unit: "nanoseconds"}
+		period = 1
+		// Off-CPU profiles must not be merged with CPU profiles of the target.
+		labels = labelsWithMetricName(labels, offCpuMetricName)
+	default:
 		sampleType = []*profile.ValueType{{Type: "alloc_objects", Unit: "count"}, {Type: "alloc_space", Unit: "bytes"}}
 		periodType = &profile.ValueType{Type: "space", Unit: "bytes"}
 		period = 512 * 1024 // todo
@@ -149,11 +175,17 @@ type ProfileBuilder struct {
 	tmpLocationIDs []uint64
 }
 
+func labelsWithMetricName(lbls labels.Labels, name string) labels.Labels {
+	b := labels.NewBuilder(lbls)
+	b.Set(labels.MetricName, name)
+	return b.Labels()
+}
+
 func (p *ProfileBuilder) CreateSample(inputSample *ProfileSample) {
 	sample := p.newSample(inputSample)
 	p.addValue(inputSample, sample)
 	for i, s := range inputSample.Stack {
-		sample.Location[i] = p.addLocation(s)
+		sample.Location[i] = p.addLocation(s, inputSample.frames(i))
 	}
 	p.Profile.Sample = append(p.Profile.Sample, sample)
 }
@@ -161,8 +193,8 @@ func (p *ProfileBuilder) CreateSample(inputSample *ProfileSample) {
 func (p *ProfileBuilder) CreateSampleOrAddValue(inputSample *ProfileSample) {
 	p.tmpLocations = p.tmpLocations[:0]
 	p.tmpLocationIDs = p.tmpLocationIDs[:0]
-	for _, s := range inputSample.Stack {
-		loc := p.addLocation(s)
+	for i, s := range inputSample.Stack {
+		loc := p.addLocation(s, inputSample.frames(i))
 		p.tmpLocations = append(p.tmpLocations, loc)
 		p.tmpLocationIDs = append(p.tmpLocationIDs, loc.ID)
 	}
@@ -179,8 +211,21 @@ func (p *ProfileBuilder) CreateSampleOrAddValue(inputSample *ProfileSample) {
 	p.Profile.Sample = append(p.Profile.Sample, sample)
 }
 
-func (p *ProfileBuilder) addLocation(function string) *profile.Location {
-	loc, ok := p.locations[function]
+func (s *ProfileSample) frames(i int) []elf.Frame {
+	if len(s.Frames) == 0 {
+		return nil
+	}
+	return s.Frames[i]
+}
+
+// addLocation returns the location of the function. If frames are present,
+// the location has a line per frame: inlined functions go first.
+func (p *ProfileBuilder) addLocation(function string, frames []elf.Frame) *profile.Location {
+	key := function
+	if len(frames) > 0 {
+		key = locationKey(frames)
+	}
+	loc, ok := p.locations[key]
 	if ok {
 		return loc
 	}
@@ -189,30 +234,58 @@ func (p *ProfileBuilder) addLocation(function string) *profile.Location {
 	loc = &profile.Location{
 		ID:      id,
 		Mapping: p.Profile.Mapping[0],
-		Line: []profile.Line{
+	}
+	if len(frames) == 0 {
+		loc.Line = []profile.Line{
 			{
-				Function: p.addFunction(function),
+				Function: p.addFunction(function, ""),
 			},
-		},
+		}
+	} else {
+		loc.Line = make([]profile.Line, len(frames))
+		for i, f := range frames {
+			loc.Line[i] = profile.Line{
+				Function: p.addFunction(f.Name, f.File),
+				Line:     int64(f.Line),
+			}
+		}
 	}
 	p.Profile.Location = append(p.Profile.Location, loc)
-	p.locations[function] = loc
+	p.locations[key] = loc
 	return loc
 }
 
-func (p *ProfileBuilder) addFunction(function string) *profile.Function {
-	f, ok := p.functions[function]
+func locationKey(frames []elf.Frame) string {
+	var sb strings.Builder
+	for _, f := range frames {
+		sb.WriteString(f.Name)
+		sb.WriteByte(0)
+		sb.WriteString(f.File)
+		sb.WriteByte(0)
+		sb.WriteString(strconv.Itoa(f.Line))
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+func (p *ProfileBuilder) addFunction(function, file string) *profile.Function {
+	key := function
+	if file != "" {
+		key = function + "\x00" + file
+	}
+	f, ok := p.functions[key]
 	if ok {
 		return f
 	}
 
 	id := uint64(len(p.Profile.Function) + 1)
 	f = &profile.Function{
-		ID:   id,
-		Name: function,
+		ID:       id,
+		Name:     function,
+		Filename: file,
 	}
 	p.Profile.Function = append(p.Profile.Function, f)
-	p.functions[function] = f
+	p.functions[key] = f
 	return f
 }
 
@@ -247,20 +320,23 @@ func uint64Bytes(s []uint64) []byte {
 }
 func (p *ProfileBuilder) newSample(inputSample *ProfileSample) *profile.Sample {
 	sample := new(profile.Sample)
-	if inputSample.SampleType == SampleTypeCpu {
-		sample.Value = []int64{0}
-	} else {
+	if inputSample.SampleType == SampleTypeMem {
 		sample.Value = []int64{0, 0}
+	} else {
+		sample.Value = []int64{0}
 	}
 	sample.Location = make([]*profile.Location, len(inputSample.Stack))
 	return sample
 }
 
 func (p *ProfileBuilder) addValue(inputSample *ProfileSample, sample *profile.Sample) {
-	if inputSample.SampleType == SampleTypeCpu {
+	switch inputSample.SampleType {
+	case SampleTypeCpu:
 		sample.Value[0] += int64(inputSample.Value) * p.Profile.Period
-	} else {
+	case SampleTypeMem:
 		sample.Value[0] += int64(inputSample.Value)
 		sample.Value[1] += int64(inputSample.Value2)
+	default:
+		sample.Value[0] += int64(inputSample.Value)
 	}
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/pprof/profile"
 	"github.com/grafana/pyroscope/ebpf/sd"
+	"github.com/grafana/pyroscope/ebpf/symtab/elf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -113,6 +114,95 @@ func TestMergeSamples(t *testing.T) {
 	}
 }
 
+func TestSampleFrames(t *testing.T) {
+	builders := NewProfileBuilders(BuildersOptions{
+		SampleRate: int64(97),
+	})
+	s := sample([]string{"comm", "main", "write"}, 1)
+	s.Frames = [][]elf.Frame{
+		nil,
+		{
+			{Name: "inlined", File: "lib.c", Line: 3},
+			{Name: "main", File: "main.c", Line: 42},
+		},
+		nil,
+	}
+	builders.AddSample(s)
+	s2 := sample([]string{"comm", "main", "write"}, 1)
+	s2.Frames = [][]elf.Frame{
+		nil,
+		{
+			{Name: "main", File: "main.c", Line: 43},
+		},
+		nil,
+	}
+	builders.AddSample(s2)
+	builder := builders.BuilderForSample(s)
+
+	buf := bytes.NewBuffer(nil)
+	_, err := builder.Write(buf)
+	require.NoError(t, err)
+	parsed, err := profile.Parse(buf)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(parsed.Sample))
+	require.Equal(t, 4, len(parsed.Location))
+	require.Equal(t, 4, len(parsed.Function))
+	lines := parsed.Sample[0].Location[1].Line
+	require.Equal(t, 2, len(lines))
+	require.Equal(t, "inlined", lines[0].Function.Name)
+	require.Equal(t, "lib.c", lines[0].Function.Filename)
+	require.Equal(t, int64(3), lines[0].Line)
+	require.Equal(t, "main", lines[1].Function.Name)
+	require.Equal(t, int64(42), lines[1].Line)
+	require.Equal(t, lines[1].Function, parsed.Sample[1].Location[1].Line[0].Function)
+	require.Equal(t, int64(43), parsed.Sample[1].Location[1].Line[0].Line)
+}
+
+func TestOffCpuSamples(t *testing.T) {
+	builders := NewProfileBuilders(BuildersOptions{
+		SampleRate: int64(97),
+	})
+	cpu := sample([]string{"a", "b"}, 3)
+	offCpu := sample([]string{"a", "c"}, 5)
+	offCpu.SampleType = SampleTypeOffCpu
+	builders.AddSample(cpu)
+	builders.AddSample(offCpu)
+	builders.AddSample(offCpu)
+	require.Equal(t, 2, len(builders.Builders))
+
+	builder := builders.BuilderForSample(offCpu)
+	require.Equal(t, "process_off_cpu", builder.Labels.Get("__name__"))
+	require.Equal(t, "bar", builder.Labels.Get("foo"))
+	require.Equal(t, "process_cpu", builders.BuilderForSample(cpu).Labels.Get("__name__"))
+
+	buf := bytes.NewBuffer(nil)
+	_, err := builder.Write(buf)
+	require.NoError(t, err)
+	parsed, err := profile.Parse(buf)
+	require.NoError(t, err)
+	require.Equal(t, "off_cpu", parsed.SampleType[0].Type)
+	require.Equal(t, "nanoseconds", parsed.SampleType[0].Unit)
+	require.Equal(t, map[string]int64{"a;c": 10}, stackCollapse(parsed))
+}
+
+func TestSampleRateOverride(t *testing.T) {
+	builders := NewProfileBuilders(BuildersOptions{
+		SampleRate: int64(100),
+	})
+	s := sample([]string{"a", "b"}, 3)
+	s.SampleRate = 10
+	builders.AddSample(s)
+
+	buf := bytes.NewBuffer(nil)
+	_, err := builders.BuilderForSample(s).Write(buf)
+	require.NoError(t, err)
+	parsed, err := profile.Parse(buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(100_000_000), parsed.Period)
+	require.Equal(t, map[string]int64{"a;b": 300_000_000}, stackCollapse(parsed))
+}
+
 func stackCollapse(parsed *profile.Profile) map[string]int64 {
 	stacks := map[string]int64{}
 	for _, sample := range parsed.Sample {
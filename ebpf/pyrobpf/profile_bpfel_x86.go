@@ -14,11 +14,17 @@ import (
 
 type ProfileGlobalConfigT struct{ NsPidIno uint64 }
 
+type ProfileOffCpuStart struct {
+	Time uint64
+	Key  ProfileSampleKey
+}
+
 type ProfilePidConfig struct {
-	Type          uint8
-	CollectUser   uint8
-	CollectKernel uint8
-	Padding       uint8
+	Type                uint8
+	CollectUser         uint8
+	CollectKernel       uint8
+	OffCpu              uint8
+	SampleDropThreshold uint32
 }
 
 type ProfilePidEvent struct {
@@ -77,17 +83,21 @@ type ProfileProgramSpecs struct {
 	DisassociateCtty *ebpf.ProgramSpec `ebpf:"disassociate_ctty"`
 	DoPerfEvent      *ebpf.ProgramSpec `ebpf:"do_perf_event"`
 	Exec             *ebpf.ProgramSpec `ebpf:"exec"`
+	OffCpu           *ebpf.ProgramSpec `ebpf:"off_cpu"`
 }
 
 // ProfileMapSpecs contains maps before they are loaded into the kernel.
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type ProfileMapSpecs struct {
-	Counts *ebpf.MapSpec `ebpf:"counts"`
-	Events *ebpf.MapSpec `ebpf:"events"`
-	Pids   *ebpf.MapSpec `ebpf:"pids"`
-	Progs  *ebpf.MapSpec `ebpf:"progs"`
-	Stacks *ebpf.MapSpec `ebpf:"stacks"`
+	Counts       *ebpf.MapSpec `ebpf:"counts"`
+	Events       *ebpf.MapSpec `ebpf:"events"`
+	OffCpuCounts *ebpf.MapSpec `ebpf:"off_cpu_counts"`
+	OffCpuStacks *ebpf.MapSpec `ebpf:"off_cpu_stacks"`
+	OffCpuStarts *ebpf.MapSpec `ebpf:"off_cpu_starts"`
+	Pids         *ebpf.MapSpec `ebpf:"pids"`
+	Progs        *ebpf.MapSpec `ebpf:"progs"`
+	Stacks       *ebpf.MapSpec `ebpf:"stacks"`
 }
 
 // ProfileObjects contains all objects after they have been loaded into the kernel.
@@ -109,17 +119,23 @@ func (o *ProfileObjects) Close() error {
 //
 // It can be passed to LoadProfileObjects or ebpf.CollectionSpec.LoadAndAssign.
 type ProfileMaps struct {
-	Counts *ebpf.Map `ebpf:"counts"`
-	Events *ebpf.Map `ebpf:"events"`
-	Pids   *ebpf.Map `ebpf:"pids"`
-	Progs  *ebpf.Map `ebpf:"progs"`
-	Stacks *ebpf.Map `ebpf:"stacks"`
+	Counts       *ebpf.Map `ebpf:"counts"`
+	Events       *ebpf.Map `ebpf:"events"`
+	OffCpuCounts *ebpf.Map `ebpf:"off_cpu_counts"`
+	OffCpuStacks *ebpf.Map `ebpf:"off_cpu_stacks"`
+	OffCpuStarts *ebpf.Map `ebpf:"off_cpu_starts"`
+	Pids         *ebpf.Map `ebpf:"pids"`
+	Progs        *ebpf.Map `ebpf:"progs"`
+	Stacks       *ebpf.Map `ebpf:"stacks"`
 }
 
 func (m *ProfileMaps) Close() error {
 	return _ProfileClose(
 		m.Counts,
 		m.Events,
+		m.OffCpuCounts,
+		m.OffCpuStacks,
+		m.OffCpuStarts,
 		m.Pids,
 		m.Progs,
 		m.Stacks,
@@ -133,6 +149,7 @@ type ProfilePrograms struct {
 	DisassociateCtty *ebpf.Program `ebpf:"disassociate_ctty"`
 	DoPerfEvent      *ebpf.Program `ebpf:"do_perf_event"`
 	Exec             *ebpf.Program `ebpf:"exec"`
+	OffCpu           *ebpf.Program `ebpf:"off_cpu"`
 }
 
 func (p *ProfilePrograms) Close() error {
@@ -140,6 +157,7 @@ func (p *ProfilePrograms) Close() error {
 		p.DisassociateCtty,
 		p.DoPerfEvent,
 		p.Exec,
+		p.OffCpu,
 	)
 }
 
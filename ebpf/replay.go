@@ -0,0 +1,156 @@
+//go:build linux
+
+package ebpfspy
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/pyroscope/ebpf/metrics"
+	"github.com/grafana/pyroscope/ebpf/pprof"
+	"github.com/grafana/pyroscope/ebpf/replay"
+	"github.com/grafana/pyroscope/ebpf/sd"
+	"github.com/grafana/pyroscope/ebpf/symtab"
+)
+
+const maxStackDepth = 127
+
+type ReplayOptions struct {
+	UnknownSymbolModuleOffset bool
+	UnknownSymbolAddress      bool
+	SymbolOptions             symtab.SymbolOptions
+	CacheOptions              symtab.CacheOptions
+}
+
+// Replay symbolizes the samples of the recording stored in the directory
+// the same way the session does, and adds them to the builders. Neither BPF
+// nor root privileges are required. See package replay for the format.
+func Replay(logger log.Logger, dir string, options ReplayOptions, builders *pprof.ProfileBuilders) error {
+	rec, err := replay.Load(dir)
+	if err != nil {
+		return err
+	}
+	elfCache, err := symtab.NewElfCache(options.CacheOptions.BuildIDCacheOptions, options.CacheOptions.SameFileCacheOptions)
+	if err != nil {
+		return fmt.Errorf("create elf cache %w", err)
+	}
+	defer elfCache.Cleanup()
+	m := metrics.NewSymtabMetrics(nil)
+
+	type process struct {
+		comm   string
+		target *sd.Target
+		table  *symtab.ProcTable
+	}
+	processes := make(map[uint32]*process, len(rec.Processes))
+	for _, p := range rec.Processes {
+		target := sd.NewTargetForTesting("", p.Pid, p.Labels)
+		symbolOptions := options.SymbolOptions
+		overrideSymbolOptions(target, &symbolOptions)
+		table := symtab.NewProcTable(logger, symtab.ProcTableOptions{
+			Pid:    int(p.Pid),
+			RootFS: replay.FilesDir(dir, p.Pid),
+			ElfTableOptions: symtab.ElfTableOptions{
+				ElfCache:      elfCache,
+				Metrics:       m,
+				SymbolOptions: &symbolOptions,
+			},
+		})
+		table.RefreshProcMap([]byte(p.Maps))
+		if err = table.Error(); err != nil {
+			return fmt.Errorf("process %d: %w", p.Pid, err)
+		}
+		defer table.Cleanup()
+		processes[p.Pid] = &process{comm: p.Comm, target: target, table: table}
+	}
+
+	var kallsyms symtab.SymbolTable = symtab.NewSymbolTab(nil)
+	if rec.Kallsyms != "" {
+		if kallsyms, err = symtab.NewKallsymsFromData([]byte(rec.Kallsyms)); err != nil {
+			return err
+		}
+	}
+
+	opt := stackOptions{
+		UnknownSymbolModuleOffset: options.UnknownSymbolModuleOffset,
+		UnknownSymbolAddress:      options.UnknownSymbolAddress,
+	}
+	sb := &stackBuilder{}
+	buf := make([]byte, maxStackDepth*8)
+	for _, s := range rec.Samples {
+		p := processes[s.Pid]
+		if p == nil {
+			level.Debug(logger).Log("msg", "process not found in the recording", "pid", s.Pid)
+			continue
+		}
+		stats := StackResolveStats{}
+		sb.reset()
+		sb.append(p.comm)
+		walkStack(sb, stackBytes(buf, s.UserStack), p.table, &stats, opt)
+		walkStack(sb, stackBytes(buf, s.KernStack), kallsyms, &stats, opt)
+		if len(sb.stack) == 1 {
+			continue // only comm
+		}
+		sb.reverse(0, len(sb.stack))
+		builders.AddSample(&pprof.ProfileSample{
+			Target:      p.target,
+			Pid:         s.Pid,
+			Aggregation: pprof.SampleAggregated,
+			SampleType:  pprof.SampleType(s.SampleType),
+			Stack:       sb.stack,
+			Frames:      sb.stackFrames(),
+			Value:       s.Value,
+		})
+	}
+	return nil
+}
+
+func (s *session) record(pid uint32, target *sd.Target, uStack, kStack []byte, value uint64, sampleType pprof.SampleType) {
+	r := s.options.Recorder
+	_, lbls := target.Labels()
+	if err := r.AddProcess(pid, lbls.Map()); err != nil {
+		level.Debug(s.logger).Log("msg", "failed to record process", "pid", pid, "err", err)
+	}
+	if len(kStack) > 0 {
+		if err := r.AddKallsyms(); err != nil {
+			level.Debug(s.logger).Log("msg", "failed to record kallsyms", "err", err)
+		}
+	}
+	r.AddSample(replay.Sample{
+		Pid:        pid,
+		UserStack:  stackAddresses(uStack),
+		KernStack:  stackAddresses(kStack),
+		Value:      value,
+		SampleType: uint32(sampleType),
+	})
+}
+
+// stackAddresses decodes the BPF stack: an array of instruction
+// pointers terminated by zero.
+func stackAddresses(stack []byte) []uint64 {
+	var addrs []uint64
+	for i := 0; i+8 <= len(stack) && i < maxStackDepth*8; i += 8 {
+		addr := binary.LittleEndian.Uint64(stack[i : i+8])
+		if addr == 0 {
+			break
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// stackBytes encodes the addresses into buf the way BPF stores stacks.
+func stackBytes(buf []byte, addrs []uint64) []byte {
+	if len(addrs) == 0 {
+		return nil
+	}
+	for i := range buf {
+		buf[i] = 0
+	}
+	for i := 0; i < len(addrs) && i < maxStackDepth; i++ {
+		binary.LittleEndian.PutUint64(buf[i*8:], addrs[i])
+	}
+	return buf
+}
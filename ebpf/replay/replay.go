@@ -0,0 +1,195 @@
+// Package replay implements a recording format of the eBPF profiler input:
+// raw stack samples and the state of the profiled processes required to
+// symbolize them. Recordings are replayed without BPF and root privileges,
+// which allows testing the symbolization in ordinary unit tests.
+//
+// A recording is a directory with the following layout:
+//
+//	recording.json    - processes and samples, see Recording
+//	files/<pid>/...   - files mapped by the process, relative to its root
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/grafana/pyroscope/ebpf/symtab"
+)
+
+const recordingFileName = "recording.json"
+
+type Recording struct {
+	Processes []Process `json:"processes"`
+	Samples   []Sample  `json:"samples"`
+	// Kallsyms holds /proc/kallsyms, if kernel stacks are recorded.
+	Kallsyms string `json:"kallsyms,omitempty"`
+}
+
+type Process struct {
+	Pid  uint32 `json:"pid"`
+	Comm string `json:"comm"`
+	// Maps holds /proc/<pid>/maps.
+	Maps string `json:"maps"`
+	// Labels of the discovery target the process belongs to.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Sample is a raw stack sample: the stacks are the instruction
+// pointers as they are collected by BPF, the innermost frame first.
+type Sample struct {
+	Pid       uint32   `json:"pid"`
+	UserStack []uint64 `json:"user_stack,omitempty"`
+	KernStack []uint64 `json:"kern_stack,omitempty"`
+	Value     uint64   `json:"value"`
+	// SampleType is the pprof.SampleType of the sample, CPU if not set.
+	SampleType uint32 `json:"sample_type,omitempty"`
+}
+
+// FilesDir returns the directory the mapped files of the process are stored in.
+func FilesDir(dir string, pid uint32) string {
+	return filepath.Join(dir, "files", strconv.Itoa(int(pid)))
+}
+
+func Load(dir string) (*Recording, error) {
+	data, err := os.ReadFile(filepath.Join(dir, recordingFileName))
+	if err != nil {
+		return nil, err
+	}
+	var r Recording
+	if err = json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse recording: %w", err)
+	}
+	return &r, nil
+}
+
+func Save(dir string, r *Recording) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, recordingFileName), data, 0o644)
+}
+
+// Recorder captures processes and samples into a recording directory.
+// Recorder is safe for concurrent use.
+type Recorder struct {
+	// ProcFS is the procfs mount point, /proc by default.
+	ProcFS string
+
+	mutex     sync.Mutex
+	dir       string
+	recording Recording
+	processes map[uint32]struct{}
+}
+
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{
+		ProcFS:    "/proc",
+		dir:       dir,
+		processes: make(map[uint32]struct{}),
+	}
+}
+
+// AddProcess captures the memory mappings of the process and copies
+// the mapped executable files. A process is only captured once.
+func (r *Recorder) AddProcess(pid uint32, labels map[string]string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.processes[pid]; ok {
+		return nil
+	}
+	r.processes[pid] = struct{}{}
+	procDir := filepath.Join(r.ProcFS, strconv.Itoa(int(pid)))
+	maps, err := os.ReadFile(filepath.Join(procDir, "maps"))
+	if err != nil {
+		return err
+	}
+	comm, err := os.ReadFile(filepath.Join(procDir, "comm"))
+	if err != nil {
+		return err
+	}
+	modules, err := symtab.ParseProcMapsExecutableModules(maps, true)
+	if err != nil {
+		return err
+	}
+	copied := make(map[string]struct{})
+	for _, m := range modules {
+		if !strings.HasPrefix(m.Pathname, "/") {
+			continue
+		}
+		if _, ok := copied[m.Pathname]; ok {
+			continue
+		}
+		copied[m.Pathname] = struct{}{}
+		src := filepath.Join(procDir, "root", m.Pathname)
+		dst := filepath.Join(FilesDir(r.dir, pid), m.Pathname)
+		if err = copyFile(src, dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("copy %s: %w", m.Pathname, err)
+		}
+	}
+	r.recording.Processes = append(r.recording.Processes, Process{
+		Pid:    pid,
+		Comm:   strings.TrimSuffix(string(comm), "\n"),
+		Maps:   string(maps),
+		Labels: labels,
+	})
+	return nil
+}
+
+// AddKallsyms captures the kernel symbols, if not captured yet.
+func (r *Recorder) AddKallsyms() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.recording.Kallsyms != "" {
+		return nil
+	}
+	kallsyms, err := os.ReadFile(filepath.Join(r.ProcFS, "kallsyms"))
+	if err != nil {
+		return err
+	}
+	r.recording.Kallsyms = string(kallsyms)
+	return nil
+}
+
+func (r *Recorder) AddSample(s Sample) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.recording.Samples = append(r.recording.Samples, s)
+}
+
+// Flush writes the recording to the directory.
+func (r *Recorder) Flush() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return Save(r.dir, &r.recording)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err = os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,45 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	procFS := t.TempDir()
+	procDir := filepath.Join(procFS, "239")
+	elfPath := filepath.Join(procDir, "root", "usr", "bin", "elf")
+	require.NoError(t, copyFile("../symtab/elf/testdata/elfs/elf", elfPath))
+	maps := "55d000001000-55d000002000 r-xp 00001000 08:01 123 /usr/bin/elf\n" +
+		"7ffd00000000-7ffd00001000 r-xp 00000000 00:00 0 [vdso]\n"
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "maps"), []byte(maps), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(procDir, "comm"), []byte("elf\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(procFS, "kallsyms"), []byte("ffffffff81000000 T _stext\n"), 0o644))
+
+	dir := t.TempDir()
+	r := NewRecorder(dir)
+	r.ProcFS = procFS
+	labels := map[string]string{"service_name": "elf"}
+	require.NoError(t, r.AddProcess(239, labels))
+	require.NoError(t, r.AddProcess(239, nil))
+	require.NoError(t, r.AddKallsyms())
+	r.AddSample(Sample{Pid: 239, UserStack: []uint64{0x55d000001150, 0x55d000001165}, Value: 3})
+	require.NoError(t, r.Flush())
+
+	rec, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, &Recording{
+		Processes: []Process{{Pid: 239, Comm: "elf", Maps: maps, Labels: labels}},
+		Samples:   []Sample{{Pid: 239, UserStack: []uint64{0x55d000001150, 0x55d000001165}, Value: 3}},
+		Kallsyms:  "ffffffff81000000 T _stext\n",
+	}, rec)
+
+	expected, err := os.ReadFile(elfPath)
+	require.NoError(t, err)
+	actual, err := os.ReadFile(filepath.Join(FilesDir(dir, 239), "usr", "bin", "elf"))
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
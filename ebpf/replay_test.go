@@ -0,0 +1,75 @@
+//go:build linux
+
+package ebpfspy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/google/pprof/profile"
+	"github.com/grafana/pyroscope/ebpf/pprof"
+	"github.com/grafana/pyroscope/ebpf/replay"
+	"github.com/grafana/pyroscope/ebpf/symtab"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	elf, err := os.ReadFile("symtab/elf/testdata/elfs/elf")
+	require.NoError(t, err)
+	elfPath := filepath.Join(replay.FilesDir(dir, 239), "usr", "bin", "elf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(elfPath), 0o755))
+	require.NoError(t, os.WriteFile(elfPath, elf, 0o644))
+	require.NoError(t, replay.Save(dir, &replay.Recording{
+		Processes: []replay.Process{{
+			Pid:    239,
+			Comm:   "elf",
+			Maps:   "55d000001000-55d000002000 r-xp 00001000 08:01 123 /usr/bin/elf\n",
+			Labels: map[string]string{"service_name": "elf"},
+		}},
+		Samples: []replay.Sample{
+			{Pid: 239, UserStack: []uint64{0x55d000001150, 0x55d000001165}, KernStack: []uint64{0xffffffff81000010}, Value: 3},
+			{Pid: 239, UserStack: []uint64{0x55d000001165}, Value: 2},
+			{Pid: 42, UserStack: []uint64{0x55d000001165}, Value: 1},
+			{Pid: 239, UserStack: []uint64{0x55d000001165}, Value: 5000, SampleType: uint32(pprof.SampleTypeOffCpu)},
+		},
+		Kallsyms: "ffffffff81000000 T do_syscall_64\n",
+	}))
+
+	builders := pprof.NewProfileBuilders(pprof.BuildersOptions{SampleRate: 97})
+	cacheOptions := symtab.GCacheOptions{Size: 16, KeepRounds: 3}
+	err = Replay(log.NewNopLogger(), dir, ReplayOptions{
+		CacheOptions: symtab.CacheOptions{
+			BuildIDCacheOptions:  cacheOptions,
+			SameFileCacheOptions: cacheOptions,
+		},
+	}, builders)
+	require.NoError(t, err)
+
+	require.Len(t, builders.Builders, 2)
+	var stacks []string
+	for _, b := range builders.Builders {
+		buf := bytes.NewBuffer(nil)
+		_, err = b.Write(buf)
+		require.NoError(t, err)
+		p, err := profile.Parse(buf)
+		require.NoError(t, err)
+		for _, s := range p.Sample {
+			var names []string
+			for i := len(s.Location) - 1; i >= 0; i-- {
+				names = append(names, s.Location[i].Line[0].Function.Name)
+			}
+			stacks = append(stacks, p.SampleType[0].Type+" "+strings.Join(names, ";")+" "+strconv.FormatInt(s.Value[0]/p.Period, 10))
+		}
+	}
+	require.ElementsMatch(t, []string{
+		"cpu elf;main;iter;do_syscall_64 3",
+		"cpu elf;main 2",
+		"off_cpu elf;main 5000",
+	}, stacks)
+}
@@ -39,11 +39,15 @@ const (
 
 	OptionGoTableFallback          = labelMetaPyroscopeOptionsPrefix + "go_table_fallback"
 	OptionCollectKernel            = labelMetaPyroscopeOptionsPrefix + "collect_kernel"
+	OptionCollectUser              = labelMetaPyroscopeOptionsPrefix + "collect_user"
+	OptionOffCPUEnabled            = labelMetaPyroscopeOptionsPrefix + "off_cpu_enabled"
+	OptionSampleRate               = labelMetaPyroscopeOptionsPrefix + "sample_rate"
 	OptionPythonFullFilePath       = labelMetaPyroscopeOptionsPrefix + "python_full_file_path"
 	OptionPythonEnabled            = labelMetaPyroscopeOptionsPrefix + "python_enabled"
 	OptionPythonBPFDebugLogEnabled = labelMetaPyroscopeOptionsPrefix + "python_bpf_debug_log"
 	OptionPythonBPFErrorLogEnabled = labelMetaPyroscopeOptionsPrefix + "python_bpf_error_log"
 	OptionDemangle                 = labelMetaPyroscopeOptionsPrefix + "demangle"
+	OptionDwarfLines               = labelMetaPyroscopeOptionsPrefix + "dwarf_lines"
 )
 
 type Target struct {
@@ -29,25 +29,34 @@ import (
 	"github.com/grafana/pyroscope/ebpf/pprof"
 	"github.com/grafana/pyroscope/ebpf/pyrobpf"
 	"github.com/grafana/pyroscope/ebpf/python"
+	"github.com/grafana/pyroscope/ebpf/replay"
 	"github.com/grafana/pyroscope/ebpf/rlimit"
 	"github.com/grafana/pyroscope/ebpf/sd"
 	"github.com/grafana/pyroscope/ebpf/symtab"
-	"github.com/samber/lo"
 )
 
 type SessionOptions struct {
-	CollectUser               bool //todo make these per target option overridable
-	CollectKernel             bool
+	CollectUser   bool
+	CollectKernel bool
+	// OffCPUEnabled enables off-CPU profiling: the time threads spend off
+	// CPU is measured with the sched/sched_switch tracepoint. Targets can
+	// override it, and the tracepoint is attached once a target enables it.
+	OffCPUEnabled             bool
 	UnknownSymbolModuleOffset bool // use libfoo.so+0xef instead of libfoo.so for unknown symbols
 	UnknownSymbolAddress      bool // use 0xcafebabe instead of [unknown]
 	PythonEnabled             bool
 	CacheOptions              symtab.CacheOptions
 	SymbolOptions             symtab.SymbolOptions
 	Metrics                   *metrics.Metrics
-	SampleRate                int
-	VerifierLogSize           int
-	PythonBPFErrorLogEnabled  bool
-	PythonBPFDebugLogEnabled  bool
+	// SampleRate is the rate of the CPU perf events. The perf events are
+	// shared by all targets, which can only lower the rate of their samples.
+	SampleRate               int
+	VerifierLogSize          int
+	PythonBPFErrorLogEnabled bool
+	PythonBPFDebugLogEnabled bool
+	// Recorder, if set, captures the collected samples
+	// and the processes for replaying them offline.
+	Recorder *replay.Recorder
 }
 
 type Session interface {
@@ -83,6 +92,10 @@ type session struct {
 
 	bpf pyrobpf.ProfileObjects
 
+	// offCPULink attaches the off-CPU program once a target enables it.
+	offCPULink  link.Link
+	offCPUError error
+
 	eventsReader    *perf.Reader
 	pidInfoRequests chan uint32
 	deadPIDEvents   chan uint32
@@ -256,10 +269,16 @@ func (s *session) CollectProfiles(cb pprof.CollectProfilesCallback) error {
 	s.symCache.NextRound()
 	s.roundNumber++
 
-	err := s.collectRegularProfile(cb)
+	err := s.collectRegularProfile(cb, s.bpf.Counts, s.bpf.Stacks, pprof.SampleTypeCpu)
 	if err != nil {
 		return err
 	}
+	if s.offCPULink != nil {
+		err = s.collectRegularProfile(cb, s.bpf.OffCpuCounts, s.bpf.OffCpuStacks, pprof.SampleTypeOffCpu)
+		if err != nil {
+			return fmt.Errorf("collect off-cpu profile: %w", err)
+		}
+	}
 
 	s.cleanup()
 
@@ -276,10 +295,10 @@ func (s *session) DebugInfo() interface{} {
 	}
 }
 
-func (s *session) collectRegularProfile(cb pprof.CollectProfilesCallback) error {
+func (s *session) collectRegularProfile(cb pprof.CollectProfilesCallback, counts, stacks *ebpf.Map, sampleType pprof.SampleType) error {
 	sb := &stackBuilder{}
 
-	keys, values, batch, err := s.getCountsMapValues()
+	keys, values, batch, err := s.getCountsMapValues(counts)
 	if err != nil {
 		return fmt.Errorf("get counts map: %w", err)
 	}
@@ -312,26 +331,34 @@ func (s *session) collectRegularProfile(cb pprof.CollectProfilesCallback) error
 		if _, ok := s.pids.dead[ck.Pid]; ok {
 			continue
 		}
+		if sampleType == pprof.SampleTypeOffCpu && !s.offCPUEnabled(target) {
+			continue
+		}
+		collectUser := s.collectUserEnabled(target)
+		collectKernel := s.collectKernelEnabled(target)
 
 		pk := symtab.PidKey(ck.Pid)
 
 		var uStack []byte
 		var kStack []byte
-		if s.options.CollectUser {
+		if collectUser {
 			if isPythonStack {
 				uStack = s.GetPythonStack(ck.UserStack) //todo lookup batch
 			} else {
-				uStack = s.GetStack(ck.UserStack)
+				uStack = s.GetStack(stacks, ck.UserStack)
 			}
 		}
-		if s.options.CollectKernel {
-			kStack = s.GetStack(ck.KernStack)
+		if collectKernel {
+			kStack = s.GetStack(stacks, ck.KernStack)
+		}
+		if s.options.Recorder != nil && !isPythonStack {
+			s.record(ck.Pid, target, uStack, kStack, value, sampleType)
 		}
 
 		stats := StackResolveStats{}
 		sb.reset()
 		sb.append(s.comm(ck.Pid))
-		if s.options.CollectUser {
+		if collectUser {
 			if isPythonStack {
 				pyProc := s.pyperf.FindProc(ck.Pid)
 				if pyProc != nil {
@@ -352,28 +379,33 @@ func (s *session) collectRegularProfile(cb pprof.CollectProfilesCallback) error
 				}
 			}
 		}
-		if s.options.CollectKernel {
+		if collectKernel {
 			s.WalkStack(sb, kStack, s.symCache.GetKallsyms(), &stats)
 		}
 		if len(sb.stack) == 1 {
 			continue // only comm
 		}
-		lo.Reverse(sb.stack)
-		cb(pprof.ProfileSample{
+		sb.reverse(0, len(sb.stack))
+		sample := pprof.ProfileSample{
 			Target:      target,
 			Pid:         ck.Pid,
 			Aggregation: pprof.SampleAggregated,
-			SampleType:  pprof.SampleTypeCpu,
+			SampleType:  sampleType,
 			Stack:       sb.stack,
-			Value:       uint64(value),
-		})
+			Frames:      sb.stackFrames(),
+			Value:       value,
+		}
+		if sampleType == pprof.SampleTypeCpu {
+			sample.SampleRate = s.collectedSampleRate(ck.Pid, target)
+		}
+		cb(sample)
 		s.collectMetrics(target, &stats, sb)
 	}
 
-	if err = s.clearCountsMap(keys, batch); err != nil {
+	if err = s.clearCountsMap(counts, keys, batch); err != nil {
 		return fmt.Errorf("clear counts map %w", err)
 	}
-	if err = s.clearStacksMap(knownStacks, s.bpf.Stacks); err != nil {
+	if err = s.clearStacksMap(knownStacks, stacks); err != nil {
 		return fmt.Errorf("clear stacks map %w", err)
 	}
 	if s.pyperfBpf.PythonStacks != nil && len(knownPythonStacks) > 0 {
@@ -418,6 +450,12 @@ func (s *session) stopLocked() {
 		_ = pe.Close()
 	}
 	s.perfEvents = nil
+	s.detachOffCPULocked()
+	if s.options.Recorder != nil {
+		if err := s.options.Recorder.Flush(); err != nil {
+			_ = level.Error(s.logger).Log("err", err, "msg", "flushing the recording")
+		}
+	}
 	for _, kprobe := range s.kprobes {
 		_ = kprobe.Close()
 	}
@@ -448,7 +486,7 @@ func (s *session) stopLocked() {
 	s.started = false
 }
 
-func (s *session) setPidConfig(pid uint32, pi procInfoLite, collectUser bool, collectKernel bool) {
+func (s *session) setPidConfig(pid uint32, pi procInfoLite, target *sd.Target, collectUser bool, collectKernel bool) {
 	s.pids.all[pid] = pi
 	config := &pyrobpf.ProfilePidConfig{
 		Type:          uint8(pi.typ),
@@ -456,6 +494,8 @@ func (s *session) setPidConfig(pid uint32, pi procInfoLite, collectUser bool, co
 		CollectKernel: uint8FromBool(collectKernel),
 	}
 
+	s.setPidOptions(config, pi, target)
+
 	if err := s.bpf.Pids.Update(&pid, config, ebpf.UpdateAny); err != nil {
 		_ = level.Error(s.logger).Log("msg", "updating pids map", "err", err)
 	}
@@ -490,70 +530,24 @@ func attachPerfEvents(sampleRate int, prog *ebpf.Program) ([]*perfEvent, error)
 	return perfEvents, nil
 }
 
-func (s *session) GetStack(stackId int64) []byte {
+func (s *session) GetStack(stacks *ebpf.Map, stackId int64) []byte {
 	if stackId < 0 {
 		return nil
 	}
 	stackIdU32 := uint32(stackId)
-	res, err := s.bpf.ProfileMaps.Stacks.LookupBytes(stackIdU32)
+	res, err := stacks.LookupBytes(stackIdU32)
 	if err != nil {
 		return nil
 	}
 	return res
 }
 
-type StackResolveStats struct {
-	known          uint32
-	unknownSymbols uint32
-	unknownModules uint32
-}
-
-func (s *StackResolveStats) add(other StackResolveStats) {
-	s.known += other.known
-	s.unknownSymbols += other.unknownSymbols
-	s.unknownModules += other.unknownModules
-}
-
 // WalkStack goes over stack, resolves symbols and appends top sb
-// stack is an array of 127 uint64s, where each uint64 is an instruction pointer
 func (s *session) WalkStack(sb *stackBuilder, stack []byte, resolver symtab.SymbolTable, stats *StackResolveStats) {
-	if len(stack) == 0 {
-		return
-	}
-	begin := len(sb.stack)
-	for i := 0; i < 127; i++ {
-		instructionPointerBytes := stack[i*8 : i*8+8]
-		instructionPointer := binary.LittleEndian.Uint64(instructionPointerBytes)
-		if instructionPointer == 0 {
-			break
-		}
-		sym := resolver.Resolve(instructionPointer)
-		var name string
-		if sym.Name != "" {
-			name = sym.Name
-			stats.known++
-		} else {
-			if sym.Module != "" {
-				if s.options.UnknownSymbolModuleOffset {
-					name = fmt.Sprintf("%s+%x", sym.Module, sym.Start)
-				} else {
-					name = sym.Module
-				}
-				stats.unknownSymbols++
-			} else {
-				if s.options.UnknownSymbolAddress {
-					name = fmt.Sprintf("%x", instructionPointer)
-				} else {
-					name = "[unknown]"
-				}
-				stats.unknownModules++
-			}
-		}
-		sb.append(name)
-	}
-	end := len(sb.stack)
-	lo.Reverse(sb.stack[begin:end])
-
+	walkStack(sb, stack, resolver, stats, stackOptions{
+		UnknownSymbolModuleOffset: s.options.UnknownSymbolModuleOffset,
+		UnknownSymbolAddress:      s.options.UnknownSymbolAddress,
+	})
 }
 
 func (s *session) readEvents(events *perf.Reader,
@@ -648,7 +642,7 @@ func (s *session) startProfilingLocked(pid uint32, target *sd.Target) {
 			s.pyperf.RemoveDeadPID(pid)
 		}
 	}
-	s.setPidConfig(pid, typ, s.options.CollectUser, s.collectKernelEnabled(target))
+	s.setPidConfig(pid, typ, target, s.collectUserEnabled(target), s.collectKernelEnabled(target))
 }
 
 type procInfoLite struct {
@@ -879,6 +873,25 @@ func overrideSymbolOptions(t *sd.Target, opt *symtab.SymbolOptions) {
 	if v, present := t.Get(sd.OptionDemangle); present {
 		opt.DemangleOptions = demangle.ConvertDemangleOptions(v)
 	}
+	if v, present := t.GetFlag(sd.OptionDwarfLines); present {
+		opt.DwarfLines = v
+	}
+}
+
+func (s *session) collectUserEnabled(target *sd.Target) bool {
+	enabled := s.options.CollectUser
+	if v, present := target.GetFlag(sd.OptionCollectUser); present {
+		enabled = v
+	}
+	return enabled
+}
+
+func (s *session) offCPUEnabled(target *sd.Target) bool {
+	enabled := s.options.OffCPUEnabled
+	if v, present := target.GetFlag(sd.OptionOffCPUEnabled); present {
+		enabled = v
+	}
+	return enabled
 }
 
 func (s *session) collectKernelEnabled(target *sd.Target) bool {
@@ -913,18 +926,6 @@ func (s *session) pythonBPFErrorLogEnabled(target *sd.Target) bool {
 	return enabled
 }
 
-type stackBuilder struct {
-	stack []string
-}
-
-func (s *stackBuilder) reset() {
-	s.stack = s.stack[:0]
-}
-
-func (s *stackBuilder) append(sym string) {
-	s.stack = append(s.stack, sym)
-}
-
 func getPIDNamespace() (dev uint64, ino uint64, err error) {
 	stat, err := os.Stat("/proc/self/ns/pid")
 	if err != nil {
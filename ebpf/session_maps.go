@@ -14,15 +14,31 @@ import (
 	"github.com/grafana/pyroscope/ebpf/pyrobpf"
 )
 
-func (s *session) getCountsMapValues() (keys []pyrobpf.ProfileSampleKey, values []uint32, batch bool, err error) {
+// getCountsMapValues returns the counts of the stacks, the counts
+// map values are either uint32 sample counts or uint64 durations.
+func (s *session) getCountsMapValues(m *ebpf.Map) (keys []pyrobpf.ProfileSampleKey, values []uint64, batch bool, err error) {
+	if m.ValueSize() == 8 {
+		return getCountsMapValues[uint64](s, m)
+	}
+	keys, counts, batch, err := getCountsMapValues[uint32](s, m)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	values = make([]uint64, len(counts))
+	for i, c := range counts {
+		values[i] = uint64(c)
+	}
+	return keys, values, batch, nil
+}
+
+func getCountsMapValues[V uint32 | uint64](s *session, m *ebpf.Map) (keys []pyrobpf.ProfileSampleKey, values []V, batch bool, err error) {
 	// try batch first
 	var (
-		m       = s.bpf.ProfileMaps.Counts
 		mapSize = m.MaxEntries()
 		nextKey = pyrobpf.ProfileSampleKey{}
 	)
 	keys = make([]pyrobpf.ProfileSampleKey, mapSize)
-	values = make([]uint32, mapSize)
+	values = make([]V, mapSize)
 
 	opts := &ebpf.BatchOptions{}
 	n, err := m.BatchLookupAndDelete(nil, &nextKey, keys, values, opts)
@@ -41,7 +57,7 @@ func (s *session) getCountsMapValues() (keys []pyrobpf.ProfileSampleKey, values
 	resultValues := values[:0]
 	it := m.Iterate()
 	k := pyrobpf.ProfileSampleKey{}
-	v := uint32(0)
+	var v V
 	for {
 		ok := it.Next(&k, &v)
 		if !ok {
@@ -62,7 +78,7 @@ func (s *session) getCountsMapValues() (keys []pyrobpf.ProfileSampleKey, values
 	return resultKeys, resultValues, false, nil
 }
 
-func (s *session) clearCountsMap(keys []pyrobpf.ProfileSampleKey, batch bool) error {
+func (s *session) clearCountsMap(m *ebpf.Map, keys []pyrobpf.ProfileSampleKey, batch bool) error {
 	if len(keys) == 0 {
 		return nil
 	}
@@ -70,7 +86,6 @@ func (s *session) clearCountsMap(keys []pyrobpf.ProfileSampleKey, batch bool) er
 		// do nothing, already deleted with GetValueAndDeleteBatch in getCountsMapValues
 		return nil
 	}
-	m := s.bpf.ProfileMaps.Counts
 	for i := range keys {
 		err := m.Delete(&keys[i])
 		if err != nil {
@@ -0,0 +1,93 @@
+//go:build linux
+
+package ebpfspy
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cilium/ebpf/link"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/pyroscope/ebpf/pyrobpf"
+	"github.com/grafana/pyroscope/ebpf/sd"
+)
+
+const sampleRateProbabilityOne = 1 << 32
+
+// setPidOptions configures the off-CPU profiling and the sample rate of the
+// process, attaching the off-CPU program once a target enables it.
+func (s *session) setPidOptions(config *pyrobpf.ProfilePidConfig, pi procInfoLite, target *sd.Target) {
+	if sampleRateSupported(pi.typ) {
+		config.SampleDropThreshold = sampleDropThreshold(s.targetSampleRate(target), s.options.SampleRate)
+	}
+	// Off-CPU stacks are only collected with frame pointers.
+	if pi.typ == pyrobpf.ProfilingTypeFramepointers && s.offCPUEnabled(target) && s.attachOffCPULocked() {
+		config.OffCpu = 1
+	}
+}
+
+// attachOffCPULocked attaches the off-CPU program to the sched/sched_switch
+// tracepoint. It returns false if the program can't be attached.
+func (s *session) attachOffCPULocked() bool {
+	if s.offCPULink != nil {
+		return true
+	}
+	if s.offCPUError != nil {
+		return false
+	}
+	l, err := link.Tracepoint("sched", "sched_switch", s.bpf.OffCpu, nil)
+	if err != nil {
+		s.offCPUError = fmt.Errorf("link sched_switch tracepoint: %w", err)
+		_ = level.Error(s.logger).Log("err", s.offCPUError, "msg", "start off-cpu profiling")
+		return false
+	}
+	s.offCPULink = l
+	return true
+}
+
+func (s *session) detachOffCPULocked() {
+	if s.offCPULink != nil {
+		_ = s.offCPULink.Close()
+		s.offCPULink = nil
+	}
+	s.offCPUError = nil
+}
+
+// sampleRateSupported reports whether the CPU samples of the processes
+// of the profiling type are dropped to lower their sample rate.
+func sampleRateSupported(typ pyrobpf.ProfilingType) bool {
+	return typ == pyrobpf.ProfilingTypeFramepointers || typ == pyrobpf.ProfilingTypePython
+}
+
+// sampleDropThreshold returns the threshold dropping CPU samples
+// taken at baseRate to keep sampleRate samples per second on average.
+func sampleDropThreshold(sampleRate, baseRate int) uint32 {
+	if sampleRate <= 0 || sampleRate >= baseRate {
+		return 0
+	}
+	return uint32(uint64(baseRate-sampleRate) * sampleRateProbabilityOne / uint64(baseRate))
+}
+
+// targetSampleRate returns the rate of the CPU samples of the target.
+// The rate can't exceed the rate of the perf events, which are shared
+// by all the targets.
+func (s *session) targetSampleRate(target *sd.Target) int {
+	v, present := target.Get(sd.OptionSampleRate)
+	if !present {
+		return s.options.SampleRate
+	}
+	sampleRate, err := strconv.Atoi(v)
+	if err != nil || sampleRate <= 0 || sampleRate > s.options.SampleRate {
+		return s.options.SampleRate
+	}
+	return sampleRate
+}
+
+// collectedSampleRate returns the rate of the CPU samples collected for the
+// process, which is the rate of the perf events if its samples are not dropped.
+func (s *session) collectedSampleRate(pid uint32, target *sd.Target) int64 {
+	if !sampleRateSupported(s.pids.all[pid].typ) {
+		return int64(s.options.SampleRate)
+	}
+	return int64(s.targetSampleRate(target))
+}
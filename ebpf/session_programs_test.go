@@ -0,0 +1,36 @@
+//go:build linux
+
+package ebpfspy
+
+import (
+	"testing"
+
+	"github.com/grafana/pyroscope/ebpf/pyrobpf"
+	"github.com/grafana/pyroscope/ebpf/sd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleDropThreshold(t *testing.T) {
+	require.Equal(t, uint32(0), sampleDropThreshold(97, 97))
+	require.Equal(t, uint32(0), sampleDropThreshold(0, 97))
+	require.Equal(t, uint32(0), sampleDropThreshold(1000, 97))
+	require.Equal(t, uint32(1<<31), sampleDropThreshold(50, 100))
+	require.Equal(t, uint32(3<<30), sampleDropThreshold(25, 100))
+}
+
+func TestCollectedSampleRate(t *testing.T) {
+	s := &session{
+		options: SessionOptions{SampleRate: 100},
+		pids: pids{all: map[uint32]procInfoLite{
+			1: {typ: pyrobpf.ProfilingTypeFramepointers},
+			2: {typ: pyrobpf.ProfilingTypePython},
+			3: {typ: pyrobpf.ProfilingTypeError},
+		}},
+	}
+	target := sd.NewTarget("", 0, sd.DiscoveryTarget{sd.OptionSampleRate: "25"})
+	require.Equal(t, int64(25), s.collectedSampleRate(1, target))
+	require.Equal(t, int64(25), s.collectedSampleRate(2, target))
+	require.Equal(t, int64(100), s.collectedSampleRate(3, target))
+	require.Equal(t, int64(100), s.collectedSampleRate(4, target))
+	require.Equal(t, int64(100), s.collectedSampleRate(1, sd.NewTarget("", 0, sd.DiscoveryTarget{})))
+}
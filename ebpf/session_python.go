@@ -15,7 +15,6 @@ import (
 	"github.com/grafana/pyroscope/ebpf/pyrobpf"
 	"github.com/grafana/pyroscope/ebpf/python"
 	"github.com/grafana/pyroscope/ebpf/sd"
-	"github.com/samber/lo"
 )
 
 func (s *session) tryStartPythonProfiling(pid uint32, target *sd.Target, pi procInfoLite) {
@@ -43,7 +42,7 @@ func (s *session) startPythonProfiling(pid uint32, target *sd.Target, pi procInf
 	if pyPerf == nil {
 		_ = level.Error(s.logger).Log("err", "pyperf process profiling init failed. pyperf == nil", "pid", pid)
 		pi.typ = pyrobpf.ProfilingTypeError
-		s.setPidConfig(pid, pi, false, false)
+		s.setPidConfig(pid, pi, target, false, false)
 		return false
 	}
 
@@ -58,7 +57,7 @@ func (s *session) startPythonProfiling(pid uint32, target *sd.Target, pi procInf
 			_ = level.Debug(s.logger).Log("err", err, "msg", "pyperf get python process data failed", "pid", pid, "target", target.String())
 		}
 		pi.typ = pyrobpf.ProfilingTypeError
-		s.setPidConfig(pid, pi, false, false)
+		s.setPidConfig(pid, pi, target, false, false)
 		return alive
 	}
 	err = nil
@@ -68,13 +67,13 @@ func (s *session) startPythonProfiling(pid uint32, target *sd.Target, pi procInf
 		if err != nil {
 			_ = level.Error(s.logger).Log("err", err, "msg", "pyperf process profiling init failed", "pid", pid)
 			pi.typ = pyrobpf.ProfilingTypeError
-			s.setPidConfig(pid, pi, false, false)
+			s.setPidConfig(pid, pi, target, false, false)
 			return false
 		}
 	}
 	_ = level.Info(s.logger).Log("msg", "pyperf process profiling init success", "pid", pid,
 		"py_data", fmt.Sprintf("%+v", pyData), "target", target.String())
-	s.setPidConfig(pid, pi, s.options.CollectUser, s.options.CollectKernel)
+	s.setPidConfig(pid, pi, target, s.collectUserEnabled(target), s.collectKernelEnabled(target))
 	return false
 }
 
@@ -207,7 +206,7 @@ func (s *session) WalkPythonStack(sb *stackBuilder, stack []byte, target *sd.Tar
 		}
 	}
 	end := len(sb.stack)
-	lo.Reverse(sb.stack[begin:end])
+	sb.reverse(begin, end)
 }
 
 func skipPythonFrame(classname string, filename string, name string) bool {
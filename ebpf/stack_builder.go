@@ -0,0 +1,126 @@
+//go:build linux
+
+package ebpfspy
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/grafana/pyroscope/ebpf/symtab"
+	"github.com/grafana/pyroscope/ebpf/symtab/elf"
+	"github.com/samber/lo"
+)
+
+type stackOptions struct {
+	UnknownSymbolModuleOffset bool
+	UnknownSymbolAddress      bool
+}
+
+type StackResolveStats struct {
+	known          uint32
+	unknownSymbols uint32
+	unknownModules uint32
+}
+
+func (s *StackResolveStats) add(other StackResolveStats) {
+	s.known += other.known
+	s.unknownSymbols += other.unknownSymbols
+	s.unknownModules += other.unknownModules
+}
+
+// walkStack goes over stack, resolves symbols and appends top sb
+// stack is an array of 127 uint64s, where each uint64 is an instruction pointer
+func walkStack(sb *stackBuilder, stack []byte, resolver symtab.SymbolTable, stats *StackResolveStats, opt stackOptions) {
+	if len(stack) == 0 {
+		return
+	}
+	frameResolver, _ := resolver.(symtab.FrameResolver)
+	begin := len(sb.stack)
+	for i := 0; i < 127; i++ {
+		instructionPointerBytes := stack[i*8 : i*8+8]
+		instructionPointer := binary.LittleEndian.Uint64(instructionPointerBytes)
+		if instructionPointer == 0 {
+			break
+		}
+		sym := resolver.Resolve(instructionPointer)
+		var name string
+		var frames []elf.Frame
+		if sym.Name != "" {
+			name = sym.Name
+			if frameResolver != nil {
+				frames = frameResolver.ResolveFrames(instructionPointer, nil)
+			}
+			stats.known++
+		} else {
+			if sym.Module != "" {
+				if opt.UnknownSymbolModuleOffset {
+					name = fmt.Sprintf("%s+%x", sym.Module, sym.Start)
+				} else {
+					name = sym.Module
+				}
+				stats.unknownSymbols++
+			} else {
+				if opt.UnknownSymbolAddress {
+					name = fmt.Sprintf("%x", instructionPointer)
+				} else {
+					name = "[unknown]"
+				}
+				stats.unknownModules++
+			}
+		}
+		sb.appendFrames(name, frames)
+	}
+	end := len(sb.stack)
+	sb.reverse(begin, end)
+
+}
+
+type stackBuilder struct {
+	stack []string
+	// frames holds source code locations of the stack entries, if
+	// any of them are resolved. Otherwise, it is empty.
+	frames [][]elf.Frame
+}
+
+func (s *stackBuilder) reset() {
+	s.stack = s.stack[:0]
+	s.frames = s.frames[:0]
+}
+
+func (s *stackBuilder) append(sym string) {
+	s.stack = append(s.stack, sym)
+	if len(s.frames) > 0 {
+		s.frames = append(s.frames, nil)
+	}
+}
+
+func (s *stackBuilder) appendFrames(sym string, frames []elf.Frame) {
+	if len(frames) == 0 {
+		s.append(sym)
+		return
+	}
+	s.padFrames()
+	s.stack = append(s.stack, sym)
+	s.frames = append(s.frames, frames)
+}
+
+func (s *stackBuilder) padFrames() {
+	for len(s.frames) < len(s.stack) {
+		s.frames = append(s.frames, nil)
+	}
+}
+
+func (s *stackBuilder) reverse(begin, end int) {
+	lo.Reverse(s.stack[begin:end])
+	if len(s.frames) > 0 {
+		s.padFrames()
+		lo.Reverse(s.frames[begin:end])
+	}
+}
+
+func (s *stackBuilder) stackFrames() [][]elf.Frame {
+	if len(s.frames) == 0 {
+		return nil
+	}
+	return s.frames
+}
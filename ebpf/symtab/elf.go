@@ -36,6 +36,9 @@ type SymbolOptions struct {
 	GoTableFallback    bool
 	PythonFullFilePath bool
 	DemangleOptions    []demangle.Option
+	// DwarfLines enables resolving file, line and inlined
+	// functions from DWARF, if present in the binary or the debug file.
+	DwarfLines bool
 }
 
 var DefaultSymbolOptions = &SymbolOptions{
@@ -165,6 +168,21 @@ func (et *ElfTable) load() {
 
 func (et *ElfTable) createSymbolTable(me *elf2.MMapedElfFile) (SymbolNameResolver, error) {
 	level.Debug(et.logger).Log("msg", "create symbol table", "path", me.FilePath())
+	symbols, err := et.createSymbolNameTable(me)
+	if err != nil || !et.options.SymbolOptions.DwarfLines {
+		return symbols, err
+	}
+	dwarfTable, err := me.NewDwarfTable(symbols, et.options.SymbolOptions.DemangleOptions)
+	if err != nil {
+		if !errors.Is(err, elf2.ErrNoDwarf) {
+			level.Debug(et.logger).Log("msg", "failed to read dwarf", "err", err, "path", me.FilePath())
+		}
+		return symbols, nil
+	}
+	return dwarfTable, nil
+}
+
+func (et *ElfTable) createSymbolNameTable(me *elf2.MMapedElfFile) (SymbolNameResolver, error) {
 	goTable, goErr := me.NewGoTable()
 	if !et.options.SymbolOptions.GoTableFallback && goErr == nil {
 		return goTable, nil
@@ -177,6 +195,12 @@ func (et *ElfTable) createSymbolTable(me *elf2.MMapedElfFile) (SymbolNameResolve
 		symbolOptions.FilterTo = goTable.Index.End
 	}
 	symTable, symErr := me.NewSymbolTable(&symbolOptions)
+	if goErr != nil {
+		// Go binaries are not expected to carry MiniDebugInfo.
+		if t := et.createMiniDebugInfoTable(me, symTable, &symbolOptions); t != nil {
+			return t, nil
+		}
+	}
 	if symErr != nil && goErr != nil {
 		return nil, fmt.Errorf("s: %s g: %s", symErr.Error(), goErr.Error())
 	}
@@ -195,6 +219,31 @@ func (et *ElfTable) createSymbolTable(me *elf2.MMapedElfFile) (SymbolNameResolve
 	panic("unreachable")
 }
 
+// createMiniDebugInfoTable returns nil if the file has no MiniDebugInfo
+// or it can not be read. symTable may be nil if the file has no symbols.
+func (et *ElfTable) createMiniDebugInfoTable(me *elf2.MMapedElfFile, symTable *elf2.SymbolTable, opt *elf2.SymbolsOptions) SymbolNameResolver {
+	mini, err := me.MiniDebugInfo()
+	if err != nil {
+		if !errors.Is(err, elf2.ErrNoMiniDebugInfo) {
+			level.Debug(et.logger).Log("msg", "failed to read minidebuginfo", "err", err, "path", me.FilePath())
+		}
+		return nil
+	}
+	miniTable, err := mini.NewSymbolTable(opt)
+	if err != nil {
+		mini.Close()
+		level.Debug(et.logger).Log("msg", "failed to read minidebuginfo symbols", "err", err, "path", me.FilePath())
+		return nil
+	}
+	if symTable == nil {
+		return miniTable
+	}
+	return &elf2.SymbolTableWithMiniDebugInfo{
+		SymTable:      symTable,
+		MiniDebugInfo: miniTable,
+	}
+}
+
 var errTableDead = fmt.Errorf("non cached table dead")
 
 func (et *ElfTable) Resolve(pc uint64) string {
@@ -226,6 +275,22 @@ func (et *ElfTable) Resolve(pc uint64) string {
 	return et.table.Resolve(pc)
 }
 
+// ResolveFrames appends the source code locations of the address to frames,
+// the innermost inlined function first. Nothing is appended if the table
+// has no DWARF information.
+func (et *ElfTable) ResolveFrames(pc uint64, frames []elf2.Frame) []elf2.Frame {
+	if !et.loaded {
+		et.load()
+	}
+	if et.err != nil {
+		return frames
+	}
+	if t, ok := et.table.(*elf2.DwarfTable); ok {
+		return t.ResolveFrames(pc-et.base, frames)
+	}
+	return frames
+}
+
 func (et *ElfTable) Cleanup() {
 	if et.table != nil {
 		et.table.Cleanup()
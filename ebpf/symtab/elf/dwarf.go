@@ -0,0 +1,355 @@
+package elf
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ianlancetaylor/demangle"
+)
+
+var ErrNoDwarf = errors.New("no dwarf info")
+
+// Frame is a source code location an address resolves to.
+type Frame struct {
+	Name string
+	File string
+	Line int
+}
+
+// DwarfTable resolves addresses to source code locations using DWARF
+// .debug_line and .debug_info. An address of an inlined call resolves to
+// multiple frames: one per inlined function and one for the function it
+// was inlined into. Function names are resolved by the wrapped table,
+// DwarfTable adds file and line information only.
+type DwarfTable struct {
+	SymbolTable SymbolNameResolver
+
+	lines  []lineRow
+	scopes []scopeRange
+	file   string
+	size   int
+}
+
+// SymbolNameResolver mirrors symtab.SymbolNameResolver to avoid an import cycle.
+type SymbolNameResolver interface {
+	Refresh()
+	Cleanup()
+	DebugInfo() SymTabDebugInfo
+	IsDead() bool
+	Resolve(addr uint64) string
+}
+
+type lineRow struct {
+	addr uint64
+	file string
+	line int
+	// end of a sequence of addresses: the row does not belong to any line.
+	end bool
+}
+
+// scope is a subprogram or an inlined subroutine. Inlined subroutines
+// are children of the scope they were inlined into.
+type scope struct {
+	name     string
+	ranges   [][2]uint64
+	callFile string
+	callLine int
+	children []*scope
+}
+
+type scopeRange struct {
+	low, high uint64
+	scope     *scope
+}
+
+func (f *MMapedElfFile) NewDwarfTable(symbols SymbolNameResolver, demangleOptions []demangle.Option) (*DwarfTable, error) {
+	if err := f.ensureOpen(); err != nil {
+		return nil, err
+	}
+	if f.Section(".debug_info") == nil && f.Section(".zdebug_info") == nil {
+		return nil, ErrNoDwarf
+	}
+	ef, err := elf.NewFile(f.fd)
+	if err != nil {
+		return nil, err
+	}
+	d, err := ef.DWARF()
+	if err != nil {
+		return nil, fmt.Errorf("read dwarf %w", err)
+	}
+	p := dwarfParser{
+		data:            d,
+		names:           make(map[dwarf.Offset]string),
+		demangleOptions: demangleOptions,
+	}
+	if err = p.parse(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(p.lines, func(i, j int) bool {
+		a, b := &p.lines[i], &p.lines[j]
+		if a.addr == b.addr {
+			// A sequence may start right where another one ends.
+			return a.end && !b.end
+		}
+		return a.addr < b.addr
+	})
+	sort.Slice(p.scopes, func(i, j int) bool {
+		return p.scopes[i].low < p.scopes[j].low
+	})
+	return &DwarfTable{
+		SymbolTable: symbols,
+		lines:       p.lines,
+		scopes:      p.scopes,
+		file:        f.fpath,
+		size:        len(p.lines) + len(p.scopes),
+	}, nil
+}
+
+func (t *DwarfTable) Refresh() {
+	t.SymbolTable.Refresh()
+}
+
+func (t *DwarfTable) Cleanup() {
+	t.SymbolTable.Cleanup()
+}
+
+func (t *DwarfTable) IsDead() bool {
+	return t.SymbolTable.IsDead()
+}
+
+func (t *DwarfTable) DebugInfo() SymTabDebugInfo {
+	return SymTabDebugInfo{
+		Name: fmt.Sprintf("DwarfTable %p", t),
+		Size: t.size + t.SymbolTable.DebugInfo().Size,
+		File: t.file,
+	}
+}
+
+func (t *DwarfTable) Resolve(addr uint64) string {
+	return t.SymbolTable.Resolve(addr)
+}
+
+// ResolveFrames appends the frames of the address to the given slice, the
+// innermost inlined function goes first. The last frame is the function
+// the address belongs to, its name is resolved by the symbol table.
+// If the address has no line information, the slice is returned as is.
+func (t *DwarfTable) ResolveFrames(addr uint64, frames []Frame) []Frame {
+	row := t.lineRow(addr)
+	if row == nil {
+		return frames
+	}
+	name := t.SymbolTable.Resolve(addr)
+	if name == "" {
+		return frames
+	}
+	file, line := row.file, row.line
+	if s := t.scope(addr); s != nil {
+		for {
+			c := s.child(addr)
+			if c == nil {
+				break
+			}
+			frames = append(frames, Frame{Name: c.name, File: file, Line: line})
+			file, line = c.callFile, c.callLine
+			s = c
+		}
+	}
+	return append(frames, Frame{Name: name, File: file, Line: line})
+}
+
+func (t *DwarfTable) lineRow(addr uint64) *lineRow {
+	i := sort.Search(len(t.lines), func(i int) bool {
+		return t.lines[i].addr > addr
+	})
+	if i == 0 {
+		return nil
+	}
+	row := &t.lines[i-1]
+	if row.end {
+		return nil
+	}
+	return row
+}
+
+func (t *DwarfTable) scope(addr uint64) *scope {
+	i := sort.Search(len(t.scopes), func(i int) bool {
+		return t.scopes[i].low > addr
+	})
+	if i == 0 {
+		return nil
+	}
+	r := &t.scopes[i-1]
+	if addr >= r.high {
+		return nil
+	}
+	return r.scope
+}
+
+func (s *scope) child(addr uint64) *scope {
+	for _, c := range s.children {
+		for _, r := range c.ranges {
+			if addr >= r[0] && addr < r[1] {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+type dwarfParser struct {
+	data            *dwarf.Data
+	names           map[dwarf.Offset]string
+	demangleOptions []demangle.Option
+
+	lines  []lineRow
+	scopes []scopeRange
+}
+
+func (p *dwarfParser) parse() error {
+	r := p.data.Reader()
+	for {
+		cu, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if cu == nil {
+			return nil
+		}
+		if cu.Tag != dwarf.TagCompileUnit {
+			r.SkipChildren()
+			continue
+		}
+		var files []*dwarf.LineFile
+		lr, err := p.data.LineReader(cu)
+		if err != nil {
+			return err
+		}
+		if lr != nil {
+			files = lr.Files()
+			if err = p.parseLines(lr); err != nil {
+				return err
+			}
+		}
+		if err = p.parseUnit(r, cu, files); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dwarfParser) parseLines(lr *dwarf.LineReader) error {
+	var e dwarf.LineEntry
+	for {
+		if err := lr.Next(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		row := lineRow{addr: e.Address, line: e.Line, end: e.EndSequence}
+		if e.File != nil {
+			row.file = e.File.Name
+		}
+		p.lines = append(p.lines, row)
+	}
+}
+
+// parseUnit reads the subprograms of the compilation unit and their
+// inlined subroutines.
+func (p *dwarfParser) parseUnit(r *dwarf.Reader, cu *dwarf.Entry, files []*dwarf.LineFile) error {
+	if !cu.Children {
+		return nil
+	}
+	// Lexical blocks and other entries may nest inlined subroutines: the
+	// stack holds the scope enclosing the children being read. It is nil
+	// for the entries that do not belong to any subprogram.
+	var stack []*scope
+	for {
+		e, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			return nil
+		}
+		if e.Tag == 0 {
+			if len(stack) == 0 {
+				return nil
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		var parent *scope
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		var s *scope
+		switch e.Tag {
+		case dwarf.TagSubprogram:
+			s = p.newScope(e)
+			for _, rng := range s.ranges {
+				p.scopes = append(p.scopes, scopeRange{low: rng[0], high: rng[1], scope: s})
+			}
+		case dwarf.TagInlinedSubroutine:
+			if parent == nil {
+				break
+			}
+			s = p.newScope(e)
+			if i, ok := e.Val(dwarf.AttrCallFile).(int64); ok && i >= 0 && int(i) < len(files) && files[i] != nil {
+				s.callFile = files[i].Name
+			}
+			if l, ok := e.Val(dwarf.AttrCallLine).(int64); ok {
+				s.callLine = int(l)
+			}
+			parent.children = append(parent.children, s)
+		}
+		if e.Children {
+			if s == nil {
+				s = parent
+			}
+			stack = append(stack, s)
+		}
+	}
+}
+
+func (p *dwarfParser) newScope(e *dwarf.Entry) *scope {
+	// Entries with malformed ranges are not fatal:
+	// the addresses resolve to the enclosing scope.
+	ranges, _ := p.data.Ranges(e)
+	return &scope{name: p.name(e, 0), ranges: ranges}
+}
+
+// name returns the name of the function entry, following the
+// abstract origin and specification references.
+func (p *dwarfParser) name(e *dwarf.Entry, depth int) string {
+	if name, ok := e.Val(dwarf.AttrLinkageName).(string); ok {
+		return demangle.Filter(name, p.demangleOptions...)
+	}
+	if name, ok := e.Val(dwarf.AttrName).(string); ok {
+		return name
+	}
+	off, ok := e.Val(dwarf.AttrAbstractOrigin).(dwarf.Offset)
+	if !ok {
+		if off, ok = e.Val(dwarf.AttrSpecification).(dwarf.Offset); !ok {
+			return ""
+		}
+	}
+	if name, ok := p.names[off]; ok {
+		return name
+	}
+	if depth > 8 {
+		return ""
+	}
+	r := p.data.Reader()
+	r.Seek(off)
+	origin, err := r.Next()
+	if err != nil || origin == nil {
+		return ""
+	}
+	name := p.name(origin, depth+1)
+	p.names[off] = name
+	return name
+}
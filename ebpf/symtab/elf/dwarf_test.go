@@ -0,0 +1,45 @@
+package elf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDwarfTable(t *testing.T) {
+	me, err := NewMMapedElfFile("testdata/elfs/go20")
+	require.NoError(t, err)
+	defer me.Close()
+	goTable, err := me.NewGoTable()
+	require.NoError(t, err)
+	table, err := me.NewDwarfTable(goTable, nil)
+	require.NoError(t, err)
+
+	testcases := []struct {
+		addr     uint64
+		expected []Frame
+	}{
+		{0x4817a0, []Frame{
+			{Name: "main.main", File: "/go/hello.go", Line: 5},
+		}},
+		{0x4817d2, []Frame{
+			{Name: "fmt.Println", File: "/usr/local/go/src/fmt/print.go", Line: 314},
+			{Name: "main.main", File: "/go/hello.go", Line: 6},
+		}},
+		{0x1, nil},
+	}
+	for _, tc := range testcases {
+		require.Equal(t, tc.expected, table.ResolveFrames(tc.addr, nil))
+	}
+	require.Equal(t, "main.main", table.Resolve(0x4817d2))
+}
+
+func TestDwarfTableNoDwarf(t *testing.T) {
+	me, err := NewMMapedElfFile("testdata/elfs/elf")
+	require.NoError(t, err)
+	defer me.Close()
+	symbols, err := me.NewSymbolTable(&SymbolsOptions{})
+	require.NoError(t, err)
+	_, err = me.NewDwarfTable(symbols, nil)
+	require.ErrorIs(t, err, ErrNoDwarf)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"debug/elf"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
@@ -18,15 +19,35 @@ type MMapedElfFile struct {
 
 	fpath string
 	err   error
-	fd    *os.File
+	fd    readerAtCloser
+	// data holds the file contents if the file
+	// is not backed by a file on disk.
+	data []byte
 
 	stringCache map[int]string
 }
 
+type readerAtCloser interface {
+	io.ReaderAt
+	io.Closer
+}
+
 func NewMMapedElfFile(fpath string) (*MMapedElfFile, error) {
-	res := &MMapedElfFile{
+	return newMMapedElfFile(&MMapedElfFile{
 		fpath: fpath,
-	}
+	})
+}
+
+// NewMMapedElfFileFromData creates an ELF file from data in memory.
+// The name is only used for informational purposes.
+func NewMMapedElfFileFromData(name string, data []byte) (*MMapedElfFile, error) {
+	return newMMapedElfFile(&MMapedElfFile{
+		fpath: name,
+		data:  data,
+	})
+}
+
+func newMMapedElfFile(res *MMapedElfFile) (*MMapedElfFile, error) {
 	err := res.ensureOpen()
 	if err != nil {
 		res.Close()
@@ -98,6 +119,10 @@ func (f *MMapedElfFile) open() error {
 	if f.err != nil {
 		return fmt.Errorf("failed previously %w", f.err)
 	}
+	if f.data != nil {
+		f.fd = bytesReaderAt{Reader: bytes.NewReader(f.data)}
+		return nil
+	}
 	fd, err := os.OpenFile(f.fpath, os.O_RDONLY, 0)
 	if err != nil {
 		f.err = err
@@ -107,6 +132,14 @@ func (f *MMapedElfFile) open() error {
 	return nil
 }
 
+type bytesReaderAt struct {
+	*bytes.Reader
+}
+
+func (bytesReaderAt) Close() error {
+	return nil
+}
+
 func (f *MMapedElfFile) SectionData(s *elf.SectionHeader) ([]byte, error) {
 	if err := f.ensureOpen(); err != nil {
 		return nil, err
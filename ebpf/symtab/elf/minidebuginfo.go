@@ -0,0 +1,102 @@
+package elf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ulikunitz/xz"
+)
+
+// ErrNoMiniDebugInfo is returned by MMapedElfFile.MiniDebugInfo
+// if there is no .gnu_debugdata section in the file.
+var ErrNoMiniDebugInfo = errors.New("no .gnu_debugdata section")
+
+// maxMiniDebugInfoSize limits the size of the decompressed MiniDebugInfo.
+const maxMiniDebugInfoSize = 64 << 20
+
+// MiniDebugInfo returns the ELF file embedded into the .gnu_debugdata section.
+// https://sourceware.org/gdb/onlinedocs/gdb/MiniDebugInfo.html
+//
+// The section holds an xz-compressed ELF file with a symbol table of the
+// functions that are not present in .dynsym. The returned file is kept in
+// memory and is not backed by a file on disk.
+func (f *MMapedElfFile) MiniDebugInfo() (*MMapedElfFile, error) {
+	section := f.Section(".gnu_debugdata")
+	if section == nil {
+		return nil, ErrNoMiniDebugInfo
+	}
+	compressed, err := f.SectionData(section)
+	if err != nil {
+		return nil, err
+	}
+	r, err := xz.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress .gnu_debugdata %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxMiniDebugInfoSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress .gnu_debugdata %w", err)
+	}
+	if len(data) > maxMiniDebugInfoSize {
+		return nil, fmt.Errorf(".gnu_debugdata is too large")
+	}
+	return NewMMapedElfFileFromData(f.fpath+":.gnu_debugdata", data)
+}
+
+// SymbolTableWithMiniDebugInfo resolves symbols using both the symbol
+// tables of the ELF file and the symbol table of its MiniDebugInfo.
+type SymbolTableWithMiniDebugInfo struct {
+	SymTable      *SymbolTable
+	MiniDebugInfo *SymbolTable
+}
+
+func (t *SymbolTableWithMiniDebugInfo) IsDead() bool {
+	return t.SymTable.IsDead() || t.MiniDebugInfo.IsDead()
+}
+
+func (t *SymbolTableWithMiniDebugInfo) DebugInfo() SymTabDebugInfo {
+	return SymTabDebugInfo{
+		Name: fmt.Sprintf("SymbolTableWithMiniDebugInfo %p ", t),
+		Size: t.Size(),
+		File: t.SymTable.File.fpath,
+	}
+}
+
+func (t *SymbolTableWithMiniDebugInfo) Size() int {
+	return t.SymTable.Size() + t.MiniDebugInfo.Size()
+}
+
+func (t *SymbolTableWithMiniDebugInfo) Refresh() {
+
+}
+
+// Resolve returns the name of the symbol closest to the address:
+// the symbols of the two tables do not overlap.
+func (t *SymbolTableWithMiniDebugInfo) Resolve(addr uint64) string {
+	i, j := -1, -1
+	if t.SymTable.Size() > 0 {
+		i = t.SymTable.Index.Values.FindIndex(addr)
+	}
+	if t.MiniDebugInfo.Size() > 0 {
+		j = t.MiniDebugInfo.Index.Values.FindIndex(addr)
+	}
+	switch {
+	case i == -1 && j == -1:
+		return ""
+	case j == -1:
+		return t.SymTable.Resolve(addr)
+	case i == -1:
+		return t.MiniDebugInfo.Resolve(addr)
+	}
+	if t.MiniDebugInfo.Index.Values.Get(j) > t.SymTable.Index.Values.Get(i) {
+		return t.MiniDebugInfo.Resolve(addr)
+	}
+	return t.SymTable.Resolve(addr)
+}
+
+func (t *SymbolTableWithMiniDebugInfo) Cleanup() {
+	t.SymTable.Cleanup()
+	t.MiniDebugInfo.Cleanup()
+}
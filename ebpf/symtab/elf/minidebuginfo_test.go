@@ -0,0 +1,32 @@
+package elf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiniDebugInfo(t *testing.T) {
+	me, err := NewMMapedElfFile("testdata/elfs/elf.minidebuginfo")
+	require.NoError(t, err)
+	defer me.Close()
+
+	_, err = me.NewSymbolTable(&SymbolsOptions{})
+	require.ErrorIs(t, err, ErrNoSymbols)
+
+	mini, err := me.MiniDebugInfo()
+	require.NoError(t, err)
+	symbols, err := mini.NewSymbolTable(&SymbolsOptions{})
+	require.NoError(t, err)
+	defer symbols.Cleanup()
+	require.Equal(t, "iter", symbols.Resolve(0x1149))
+	require.Equal(t, "main", symbols.Resolve(0x115e))
+}
+
+func TestNoMiniDebugInfo(t *testing.T) {
+	me, err := NewMMapedElfFile("testdata/elfs/elf")
+	require.NoError(t, err)
+	defer me.Close()
+	_, err = me.MiniDebugInfo()
+	require.ErrorIs(t, err, ErrNoMiniDebugInfo)
+}
@@ -11,6 +11,16 @@ objcopy --add-gnu-debuglink=elf.debug elf.stripped elf.debuglink
 strip --remove-section .note.gnu.build-id elf.debuglink -o elf.debuglink
 objcopy --remove-section .note.gnu.build-id elf elf.nobuildid
 
+# https://sourceware.org/gdb/onlinedocs/gdb/MiniDebugInfo.html
+nm -D elf --format=posix --defined-only | awk '{ print $1 }' | sort > dynsyms
+nm elf --format=posix --defined-only | awk '{ if ($2 == "T" || $2 == "t") print $1 }' | sort > funcsyms
+comm -13 dynsyms funcsyms > keep_symbols
+objcopy --only-keep-debug elf mini_debuginfo
+objcopy -S --remove-section .gdb_index --remove-section .comment --keep-symbols=keep_symbols mini_debuginfo
+strip --strip-all -R .comment elf -o elf.minidebuginfo
+xz mini_debuginfo
+objcopy --add-section .gnu_debugdata=mini_debuginfo.xz elf.minidebuginfo
+
 
 build_id=$(readelf -n elf | grep 'Build ID' | awk '{print $3}')
 dir=${build_id:0:2}
@@ -35,6 +35,40 @@ func TestElf(t *testing.T) {
 	}
 }
 
+func TestElfMiniDebugInfo(t *testing.T) {
+	elfCache, _ := NewElfCache(testCacheOptions, testCacheOptions)
+	defer elfCache.Cleanup()
+	logger := util.TestLogger(t)
+	tab := NewElfTable(logger, &ProcMap{StartAddr: 0x1000, Offset: 0x1000}, ".", "elf/testdata/elfs/elf.minidebuginfo",
+		ElfTableOptions{
+			ElfCache: elfCache,
+			Metrics:  metrics.NewSymtabMetrics(nil),
+		})
+	require.Equal(t, "iter", tab.Resolve(0x1149))
+	require.Equal(t, "main", tab.Resolve(0x115e))
+
+	require.NotNil(t, elfCache.GetSymbolsByBuildID(elf.GNUBuildID("1fcfa068c5fdb9f31e6d9f3f89019beacb70182d")))
+}
+
+func TestElfDwarfLines(t *testing.T) {
+	elfCache, _ := NewElfCache(testCacheOptions, testCacheOptions)
+	defer elfCache.Cleanup()
+	logger := util.TestLogger(t)
+	tab := NewElfTable(logger, &ProcMap{StartAddr: 0x401000, Offset: 0x1000}, ".", "elf/testdata/elfs/go20",
+		ElfTableOptions{
+			ElfCache:      elfCache,
+			SymbolOptions: &SymbolOptions{DwarfLines: true},
+			Metrics:       metrics.NewSymtabMetrics(nil),
+		})
+
+	require.Equal(t, "main.main", tab.Resolve(0x4817d2))
+	frames := tab.ResolveFrames(0x4817d2, nil)
+	require.Equal(t, []elf.Frame{
+		{Name: "fmt.Println", File: "/usr/local/go/src/fmt/print.go", Line: 314},
+		{Name: "main.main", File: "/go/hello.go", Line: 6},
+	}, frames)
+}
+
 func TestGoTableFallbackFiltering(t *testing.T) {
 	ts := []struct {
 		f string
@@ -2,7 +2,6 @@ package gosym
 
 import (
 	"io"
-	"os"
 
 	bufra "github.com/avvmoto/buf-readerat"
 )
@@ -25,7 +24,7 @@ type FilePCLNData struct {
 	offset int
 }
 
-func NewFilePCLNData(f *os.File, offset int) *FilePCLNData {
+func NewFilePCLNData(f io.ReaderAt, offset int) *FilePCLNData {
 	return &FilePCLNData{
 		file:   bufra.NewBufReaderAt(f, 4*0x1000),
 		offset: offset,
@@ -0,0 +1,227 @@
+package symtab
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/grafana/pyroscope/ebpf/symtab/elf"
+)
+
+// PerfMapTable resolves the addresses of JIT-compiled code using the
+// symbol files JIT runtimes write for perf: /tmp/perf-<pid>.map and
+// jit-<pid>.dump. The file is parsed again on Refresh if it has changed.
+// https://github.com/torvalds/linux/blob/master/tools/perf/Documentation/jit-interface.txt
+// https://github.com/torvalds/linux/blob/master/tools/perf/Documentation/jitdump-specification.txt
+type PerfMapTable struct {
+	// path of the file on the host
+	path string
+	// path of the file as seen by the process
+	module string
+	parse  func(data []byte) ([]perfMapSymbol, error)
+
+	symbols []perfMapSymbol
+	size    int64
+	modTime time.Time
+	err     error
+}
+
+type perfMapSymbol struct {
+	start, end uint64
+	name       string
+}
+
+func NewPerfMapTable(path, module string) *PerfMapTable {
+	return &PerfMapTable{path: path, module: module, parse: parsePerfMap}
+}
+
+func NewJitDumpTable(path, module string) *PerfMapTable {
+	return &PerfMapTable{path: path, module: module, parse: parseJitDump}
+}
+
+func (t *PerfMapTable) Refresh() {
+	fi, err := os.Stat(t.path)
+	if err != nil {
+		t.symbols = nil
+		t.size = 0
+		t.modTime = time.Time{}
+		t.err = err
+		return
+	}
+	if t.err == nil && fi.Size() == t.size && fi.ModTime().Equal(t.modTime) {
+		return
+	}
+	t.size = fi.Size()
+	t.modTime = fi.ModTime()
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		t.err = err
+		return
+	}
+	symbols, err := t.parse(data)
+	if err != nil {
+		t.err = err
+		return
+	}
+	// Code may be reloaded at an address of the code freed before:
+	// the symbol loaded last wins.
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].start < symbols[j].start
+	})
+	j := 0
+	for i := range symbols {
+		if j > 0 && symbols[j-1].start == symbols[i].start {
+			j--
+		}
+		symbols[j] = symbols[i]
+		j++
+	}
+	t.symbols = symbols[:j]
+	t.err = nil
+}
+
+func (t *PerfMapTable) Cleanup() {
+}
+
+func (t *PerfMapTable) Error() error {
+	return t.err
+}
+
+func (t *PerfMapTable) IsDead() bool {
+	return t.err != nil && errors.Is(t.err, os.ErrNotExist)
+}
+
+func (t *PerfMapTable) DebugInfo() elf.SymTabDebugInfo {
+	return elf.SymTabDebugInfo{
+		Name: fmt.Sprintf("PerfMapTable %p", t),
+		Size: len(t.symbols),
+		File: t.module,
+	}
+}
+
+func (t *PerfMapTable) Resolve(addr uint64) string {
+	i := sort.Search(len(t.symbols), func(i int) bool {
+		return t.symbols[i].start > addr
+	})
+	if i == 0 {
+		return ""
+	}
+	s := &t.symbols[i-1]
+	if addr >= s.end {
+		return ""
+	}
+	return s.name
+}
+
+// parsePerfMap parses the perf map lines: START SIZE symbolname,
+// where START and SIZE are hex numbers. Malformed lines are skipped.
+func parsePerfMap(data []byte) ([]perfMapSymbol, error) {
+	var symbols []perfMapSymbol
+	for len(data) > 0 {
+		var line []byte
+		if i := bytes.IndexByte(data, '\n'); i == -1 {
+			line, data = data, nil
+		} else {
+			line, data = data[:i], data[i+1:]
+		}
+		fields := bytes.SplitN(bytes.TrimRight(line, "\r"), []byte{' '}, 3)
+		if len(fields) != 3 || len(fields[2]) == 0 {
+			continue
+		}
+		start, err := parseHex(fields[0])
+		if err != nil {
+			continue
+		}
+		size, err := parseHex(fields[1])
+		if err != nil || size == 0 {
+			continue
+		}
+		symbols = append(symbols, perfMapSymbol{
+			start: start,
+			end:   start + size,
+			name:  string(fields[2]),
+		})
+	}
+	return symbols, nil
+}
+
+func parseHex(s []byte) (uint64, error) {
+	s = bytes.TrimPrefix(s, []byte("0x"))
+	return strconv.ParseUint(string(s), 16, 64)
+}
+
+const (
+	jitDumpMagic        = 0x4A695444
+	jitDumpHeaderSize   = 40
+	jitDumpRecordHeader = 16
+
+	jitCodeLoad = 0
+	jitCodeMove = 1
+)
+
+// parseJitDump parses JIT_CODE_LOAD and JIT_CODE_MOVE records of the
+// jitdump file. The last record may be incomplete if the runtime is
+// writing it, the records before it are returned.
+func parseJitDump(data []byte) ([]perfMapSymbol, error) {
+	if len(data) < jitDumpHeaderSize {
+		return nil, fmt.Errorf("jitdump header too short: %d", len(data))
+	}
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint32(data) == jitDumpMagic:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(data) == jitDumpMagic:
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid jitdump magic %x", data[:4])
+	}
+	headerSize := order.Uint32(data[8:])
+	if headerSize < jitDumpHeaderSize || int(headerSize) > len(data) {
+		return nil, fmt.Errorf("invalid jitdump header size %d", headerSize)
+	}
+	var symbols []perfMapSymbol
+	names := make(map[uint64]string)
+	for data = data[headerSize:]; len(data) >= jitDumpRecordHeader; {
+		id := order.Uint32(data)
+		size := order.Uint32(data[4:])
+		if size < jitDumpRecordHeader || int(size) > len(data) {
+			break
+		}
+		record := data[jitDumpRecordHeader:size]
+		data = data[size:]
+		switch id {
+		case jitCodeLoad:
+			// pid, tid, vma, code_addr, code_size, code_index, name
+			if len(record) < 40 {
+				continue
+			}
+			addr := order.Uint64(record[16:])
+			codeSize := order.Uint64(record[24:])
+			index := order.Uint64(record[32:])
+			name := record[40:]
+			if i := bytes.IndexByte(name, 0); i != -1 {
+				name = name[:i]
+			}
+			names[index] = string(name)
+			symbols = append(symbols, perfMapSymbol{start: addr, end: addr + codeSize, name: string(name)})
+		case jitCodeMove:
+			// pid, tid, vma, old_code_addr, new_code_addr, code_size, code_index
+			if len(record) < 48 {
+				continue
+			}
+			name, ok := names[order.Uint64(record[40:])]
+			if !ok {
+				continue
+			}
+			addr := order.Uint64(record[24:])
+			codeSize := order.Uint64(record[32:])
+			symbols = append(symbols, perfMapSymbol{start: addr, end: addr + codeSize, name: name})
+		}
+	}
+	return symbols, nil
+}
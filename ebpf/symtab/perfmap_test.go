@@ -0,0 +1,128 @@
+package symtab
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grafana/pyroscope/ebpf/metrics"
+	"github.com/grafana/pyroscope/ebpf/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerfMap(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "perf-239.map")
+	require.NoError(t, os.WriteFile(f, []byte(
+		"7f0000001000 20 LazyCompile:~foo /app/index.js:1\n"+
+			"invalid line\n"+
+			"0x7f0000001040 10 bar\n"), 0o644))
+	table := NewPerfMapTable(f, "/tmp/perf-239.map")
+	table.Refresh()
+	require.NoError(t, table.Error())
+	require.Equal(t, "", table.Resolve(0x7f0000000fff))
+	require.Equal(t, "LazyCompile:~foo /app/index.js:1", table.Resolve(0x7f0000001000))
+	require.Equal(t, "LazyCompile:~foo /app/index.js:1", table.Resolve(0x7f000000101f))
+	require.Equal(t, "", table.Resolve(0x7f0000001020))
+	require.Equal(t, "bar", table.Resolve(0x7f0000001045))
+
+	require.NoError(t, os.WriteFile(f, []byte("7f0000001000 20 baz\n7f0000002000 20 qux\n"), 0o644))
+	table.Refresh()
+	require.Equal(t, "baz", table.Resolve(0x7f0000001000))
+	require.Equal(t, "qux", table.Resolve(0x7f0000002000))
+
+	require.NoError(t, os.Remove(f))
+	table.Refresh()
+	require.True(t, table.IsDead())
+	require.Equal(t, "", table.Resolve(0x7f0000001000))
+}
+
+type jitDumpRecord struct {
+	id        uint32
+	addr      uint64
+	oldAddr   uint64
+	size      uint64
+	index     uint64
+	name      string
+	truncated bool
+}
+
+func jitDump(records ...jitDumpRecord) []byte {
+	buf := bytes.NewBuffer(nil)
+	le := binary.LittleEndian
+	_ = binary.Write(buf, le, []uint32{jitDumpMagic, 1, jitDumpHeaderSize, 62, 0, 239})
+	_ = binary.Write(buf, le, []uint64{0, 0})
+	for _, r := range records {
+		body := bytes.NewBuffer(nil)
+		_ = binary.Write(body, le, []uint32{239, 239})
+		switch r.id {
+		case jitCodeLoad:
+			_ = binary.Write(body, le, []uint64{r.addr, r.addr, r.size, r.index})
+			body.WriteString(r.name)
+			body.WriteByte(0)
+			body.Write(make([]byte, r.size)) // code
+		case jitCodeMove:
+			_ = binary.Write(body, le, []uint64{r.addr, r.oldAddr, r.addr, r.size, r.index})
+		}
+		_ = binary.Write(buf, le, []uint32{r.id, uint32(jitDumpRecordHeader + body.Len())})
+		_ = binary.Write(buf, le, uint64(0))
+		b := body.Bytes()
+		if r.truncated {
+			b = b[:len(b)/2]
+		}
+		buf.Write(b)
+	}
+	return buf.Bytes()
+}
+
+func TestJitDump(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "jit-239.dump")
+	require.NoError(t, os.WriteFile(f, jitDump(
+		jitDumpRecord{id: jitCodeLoad, addr: 0x7f0000001000, size: 0x20, index: 1, name: "foo"},
+		jitDumpRecord{id: jitCodeLoad, addr: 0x7f0000001100, size: 0x20, index: 2, name: "bar"},
+		jitDumpRecord{id: jitCodeMove, addr: 0x7f0000002000, oldAddr: 0x7f0000001100, size: 0x20, index: 2},
+		jitDumpRecord{id: jitCodeLoad, addr: 0x7f0000001000, size: 0x10, index: 3, name: "baz"},
+		jitDumpRecord{id: jitCodeLoad, addr: 0x7f0000003000, size: 0x10, index: 4, name: "truncated", truncated: true},
+	), 0o644))
+	table := NewJitDumpTable(f, "/tmp/jit-239.dump")
+	table.Refresh()
+	require.NoError(t, table.Error())
+	require.Equal(t, "baz", table.Resolve(0x7f0000001000))
+	require.Equal(t, "", table.Resolve(0x7f0000001010))
+	require.Equal(t, "bar", table.Resolve(0x7f0000001100))
+	require.Equal(t, "bar", table.Resolve(0x7f0000002010))
+	require.Equal(t, "", table.Resolve(0x7f0000003000))
+}
+
+func TestProcPerfMap(t *testing.T) {
+	rootFS := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootFS, "tmp"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootFS, "tmp", "perf-239.map"),
+		[]byte("7f0000001000 20 perfmap_fn\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(rootFS, "tmp", "jit-239.dump"), jitDump(
+		jitDumpRecord{id: jitCodeLoad, addr: 0x7f0000002000, size: 0x20, index: 1, name: "jitdump_fn"},
+	), 0o644))
+	maps := `7f0000001000-7f0000003000 rwxp 00000000 00:00 0
+7f0000004000-7f0000005000 r-xp 00000000 09:00 1234                       /tmp/jit-239.dump
+`
+	elfCache, _ := NewElfCache(testCacheOptions, testCacheOptions)
+	p := NewProcTable(util.TestLogger(t), ProcTableOptions{
+		Pid:    239,
+		RootFS: rootFS,
+		ElfTableOptions: ElfTableOptions{
+			ElfCache: elfCache,
+			Metrics:  metrics.NewSymtabMetrics(nil),
+		},
+	})
+	p.RefreshProcMap([]byte(maps))
+	require.NoError(t, p.Error())
+	require.Equal(t, Symbol{Start: 0x7f0000001010, Name: "perfmap_fn", Module: "/tmp/perf-239.map"}, p.Resolve(0x7f0000001010))
+	require.Equal(t, Symbol{Start: 0x7f0000002010, Name: "jitdump_fn", Module: "/tmp/jit-239.dump"}, p.Resolve(0x7f0000002010))
+	require.Equal(t, Symbol{}, p.Resolve(0x7f0000002020))
+
+	require.NoError(t, os.WriteFile(filepath.Join(rootFS, "tmp", "perf-239.map"),
+		[]byte("7f0000001000 20 perfmap_fn\n7f0000002800 20 perfmap_fn2\n"), 0o644))
+	p.RefreshProcMap([]byte(maps))
+	require.Equal(t, "perfmap_fn2", p.Resolve(0x7f0000002800).Name)
+}
@@ -22,6 +22,11 @@ type ProcTable struct {
 	options    ProcTableOptions
 	rootFS     string
 	err        error
+
+	// perf map and jitdump tables for the anonymous executable mappings
+	jitTables map[string]*PerfMapTable
+	jitOrder  []*PerfMapTable
+	nsPid     int
 }
 
 type ProcTableDebugInfo struct {
@@ -43,20 +48,33 @@ func (p *ProcTable) DebugInfo() ProcTableDebugInfo {
 			res.ElfTables[fmt.Sprintf("%x %x %s", f.dev, f.inode, f.path)] = d
 		}
 	}
+	for _, t := range p.jitOrder {
+		if d := t.DebugInfo(); d.Size != 0 {
+			res.ElfTables[d.File] = d
+		}
+	}
 	return res
 }
 
 type ProcTableOptions struct {
 	Pid int
+	// RootFS is the directory the mapped files are read from.
+	// Defaults to /proc/<pid>/root.
+	RootFS string
 	ElfTableOptions
 }
 
 func NewProcTable(logger log.Logger, options ProcTableOptions) *ProcTable {
+	rootFS := options.RootFS
+	if rootFS == "" {
+		rootFS = path.Join("/proc", strconv.Itoa(options.Pid), "root")
+	}
 	return &ProcTable{
 		logger:     logger,
 		file2Table: make(map[file]*ElfTable),
+		jitTables:  make(map[string]*PerfMapTable),
 		options:    options,
-		rootFS:     path.Join("/proc", strconv.Itoa(options.Pid), "root"),
+		rootFS:     rootFS,
 	}
 }
 
@@ -86,12 +104,17 @@ func (p *ProcTable) Refresh() {
 	}
 }
 
+// RefreshProcMap updates the table with the given /proc/<pid>/maps
+// contents instead of reading them from procfs.
+func (p *ProcTable) RefreshProcMap(procMaps []byte) {
+	p.err = p.refreshProcMap(procMaps)
+}
+
 func (p *ProcTable) Error() error {
 	return p.err
 }
 
 func (p *ProcTable) refreshProcMap(procMaps []byte) error {
-	// todo support perf map files
 	for i := range p.ranges {
 		p.ranges[i].elfTable = nil
 	}
@@ -102,7 +125,12 @@ func (p *ProcTable) refreshProcMap(procMaps []byte) error {
 		return err
 	}
 
+	var jitDumps []string
 	for _, m := range maps {
+		if isJitDump(m.Pathname) {
+			jitDumps = append(jitDumps, m.Pathname)
+			continue
+		}
 		p.ranges = append(p.ranges, elfRange{
 			mapRange: m,
 		})
@@ -123,9 +151,64 @@ func (p *ProcTable) refreshProcMap(procMaps []byte) error {
 	for _, f := range filesToDelete {
 		delete(p.file2Table, f)
 	}
+	p.refreshJitTables(jitDumps)
 	return nil
 }
 
+// refreshJitTables updates the perf map and the jitdump tables. The jitdump
+// files are found by their mappings: the runtime maps them to let perf
+// know where the file is. The perf map is at /tmp/perf-<pid>.map, where
+// pid is the process id in its pid namespace.
+func (p *ProcTable) refreshJitTables(jitDumps []string) {
+	if p.nsPid == 0 {
+		p.nsPid = p.namespacePid()
+	}
+	tables := make(map[string]*PerfMapTable, len(jitDumps)+1)
+	p.jitOrder = p.jitOrder[:0]
+	add := func(module string, newTable func(path, module string) *PerfMapTable) {
+		t, ok := p.jitTables[module]
+		if !ok {
+			t = newTable(path.Join(p.rootFS, module), module)
+		}
+		t.Refresh()
+		tables[module] = t
+		if t.Error() == nil {
+			p.jitOrder = append(p.jitOrder, t)
+		}
+	}
+	for _, module := range jitDumps {
+		add(module, NewJitDumpTable)
+	}
+	add(fmt.Sprintf("/tmp/perf-%d.map", p.nsPid), NewPerfMapTable)
+	p.jitTables = tables
+}
+
+// namespacePid returns the pid of the process in its own pid namespace.
+func (p *ProcTable) namespacePid() int {
+	if p.options.RootFS != "" {
+		return p.options.Pid
+	}
+	status, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", p.options.Pid))
+	if err != nil {
+		return p.options.Pid
+	}
+	for _, line := range strings.Split(string(status), "\n") {
+		if !strings.HasPrefix(line, "NSpid:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if pid, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+			return pid
+		}
+	}
+	return p.options.Pid
+}
+
+func isJitDump(pathname string) bool {
+	base := path.Base(pathname)
+	return strings.HasPrefix(base, "jit-") && strings.HasSuffix(base, ".dump")
+}
+
 func (p *ProcTable) getElfTable(r *elfRange) *ElfTable {
 	f := r.mapRange.file()
 	e, ok := p.file2Table[f]
@@ -149,7 +232,7 @@ func (p *ProcTable) Resolve(pc uint64) Symbol {
 	r := p.ranges[i]
 	t := r.elfTable
 	if t == nil {
-		return Symbol{}
+		return p.resolveJit(pc)
 	}
 	s := t.Resolve(pc)
 	moduleOffset := pc - t.base
@@ -160,6 +243,30 @@ func (p *ProcTable) Resolve(pc uint64) Symbol {
 	return Symbol{Start: moduleOffset, Name: s, Module: r.mapRange.Pathname}
 }
 
+func (p *ProcTable) resolveJit(pc uint64) Symbol {
+	for _, t := range p.jitOrder {
+		if name := t.Resolve(pc); name != "" {
+			return Symbol{Start: pc, Name: name, Module: t.module}
+		}
+	}
+	return Symbol{}
+}
+
+func (p *ProcTable) ResolveFrames(pc uint64, frames []elf.Frame) []elf.Frame {
+	if o := p.options.SymbolOptions; o == nil || !o.DwarfLines {
+		return frames
+	}
+	i, found := slices.BinarySearchFunc(p.ranges, pc, binarySearchElfRange)
+	if !found {
+		return frames
+	}
+	t := p.ranges[i].elfTable
+	if t == nil {
+		return frames
+	}
+	return t.ResolveFrames(pc, frames)
+}
+
 func (p *ProcTable) createElfTable(m *ProcMap) *ElfTable {
 	if !strings.HasPrefix(m.Pathname, "/") {
 		return nil
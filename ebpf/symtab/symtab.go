@@ -8,6 +8,12 @@ type SymbolTable interface {
 	Resolve(addr uint64) Symbol
 }
 
+// FrameResolver is implemented by the symbol tables that
+// resolve addresses to source code locations.
+type FrameResolver interface {
+	ResolveFrames(addr uint64, frames []elf.Frame) []elf.Frame
+}
+
 type SymbolNameResolver interface {
 	Refresh()
 	Cleanup()
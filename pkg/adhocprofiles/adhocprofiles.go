@@ -6,8 +6,11 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io"
+	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
@@ -18,21 +21,64 @@ import (
 	"github.com/oklog/ulid"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/proto"
 
 	v1 "github.com/grafana/pyroscope/api/gen/proto/go/adhocprofiles/v1"
+	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
 	"github.com/grafana/pyroscope/pkg/frontend"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/og/storage/metadata"
+	"github.com/grafana/pyroscope/pkg/og/storage/tree"
 	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
 	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer/convert"
+	"github.com/grafana/pyroscope/pkg/pprof"
 	"github.com/grafana/pyroscope/pkg/validation"
 )
 
+const cleanupInterval = time.Hour
+
+type Limits interface {
+	frontend.Limits
+	AdHocProfilesMaxProfiles(tenantID string) int
+	AdHocProfilesMaxTotalSizeBytes(tenantID string) int
+	AdHocProfilesRetentionPeriod(tenantID string) time.Duration
+}
+
+// PushService ingests profiles into the tenant's time series storage.
+type PushService interface {
+	PushParsed(ctx context.Context, req *distributormodel.PushRequest) (*connect.Response[pushv1.PushResponse], error)
+}
+
+// PushServiceFunc is an adapter to use a function as a PushService.
+type PushServiceFunc func(ctx context.Context, req *distributormodel.PushRequest) (*connect.Response[pushv1.PushResponse], error)
+
+func (f PushServiceFunc) PushParsed(ctx context.Context, req *distributormodel.PushRequest) (*connect.Response[pushv1.PushResponse], error) {
+	return f(ctx, req)
+}
+
 type AdHocProfiles struct {
 	services.Service
 
 	logger log.Logger
-	limits frontend.Limits
+	limits Limits
 	bucket objstore.Bucket
+	pusher PushService
+
+	usageMu sync.Mutex
+	usage   map[string]*tenantUsage
+}
+
+// tenantUsage is the number of profiles and bytes stored by a tenant,
+// accounted for the limits. It is loaded from the bucket on first use,
+// and updated as profiles are uploaded. It is loaded again after profiles
+// are deleted or renamed, and after the cleanup of expired profiles.
+type tenantUsage struct {
+	mu       sync.Mutex
+	loaded   bool
+	profiles int
+	bytes    int
 }
 
 type AdHocProfile struct {
@@ -41,19 +87,56 @@ type AdHocProfile struct {
 	UploadedAt time.Time `json:"uploadedAt"`
 }
 
-func NewAdHocProfiles(bucket objstore.Bucket, logger log.Logger, limits frontend.Limits) *AdHocProfiles {
+func NewAdHocProfiles(bucket objstore.Bucket, logger log.Logger, limits Limits, pusher PushService) *AdHocProfiles {
 	a := &AdHocProfiles{
 		logger: logger,
 		bucket: bucket,
 		limits: limits,
+		pusher: pusher,
 	}
 	a.Service = services.NewBasicService(nil, a.running, nil)
 	return a
 }
 
 func (a *AdHocProfiles) running(ctx context.Context) error {
-	<-ctx.Done()
-	return nil
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.deleteExpired(ctx, time.Now()); err != nil {
+				level.Warn(a.logger).Log("msg", "failed to delete expired ad hoc profiles", "err", err)
+			}
+			// Profiles may also be changed by other instances.
+			a.resetUsage()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// deleteExpired deletes the profiles uploaded before the retention period
+// of their tenant.
+func (a *AdHocProfiles) deleteExpired(ctx context.Context, now time.Time) error {
+	return a.bucket.Iter(ctx, "", func(dir string) error {
+		tenantID := strings.TrimSuffix(dir, "/")
+		retention := a.limits.AdHocProfilesRetentionPeriod(tenantID)
+		if retention <= 0 {
+			return nil
+		}
+		bucket := a.getBucket(tenantID)
+		return bucket.Iter(ctx, "", func(id string) error {
+			uploadedAt, _, err := parseID(id)
+			if err != nil || now.Sub(uploadedAt) < retention {
+				return nil
+			}
+			if err := bucket.Delete(ctx, id); err != nil && !bucket.IsObjNotFoundErr(err) {
+				return err
+			}
+			level.Debug(a.logger).Log("msg", "deleted expired ad hoc profile", "tenant", tenantID, "id", id)
+			return nil
+		})
+	})
 }
 
 func (a *AdHocProfiles) Upload(ctx context.Context, c *connect.Request[v1.AdHocProfilesUploadRequest]) (*connect.Response[v1.AdHocProfilesGetResponse], error) {
@@ -68,8 +151,6 @@ func (a *AdHocProfiles) Upload(ctx context.Context, c *connect.Request[v1.AdHocP
 		UploadedAt: time.Now().UTC(),
 	}
 
-	// TODO: Add per-tenant upload limits (number of files, total size, etc.)
-
 	maxNodes, err := validation.ValidateMaxNodes(a.limits, []string{tenantID}, c.Msg.GetMaxNodes())
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not determine max nodes")
@@ -84,14 +165,23 @@ func (a *AdHocProfiles) Upload(ctx context.Context, c *connect.Request[v1.AdHocP
 
 	uid := ulid.MustNew(ulid.Timestamp(adHocProfile.UploadedAt), rand.Reader)
 	id := strings.Join([]string{uid.String(), adHocProfile.Name}, "-")
+	if err = validateID(id); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid profile name %q", adHocProfile.Name))
+	}
 
 	dataToStore, err := json.Marshal(adHocProfile)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to upload profile")
 	}
 
+	usage := a.tenantUsage(tenantID)
+	if err = usage.reserve(ctx, a.limits, tenantID, bucket, len(dataToStore)); err != nil {
+		return nil, err
+	}
+
 	err = bucket.Upload(ctx, id, bytes.NewReader(dataToStore))
 	if err != nil {
+		usage.release(len(dataToStore))
 		return nil, errors.Wrapf(err, "failed to upload profile")
 	}
 
@@ -115,21 +205,11 @@ func (a *AdHocProfiles) Get(ctx context.Context, c *connect.Request[v1.AdHocProf
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-
-	bucket := a.getBucket(tenantID)
-
-	reader, err := bucket.Get(ctx, c.Msg.GetId())
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get profile")
-	}
-
-	adHocProfileBytes, err := io.ReadAll(reader)
-	if err != nil {
+	if err = validateID(c.Msg.GetId()); err != nil {
 		return nil, err
 	}
 
-	var adHocProfile AdHocProfile
-	err = json.Unmarshal(adHocProfileBytes, &adHocProfile)
+	adHocProfile, err := a.get(ctx, a.getBucket(tenantID), c.Msg.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +219,7 @@ func (a *AdHocProfiles) Get(ctx context.Context, c *connect.Request[v1.AdHocProf
 		return nil, errors.Wrapf(err, "could not determine max nodes")
 	}
 
-	profile, profileTypes, err := parse(&adHocProfile, c.Msg.ProfileType, maxNodes)
+	profile, profileTypes, err := parse(adHocProfile, c.Msg.ProfileType, maxNodes)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to parse profile")
 	}
@@ -167,17 +247,15 @@ func (a *AdHocProfiles) List(ctx context.Context, c *connect.Request[v1.AdHocPro
 
 	profiles := make([]*v1.AdHocProfilesProfileMetadata, 0)
 	err = bucket.Iter(ctx, "", func(s string) error {
-		separatorIndex := strings.IndexRune(s, '-')
-		id, err := ulid.Parse(s[0:separatorIndex])
+		uploadedAt, name, err := parseID(s)
 		if err != nil {
 			level.Warn(a.logger).Log("msg", "cannot parse ad hoc profile", "key", s, "err", err)
 			return nil
 		}
-		name := s[separatorIndex+1:]
 		profiles = append(profiles, &v1.AdHocProfilesProfileMetadata{
 			Id:         s,
 			Name:       name,
-			UploadedAt: int64(id.Time()),
+			UploadedAt: uploadedAt.UnixMilli(),
 		})
 		return nil
 	})
@@ -197,6 +275,301 @@ func (a *AdHocProfiles) List(ctx context.Context, c *connect.Request[v1.AdHocPro
 	return connect.NewResponse(&v1.AdHocProfilesListResponse{Profiles: profiles}), nil
 }
 
+func (a *AdHocProfiles) Diff(ctx context.Context, c *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error) {
+	for _, id := range []string{c.Msg.LeftId, c.Msg.RightId} {
+		if err := validateID(id); err != nil {
+			return nil, err
+		}
+	}
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	maxNodes, err := validation.ValidateMaxNodes(a.limits, []string{tenantID}, c.Msg.GetMaxNodes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not determine max nodes")
+	}
+
+	bucket := a.getBucket(tenantID)
+	left, err := a.get(ctx, bucket, c.Msg.LeftId)
+	if err != nil {
+		return nil, err
+	}
+	right, err := a.get(ctx, bucket, c.Msg.RightId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The profiles are not truncated before computing the diff: truncation
+	// is applied to the combined tree.
+	leftProfile, _, err := parse(left, c.Msg.LeftProfileType, -1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse left profile")
+	}
+	rightProfile, _, err := parse(right, c.Msg.RightProfileType, -1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse right profile")
+	}
+	if leftProfile.Metadata.Units != rightProfile.Metadata.Units {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("cannot diff profiles with different units: %q and %q", leftProfile.Metadata.Units, rightProfile.Metadata.Units))
+	}
+
+	diff, err := flamebearer.Diff(leftProfile.Metadata.Name, leftProfile, rightProfile, int(maxNodes))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to diff profiles")
+	}
+	jsonProfile, err := json.Marshal(diff)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to diff profiles")
+	}
+
+	return connect.NewResponse(&v1.AdHocProfilesDiffResponse{
+		FlamebearerProfile: string(jsonProfile),
+	}), nil
+}
+
+func (a *AdHocProfiles) Delete(ctx context.Context, c *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error) {
+	if err := validateID(c.Msg.GetId()); err != nil {
+		return nil, err
+	}
+	bucket, err := a.getBucketFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bucket.Delete(ctx, c.Msg.GetId()); err != nil {
+		if bucket.IsObjNotFoundErr(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("profile %s not found", c.Msg.GetId()))
+		}
+		return nil, errors.Wrapf(err, "failed to delete profile")
+	}
+	a.resetTenantUsage(ctx)
+	return connect.NewResponse(&v1.AdHocProfilesDeleteResponse{}), nil
+}
+
+func (a *AdHocProfiles) Rename(ctx context.Context, c *connect.Request[v1.AdHocProfilesRenameRequest]) (*connect.Response[v1.AdHocProfilesRenameResponse], error) {
+	if err := validateID(c.Msg.GetId()); err != nil {
+		return nil, err
+	}
+	bucket, err := a.getBucketFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := c.Msg.GetName()
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid profile name %q", name))
+	}
+	uploadedAt, _, err := parseID(c.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	adHocProfile, err := a.get(ctx, bucket, c.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	// The format of the profile is detected from the extension of its name,
+	// which therefore can't be changed.
+	if ext := path.Ext(adHocProfile.Name); path.Ext(name) != ext {
+		name += ext
+	}
+
+	// The identifier contains the name: the profile is stored again under
+	// a new identifier keeping the upload ULID.
+	id := c.Msg.GetId()
+	newID := strings.Join([]string{id[:strings.IndexRune(id, '-')], name}, "-")
+	if newID != id {
+		adHocProfile.Name = name
+		dataToStore, err := json.Marshal(adHocProfile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to rename profile")
+		}
+		if err = bucket.Upload(ctx, newID, bytes.NewReader(dataToStore)); err != nil {
+			return nil, errors.Wrapf(err, "failed to rename profile")
+		}
+		if err = bucket.Delete(ctx, id); err != nil {
+			return nil, errors.Wrapf(err, "failed to rename profile")
+		}
+		a.resetTenantUsage(ctx)
+	}
+
+	return connect.NewResponse(&v1.AdHocProfilesRenameResponse{
+		Profile: &v1.AdHocProfilesProfileMetadata{
+			Id:         newID,
+			Name:       name,
+			UploadedAt: uploadedAt.UnixMilli(),
+		},
+	}), nil
+}
+
+func (a *AdHocProfiles) Import(ctx context.Context, c *connect.Request[v1.AdHocProfilesImportRequest]) (*connect.Response[v1.AdHocProfilesImportResponse], error) {
+	if err := validateID(c.Msg.GetId()); err != nil {
+		return nil, err
+	}
+	bucket, err := a.getBucketFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if a.pusher == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("importing ad hoc profiles requires the distributor to run in the same process"))
+	}
+
+	labels := phlaremodel.Labels(c.Msg.Labels).Clone()
+	if labels.Get(phlaremodel.LabelNameProfileName) == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("the %s label is required, e.g. process_cpu or memory", phlaremodel.LabelNameProfileName))
+	}
+
+	adHocProfile, err := a.get(ctx, bucket, c.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	timestamp := adHocProfile.UploadedAt
+	if c.Msg.Timestamp != 0 {
+		timestamp = time.UnixMilli(c.Msg.Timestamp)
+	}
+
+	samples, size, err := toPprof(adHocProfile, timestamp)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.Wrapf(err, "failed to convert profile"))
+	}
+	_, err = a.pusher.PushParsed(ctx, &distributormodel.PushRequest{
+		RawProfileSize: size,
+		RawProfileType: distributormodel.RawProfileTypePPROF,
+		Series: []*distributormodel.ProfileSeries{{
+			Labels:  labels,
+			Samples: samples,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&v1.AdHocProfilesImportResponse{}), nil
+}
+
+func (a *AdHocProfiles) tenantUsage(tenantID string) *tenantUsage {
+	a.usageMu.Lock()
+	defer a.usageMu.Unlock()
+	if a.usage == nil {
+		a.usage = make(map[string]*tenantUsage)
+	}
+	u, ok := a.usage[tenantID]
+	if !ok {
+		u = new(tenantUsage)
+		a.usage[tenantID] = u
+	}
+	return u
+}
+
+func (a *AdHocProfiles) resetTenantUsage(ctx context.Context) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return
+	}
+	a.usageMu.Lock()
+	delete(a.usage, tenantID)
+	a.usageMu.Unlock()
+}
+
+func (a *AdHocProfiles) resetUsage() {
+	a.usageMu.Lock()
+	a.usage = nil
+	a.usageMu.Unlock()
+}
+
+// reserve accounts a new profile of the given size, and returns an
+// error if storing it exceeds the per-tenant ad hoc profiles limits.
+func (u *tenantUsage) reserve(ctx context.Context, limits Limits, tenantID string, bucket objstore.Bucket, size int) error {
+	maxProfiles := limits.AdHocProfilesMaxProfiles(tenantID)
+	maxTotalSize := limits.AdHocProfilesMaxTotalSizeBytes(tenantID)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if maxProfiles <= 0 && maxTotalSize <= 0 {
+		if u.loaded {
+			u.profiles++
+			u.bytes += size
+		}
+		return nil
+	}
+	if maxTotalSize > 0 && size > maxTotalSize {
+		return connect.NewError(connect.CodeResourceExhausted,
+			fmt.Errorf("profile size of %d bytes exceeds the limit of %d bytes for ad hoc profiles", size, maxTotalSize))
+	}
+	if !u.loaded {
+		if err := u.load(ctx, bucket); err != nil {
+			return errors.Wrapf(err, "failed to check ad hoc profiles limits")
+		}
+	}
+	if maxProfiles > 0 && u.profiles >= maxProfiles {
+		return connect.NewError(connect.CodeResourceExhausted,
+			fmt.Errorf("the limit of %d ad hoc profiles is reached, delete profiles before uploading new ones", maxProfiles))
+	}
+	if maxTotalSize > 0 && u.bytes+size > maxTotalSize {
+		return connect.NewError(connect.CodeResourceExhausted,
+			fmt.Errorf("the limit of %d bytes of ad hoc profiles is reached, delete profiles before uploading new ones", maxTotalSize))
+	}
+	u.profiles++
+	u.bytes += size
+	return nil
+}
+
+// release removes a profile reserved but not stored from the usage.
+func (u *tenantUsage) release(size int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.loaded {
+		u.profiles--
+		u.bytes -= size
+	}
+}
+
+func (u *tenantUsage) load(ctx context.Context, bucket objstore.Bucket) error {
+	var profiles, size int
+	err := bucket.Iter(ctx, "", func(id string) error {
+		attrs, err := bucket.Attributes(ctx, id)
+		if err != nil {
+			if bucket.IsObjNotFoundErr(err) {
+				return nil
+			}
+			return err
+		}
+		profiles++
+		size += int(attrs.Size)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	u.profiles, u.bytes, u.loaded = profiles, size, true
+	return nil
+}
+
+// get retrieves the profile with the given identifier.
+func (a *AdHocProfiles) get(ctx context.Context, bucket objstore.Bucket, id string) (*AdHocProfile, error) {
+	reader, err := bucket.Get(ctx, id)
+	if err != nil {
+		if bucket.IsObjNotFoundErr(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("profile %s not found", id))
+		}
+		return nil, errors.Wrapf(err, "failed to get profile")
+	}
+	defer reader.Close()
+
+	adHocProfileBytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var adHocProfile AdHocProfile
+	err = json.Unmarshal(adHocProfileBytes, &adHocProfile)
+	if err != nil {
+		return nil, err
+	}
+	return &adHocProfile, nil
+}
+
 func (a *AdHocProfiles) getBucketFromContext(ctx context.Context) (objstore.Bucket, error) {
 	tenantID, err := tenant.TenantID(ctx)
 	if err != nil {
@@ -209,6 +582,93 @@ func (a *AdHocProfiles) getBucket(tenantID string) objstore.Bucket {
 	return objstore.NewPrefixedBucket(a.bucket, tenantID+"/adhoc")
 }
 
+// sampleTypes maps the units of the profile formats without sample
+// types to the pprof sample type used for ingestion.
+var sampleTypes = map[metadata.Units]tree.PprofMetadata{
+	metadata.SamplesUnits:         {Type: "samples", Unit: "count"},
+	metadata.ObjectsUnits:         {Type: "objects", Unit: "count"},
+	metadata.GoroutinesUnits:      {Type: "goroutine", Unit: "count"},
+	metadata.BytesUnits:           {Type: "space", Unit: "bytes"},
+	metadata.LockNanosecondsUnits: {Type: "delay", Unit: "nanoseconds"},
+	metadata.LockSamplesUnits:     {Type: "contentions", Unit: "count"},
+}
+
+// toPprof converts the ad hoc profile to pprof profiles with the given
+// timestamp. pprof files are kept as they are, other formats are converted
+// to a profile per sample type.
+func toPprof(p *AdHocProfile, timestamp time.Time) ([]*distributormodel.ProfileSample, int, error) {
+	data, err := base64.StdEncoding.DecodeString(p.Data)
+	if err != nil {
+		return nil, 0, err
+	}
+	f := convert.ProfileFile{Name: p.Name, Data: data}
+	convertFn, fileType, err := convert.Converter(f)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if fileType == convert.ProfileFileTypePprof {
+		profile, err := pprof.RawFromBytes(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		profile.TimeNanos = timestamp.UnixNano()
+		return []*distributormodel.ProfileSample{{Profile: profile, RawProfile: data}}, len(data), nil
+	}
+
+	profiles, err := convertFn(f.Data, f.Name, -1)
+	if err != nil {
+		return nil, 0, err
+	}
+	samples := make([]*distributormodel.ProfileSample, 0, len(profiles))
+	for _, fb := range profiles {
+		t, err := flamebearer.ProfileToTree(*fb)
+		if err != nil {
+			return nil, 0, err
+		}
+		mdata, ok := sampleTypes[fb.Metadata.Units]
+		if !ok {
+			mdata = sampleTypes[metadata.SamplesUnits]
+		}
+		mdata.StartTime = timestamp
+		b, err := proto.Marshal(t.Pprof(&mdata))
+		if err != nil {
+			return nil, 0, err
+		}
+		profile, err := pprof.RawFromBytes(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		samples = append(samples, &distributormodel.ProfileSample{Profile: profile})
+	}
+	return samples, len(data), nil
+}
+
+// validateID returns an error if the given identifier is not one of an
+// uploaded profile. Identifiers are used as object names in the bucket of
+// the tenant: they must not be able to refer to objects outside of it.
+func validateID(id string) error {
+	if _, _, err := parseID(id); err != nil ||
+		strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid profile id %q", id))
+	}
+	return nil
+}
+
+// parseID returns the upload time and the name of the profile with the
+// given identifier.
+func parseID(id string) (uploadedAt time.Time, name string, err error) {
+	separatorIndex := strings.IndexRune(id, '-')
+	if separatorIndex < 0 {
+		return time.Time{}, "", fmt.Errorf("invalid profile id %q", id)
+	}
+	uid, err := ulid.Parse(id[0:separatorIndex])
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return ulid.Time(uid.Time()), id[separatorIndex+1:], nil
+}
+
 func parse(p *AdHocProfile, profileType *string, maxNodes int64) (fg *flamebearer.FlamebearerProfile, profileTypes []string, err error) {
 	base64decoded, err := base64.StdEncoding.DecodeString(p.Data)
 	if err != nil {
@@ -7,13 +7,19 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/stretchr/testify/require"
 	thanosobjstore "github.com/thanos-io/objstore"
 
 	v1 "github.com/grafana/pyroscope/api/gen/proto/go/adhocprofiles/v1"
+	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	phlareobjstore "github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
 	"github.com/grafana/pyroscope/pkg/tenant"
 	"github.com/grafana/pyroscope/pkg/util"
 	"github.com/grafana/pyroscope/pkg/validation"
@@ -29,8 +35,8 @@ func TestAdHocProfiles_Get(t *testing.T) {
 		Data: encodedProfile,
 	}
 	jsonProfile, _ := json.Marshal(ahp)
-	_ = bucket.Upload(context.Background(), "tenant/adhoc/existing-invalid-json", bytes.NewReader([]byte{1, 2, 3}))
-	_ = bucket.Upload(context.Background(), "tenant/adhoc/existing-valid-profile", bytes.NewReader(jsonProfile))
+	_ = bucket.Upload(context.Background(), "tenant/adhoc/01HN1KQCC3Y0Z5J8E7MAV5T0QH-existing-invalid-json", bytes.NewReader([]byte{1, 2, 3}))
+	_ = bucket.Upload(context.Background(), "tenant/adhoc/01HN1KQCC3Y0Z5J8E7MAV5T0QH-existing-valid-profile", bytes.NewReader(jsonProfile))
 	type args struct {
 		ctx context.Context
 		c   *connect.Request[v1.AdHocProfilesGetRequest]
@@ -52,7 +58,7 @@ func TestAdHocProfiles_Get(t *testing.T) {
 			name: "return error when getting a non existing profile",
 			args: args{
 				ctx: tenant.InjectTenantID(context.Background(), "tenant"),
-				c:   connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: "non-existing-id"}),
+				c:   connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: "01HN1KQCC3Y0Z5J8E7MAV5T0QH-non-existing-id"}),
 			},
 			wantErr: true,
 		},
@@ -60,7 +66,7 @@ func TestAdHocProfiles_Get(t *testing.T) {
 			name: "return error when getting an existing invalid profile",
 			args: args{
 				ctx: tenant.InjectTenantID(context.Background(), "tenant"),
-				c:   connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: "existing-invalid-profile"}),
+				c:   connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: "01HN1KQCC3Y0Z5J8E7MAV5T0QH-existing-invalid-profile"}),
 			},
 			wantErr: true,
 		},
@@ -68,7 +74,7 @@ func TestAdHocProfiles_Get(t *testing.T) {
 			name: "return data when getting an existing valid profile",
 			args: args{
 				ctx: tenant.InjectTenantID(context.Background(), "tenant"),
-				c:   connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: "existing-valid-profile"}),
+				c:   connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: "01HN1KQCC3Y0Z5J8E7MAV5T0QH-existing-valid-profile"}),
 			},
 			wantErr: false,
 		},
@@ -175,3 +181,248 @@ func TestAdHocProfiles_Upload(t *testing.T) {
 		})
 	}
 }
+
+func newTestAdHocProfiles(t *testing.T, limits validation.MockLimits) (*AdHocProfiles, context.Context, string) {
+	t.Helper()
+	bucket := phlareobjstore.NewBucket(thanosobjstore.NewInMemBucket())
+	rawProfile, err := os.ReadFile("testdata/cpu.pprof")
+	require.NoError(t, err)
+	limits.MaxFlameGraphNodesDefaultValue = 8192
+	a := &AdHocProfiles{
+		logger: util.Logger,
+		limits: limits,
+		bucket: bucket,
+	}
+	return a, tenant.InjectTenantID(context.Background(), "tenant"), base64.StdEncoding.EncodeToString(rawProfile)
+}
+
+func TestAdHocProfiles_Diff(t *testing.T) {
+	a, ctx, encodedProfile := newTestAdHocProfiles(t, validation.MockLimits{})
+	left, err := a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "left.pprof", Profile: encodedProfile}))
+	require.NoError(t, err)
+	right, err := a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "right.pprof", Profile: encodedProfile}))
+	require.NoError(t, err)
+
+	response, err := a.Diff(ctx, connect.NewRequest(&v1.AdHocProfilesDiffRequest{
+		LeftId:  left.Msg.Id,
+		RightId: right.Msg.Id,
+	}))
+	require.NoError(t, err)
+	var diff flamebearer.FlamebearerProfile
+	require.NoError(t, json.Unmarshal([]byte(response.Msg.FlamebearerProfile), &diff))
+	require.Equal(t, "double", diff.Metadata.Format)
+	require.Equal(t, diff.LeftTicks, diff.RightTicks)
+	require.NotZero(t, diff.LeftTicks)
+
+	_, err = a.Diff(ctx, connect.NewRequest(&v1.AdHocProfilesDiffRequest{
+		LeftId:  left.Msg.Id,
+		RightId: "01HN1KQCC3Y0Z5J8E7MAV5T0QH-non-existing-id",
+	}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestAdHocProfiles_DeleteAndRename(t *testing.T) {
+	a, ctx, encodedProfile := newTestAdHocProfiles(t, validation.MockLimits{})
+	uploaded, err := a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "cpu.pprof", Profile: encodedProfile}))
+	require.NoError(t, err)
+
+	_, err = a.Rename(ctx, connect.NewRequest(&v1.AdHocProfilesRenameRequest{Id: uploaded.Msg.Id, Name: "a/b"}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	renamed, err := a.Rename(ctx, connect.NewRequest(&v1.AdHocProfilesRenameRequest{Id: uploaded.Msg.Id, Name: "baseline.pprof"}))
+	require.NoError(t, err)
+	require.Equal(t, "baseline.pprof", renamed.Msg.Profile.Name)
+	require.Equal(t, uploaded.Msg.UploadedAt, renamed.Msg.Profile.UploadedAt)
+	require.NotEqual(t, uploaded.Msg.Id, renamed.Msg.Profile.Id)
+
+	list, err := a.List(ctx, connect.NewRequest(&v1.AdHocProfilesListRequest{}))
+	require.NoError(t, err)
+	require.Equal(t, []*v1.AdHocProfilesProfileMetadata{renamed.Msg.Profile}, list.Msg.Profiles)
+
+	got, err := a.Get(ctx, connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: renamed.Msg.Profile.Id}))
+	require.NoError(t, err)
+	require.Equal(t, "baseline.pprof", got.Msg.Name)
+
+	// The extension the format is detected from is kept.
+	renamed, err = a.Rename(ctx, connect.NewRequest(&v1.AdHocProfilesRenameRequest{Id: renamed.Msg.Profile.Id, Name: "baseline"}))
+	require.NoError(t, err)
+	require.Equal(t, "baseline.pprof", renamed.Msg.Profile.Name)
+	renamed, err = a.Rename(ctx, connect.NewRequest(&v1.AdHocProfilesRenameRequest{Id: renamed.Msg.Profile.Id, Name: "baseline.json"}))
+	require.NoError(t, err)
+	require.Equal(t, "baseline.json.pprof", renamed.Msg.Profile.Name)
+	got, err = a.Get(ctx, connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: renamed.Msg.Profile.Id}))
+	require.NoError(t, err)
+	require.NotEmpty(t, got.Msg.FlamebearerProfile)
+
+	_, err = a.Delete(ctx, connect.NewRequest(&v1.AdHocProfilesDeleteRequest{Id: renamed.Msg.Profile.Id}))
+	require.NoError(t, err)
+	_, err = a.Delete(ctx, connect.NewRequest(&v1.AdHocProfilesDeleteRequest{Id: renamed.Msg.Profile.Id}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	list, err = a.List(ctx, connect.NewRequest(&v1.AdHocProfilesListRequest{}))
+	require.NoError(t, err)
+	require.Empty(t, list.Msg.Profiles)
+}
+
+func TestAdHocProfiles_RejectTraversingIDs(t *testing.T) {
+	a, ctx, encodedProfile := newTestAdHocProfiles(t, validation.MockLimits{})
+	const otherTenantProfile = "other-tenant/adhoc/01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof"
+	require.NoError(t, a.bucket.Upload(ctx, otherTenantProfile, bytes.NewReader([]byte{1})))
+
+	for _, id := range []string{
+		"01HMXV8BF4EH71NBYZNPPVGJ2X-../../other-tenant/adhoc/01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof",
+		"../../other-tenant/adhoc/01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof",
+		`01HMXV8BF4EH71NBYZNPPVGJ2X-..\..\other-tenant`,
+		"01HMXV8BF4EH71NBYZNPPVGJ2X-a/b",
+	} {
+		_, err := a.Delete(ctx, connect.NewRequest(&v1.AdHocProfilesDeleteRequest{Id: id}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), id)
+		_, err = a.Rename(ctx, connect.NewRequest(&v1.AdHocProfilesRenameRequest{Id: id, Name: "renamed.pprof"}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), id)
+		_, err = a.Get(ctx, connect.NewRequest(&v1.AdHocProfilesGetRequest{Id: id}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), id)
+		_, err = a.Import(ctx, connect.NewRequest(&v1.AdHocProfilesImportRequest{Id: id}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), id)
+	}
+	exists, err := a.bucket.Exists(ctx, otherTenantProfile)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	_, err = a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "../../other-tenant/cpu.pprof", Profile: encodedProfile}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestAdHocProfiles_UploadLimits(t *testing.T) {
+	upload := func(a *AdHocProfiles, ctx context.Context, profile string) error {
+		_, err := a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "cpu.pprof", Profile: profile}))
+		return err
+	}
+
+	a, ctx, encodedProfile := newTestAdHocProfiles(t, validation.MockLimits{AdHocProfilesMaxProfilesValue: 2})
+	require.NoError(t, upload(a, ctx, encodedProfile))
+	require.NoError(t, upload(a, ctx, encodedProfile))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(upload(a, ctx, encodedProfile)))
+
+	a, ctx, encodedProfile = newTestAdHocProfiles(t, validation.MockLimits{AdHocProfilesMaxTotalSizeBytesValue: 3 * len(encodedProfile) / 2})
+	require.NoError(t, upload(a, ctx, encodedProfile))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(upload(a, ctx, encodedProfile)))
+}
+
+func TestAdHocProfiles_UploadLimitsUsage(t *testing.T) {
+	a, ctx, encodedProfile := newTestAdHocProfiles(t, validation.MockLimits{AdHocProfilesMaxProfilesValue: 2})
+	bucket := &iterCountingBucket{Bucket: a.bucket}
+	a.bucket = bucket
+	upload := func() (string, error) {
+		res, err := a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "cpu.pprof", Profile: encodedProfile}))
+		if err != nil {
+			return "", err
+		}
+		return res.Msg.Id, nil
+	}
+
+	id, err := upload()
+	require.NoError(t, err)
+	_, err = upload()
+	require.NoError(t, err)
+	_, err = upload()
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	// The usage is only loaded once from the bucket.
+	require.Equal(t, 1, bucket.iters)
+
+	// Deleting a profile frees the quota.
+	_, err = a.Delete(ctx, connect.NewRequest(&v1.AdHocProfilesDeleteRequest{Id: id}))
+	require.NoError(t, err)
+	_, err = upload()
+	require.NoError(t, err)
+	require.Equal(t, 2, bucket.iters)
+}
+
+type iterCountingBucket struct {
+	phlareobjstore.Bucket
+	iters int
+}
+
+func (b *iterCountingBucket) Iter(ctx context.Context, dir string, f func(string) error, options ...thanosobjstore.IterOption) error {
+	b.iters++
+	return b.Bucket.Iter(ctx, dir, f, options...)
+}
+
+func TestAdHocProfiles_DeleteExpired(t *testing.T) {
+	a, ctx, _ := newTestAdHocProfiles(t, validation.MockLimits{AdHocProfilesRetentionPeriodValue: 24 * time.Hour})
+	_ = a.bucket.Upload(ctx, "tenant/adhoc/01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof", bytes.NewReader([]byte{1}))
+	_ = a.bucket.Upload(ctx, "tenant/adhoc/01HMXRV02963FK36GGRE9N6MPH-heap.pprof", bytes.NewReader([]byte{1}))
+	_ = a.bucket.Upload(ctx, "tenant/adhoc/bad-id-should-be-ignored", bytes.NewReader([]byte{1}))
+
+	// 01HMXV8BF4EH71NBYZNPPVGJ2X was uploaded 42 minutes after 01HMXRV02963FK36GGRE9N6MPH.
+	now := time.UnixMilli(1706103680484).Add(24*time.Hour - time.Minute)
+	require.NoError(t, a.deleteExpired(ctx, now))
+
+	list, err := a.List(ctx, connect.NewRequest(&v1.AdHocProfilesListRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Profiles, 1)
+	require.Equal(t, "01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof", list.Msg.Profiles[0].Id)
+	exists, err := a.bucket.Exists(ctx, "tenant/adhoc/bad-id-should-be-ignored")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+type mockPusher struct {
+	requests []*distributormodel.PushRequest
+}
+
+func (m *mockPusher) PushParsed(_ context.Context, req *distributormodel.PushRequest) (*connect.Response[pushv1.PushResponse], error) {
+	m.requests = append(m.requests, req)
+	return connect.NewResponse(&pushv1.PushResponse{}), nil
+}
+
+func TestAdHocProfiles_Import(t *testing.T) {
+	a, ctx, encodedProfile := newTestAdHocProfiles(t, validation.MockLimits{})
+	labels := []*typesv1.LabelPair{
+		{Name: "__name__", Value: "process_cpu"},
+		{Name: "service_name", Value: "adhoc"},
+	}
+
+	_, err := a.Import(ctx, connect.NewRequest(&v1.AdHocProfilesImportRequest{Id: "01HN1KQCC3Y0Z5J8E7MAV5T0QH-non-existing-id", Labels: labels}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+
+	pusher := &mockPusher{}
+	a.pusher = pusher
+	uploaded, err := a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "cpu.pprof", Profile: encodedProfile}))
+	require.NoError(t, err)
+
+	_, err = a.Import(ctx, connect.NewRequest(&v1.AdHocProfilesImportRequest{Id: uploaded.Msg.Id, Labels: labels[1:]}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = a.Import(ctx, connect.NewRequest(&v1.AdHocProfilesImportRequest{Id: "01HN1KQCC3Y0Z5J8E7MAV5T0QH-non-existing-id", Labels: labels}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	timestamp := time.UnixMilli(1706103680484)
+	_, err = a.Import(ctx, connect.NewRequest(&v1.AdHocProfilesImportRequest{
+		Id:        uploaded.Msg.Id,
+		Timestamp: timestamp.UnixMilli(),
+		Labels:    labels,
+	}))
+	require.NoError(t, err)
+	require.Len(t, pusher.requests, 1)
+	series := pusher.requests[0].Series
+	require.Len(t, series, 1)
+	require.Equal(t, "process_cpu", phlaremodel.Labels(series[0].Labels).Get("__name__"))
+	require.Len(t, series[0].Samples, 1)
+	require.Equal(t, timestamp.UnixNano(), series[0].Samples[0].Profile.TimeNanos)
+	require.NotEmpty(t, series[0].Samples[0].Profile.Sample)
+
+	collapsed := base64.StdEncoding.EncodeToString([]byte("main;foo 2\nmain;bar 3\n"))
+	uploaded, err = a.Upload(ctx, connect.NewRequest(&v1.AdHocProfilesUploadRequest{Name: "profile.collapsed", Profile: collapsed}))
+	require.NoError(t, err)
+	_, err = a.Import(ctx, connect.NewRequest(&v1.AdHocProfilesImportRequest{Id: uploaded.Msg.Id, Labels: labels}))
+	require.NoError(t, err)
+	require.Len(t, pusher.requests, 2)
+	sample := pusher.requests[1].Series[0].Samples[0]
+	require.Equal(t, uploaded.Msg.UploadedAt, time.Unix(0, sample.Profile.TimeNanos).UnixMilli())
+	require.Len(t, sample.Profile.SampleType, 1)
+	require.Equal(t, "samples", sample.Profile.StringTable[sample.Profile.SampleType[0].Type])
+	var total int64
+	for _, s := range sample.Profile.Sample {
+		total += s.Value[0]
+	}
+	require.Equal(t, int64(5), total)
+}
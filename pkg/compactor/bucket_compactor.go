@@ -28,6 +28,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/prometheus/model/labels"
 	"go.uber.org/atomic"
+	"golang.org/x/exp/slices"
 
 	"github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/objstore/client"
@@ -234,9 +235,10 @@ type Compactor interface {
 const (
 	CompactionSplitByFingerprint         = "fingerprint"
 	CompactionSplitByStacktracePartition = "stacktracePartition"
+	CompactionSplitByLabel               = "label"
 )
 
-var CompactionSplitBys = []string{CompactionSplitByFingerprint, CompactionSplitByStacktracePartition}
+var CompactionSplitBys = []string{CompactionSplitByFingerprint, CompactionSplitByStacktracePartition, CompactionSplitByLabel}
 
 func getCompactionSplitBy(name string) phlaredb.SplitByFunc {
 	switch name {
@@ -253,6 +255,7 @@ type BlockCompactor struct {
 	blockOpenConcurrency int
 	downsamplerEnabled   bool
 	splitBy              phlaredb.SplitByFunc
+	splitByLabel         string
 	logger               log.Logger
 	metrics              *CompactorMetrics
 }
@@ -407,6 +410,7 @@ func (c *BlockCompactor) CompactWithSplitting(ctx context.Context, dest string,
 		SplitBy:            c.splitBy,
 		DownsamplerEnabled: c.downsamplerEnabled,
 		Logger:             c.logger,
+		SplitByLabel:       c.splitByLabel,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "compact blocks %v", dirs)
@@ -469,6 +473,7 @@ func (c *BucketCompactor) runCompactionJob(ctx context.Context, job *Job) (shoul
 	jobLogger = log.With(jobLogger, "minTime", toCompactMinTime.String(), "maxTime", toCompactMaxTime.String())
 
 	level.Info(jobLogger).Log("msg", "compaction available and planned; downloading blocks", "blocks", len(toCompact), "plan", fmt.Sprintf("%v", toCompact))
+	c.status.jobPhase(job, fmt.Sprintf("downloading %d blocks", len(toCompact)))
 
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "CompactJob",
 		opentracing.Tag{Key: "GroupKey", Value: job.Key()},
@@ -519,6 +524,7 @@ func (c *BucketCompactor) runCompactionJob(ctx context.Context, job *Job) (shoul
 		return false, nil, err
 	}
 
+	c.status.jobPhase(job, "compacting")
 	err = func() error {
 		sp, ctx := opentracing.StartSpanFromContext(ctx, "CompactBlocks")
 		compactionBegin := time.Now()
@@ -556,6 +562,7 @@ func (c *BucketCompactor) runCompactionJob(ctx context.Context, job *Job) (shoul
 	ctx = opentracing.ContextWithSpan(ctx, sp)
 	defer cancel()
 
+	c.status.jobPhase(job, fmt.Sprintf("uploading %d blocks", len(compIDs)))
 	err = func() error {
 		sp, ctx := opentracing.StartSpanFromContext(ctx, "Uploading blocks", opentracing.Tag{Key: "count", Value: len(compIDs)})
 		uploadBegin := time.Now()
@@ -598,6 +605,7 @@ func (c *BucketCompactor) runCompactionJob(ctx context.Context, job *Job) (shoul
 		return false, nil, err
 	}
 
+	c.status.jobPhase(job, "marking source blocks for deletion")
 	sp, ctx = opentracing.StartSpanFromContext(ctx, "Deleting blocks", opentracing.Tag{Key: "count", Value: len(compIDs)})
 	defer sp.Finish()
 	// Mark for deletion the blocks we just compacted from the job and bucket so they do not get included
@@ -739,6 +747,9 @@ type BucketCompactor struct {
 	waitPeriod           time.Duration
 	blockSyncConcurrency int
 	metrics              *BucketCompactorMetrics
+
+	// status records the planned and running jobs for the status page, if set.
+	status *tenantStatusRecorder
 }
 
 // NewBucketCompactor creates a new bucket compactor.
@@ -834,8 +845,10 @@ func (c *BucketCompactor) Compact(ctx context.Context, maxCompactionTime time.Du
 					}
 
 					c.metrics.groupCompactionRunsStarted.Inc()
+					c.status.jobStarted(g)
 
 					shouldRerunJob, compactedBlockIDs, err := c.runCompactionJob(workCtx, g)
+					c.status.jobFinished(g, err)
 					if err == nil {
 						c.metrics.groupCompactionRunsCompleted.Inc()
 						if hasNonZeroULIDs(compactedBlockIDs) {
@@ -879,6 +892,7 @@ func (c *BucketCompactor) Compact(ctx context.Context, maxCompactionTime time.Du
 			return errors.Wrap(err, "build compaction jobs")
 		}
 		sp.LogKV("discovered_jobs", len(jobs))
+		plannedJobs := slices.Clone(jobs)
 
 		// There is another check just before we start processing the job, but we can avoid sending it
 		// to the goroutine in the first place.
@@ -887,6 +901,7 @@ func (c *BucketCompactor) Compact(ctx context.Context, maxCompactionTime time.Du
 			return err
 		}
 		sp.LogKV("own_jobs", len(jobs))
+		ownJobs := slices.Clone(jobs)
 
 		// Record the difference between now and the max time for a block being compacted. This
 		// is used to detect compactors not being able to keep up with the rate of blocks being
@@ -899,6 +914,7 @@ func (c *BucketCompactor) Compact(ctx context.Context, maxCompactionTime time.Du
 		// Skip jobs for which the wait period hasn't been honored yet.
 		jobs = c.filterJobsByWaitPeriod(ctx, jobs)
 		sp.LogKV("filtered_jobs", len(jobs))
+		c.status.planned(plannedJobs, ownJobs, jobs)
 
 		// Sort jobs based on the configured ordering algorithm.
 		jobs = c.sortJobs(jobs)
@@ -30,6 +30,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/atomic"
 
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/phlaredb/block"
 	"github.com/grafana/pyroscope/pkg/phlaredb/bucket"
@@ -56,6 +57,7 @@ var (
 	errInvalidBlockDuration               = "compactor block range periods should be divisible by the max block duration, but %s is not divisible by %s"
 	errInvalidCompactionOrder             = fmt.Errorf("unsupported compaction order (supported values: %s)", strings.Join(CompactionOrders, ", "))
 	errInvalidCompactionSplitBy           = fmt.Errorf("unsupported compaction split by (supported values: %s)", strings.Join(CompactionSplitBys, ", "))
+	errInvalidCompactionSplitByLabel      = fmt.Errorf("the compaction split by label must be set when splitting blocks by %s", CompactionSplitByLabel)
 	errInvalidMaxOpeningBlocksConcurrency = fmt.Errorf("invalid max-opening-blocks-concurrency value, must be positive")
 	RingOp                                = ring.NewOp([]ring.InstanceState{ring.ACTIVE}, nil)
 )
@@ -113,8 +115,9 @@ type Config struct {
 	// Compactors sharding.
 	ShardingRing RingConfig `yaml:"sharding_ring"`
 
-	CompactionJobsOrder string `yaml:"compaction_jobs_order" category:"advanced"`
-	CompactionSplitBy   string `yaml:"compaction_split_by" category:"advanced"`
+	CompactionJobsOrder    string `yaml:"compaction_jobs_order" category:"advanced"`
+	CompactionSplitBy      string `yaml:"compaction_split_by" category:"advanced"`
+	CompactionSplitByLabel string `yaml:"compaction_split_by_label" category:"advanced"`
 
 	// No need to add options to customize the retry backoff,
 	// given the defaults should be fine, but allow to override
@@ -149,6 +152,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	f.IntVar(&cfg.CleanupConcurrency, "compactor.cleanup-concurrency", 20, "Max number of tenants for which blocks cleanup and maintenance should run concurrently.")
 	f.StringVar(&cfg.CompactionJobsOrder, "compactor.compaction-jobs-order", CompactionOrderOldestFirst, fmt.Sprintf("The sorting to use when deciding which compaction jobs should run first for a given tenant. Supported values are: %s.", strings.Join(CompactionOrders, ", ")))
 	f.StringVar(&cfg.CompactionSplitBy, "compactor.compaction-split-by", CompactionSplitByFingerprint, fmt.Sprintf("Experimental: The strategy to use when splitting blocks during compaction. Supported values are: %s.", strings.Join(CompactionSplitBys, ", ")))
+	f.StringVar(&cfg.CompactionSplitByLabel, "compactor.compaction-split-by-label", phlaremodel.LabelNameServiceName, fmt.Sprintf("Experimental: The label to split blocks by, when the split strategy is %q, e.g. service_name or __profile_type__. The series are split by the hash of the label value, and the label and the shard count are recorded in the meta.json of each block, which allows store-gateways and queriers to skip the shards that can't hold the label value the query selects.", CompactionSplitByLabel))
 	f.DurationVar(&cfg.DeletionDelay, "compactor.deletion-delay", 12*time.Hour, "Time before a block marked for deletion is deleted from bucket. "+
 		"If not 0, blocks will be marked for deletion and compactor component will permanently delete blocks marked for deletion from the bucket. "+
 		"If 0, blocks will be deleted straight away. Note that deleting blocks immediately can cause query failures.")
@@ -185,6 +189,10 @@ func (cfg *Config) Validate(maxBlockDuration time.Duration) error {
 		return errInvalidCompactionSplitBy
 	}
 
+	if cfg.CompactionSplitBy == CompactionSplitByLabel && cfg.CompactionSplitByLabel == "" {
+		return errInvalidCompactionSplitByLabel
+	}
+
 	return nil
 }
 
@@ -278,6 +286,9 @@ type MultitenantCompactor struct {
 
 	// Compactor metrics
 	compactorMetrics *CompactorMetrics
+
+	// Jobs planned and run for the owned tenants, shown on the status page.
+	compactionStatus *compactionStatus
 }
 
 // NewMultitenantCompactor makes a new MultitenantCompactor.
@@ -380,6 +391,7 @@ func newMultitenantCompactor(
 			Help: "Total number of files from successfully uploaded and validated blocks using block upload API.",
 		}, []string{"user"}),
 		compactorMetrics: newCompactorMetrics(registerer),
+		compactionStatus: newCompactionStatus(),
 	}
 
 	promauto.With(registerer).NewGaugeFunc(prometheus.GaugeOpts{
@@ -634,7 +646,10 @@ func (c *MultitenantCompactor) compactUsers(ctx context.Context) {
 
 		level.Info(c.logger).Log("msg", "starting compaction of user blocks", "tenant", userID)
 
-		if err = c.compactUserWithRetries(ctx, userID); err != nil {
+		c.compactionStatus.compactionStarted(userID)
+		err = c.compactUserWithRetries(ctx, userID)
+		c.compactionStatus.compactionFinished(userID, err)
+		if err != nil {
 			switch {
 			case errors.Is(err, context.Canceled):
 				// We don't want to count shutdowns as failed compactions because we will pick up with the rest of the compaction after the restart.
@@ -652,6 +667,8 @@ func (c *MultitenantCompactor) compactUsers(ctx context.Context) {
 		level.Info(c.logger).Log("msg", "successfully compacted user blocks", "tenant", userID)
 	}
 
+	c.compactionStatus.retainTenants(ownedUsers)
+
 	// Delete local files for unowned tenants, if there are any. This cleans up
 	// leftover local files for tenants that belong to different compactors now,
 	// or have been deleted completely.
@@ -773,6 +790,8 @@ func (c *MultitenantCompactor) compactUser(ctx context.Context, userID string) e
 		return errors.Wrap(err, "failed to create bucket compactor")
 	}
 
+	compactor.status = c.compactionStatus.tenant(userID, c.shardingStrategy.jobOwner)
+
 	if err := compactor.Compact(ctx, c.compactorCfg.MaxCompactionTime); err != nil {
 		return errors.Wrap(err, "compaction")
 	}
@@ -816,6 +835,8 @@ type shardingStrategy interface {
 	// blocksCleanerOwnUser must be concurrency-safe
 	blocksCleanerOwnUser(userID string) (bool, error)
 	ownJob(job *Job) (bool, error)
+	// jobOwner returns the address of the instance owning the job.
+	jobOwner(job *Job) (string, error)
 }
 
 // splitAndMergeShardingStrategy is used by split-and-merge compactor when configured with sharding.
@@ -872,23 +893,39 @@ func (s *splitAndMergeShardingStrategy) ownJob(job *Job) (bool, error) {
 	return instanceOwnsTokenInRing(r, s.ringLifecycler.GetInstanceAddr(), job.ShardingKey())
 }
 
+func (s *splitAndMergeShardingStrategy) jobOwner(job *Job) (string, error) {
+	r := s.ring.ShuffleShard(job.UserID(), s.configProvider.CompactorTenantShardSize(job.UserID()))
+
+	return tokenOwnerInRing(r, job.ShardingKey())
+}
+
 func instanceOwnsTokenInRing(r ring.ReadRing, instanceAddr string, key string) (bool, error) {
+	// Check whether this compactor instance owns the token.
+	owner, err := tokenOwnerInRing(r, key)
+	if err != nil {
+		return false, err
+	}
+
+	return owner == instanceAddr, nil
+}
+
+// tokenOwnerInRing returns the address of the instance owning the token of the key.
+func tokenOwnerInRing(r ring.ReadRing, key string) (string, error) {
 	// Hash the key.
 	hasher := fnv.New32a()
 	_, _ = hasher.Write([]byte(key))
 	hash := hasher.Sum32()
 
-	// Check whether this compactor instance owns the token.
 	rs, err := r.Get(hash, RingOp, nil, nil, nil)
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
 	if len(rs.Instances) != 1 {
-		return false, fmt.Errorf("unexpected number of compactors in the shard (expected 1, got %d)", len(rs.Instances))
+		return "", fmt.Errorf("unexpected number of compactors in the shard (expected 1, got %d)", len(rs.Instances))
 	}
 
-	return rs.Instances[0].Addr == instanceAddr, nil
+	return rs.Instances[0].Addr, nil
 }
 
 const compactorMetaPrefix = "compactor-meta-"
@@ -7,10 +7,13 @@ package compactor
 
 import (
 	_ "embed" // Used to embed html template
+	"fmt"
 	"html/template"
 	"net/http"
+	"time"
 
 	"github.com/go-kit/log/level"
+	"github.com/gorilla/mux"
 	"github.com/grafana/dskit/services"
 
 	util_log "github.com/grafana/pyroscope/pkg/util"
@@ -20,12 +23,41 @@ var (
 	//go:embed status.gohtml
 	statusPageHTML     string
 	statusPageTemplate = template.Must(template.New("main").Parse(statusPageHTML))
+
+	//go:embed tenants.gohtml
+	tenantsPageHTML     string
+	tenantsPageTemplate = template.Must(template.New("webpage").Parse(tenantsPageHTML))
+
+	//go:embed jobs.gohtml
+	jobsPageHTML     string
+	jobsPageTemplate = template.Must(template.New("webpage").Parse(jobsPageHTML))
 )
 
 type statusPageContents struct {
 	Message string
 }
 
+type tenantsPageContents struct {
+	Now     time.Time       `json:"now"`
+	Tenants []tenantSummary `json:"tenants"`
+}
+
+// tenantSummary is the compaction status of a tenant without its jobs.
+type tenantSummary struct {
+	tenantStatus
+	PlannedJobs   int `json:"plannedJobs"`
+	OwnedJobs     int `json:"ownedJobs"`
+	WaitingJobs   int `json:"waitingJobs"`
+	RunningJobs   int `json:"runningJobs"`
+	SucceededJobs int `json:"succeededJobs"`
+	FailedJobs    int `json:"failedJobs"`
+}
+
+type jobsPageContents struct {
+	Now    time.Time    `json:"now"`
+	Status tenantStatus `json:"status"`
+}
+
 func writeMessage(w http.ResponseWriter, message string) {
 	w.WriteHeader(http.StatusOK)
 	err := statusPageTemplate.Execute(w, statusPageContents{Message: message})
@@ -44,3 +76,56 @@ func (c *MultitenantCompactor) RingHandler(w http.ResponseWriter, req *http.Requ
 
 	c.ring.ServeHTTP(w, req)
 }
+
+// TenantsHandler shows the compaction status of the tenants owned by the compactor.
+func (c *MultitenantCompactor) TenantsHandler(w http.ResponseWriter, req *http.Request) {
+	tenants := c.compactionStatus.list()
+	summaries := make([]tenantSummary, 0, len(tenants))
+	for _, t := range tenants {
+		s := tenantSummary{PlannedJobs: len(t.Jobs)}
+		for _, j := range t.Jobs {
+			if j.Owned {
+				s.OwnedJobs++
+			}
+			switch j.State {
+			case jobStateWaiting:
+				s.WaitingJobs++
+			case jobStateRunning:
+				s.RunningJobs++
+			case jobStateSucceeded:
+				s.SucceededJobs++
+			case jobStateFailed:
+				s.FailedJobs++
+			}
+		}
+		t.Jobs = nil
+		s.tenantStatus = t
+		summaries = append(summaries, s)
+	}
+
+	util_log.RenderHTTPResponse(w, tenantsPageContents{
+		Now:     time.Now(),
+		Tenants: summaries,
+	}, tenantsPageTemplate, req)
+}
+
+// JobsHandler shows the compaction jobs planned for a tenant, and the
+// progress of the ones run by the compactor.
+func (c *MultitenantCompactor) JobsHandler(w http.ResponseWriter, req *http.Request) {
+	tenantID := mux.Vars(req)["tenant"]
+	if tenantID == "" {
+		util_log.WriteTextResponse(w, "Tenant ID can't be empty")
+		return
+	}
+
+	status, ok := c.compactionStatus.get(tenantID)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Tenant %s is not compacted by this compactor", tenantID), http.StatusNotFound)
+		return
+	}
+
+	util_log.RenderHTTPResponse(w, jobsPageContents{
+		Now:    time.Now(),
+		Status: status,
+	}, jobsPageTemplate, req)
+}
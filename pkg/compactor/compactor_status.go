@@ -0,0 +1,312 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package compactor
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grafana/pyroscope/pkg/util"
+)
+
+type jobState string
+
+const (
+	// jobStatePlanned is the state of jobs which haven't been started yet, or
+	// are owned by another compactor.
+	jobStatePlanned jobState = "planned"
+	// jobStateWaiting is the state of jobs skipped because their blocks were
+	// uploaded within the compaction wait period.
+	jobStateWaiting   jobState = "waiting"
+	jobStateRunning   jobState = "running"
+	jobStateSucceeded jobState = "succeeded"
+	jobStateFailed    jobState = "failed"
+)
+
+// jobStatus is the status of a compaction job, as shown on the status page.
+type jobStatus struct {
+	Key     string    `json:"key"`
+	Stage   string    `json:"stage"`
+	Shard   string    `json:"shard,omitempty"`
+	MinTime time.Time `json:"minTime"`
+	MaxTime time.Time `json:"maxTime"`
+	Blocks  []string  `json:"blocks"`
+
+	// Owner is the address of the compactor instance owning the job.
+	Owner string `json:"owner,omitempty"`
+	// Owned is true if the job is owned by this compactor instance.
+	Owned bool `json:"owned"`
+
+	State jobState `json:"state"`
+	// Phase is the step of the compaction a running job is in.
+	Phase      string     `json:"phase,omitempty"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Error is the error of the last run of the job, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// tenantStatus is the compaction status of a tenant, as shown on the status
+// page.
+type tenantStatus struct {
+	Tenant        string     `json:"tenant"`
+	Running       bool       `json:"running"`
+	PlannedAt     *time.Time `json:"plannedAt,omitempty"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	LastErrorAt   *time.Time `json:"lastErrorAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+
+	// CompactionLag is the time elapsed since the end of the oldest block
+	// of the planned jobs, which is how far behind the compaction is.
+	CompactionLag string `json:"compactionLag,omitempty"`
+
+	Jobs []*jobStatus `json:"jobs,omitempty"`
+
+	oldestPlannedBlock time.Time
+}
+
+// jobOwnerFunc returns the address of the compactor instance owning a job.
+type jobOwnerFunc func(job *Job) (string, error)
+
+// compactionStatus keeps track of the jobs planned and run for the tenants
+// owned by the compactor. It is safe for concurrent use.
+type compactionStatus struct {
+	mtx     sync.Mutex
+	tenants map[string]*tenantStatus
+}
+
+func newCompactionStatus() *compactionStatus {
+	return &compactionStatus{
+		tenants: make(map[string]*tenantStatus),
+	}
+}
+
+// tenant returns the recorder of the compaction status of a tenant.
+func (s *compactionStatus) tenant(userID string, jobOwner jobOwnerFunc) *tenantStatusRecorder {
+	return &tenantStatusRecorder{
+		status:   s,
+		userID:   userID,
+		jobOwner: jobOwner,
+	}
+}
+
+// update calls f with the status of the tenant, which is created if needed.
+func (s *compactionStatus) update(userID string, f func(t *tenantStatus)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	t, ok := s.tenants[userID]
+	if !ok {
+		t = &tenantStatus{Tenant: userID}
+		s.tenants[userID] = t
+	}
+	f(t)
+}
+
+// compactionStarted records the start of the compaction of a tenant.
+func (s *compactionStatus) compactionStarted(userID string) {
+	s.update(userID, func(t *tenantStatus) {
+		t.Running = true
+	})
+}
+
+// compactionFinished records the end of the compaction of a tenant.
+func (s *compactionStatus) compactionFinished(userID string, err error) {
+	now := time.Now()
+	s.update(userID, func(t *tenantStatus) {
+		t.Running = false
+		if err != nil {
+			t.LastErrorAt = &now
+			t.LastError = err.Error()
+		} else {
+			t.LastSuccessAt = &now
+		}
+	})
+}
+
+// retainTenants forgets the status of the tenants not owned anymore.
+func (s *compactionStatus) retainTenants(owned map[string]struct{}) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for userID := range s.tenants {
+		if _, ok := owned[userID]; !ok {
+			delete(s.tenants, userID)
+		}
+	}
+}
+
+// get returns a copy of the status of a tenant.
+func (s *compactionStatus) get(userID string) (tenantStatus, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	t, ok := s.tenants[userID]
+	if !ok {
+		return tenantStatus{}, false
+	}
+	return t.snapshot(time.Now()), true
+}
+
+// list returns a copy of the status of all tenants, sorted by tenant ID.
+func (s *compactionStatus) list() []tenantStatus {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := time.Now()
+	tenants := make([]tenantStatus, 0, len(s.tenants))
+	for _, t := range s.tenants {
+		tenants = append(tenants, t.snapshot(now))
+	}
+	sort.Slice(tenants, func(i, j int) bool {
+		return tenants[i].Tenant < tenants[j].Tenant
+	})
+	return tenants
+}
+
+func (t *tenantStatus) snapshot(now time.Time) tenantStatus {
+	c := *t
+	c.Jobs = make([]*jobStatus, 0, len(t.Jobs))
+	for _, j := range t.Jobs {
+		jc := *j
+		c.Jobs = append(c.Jobs, &jc)
+	}
+	if !t.oldestPlannedBlock.IsZero() {
+		c.CompactionLag = now.Sub(t.oldestPlannedBlock).Round(time.Second).String()
+	}
+	return c
+}
+
+// tenantStatusRecorder records the compaction status of a single tenant.
+// A nil recorder records nothing.
+type tenantStatusRecorder struct {
+	status   *compactionStatus
+	userID   string
+	jobOwner jobOwnerFunc
+}
+
+// planned records the jobs planned for the tenant: all the jobs found by
+// the grouper, the ones owned by this compactor and, among them, the ones
+// scheduled for compaction.
+func (r *tenantStatusRecorder) planned(planned, owned, scheduled []*Job) {
+	if r == nil {
+		return
+	}
+	now := time.Now()
+
+	ownedKeys := make(map[string]struct{}, len(owned))
+	for _, j := range owned {
+		ownedKeys[j.Key()] = struct{}{}
+	}
+	scheduledKeys := make(map[string]struct{}, len(scheduled))
+	for _, j := range scheduled {
+		scheduledKeys[j.Key()] = struct{}{}
+	}
+
+	jobs := make([]*jobStatus, 0, len(planned))
+	var oldestBlock time.Time
+	for _, j := range planned {
+		js := &jobStatus{
+			Key:     j.Key(),
+			Stage:   string(stageMerge),
+			Shard:   j.ShardID(),
+			MinTime: util.TimeFromMillis(j.MinTime()).UTC(),
+			MaxTime: util.TimeFromMillis(j.MaxTime()).UTC(),
+			State:   jobStatePlanned,
+		}
+		if j.UseSplitting() {
+			js.Stage = string(stageSplit)
+		}
+		for _, id := range j.IDs() {
+			js.Blocks = append(js.Blocks, id.String())
+		}
+		if r.jobOwner != nil {
+			if owner, err := r.jobOwner(j); err == nil {
+				js.Owner = owner
+			}
+		}
+		if _, ok := ownedKeys[j.Key()]; ok {
+			js.Owned = true
+			if _, ok := scheduledKeys[j.Key()]; !ok {
+				js.State = jobStateWaiting
+			}
+		}
+		for _, m := range j.Metas() {
+			if maxTime := util.TimeFromMillis(int64(m.MaxTime)); oldestBlock.IsZero() || maxTime.Before(oldestBlock) {
+				oldestBlock = maxTime
+			}
+		}
+		jobs = append(jobs, js)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].MinTime.Equal(jobs[j].MinTime) {
+			return jobs[i].MinTime.Before(jobs[j].MinTime)
+		}
+		return jobs[i].Key < jobs[j].Key
+	})
+
+	r.status.update(r.userID, func(t *tenantStatus) {
+		// Keep the error of the last run of the jobs planned again.
+		errs := make(map[string]string)
+		for _, j := range t.Jobs {
+			if j.Error != "" {
+				errs[j.Key] = j.Error
+			}
+		}
+		for _, j := range jobs {
+			j.Error = errs[j.Key]
+		}
+		t.PlannedAt = &now
+		t.Jobs = jobs
+		t.oldestPlannedBlock = oldestBlock
+	})
+}
+
+func (r *tenantStatusRecorder) updateJob(key string, f func(j *jobStatus)) {
+	if r == nil {
+		return
+	}
+	r.status.update(r.userID, func(t *tenantStatus) {
+		for _, j := range t.Jobs {
+			if j.Key == key {
+				f(j)
+				return
+			}
+		}
+	})
+}
+
+// jobStarted records the start of a job.
+func (r *tenantStatusRecorder) jobStarted(job *Job) {
+	now := time.Now()
+	r.updateJob(job.Key(), func(j *jobStatus) {
+		j.State = jobStateRunning
+		j.Phase = ""
+		j.StartedAt = &now
+		j.FinishedAt = nil
+	})
+}
+
+// jobPhase records the step of the compaction a running job is in.
+func (r *tenantStatusRecorder) jobPhase(job *Job, phase string) {
+	r.updateJob(job.Key(), func(j *jobStatus) {
+		j.Phase = phase
+	})
+}
+
+// jobFinished records the end of a job.
+func (r *tenantStatusRecorder) jobFinished(job *Job, err error) {
+	now := time.Now()
+	r.updateJob(job.Key(), func(j *jobStatus) {
+		j.Phase = ""
+		j.FinishedAt = &now
+		if err != nil {
+			j.State = jobStateFailed
+			j.Error = err.Error()
+		} else {
+			j.State = jobStateSucceeded
+			j.Error = ""
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package compactor
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/oklog/ulid"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/phlaredb/block"
+)
+
+func newStatusTestJob(t *testing.T, key string, splitting bool, blocks ...uint64) *Job {
+	job := NewJob("user", key, labels.EmptyLabels(), 0, splitting, 2, 0, key)
+	job.shardID = "1_of_2"
+	for _, id := range blocks {
+		require.NoError(t, job.AppendMeta(&block.Meta{
+			ULID:    ulid.MustNew(id, nil),
+			MinTime: 0,
+			MaxTime: 1000,
+		}))
+	}
+	return job
+}
+
+func TestCompactionStatus(t *testing.T) {
+	s := newCompactionStatus()
+	r := s.tenant("user", func(job *Job) (string, error) {
+		if job.Key() == "other" {
+			return "compactor-2:9095", nil
+		}
+		return "compactor-1:9095", nil
+	})
+
+	split := newStatusTestJob(t, "split", true, 1, 2)
+	merge := newStatusTestJob(t, "merge", false, 3)
+	waiting := newStatusTestJob(t, "waiting", false, 4)
+	other := newStatusTestJob(t, "other", false, 5)
+
+	s.compactionStarted("user")
+	r.planned([]*Job{split, merge, waiting, other}, []*Job{split, merge, waiting}, []*Job{split, merge})
+	r.jobStarted(split)
+	r.jobPhase(split, "compacting")
+	r.jobStarted(merge)
+	r.jobFinished(merge, errors.New("upload failed"))
+
+	status, ok := s.get("user")
+	require.True(t, ok)
+	assert.True(t, status.Running)
+	assert.NotNil(t, status.PlannedAt)
+	assert.NotEmpty(t, status.CompactionLag)
+
+	jobs := map[string]*jobStatus{}
+	for _, j := range status.Jobs {
+		jobs[j.Key] = j
+	}
+	require.Len(t, jobs, 4)
+
+	assert.Equal(t, "split", jobs["split"].Stage)
+	assert.Equal(t, "1_of_2", jobs["split"].Shard)
+	assert.Equal(t, []string{ulid.MustNew(1, nil).String(), ulid.MustNew(2, nil).String()}, jobs["split"].Blocks)
+	assert.Equal(t, jobStateRunning, jobs["split"].State)
+	assert.Equal(t, "compacting", jobs["split"].Phase)
+	assert.NotNil(t, jobs["split"].StartedAt)
+
+	assert.Equal(t, "merge", jobs["merge"].Stage)
+	assert.Equal(t, jobStateFailed, jobs["merge"].State)
+	assert.Equal(t, "upload failed", jobs["merge"].Error)
+
+	assert.Equal(t, jobStateWaiting, jobs["waiting"].State)
+	assert.True(t, jobs["waiting"].Owned)
+
+	assert.Equal(t, jobStatePlanned, jobs["other"].State)
+	assert.False(t, jobs["other"].Owned)
+	assert.Equal(t, "compactor-2:9095", jobs["other"].Owner)
+
+	// The error of a failed job is kept when the job is planned again.
+	r.planned([]*Job{merge}, []*Job{merge}, []*Job{merge})
+	status, _ = s.get("user")
+	require.Len(t, status.Jobs, 1)
+	assert.Equal(t, jobStatePlanned, status.Jobs[0].State)
+	assert.Equal(t, "upload failed", status.Jobs[0].Error)
+
+	s.compactionFinished("user", errors.New("compaction: group merge: upload failed"))
+	status, _ = s.get("user")
+	assert.False(t, status.Running)
+	assert.Equal(t, "compaction: group merge: upload failed", status.LastError)
+	assert.NotNil(t, status.LastErrorAt)
+	assert.Nil(t, status.LastSuccessAt)
+
+	// Snapshots are not modified by later updates.
+	r.jobStarted(merge)
+	assert.Equal(t, jobStatePlanned, status.Jobs[0].State)
+
+	s.retainTenants(map[string]struct{}{})
+	_, ok = s.get("user")
+	assert.False(t, ok)
+
+	// A nil recorder records nothing.
+	var nilRecorder *tenantStatusRecorder
+	nilRecorder.planned([]*Job{merge}, nil, nil)
+	nilRecorder.jobStarted(merge)
+	nilRecorder.jobFinished(merge, nil)
+}
+
+func TestCompactionStatusHandlers(t *testing.T) {
+	c := &MultitenantCompactor{compactionStatus: newCompactionStatus()}
+	r := c.compactionStatus.tenant("user", func(*Job) (string, error) { return "compactor-1:9095", nil })
+	job := newStatusTestJob(t, "merge", false, 1)
+	c.compactionStatus.compactionStarted("user")
+	r.planned([]*Job{job}, []*Job{job}, []*Job{job})
+	r.jobStarted(job)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/compactor/tenants", c.TenantsHandler)
+	router.HandleFunc("/compactor/tenant/{tenant}/jobs", c.JobsHandler)
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/compactor/tenants", "application/json")
+	require.Equal(t, http.StatusOK, w.Code)
+	var tenants tenantsPageContents
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tenants))
+	require.Len(t, tenants.Tenants, 1)
+	assert.Equal(t, "user", tenants.Tenants[0].Tenant)
+	assert.Equal(t, 1, tenants.Tenants[0].PlannedJobs)
+	assert.Equal(t, 1, tenants.Tenants[0].RunningJobs)
+	assert.Empty(t, tenants.Tenants[0].Jobs)
+
+	w = get("/compactor/tenants", "text/html")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<a href="tenant/user/jobs">user</a>`)
+
+	w = get("/compactor/tenant/user/jobs", "application/json")
+	require.Equal(t, http.StatusOK, w.Code)
+	var jobs jobsPageContents
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jobs))
+	require.Len(t, jobs.Status.Jobs, 1)
+	assert.Equal(t, jobStateRunning, jobs.Status.Jobs[0].State)
+	assert.Equal(t, "compactor-1:9095", jobs.Status.Jobs[0].Owner)
+
+	w = get("/compactor/tenant/user/jobs", "text/html")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "compactor-1:9095 (this compactor)")
+
+	w = get("/compactor/tenant/unknown/jobs", "text/html")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -122,6 +122,14 @@ func TestConfig_Validate(t *testing.T) {
 			expected: errInvalidCompactionOrder.Error(),
 			maxBlock: 1 * time.Hour,
 		},
+		"should fail on splitting by an empty label": {
+			setup: func(cfg *Config) {
+				cfg.CompactionSplitBy = CompactionSplitByLabel
+				cfg.CompactionSplitByLabel = ""
+			},
+			expected: errInvalidCompactionSplitByLabel.Error(),
+			maxBlock: 1 * time.Hour,
+		},
 		"should fail on invalid value of max-opening-blocks-concurrency": {
 			setup:    func(cfg *Config) { cfg.MaxOpeningBlocksConcurrency = 0 },
 			expected: errInvalidMaxOpeningBlocksConcurrency.Error(),
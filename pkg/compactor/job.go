@@ -31,6 +31,9 @@ type Job struct {
 	useSplitting   bool
 	shardingKey    string
 
+	// The shard the blocks in this job belong to, as planned by the split-and-merge grouper.
+	shardID string
+
 	// The number of shards to split compacted block into. Not used if splitting is disabled.
 	splitNumShards uint32
 	splitStageSize uint32
@@ -152,6 +155,12 @@ func (job *Job) SplitStageSize() uint32 {
 	return job.splitStageSize
 }
 
+// ShardID returns the shard the blocks in this job belong to, if known. For split jobs this is
+// the group of source blocks, for merge jobs the shard ID label of the source blocks.
+func (job *Job) ShardID() string {
+	return job.shardID
+}
+
 // ShardingKey returns the key used to shard this job across multiple instances.
 func (job *Job) ShardingKey() string {
 	return job.shardingKey
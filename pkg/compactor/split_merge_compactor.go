@@ -26,17 +26,22 @@ func splitAndMergePlannerFactory(cfg Config) Planner {
 }
 
 func splitAndMergeCompactorFactory(_ context.Context, cfg Config, cfgProvider ConfigProvider, userID string, logger log.Logger, metrics *CompactorMetrics) (Compactor, error) {
-	splitBy := getCompactionSplitBy(cfg.CompactionSplitBy)
-	if splitBy == nil {
-		return nil, errInvalidCompactionSplitBy
-	}
-	return &BlockCompactor{
+	c := &BlockCompactor{
 		blockOpenConcurrency: cfg.MaxOpeningBlocksConcurrency,
 		downsamplerEnabled:   cfg.DownsamplerEnabled && cfgProvider.CompactorDownsamplerEnabled(userID),
-		splitBy:              splitBy,
 		logger:               logger,
 		metrics:              metrics,
-	}, nil
+	}
+	if cfg.CompactionSplitBy == CompactionSplitByLabel {
+		// The series are split by the label in phlaredb, which also records
+		// the range of the label values in the meta of the blocks.
+		c.splitByLabel = cfg.CompactionSplitByLabel
+		return c, nil
+	}
+	if c.splitBy = getCompactionSplitBy(cfg.CompactionSplitBy); c.splitBy == nil {
+		return nil, errInvalidCompactionSplitBy
+	}
+	return c, nil
 }
 
 // configureSplitAndMergeCompactor updates the provided configuration injecting the split-and-merge compactor.
@@ -91,6 +91,7 @@ func (g *SplitAndMergeGrouper) Groups(blocks map[ulid.ULID]*block.Meta) (res []*
 			g.splitStageSize,
 			job.shardingKey(),
 		)
+		compactionJob.shardID = job.shardID
 
 		for _, m := range job.blocks {
 			if err := compactionJob.AppendMeta(m); err != nil {
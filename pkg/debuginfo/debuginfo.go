@@ -0,0 +1,232 @@
+package debuginfo
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/services"
+	"github.com/grafana/dskit/tenant"
+	"github.com/pkg/errors"
+
+	debuginfov1 "github.com/grafana/pyroscope/api/gen/proto/go/debuginfo/v1"
+	"github.com/grafana/pyroscope/pkg/objstore"
+)
+
+type Config struct {
+	MaxUploadSize int64  `yaml:"max_upload_size"`
+	CacheDir      string `yaml:"cache_dir"`
+	CacheSize     int    `yaml:"cache_size"`
+}
+
+func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
+	f.Int64Var(&cfg.MaxUploadSize, "debuginfo.max-upload-size", 1<<30, "Maximum size of a debug information file in bytes. 0 to disable.")
+	f.StringVar(&cfg.CacheDir, "debuginfo.cache-dir", "./data/debuginfo-cache/", "Directory to store debug information files used for symbolization. This directory is not required to be persisted between restarts.")
+	f.IntVar(&cfg.CacheSize, "debuginfo.cache-size", 64, "Maximum number of debug information files kept open for symbolization.")
+}
+
+var (
+	errUploadTooLarge = errors.New("debug info file is too large")
+	errNoBuildID      = errors.New("debug info file has no GNU build ID")
+)
+
+// DebugInfo serves the debug information upload API.
+type DebugInfo struct {
+	services.Service
+
+	logger log.Logger
+	cfg    Config
+	store  *Store
+}
+
+func New(cfg Config, bucket objstore.Bucket, logger log.Logger) *DebugInfo {
+	d := &DebugInfo{
+		logger: logger,
+		cfg:    cfg,
+		store:  NewStore(bucket),
+	}
+	d.Service = services.NewBasicService(nil, d.running, nil)
+	return d
+}
+
+func (d *DebugInfo) running(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (d *DebugInfo) HasDebugInfo(ctx context.Context, c *connect.Request[debuginfov1.HasDebugInfoRequest]) (*connect.Response[debuginfov1.HasDebugInfoResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	buildID, err := NormalizeBuildID(c.Msg.BuildId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	exists, err := d.store.Exists(ctx, tenantID, buildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check debug info")
+	}
+	return connect.NewResponse(&debuginfov1.HasDebugInfoResponse{Exists: exists}), nil
+}
+
+func (d *DebugInfo) Upload(ctx context.Context, stream *connect.ClientStream[debuginfov1.UploadRequest]) (*connect.Response[debuginfov1.UploadResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if !stream.Receive() {
+		if err = stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("upload info is missing"))
+	}
+	info := stream.Msg().GetInfo()
+	if info == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("the first message must contain upload info"))
+	}
+	buildID, err := NormalizeBuildID(info.BuildId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	r := &uploadReader{stream: stream, limit: d.cfg.MaxUploadSize}
+	f, err := d.spool(r)
+	if err != nil {
+		if errors.Is(err, errUploadTooLarge) {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("%w: the limit is %d bytes", errUploadTooLarge, d.cfg.MaxUploadSize))
+		}
+		return nil, errors.Wrap(err, "failed to upload debug info")
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+	fileBuildID, err := gnuBuildID(f)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if fileBuildID != buildID {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("build ID %s does not match the build ID of the debug info file %s", buildID, fileBuildID))
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "failed to upload debug info")
+	}
+	if err = d.store.Upload(ctx, tenantID, buildID, f); err != nil {
+		return nil, errors.Wrap(err, "failed to upload debug info")
+	}
+
+	level.Debug(d.logger).Log("msg", "debug info uploaded", "tenant", tenantID, "build_id", buildID, "name", info.Name, "size", r.size)
+	return connect.NewResponse(&debuginfov1.UploadResponse{
+		BuildId: buildID,
+		Size:    uint64(r.size),
+	}), nil
+}
+
+// spool writes the uploaded file to a temporary file in the cache directory,
+// so that the upload is complete and within the size limit before anything is
+// written to the object store: an aborted upload must not leave a truncated
+// file or overwrite a valid one. The file is returned positioned at the start.
+func (d *DebugInfo) spool(r io.Reader) (*os.File, error) {
+	if err := os.MkdirAll(d.cfg.CacheDir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(d.cfg.CacheDir, "upload-")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(f, r); err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+// uploadReader reads the file chunks from the upload stream.
+type uploadReader struct {
+	stream *connect.ClientStream[debuginfov1.UploadRequest]
+	buf    []byte
+	size   int64
+	limit  int64
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		r.buf = r.stream.Msg().GetChunk()
+		r.size += int64(len(r.buf))
+		if r.limit > 0 && r.size > r.limit {
+			return 0, errUploadTooLarge
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// gnuBuildID returns the build ID stored in the GNU build ID note of the
+// ELF file, hex-encoded.
+func gnuBuildID(r io.ReaderAt) (string, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return "", fmt.Errorf("debug info file is not a valid ELF file: %w", err)
+	}
+	for _, s := range f.Sections {
+		if s.Type != elf.SHT_NOTE {
+			continue
+		}
+		data, err := s.Data()
+		if err != nil {
+			return "", fmt.Errorf("failed to read ELF section %s: %w", s.Name, err)
+		}
+		if id, ok := findGNUBuildIDNote(f.ByteOrder, data); ok {
+			return hex.EncodeToString(id), nil
+		}
+	}
+	return "", errNoBuildID
+}
+
+// findGNUBuildIDNote returns the description of the NT_GNU_BUILD_ID note
+// of the section data, which is the build ID.
+func findGNUBuildIDNote(order binary.ByteOrder, data []byte) ([]byte, bool) {
+	const ntGNUBuildID = 3
+	for len(data) >= 12 {
+		nameSize := uint64(order.Uint32(data[0:4]))
+		descSize := uint64(order.Uint32(data[4:8]))
+		typ := order.Uint32(data[8:12])
+		data = data[12:]
+		nameEnd := align4(nameSize)
+		descEnd := nameEnd + align4(descSize)
+		if descEnd > uint64(len(data)) {
+			return nil, false
+		}
+		name := bytes.TrimRight(data[:nameSize], "\x00")
+		if typ == ntGNUBuildID && string(name) == "GNU" && descSize > 0 {
+			return data[nameEnd : nameEnd+descSize], true
+		}
+		data = data[descEnd:]
+	}
+	return nil, false
+}
+
+func align4(n uint64) uint64 { return (n + 3) &^ 3 }
@@ -0,0 +1,137 @@
+package debuginfo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore/providers/filesystem"
+
+	debuginfov1 "github.com/grafana/pyroscope/api/gen/proto/go/debuginfo/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/debuginfo/v1/debuginfov1connect"
+	phlareobjstore "github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/tenant"
+)
+
+func newTestClient(t *testing.T, cfg Config) (debuginfov1connect.DebugInfoServiceClient, *Store) {
+	// The filesystem bucket writes the object while reading the upload.
+	fs, err := filesystem.NewBucket(t.TempDir())
+	require.NoError(t, err)
+	bucket := phlareobjstore.NewBucket(fs)
+	cfg.CacheDir = t.TempDir()
+	d := New(cfg, bucket, log.NewNopLogger())
+	mux := http.NewServeMux()
+	mux.Handle(debuginfov1connect.NewDebugInfoServiceHandler(d))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r.WithContext(tenant.InjectTenantID(r.Context(), "tenant")))
+	}))
+	t.Cleanup(server.Close)
+	return debuginfov1connect.NewDebugInfoServiceClient(server.Client(), server.URL), d.store
+}
+
+func upload(ctx context.Context, client debuginfov1connect.DebugInfoServiceClient, buildID string, data []byte, chunkSize int) (*debuginfov1.UploadResponse, error) {
+	stream := client.Upload(ctx)
+	if err := stream.Send(&debuginfov1.UploadRequest{Data: &debuginfov1.UploadRequest_Info{
+		Info: &debuginfov1.UploadInfo{BuildId: buildID, Name: "binary"},
+	}}); err != nil {
+		return nil, err
+	}
+	for len(data) > 0 {
+		n := chunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+		if err := stream.Send(&debuginfov1.UploadRequest{Data: &debuginfov1.UploadRequest_Chunk{Chunk: data[:n]}}); err != nil {
+			return nil, err
+		}
+		data = data[n:]
+	}
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+func readTestELF(t *testing.T, name string) []byte {
+	data, err := os.ReadFile("../../ebpf/symtab/elf/testdata/elfs/" + name)
+	require.NoError(t, err)
+	return data
+}
+
+func Test_DebugInfo_Upload(t *testing.T) {
+	client, store := newTestClient(t, Config{MaxUploadSize: 64 << 10})
+	ctx := context.Background()
+	data := readTestELF(t, "elf.debug")
+
+	has, err := client.HasDebugInfo(ctx, connect.NewRequest(&debuginfov1.HasDebugInfoRequest{BuildId: testBuildID}))
+	require.NoError(t, err)
+	require.False(t, has.Msg.Exists)
+
+	resp, err := upload(ctx, client, "1FCFA068C5FDB9F31E6D9F3F89019BEACB70182D", data, 1<<10)
+	require.NoError(t, err)
+	require.Equal(t, testBuildID, resp.BuildId)
+	require.Equal(t, uint64(len(data)), resp.Size)
+
+	has, err = client.HasDebugInfo(ctx, connect.NewRequest(&debuginfov1.HasDebugInfoRequest{BuildId: testBuildID}))
+	require.NoError(t, err)
+	require.True(t, has.Msg.Exists)
+
+	r, err := store.Get(ctx, "tenant", testBuildID)
+	require.NoError(t, err)
+	uploaded, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, data, uploaded)
+}
+
+func Test_DebugInfo_Upload_Validation(t *testing.T) {
+	client, store := newTestClient(t, Config{MaxUploadSize: 64 << 10})
+	ctx := context.Background()
+
+	_, err := upload(ctx, client, "not-a-build-id", readTestELF(t, "elf"), 1<<10)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = upload(ctx, client, testBuildID, make([]byte, 128<<10), 1<<10)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// The build ID must match the GNU build ID note of the ELF file.
+	for _, data := range [][]byte{
+		[]byte("not an ELF file"),
+		readTestELF(t, "elf.nobuildid"),
+		readTestELF(t, "elf.nopie"),
+	} {
+		_, err = upload(ctx, client, testBuildID, data, 1<<10)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	}
+	exists, err := store.Exists(ctx, "tenant", testBuildID)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = client.HasDebugInfo(ctx, connect.NewRequest(&debuginfov1.HasDebugInfoRequest{BuildId: "xyz"}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func Test_DebugInfo_Upload_TooLargeKeepsExisting(t *testing.T) {
+	client, store := newTestClient(t, Config{MaxUploadSize: 64 << 10})
+	ctx := context.Background()
+	data := readTestELF(t, "elf")
+
+	_, err := upload(ctx, client, testBuildID, data, 1<<10)
+	require.NoError(t, err)
+	_, err = upload(ctx, client, testBuildID, make([]byte, 128<<10), 1<<10)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	r, err := store.Get(ctx, "tenant", testBuildID)
+	require.NoError(t, err)
+	uploaded, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, data, uploaded)
+}
@@ -0,0 +1,70 @@
+package debuginfo
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/grafana/pyroscope/pkg/objstore"
+)
+
+const debugInfoFileName = "debuginfo"
+
+// Store keeps debug information files in the object store.
+// Files are stored per tenant and are identified by the
+// GNU build ID of the binary.
+type Store struct {
+	bucket objstore.Bucket
+}
+
+func NewStore(bucket objstore.Bucket) *Store {
+	return &Store{bucket: bucket}
+}
+
+func (s *Store) tenantBucket(tenantID string) objstore.Bucket {
+	return objstore.NewPrefixedBucket(s.bucket, tenantID+"/debuginfo")
+}
+
+func objectPath(buildID string) string {
+	return path.Join(buildID, debugInfoFileName)
+}
+
+// Exists reports whether the debug information file
+// for the build ID has been uploaded.
+func (s *Store) Exists(ctx context.Context, tenantID, buildID string) (bool, error) {
+	return s.tenantBucket(tenantID).Exists(ctx, objectPath(buildID))
+}
+
+// Upload stores the debug information file read from r.
+// An existing file is overwritten.
+func (s *Store) Upload(ctx context.Context, tenantID, buildID string, r io.Reader) error {
+	return s.tenantBucket(tenantID).Upload(ctx, objectPath(buildID), r)
+}
+
+// Get returns a reader of the debug information file. The caller
+// is responsible for closing the reader. If the file does not
+// exist, the error satisfies IsObjNotFoundErr.
+func (s *Store) Get(ctx context.Context, tenantID, buildID string) (io.ReadCloser, error) {
+	return s.tenantBucket(tenantID).Get(ctx, objectPath(buildID))
+}
+
+func (s *Store) IsObjNotFoundErr(err error) bool {
+	return s.bucket.IsObjNotFoundErr(err)
+}
+
+// NormalizeBuildID validates the hex-encoded GNU build ID
+// and returns it in the lower case.
+func NormalizeBuildID(buildID string) (string, error) {
+	// GNU build IDs are usually 20 bytes long (SHA-1), but
+	// other lengths are possible, depending on the linker.
+	if len(buildID) < 8 || len(buildID) > 128 {
+		return "", fmt.Errorf("invalid build ID length: %d", len(buildID))
+	}
+	if _, err := hex.DecodeString(buildID); err != nil {
+		return "", fmt.Errorf("invalid build ID %q: %w", buildID, err)
+	}
+	return strings.ToLower(buildID), nil
+}
@@ -0,0 +1,385 @@
+package debuginfo
+
+import (
+	"context"
+	"debug/elf"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/tenant"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/ianlancetaylor/demangle"
+	"golang.org/x/sync/singleflight"
+
+	elfsym "github.com/grafana/pyroscope/ebpf/symtab/elf"
+	"github.com/grafana/pyroscope/pkg/objstore"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+const (
+	// Build IDs with no debug information uploaded are not
+	// looked up in the object store for this period.
+	missingDebugInfoTTL  = 5 * time.Minute
+	missingDebugInfoSize = 4 << 10
+
+	// Symbolized partitions of blocks are cached: the symbols
+	// of a block never change.
+	symbolizedPartitionsSize = 256
+
+	pageSize = 4 << 10
+)
+
+var demangleOptions = []demangle.Option{
+	demangle.NoParams,
+	demangle.NoEnclosingParams,
+	demangle.NoTemplateParams,
+}
+
+// Symbolizer resolves function names of locations that belong to
+// mappings with a build ID, but have no line information. This is
+// the case for profiles of stripped native binaries: debug information
+// files are uploaded separately and are fetched from the object store
+// at query time. Symbol tables are cached per build ID, and the
+// symbolized partitions of blocks per partition.
+type Symbolizer struct {
+	logger log.Logger
+	store  *Store
+	dir    string
+
+	tables     *lru.Cache[string, *symbolTable]
+	missing    *expirable.LRU[string, struct{}]
+	loading    singleflight.Group
+	partitions *lru.Cache[string, *symbolizedPartition]
+}
+
+func NewSymbolizer(cfg Config, bucket objstore.Bucket, logger log.Logger) (*Symbolizer, error) {
+	if err := os.MkdirAll(cfg.CacheDir, 0o755); err != nil {
+		return nil, err
+	}
+	tables, err := lru.NewWithEvict(cfg.CacheSize, func(_ string, t *symbolTable) { t.close() })
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := lru.New[string, *symbolizedPartition](symbolizedPartitionsSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Symbolizer{
+		logger:     logger,
+		store:      NewStore(bucket),
+		dir:        cfg.CacheDir,
+		tables:     tables,
+		missing:    expirable.NewLRU[string, struct{}](missingDebugInfoSize, nil, missingDebugInfoTTL),
+		partitions: partitions,
+	}, nil
+}
+
+// Symbolize implements symdb.Symbolizer. If none of the locations
+// can be symbolized, the source symbols are returned as is.
+func (s *Symbolizer) Symbolize(ctx context.Context, key symdb.SymbolsKey, symbols *symdb.Symbols) (*symdb.Symbols, error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return symbols, nil
+	}
+	var partitionKey string
+	if key.Block != "" {
+		partitionKey = tenantID + "/" + key.Block + "/" + strconv.FormatUint(key.Partition, 10)
+		if p, ok := s.partitions.Get(partitionKey); ok {
+			return p.symbols(symbols), nil
+		}
+	}
+	// The result is only cached if the debug info of all the
+	// mappings is loaded, as it may be uploaded later.
+	cache := partitionKey != ""
+	tables := make(map[uint32]*symbolTable)
+	for _, loc := range symbols.Locations {
+		if len(loc.Line) > 0 || loc.Address == 0 {
+			continue
+		}
+		if _, ok := tables[loc.MappingId]; ok {
+			continue
+		}
+		var t *symbolTable
+		if m := symbols.Mappings[loc.MappingId]; m.BuildId != 0 {
+			// Only GNU build IDs are looked up.
+			if buildID, err := NormalizeBuildID(symbols.Strings[m.BuildId]); err == nil {
+				if t, err = s.symbolTable(ctx, tenantID, buildID); err != nil {
+					if ctx.Err() != nil {
+						return nil, ctx.Err()
+					}
+					level.Warn(s.logger).Log("msg", "failed to load debug info", "build_id", buildID, "err", err)
+				}
+				cache = cache && t != nil
+			}
+		}
+		tables[loc.MappingId] = t
+	}
+	r := symbolsRewriter{source: symbols}
+	for i, loc := range symbols.Locations {
+		t := tables[loc.MappingId]
+		if t == nil || len(loc.Line) > 0 || loc.Address == 0 {
+			continue
+		}
+		if name := t.resolve(symbols.Mappings[loc.MappingId], loc.Address); name != "" {
+			r.setFunction(i, name)
+		}
+	}
+	if cache {
+		s.partitions.Add(partitionKey, r.partition())
+	}
+	return r.symbols(), nil
+}
+
+// symbolTable returns the symbol table of the normalized build ID,
+// or nil if no debug info is uploaded.
+func (s *Symbolizer) symbolTable(ctx context.Context, tenantID, buildID string) (*symbolTable, error) {
+	key := tenantID + "/" + buildID
+	if t, ok := s.tables.Get(key); ok {
+		return t, nil
+	}
+	if s.missing.Contains(key) {
+		return nil, nil
+	}
+	v, err, _ := s.loading.Do(key, func() (interface{}, error) {
+		t, err := s.load(ctx, tenantID, buildID)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			s.missing.Add(key, struct{}{})
+			return nil, nil
+		}
+		s.tables.Add(key, t)
+		return t, nil
+	})
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return v.(*symbolTable), nil
+}
+
+func (s *Symbolizer) load(ctx context.Context, tenantID, buildID string) (*symbolTable, error) {
+	rc, err := s.store.Get(ctx, tenantID, buildID)
+	if err != nil {
+		if s.store.IsObjNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+	dir := filepath.Join(s.dir, tenantID)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(dir, buildID)
+	if err != nil {
+		return nil, err
+	}
+	path := f.Name()
+	_, err = io.Copy(f, rc)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	t, err := openSymbolTable(path)
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	return t, nil
+}
+
+type symbolResolver interface {
+	Resolve(addr uint64) string
+	Cleanup()
+}
+
+type symbolTable struct {
+	// Symbol names are read from the file lazily:
+	// access to the resolver must be serialized.
+	m      sync.Mutex
+	path   string
+	typ    elf.Type
+	progs  []elf.ProgHeader
+	table  symbolResolver
+	closed bool
+}
+
+func openSymbolTable(path string) (*symbolTable, error) {
+	f, err := elfsym.NewMMapedElfFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &symbolTable{
+		path:  path,
+		typ:   f.Type,
+		progs: f.Progs,
+	}
+	goTable, goErr := f.NewGoTable()
+	symTable, symErr := f.NewSymbolTable(&elfsym.SymbolsOptions{DemangleOptions: demangleOptions})
+	switch {
+	case goErr == nil && symErr == nil:
+		t.table = &elfsym.GoTableWithFallback{GoTable: goTable, SymTable: symTable}
+	case goErr == nil:
+		t.table = goTable
+	case symErr == nil:
+		t.table = symTable
+	default:
+		f.Close()
+		return nil, fmt.Errorf("no symbols found: s: %s g: %s", symErr, goErr)
+	}
+	return t, nil
+}
+
+// resolve returns the name of the function the address belongs to.
+// The address is translated from the process address space to the
+// virtual address in the ELF file using the mapping.
+func (t *symbolTable) resolve(m *schemav1.InMemoryMapping, addr uint64) string {
+	addr, ok := t.virtualAddress(m, addr)
+	if !ok {
+		return ""
+	}
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.closed {
+		return ""
+	}
+	return t.table.Resolve(addr)
+}
+
+func (t *symbolTable) virtualAddress(m *schemav1.InMemoryMapping, addr uint64) (uint64, bool) {
+	if t.typ == elf.ET_EXEC {
+		// Non-PIE executables are loaded at the linked address.
+		return addr, true
+	}
+	if addr < m.MemoryStart || (m.MemoryLimit > 0 && addr >= m.MemoryLimit) {
+		return 0, false
+	}
+	text := t.textSegment()
+	if text == nil {
+		return 0, false
+	}
+	if text.Filesz == 0 {
+		// Separate debug info files do not preserve file offsets of
+		// the segments: we assume that the mapping corresponds to the
+		// executable segment, which is mapped at the page boundary.
+		return addr - m.MemoryStart + text.Vaddr&^(pageSize-1), true
+	}
+	offset := addr - m.MemoryStart + m.FileOffset
+	for _, p := range t.progs {
+		if p.Type == elf.PT_LOAD && offset >= p.Off && offset < p.Off+p.Filesz {
+			return offset - p.Off + p.Vaddr, true
+		}
+	}
+	return 0, false
+}
+
+func (t *symbolTable) textSegment() *elf.ProgHeader {
+	for i := range t.progs {
+		if p := &t.progs[i]; p.Type == elf.PT_LOAD && p.Flags&elf.PF_X != 0 {
+			return p
+		}
+	}
+	return nil
+}
+
+func (t *symbolTable) close() {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if !t.closed {
+		t.closed = true
+		t.table.Cleanup()
+		_ = os.Remove(t.path)
+	}
+}
+
+// symbolsRewriter creates a copy of the symbols on the first
+// modification: the source symbols are never modified.
+type symbolsRewriter struct {
+	source    *symdb.Symbols
+	copied    *symdb.Symbols
+	functions map[string]uint32
+}
+
+func (r *symbolsRewriter) setFunction(location int, name string) {
+	if r.copied == nil {
+		r.copied = &symdb.Symbols{
+			Stacktraces: r.source.Stacktraces,
+			Locations:   append([]*schemav1.InMemoryLocation(nil), r.source.Locations...),
+			Mappings:    r.source.Mappings,
+			Functions:   append([]*schemav1.InMemoryFunction(nil), r.source.Functions...),
+			Strings:     append([]string(nil), r.source.Strings...),
+		}
+		r.functions = make(map[string]uint32)
+	}
+	fn, ok := r.functions[name]
+	if !ok {
+		fn = uint32(len(r.copied.Functions))
+		r.copied.Functions = append(r.copied.Functions, &schemav1.InMemoryFunction{
+			Id:         uint64(fn),
+			Name:       uint32(len(r.copied.Strings)),
+			SystemName: uint32(len(r.copied.Strings)),
+		})
+		r.copied.Strings = append(r.copied.Strings, name)
+		r.functions[name] = fn
+	}
+	loc := *r.copied.Locations[location]
+	loc.Line = []schemav1.InMemoryLine{{FunctionId: fn}}
+	r.copied.Locations[location] = &loc
+}
+
+func (r *symbolsRewriter) symbols() *symdb.Symbols {
+	if r.copied != nil {
+		return r.copied
+	}
+	return r.source
+}
+
+func (r *symbolsRewriter) partition() *symbolizedPartition {
+	if r.copied == nil {
+		return &symbolizedPartition{}
+	}
+	return &symbolizedPartition{
+		locations: r.copied.Locations,
+		functions: r.copied.Functions,
+		strings:   r.copied.Strings,
+	}
+}
+
+// symbolizedPartition is the result of the symbolization of a block
+// partition. Only the symbols rewritten are kept: the stack traces and
+// mappings are taken from the source symbols, which hold the references
+// to the partition.
+type symbolizedPartition struct {
+	// Nil, if no locations were symbolized.
+	locations []*schemav1.InMemoryLocation
+	functions []*schemav1.InMemoryFunction
+	strings   []string
+}
+
+func (p *symbolizedPartition) symbols(source *symdb.Symbols) *symdb.Symbols {
+	if p.locations == nil {
+		return source
+	}
+	return &symdb.Symbols{
+		Stacktraces: source.Stacktraces,
+		Locations:   p.locations,
+		Mappings:    source.Mappings,
+		Functions:   p.functions,
+		Strings:     p.strings,
+	}
+}
@@ -0,0 +1,125 @@
+package debuginfo
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	thanosobjstore "github.com/thanos-io/objstore"
+
+	phlareobjstore "github.com/grafana/pyroscope/pkg/objstore"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+	"github.com/grafana/pyroscope/pkg/tenant"
+)
+
+const testBuildID = "1fcfa068c5fdb9f31e6d9f3f89019beacb70182d"
+
+func newTestSymbolizer(t *testing.T) (*Symbolizer, *Store) {
+	bucket := phlareobjstore.NewBucket(thanosobjstore.NewInMemBucket())
+	s, err := NewSymbolizer(Config{CacheDir: t.TempDir(), CacheSize: 2}, bucket, log.NewNopLogger())
+	require.NoError(t, err)
+	return s, NewStore(bucket)
+}
+
+func Test_Symbolizer(t *testing.T) {
+	for _, file := range []string{
+		// The binary itself.
+		"elf",
+		// Separate debug info file, as produced by objcopy --only-keep-debug.
+		"elf.debug",
+	} {
+		file := file
+		t.Run(file, func(t *testing.T) {
+			testSymbolizer(t, "../../ebpf/symtab/elf/testdata/elfs/"+file)
+		})
+	}
+}
+
+func testSymbolizer(t *testing.T, path string) {
+	symbolizer, store := newTestSymbolizer(t)
+	ctx := tenant.InjectTenantID(context.Background(), "tenant")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Upload(ctx, "tenant", testBuildID, bytes.NewReader(data)))
+
+	source := &symdb.Symbols{
+		Mappings: []*schemav1.InMemoryMapping{
+			{},
+			{MemoryStart: 0x1000, MemoryLimit: 0x2000, FileOffset: 0x1000, BuildId: 1},
+			{MemoryStart: 0x3000, MemoryLimit: 0x4000, BuildId: 2},
+		},
+		Locations: []*schemav1.InMemoryLocation{
+			{},
+			{MappingId: 1, Address: 0x1149},
+			{MappingId: 1, Address: 0x115e},
+			{MappingId: 1, Address: 0x1150, Line: []schemav1.InMemoryLine{{FunctionId: 1}}},
+			{MappingId: 2, Address: 0x3100},
+		},
+		Functions: []*schemav1.InMemoryFunction{
+			{},
+			{Id: 1, Name: 3},
+		},
+		Strings: []string{"", testBuildID, "0123456789abcdef", "symbolized"},
+	}
+
+	symbolized, err := symbolizer.Symbolize(ctx, symdb.SymbolsKey{}, source)
+	require.NoError(t, err)
+	functionName := func(s *symdb.Symbols, loc int) string {
+		lines := s.Locations[loc].Line
+		if len(lines) == 0 {
+			return ""
+		}
+		return s.Strings[s.Functions[lines[0].FunctionId].Name]
+	}
+	require.Equal(t, "iter", functionName(symbolized, 1))
+	require.Equal(t, "main", functionName(symbolized, 2))
+	require.Equal(t, "symbolized", functionName(symbolized, 3))
+	require.Equal(t, "", functionName(symbolized, 4))
+
+	// The source symbols are not modified.
+	require.Empty(t, source.Locations[1].Line)
+	require.Len(t, source.Functions, 2)
+	require.Len(t, source.Strings, 4)
+
+	// Symbol tables are cached.
+	require.Equal(t, 1, symbolizer.tables.Len())
+	require.True(t, symbolizer.missing.Contains("tenant/0123456789abcdef"))
+
+	// Debug info is not shared between tenants.
+	otherTenant := tenant.InjectTenantID(context.Background(), "other")
+	symbolized, err = symbolizer.Symbolize(otherTenant, symdb.SymbolsKey{}, source)
+	require.NoError(t, err)
+	require.Same(t, source, symbolized)
+
+	// Partitions of blocks are not cached while debug info is missing.
+	key := symdb.SymbolsKey{Block: "block", Partition: 1}
+	_, err = symbolizer.Symbolize(ctx, key, source)
+	require.NoError(t, err)
+	require.Equal(t, 0, symbolizer.partitions.Len())
+
+	source.Locations = source.Locations[:4]
+	symbolized, err = symbolizer.Symbolize(ctx, key, source)
+	require.NoError(t, err)
+	require.Equal(t, 1, symbolizer.partitions.Len())
+	cached, err := symbolizer.Symbolize(ctx, key, source)
+	require.NoError(t, err)
+	require.Same(t, symbolized.Locations[1], cached.Locations[1])
+	require.Equal(t, "iter", functionName(cached, 1))
+	require.Same(t, source.Mappings[1], cached.Mappings[1])
+}
+
+func Test_Symbolizer_NoTenant(t *testing.T) {
+	symbolizer, _ := newTestSymbolizer(t)
+	source := &symdb.Symbols{
+		Mappings:  []*schemav1.InMemoryMapping{{}, {BuildId: 1}},
+		Locations: []*schemav1.InMemoryLocation{{}, {MappingId: 1, Address: 0x1149}},
+		Strings:   []string{"", testBuildID},
+	}
+	symbolized, err := symbolizer.Symbolize(context.Background(), symdb.SymbolsKey{}, source)
+	require.NoError(t, err)
+	require.Same(t, source, symbolized)
+}
@@ -121,6 +121,8 @@ type Limits interface {
 	MaxProfileStacktraceDepth(tenantID string) int
 	MaxProfileSymbolValueLength(tenantID string) int
 	MaxSessionsPerSeries(tenantID string) int
+	SampleLabels(tenantID string) []string
+	IngestionNormalizationRules(tenantID string) []*pprof.NormalizationRule
 	validation.ProfileValidationLimits
 	aggregator.Limits
 }
@@ -307,8 +309,12 @@ func (d *Distributor) PushParsed(ctx context.Context, req *distributormodel.Push
 
 	// Normalisation is quite an expensive operation,
 	// therefore it should be done after the rate limit check.
+	rules := d.limits.IngestionNormalizationRules(tenantID)
 	for _, series := range req.Series {
 		for _, sample := range series.Samples {
+			if len(rules) > 0 {
+				sample.Profile.Profile = pprof.ApplyNormalizationRules(sample.Profile.Profile, rules)
+			}
 			sample.Profile.Normalize()
 		}
 	}
@@ -388,7 +394,7 @@ func (d *Distributor) sendRequests(ctx context.Context, req *distributormodel.Pu
 	}
 
 	// Next we split profiles by labels.
-	profileSeries := extractSampleSeries(req)
+	profileSeries := extractSampleSeries(req, d.limits.SampleLabels(tenantID)...)
 	// Filter our series and profiles without samples.
 	for _, series := range profileSeries {
 		series.Samples = slices.RemoveInPlace(series.Samples, func(sample *distributormodel.ProfileSample, _ int) bool {
@@ -617,8 +623,12 @@ func (d *Distributor) HealthyInstancesCount() int {
 	return int(d.healthyInstancesCount.Load())
 }
 
-func extractSampleSeries(req *distributormodel.PushRequest) []*distributormodel.ProfileSeries {
+// extractSampleSeries splits profiles by sample labels, which are promoted
+// to series labels. The span ID label and the sample labels provided are
+// kept at the sample level.
+func extractSampleSeries(req *distributormodel.PushRequest, sampleLabels ...string) []*distributormodel.ProfileSeries {
 	profileSeries := make([]*distributormodel.ProfileSeries, 0, len(req.Series))
+	keep := append([]string{pprof.SpanIDLabelName}, sampleLabels...)
 	for _, series := range req.Series {
 		s := &distributormodel.ProfileSeries{
 			Labels:  series.Labels,
@@ -626,7 +636,7 @@ func extractSampleSeries(req *distributormodel.PushRequest) []*distributormodel.
 		}
 		for _, raw := range series.Samples {
 			pprof.RenameLabel(raw.Profile.Profile, pprof.ProfileIDLabelName, pprof.SpanIDLabelName)
-			groups := pprof.GroupSamplesWithoutLabels(raw.Profile.Profile, pprof.SpanIDLabelName)
+			groups := pprof.GroupSamplesWithoutLabels(raw.Profile.Profile, keep...)
 			if len(groups) == 0 || (len(groups) == 1 && len(groups[0].Labels) == 0) {
 				// No sample labels in the profile.
 				// We do not modify the request.
@@ -1114,3 +1114,58 @@ func expectMetricsChange(t *testing.T, m1, m2, expectedChange map[prometheus.Col
 		assert.Equal(t, expectedDelta, delta, "metric %s", counter)
 	}
 }
+
+func Test_NormalizationRules(t *testing.T) {
+	rule := &pprof2.NormalizationRule{Action: pprof2.NormalizationDrop, Regex: `^reflect\.`}
+	require.NoError(t, rule.Validate())
+	overrides := validation.MockOverrides(func(defaults *validation.Limits, tenantLimits map[string]*validation.Limits) {
+		l := validation.MockDefaultLimits()
+		l.IngestionNormalizationRules = []*pprof2.NormalizationRule{rule}
+		tenantLimits["user-1"] = l
+	})
+
+	ing := newFakeIngester(t, false)
+	d, err := New(Config{DistributorRing: ringConfig}, testhelper.NewMockRing([]ring.InstanceDesc{{Addr: "foo"}}, 3),
+		&poolFactory{func(addr string) (client.PoolClient, error) { return ing, nil }},
+		overrides, nil, log.NewLogfmtLogger(os.Stdout))
+	require.NoError(t, err)
+
+	functionNames := func(tenantID string) []string {
+		ing.requests = nil
+		p := &profilev1.Profile{
+			SampleType:  []*profilev1.ValueType{{Type: 1, Unit: 2}},
+			PeriodType:  &profilev1.ValueType{Type: 1, Unit: 2},
+			StringTable: []string{"", "cpu", "nanoseconds", "main", "reflect.Value.call", "handler"},
+			Mapping:     []*profilev1.Mapping{{Id: 1, HasFunctions: true}},
+			Function:    []*profilev1.Function{{Id: 1, Name: 3}, {Id: 2, Name: 4}, {Id: 3, Name: 5}},
+			Location: []*profilev1.Location{
+				{Id: 1, MappingId: 1, Line: []*profilev1.Line{{FunctionId: 1}}},
+				{Id: 2, MappingId: 1, Line: []*profilev1.Line{{FunctionId: 2}}},
+				{Id: 3, MappingId: 1, Line: []*profilev1.Line{{FunctionId: 3}}},
+			},
+			Sample: []*profilev1.Sample{{LocationId: []uint64{3, 2, 1}, Value: []int64{1}}},
+		}
+		_, err = d.PushParsed(tenant.InjectTenantID(context.Background(), tenantID), &distributormodel.PushRequest{
+			Series: []*distributormodel.ProfileSeries{{
+				Labels: []*typesv1.LabelPair{
+					{Name: phlaremodel.LabelNameServiceName, Value: "svc"},
+					{Name: "__name__", Value: "cpu"},
+				},
+				Samples: []*distributormodel.ProfileSample{{Profile: pprof2.RawFromProto(p)}},
+			}},
+			TotalProfiles: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, ing.requests, 1)
+		pushed, err := pprof2.RawFromBytes(ing.requests[0].Series[0].Samples[0].RawProfile)
+		require.NoError(t, err)
+		var names []string
+		for _, fn := range pushed.Function {
+			names = append(names, pushed.StringTable[fn.Name])
+		}
+		return names
+	}
+
+	assert.ElementsMatch(t, []string{"main", "reflect.Value.call", "handler"}, functionNames("user-0"))
+	assert.ElementsMatch(t, []string{"main", "handler"}, functionNames("user-1"))
+}
@@ -96,10 +96,12 @@ type Frontend struct {
 
 type Limits interface {
 	QuerySplitDuration(string) time.Duration
+	QueryShards(string) int
 	MaxQueryParallelism(string) int
 	MaxQueryLength(tenantID string) time.Duration
 	MaxQueryLookback(tenantID string) time.Duration
 	validation.FlameGraphLimits
+	validation.QueryCostLimits
 }
 
 type frontendRequest struct {
@@ -277,6 +279,27 @@ enqueueAgain:
 	}
 }
 
+// withQueryStats returns a context collecting the stats of the sub-queries
+// of a request. If the stats are already collected in the context, e.g.,
+// by the parent request, they are shared.
+func withQueryStats(ctx context.Context) (*stats.Stats, context.Context) {
+	if s := stats.FromContext(ctx); s != nil {
+		return s, ctx
+	}
+	return stats.ContextWithEmptyStats(ctx)
+}
+
+// validateQueryCost returns an error if the bytes read and the profiles
+// fetched by the sub-queries completed so far exceed the query cost limits
+// of the tenants.
+func (f *Frontend) validateQueryCost(ctx context.Context, tenantIDs []string) error {
+	s := stats.FromContext(ctx)
+	if err := validation.ValidateQueryCost(f.limits, tenantIDs, s.LoadReadBytes(), s.LoadFetchedProfiles()); err != nil {
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	return nil
+}
+
 func (f *Frontend) QueryResult(ctx context.Context, r *connect.Request[frontendpb.QueryResultRequest]) (*connect.Response[frontendpb.QueryResultResponse], error) {
 	qrReq := r.Msg
 	tenantIDs, err := tenant.TenantIDs(ctx)
@@ -19,6 +19,8 @@ func (f *Frontend) Diff(ctx context.Context,
 	*connect.Response[querierv1.DiffResponse], error,
 ) {
 	ctx = connectgrpc.WithProcedure(ctx, querierv1connect.QuerierServiceDiffProcedure)
+	// Both sides of the diff share the query stats and limits.
+	queryStats, ctx := withQueryStats(ctx)
 	g, ctx := errgroup.WithContext(ctx)
 	tenantIDs, err := tenant.TenantIDs(ctx)
 	if err != nil {
@@ -66,5 +68,7 @@ func (f *Frontend) Diff(ctx context.Context,
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	return connect.NewResponse(&querierv1.DiffResponse{Flamegraph: diff}), nil
+	resp := connect.NewResponse(&querierv1.DiffResponse{Flamegraph: diff})
+	queryStats.SetHeaders(resp.Header())
+	return resp, nil
 }
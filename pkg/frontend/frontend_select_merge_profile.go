@@ -43,6 +43,7 @@ func (f *Frontend) SelectMergeProfile(ctx context.Context, c *connect.Request[qu
 	c.Msg.Start = int64(validated.Start)
 	c.Msg.End = int64(validated.End)
 
+	queryStats, ctx := withQueryStats(ctx)
 	g, ctx := errgroup.WithContext(ctx)
 	if maxConcurrent := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.MaxQueryParallelism); maxConcurrent > 0 {
 		g.SetLimit(maxConcurrent)
@@ -55,34 +56,52 @@ func (f *Frontend) SelectMergeProfile(ctx context.Context, c *connect.Request[qu
 	//   the method is used for pprof export and
 	//   truncation is not applicable for that.
 
+	shards := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.QueryShards)
+	selectors, err := ShardSelectors(c.Msg.LabelSelector, shards)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	var lock sync.Mutex
 	var m pprof.ProfileMerge
 	for intervals.Next() {
 		r := intervals.At()
-		g.Go(func() error {
-			req := connectgrpc.CloneRequest(c, &querierv1.SelectMergeProfileRequest{
-				ProfileTypeID:      c.Msg.ProfileTypeID,
-				LabelSelector:      c.Msg.LabelSelector,
-				Start:              r.Start.UnixMilli(),
-				End:                r.End.UnixMilli(),
-				MaxNodes:           c.Msg.MaxNodes,
-				StackTraceSelector: c.Msg.StackTraceSelector,
+		for _, selector := range selectors {
+			selector := selector
+			g.Go(func() error {
+				req := connectgrpc.CloneRequest(c, &querierv1.SelectMergeProfileRequest{
+					ProfileTypeID:       c.Msg.ProfileTypeID,
+					LabelSelector:       selector,
+					Start:               r.Start.UnixMilli(),
+					End:                 r.End.UnixMilli(),
+					MaxNodes:            c.Msg.MaxNodes,
+					StackTraceSelector:  c.Msg.StackTraceSelector,
+					SampleLabelSelector: c.Msg.SampleLabelSelector,
+					CollapseRecursion:   c.Msg.CollapseRecursion,
+					SampleLabels:        c.Msg.SampleLabels,
+				})
+				resp, err := connectgrpc.RoundTripUnary[
+					querierv1.SelectMergeProfileRequest,
+					profilev1.Profile](ctx, f, req)
+				if err != nil {
+					return err
+				}
+				lock.Lock()
+				err = m.Merge(resp.Msg)
+				lock.Unlock()
+				if err != nil {
+					return err
+				}
+				return f.validateQueryCost(ctx, tenantIDs)
 			})
-			resp, err := connectgrpc.RoundTripUnary[
-				querierv1.SelectMergeProfileRequest,
-				profilev1.Profile](ctx, f, req)
-			if err != nil {
-				return err
-			}
-			lock.Lock()
-			defer lock.Unlock()
-			return m.Merge(resp.Msg)
-		})
+		}
 	}
 
 	if err = g.Wait(); err != nil {
 		return nil, err
 	}
 
-	return connect.NewResponse(m.Profile()), nil
+	resp := connect.NewResponse(m.Profile())
+	queryStats.SetHeaders(resp.Header())
+	return resp, nil
 }
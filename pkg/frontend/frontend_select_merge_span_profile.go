@@ -47,6 +47,7 @@ func (f *Frontend) SelectMergeSpanProfile(ctx context.Context,
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
+	queryStats, ctx := withQueryStats(ctx)
 	g, ctx := errgroup.WithContext(ctx)
 	if maxConcurrent := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.MaxQueryParallelism); maxConcurrent > 0 {
 		g.SetLimit(maxConcurrent)
@@ -56,26 +57,35 @@ func (f *Frontend) SelectMergeSpanProfile(ctx context.Context,
 	interval := validationutil.MaxDurationOrZeroPerTenant(tenantIDs, f.limits.QuerySplitDuration)
 	intervals := NewTimeIntervalIterator(time.UnixMilli(int64(validated.Start)), time.UnixMilli(int64(validated.End)), interval)
 
+	shards := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.QueryShards)
+	selectors, err := ShardSelectors(c.Msg.LabelSelector, shards)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	for intervals.Next() {
 		r := intervals.At()
-		g.Go(func() error {
-			req := connectgrpc.CloneRequest(c, &querierv1.SelectMergeSpanProfileRequest{
-				ProfileTypeID: c.Msg.ProfileTypeID,
-				LabelSelector: c.Msg.LabelSelector,
-				Start:         r.Start.UnixMilli(),
-				End:           r.End.UnixMilli(),
-				MaxNodes:      &maxNodes,
-				SpanSelector:  c.Msg.SpanSelector,
+		for _, selector := range selectors {
+			selector := selector
+			g.Go(func() error {
+				req := connectgrpc.CloneRequest(c, &querierv1.SelectMergeSpanProfileRequest{
+					ProfileTypeID: c.Msg.ProfileTypeID,
+					LabelSelector: selector,
+					Start:         r.Start.UnixMilli(),
+					End:           r.End.UnixMilli(),
+					MaxNodes:      &maxNodes,
+					SpanSelector:  c.Msg.SpanSelector,
+				})
+				resp, err := connectgrpc.RoundTripUnary[
+					querierv1.SelectMergeSpanProfileRequest,
+					querierv1.SelectMergeSpanProfileResponse](ctx, f, req)
+				if err != nil {
+					return err
+				}
+				m.MergeFlameGraph(resp.Msg.Flamegraph)
+				return f.validateQueryCost(ctx, tenantIDs)
 			})
-			resp, err := connectgrpc.RoundTripUnary[
-				querierv1.SelectMergeSpanProfileRequest,
-				querierv1.SelectMergeSpanProfileResponse](ctx, f, req)
-			if err != nil {
-				return err
-			}
-			m.MergeFlameGraph(resp.Msg.Flamegraph)
-			return nil
-		})
+		}
 	}
 
 	if err = g.Wait(); err != nil {
@@ -83,7 +93,9 @@ func (f *Frontend) SelectMergeSpanProfile(ctx context.Context,
 	}
 
 	t := m.Tree()
-	return connect.NewResponse(&querierv1.SelectMergeSpanProfileResponse{
+	resp := connect.NewResponse(&querierv1.SelectMergeSpanProfileResponse{
 		Flamegraph: phlaremodel.NewFlameGraph(t, c.Msg.GetMaxNodes()),
-	}), nil
+	})
+	queryStats.SetHeaders(resp.Header())
+	return resp, nil
 }
@@ -2,6 +2,7 @@ package frontend
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
@@ -22,6 +23,71 @@ func (f *Frontend) SelectMergeStacktraces(ctx context.Context,
 	c *connect.Request[querierv1.SelectMergeStacktracesRequest]) (
 	*connect.Response[querierv1.SelectMergeStacktracesResponse], error,
 ) {
+	queryStats, ctx := withQueryStats(ctx)
+	m := phlaremodel.NewFlameGraphMerger()
+	empty, err := f.selectMergeStacktraces(ctx, c, m, nil)
+	if err != nil {
+		return nil, err
+	}
+	if empty {
+		return connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{}), nil
+	}
+
+	resp := connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{
+		Flamegraph: phlaremodel.NewFlameGraph(m.Tree(), c.Msg.GetMaxNodes()),
+	})
+	queryStats.SetHeaders(resp.Header())
+	return resp, nil
+}
+
+// SelectMergeStacktracesStream sends the flamegraph merged so far each time
+// a sub-query completes, followed by the complete flamegraph in the final
+// message. The query stats are reported in the response trailers.
+func (f *Frontend) SelectMergeStacktracesStream(ctx context.Context,
+	c *connect.Request[querierv1.SelectMergeStacktracesRequest],
+	stream *connect.ServerStream[querierv1.SelectMergeStacktracesStreamResponse],
+) error {
+	queryStats, ctx := withQueryStats(ctx)
+	m := phlaremodel.NewFlameGraphMerger()
+	var total int
+	empty, err := f.selectMergeStacktraces(ctx, c, m, func(completed, n int) error {
+		total = n
+		if completed == n {
+			// The result is sent in the final message.
+			return nil
+		}
+		return stream.Send(&querierv1.SelectMergeStacktracesStreamResponse{
+			Flamegraph: m.FlameGraph(c.Msg.GetMaxNodes()),
+			Completed:  int64(completed),
+			Total:      int64(n),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	final := &querierv1.SelectMergeStacktracesStreamResponse{
+		Completed: int64(total),
+		Total:     int64(total),
+		Final:     true,
+	}
+	if !empty {
+		final.Flamegraph = m.FlameGraph(c.Msg.GetMaxNodes())
+	}
+	queryStats.SetHeaders(stream.ResponseTrailer())
+	return stream.Send(final)
+}
+
+// selectMergeStacktraces splits the query into sub-queries and merges their
+// results into m. If onMerge is not nil, it is called after the result of
+// each sub-query is merged, with the number of the completed sub-queries and
+// the total number of sub-queries. The calls are serialized. The returned
+// flag indicates that the query range is empty and nothing was queried.
+func (f *Frontend) selectMergeStacktraces(ctx context.Context,
+	c *connect.Request[querierv1.SelectMergeStacktracesRequest],
+	m *phlaremodel.FlameGraphMerger,
+	onMerge func(completed, total int) error,
+) (bool, error) {
 	opentracing.SpanFromContext(ctx).
 		SetTag("start", model.Time(c.Msg.Start).Time().String()).
 		SetTag("end", model.Time(c.Msg.End).Time().String()).
@@ -32,19 +98,19 @@ func (f *Frontend) SelectMergeStacktraces(ctx context.Context,
 	ctx = connectgrpc.WithProcedure(ctx, querierv1connect.QuerierServiceSelectMergeStacktracesProcedure)
 	tenantIDs, err := tenant.TenantIDs(ctx)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return false, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
 	validated, err := validation.ValidateRangeRequest(f.limits, tenantIDs, model.Interval{Start: model.Time(c.Msg.Start), End: model.Time(c.Msg.End)}, model.Now())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return false, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 	if validated.IsEmpty {
-		return connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{}), nil
+		return true, nil
 	}
 	maxNodes, err := validation.ValidateMaxNodes(f.limits, tenantIDs, c.Msg.GetMaxNodes())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return false, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
 	g, ctx := errgroup.WithContext(ctx)
@@ -52,20 +118,38 @@ func (f *Frontend) SelectMergeStacktraces(ctx context.Context,
 		g.SetLimit(maxConcurrent)
 	}
 
-	m := phlaremodel.NewFlameGraphMerger()
 	interval := validationutil.MaxDurationOrZeroPerTenant(tenantIDs, f.limits.QuerySplitDuration)
 	intervals := NewTimeIntervalIterator(time.UnixMilli(int64(validated.Start)), time.UnixMilli(int64(validated.End)), interval)
 
+	shards := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.QueryShards)
+	selectors, err := ShardSelectors(c.Msg.LabelSelector, shards)
+	if err != nil {
+		return false, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var requests []*connect.Request[querierv1.SelectMergeStacktracesRequest]
 	for intervals.Next() {
 		r := intervals.At()
+		for _, selector := range selectors {
+			requests = append(requests, connectgrpc.CloneRequest(c, &querierv1.SelectMergeStacktracesRequest{
+				ProfileTypeID:       c.Msg.ProfileTypeID,
+				LabelSelector:       selector,
+				Start:               r.Start.UnixMilli(),
+				End:                 r.End.UnixMilli(),
+				MaxNodes:            &maxNodes,
+				SampleLabelSelector: c.Msg.SampleLabelSelector,
+				CollapseRecursion:   c.Msg.CollapseRecursion,
+			}))
+		}
+	}
+
+	var (
+		mu        sync.Mutex
+		completed int
+	)
+	for _, req := range requests {
+		req := req
 		g.Go(func() error {
-			req := connectgrpc.CloneRequest(c, &querierv1.SelectMergeStacktracesRequest{
-				ProfileTypeID: c.Msg.ProfileTypeID,
-				LabelSelector: c.Msg.LabelSelector,
-				Start:         r.Start.UnixMilli(),
-				End:           r.End.UnixMilli(),
-				MaxNodes:      &maxNodes,
-			})
 			resp, err := connectgrpc.RoundTripUnary[
 				querierv1.SelectMergeStacktracesRequest,
 				querierv1.SelectMergeStacktracesResponse](ctx, f, req)
@@ -73,16 +157,18 @@ func (f *Frontend) SelectMergeStacktraces(ctx context.Context,
 				return err
 			}
 			m.MergeFlameGraph(resp.Msg.Flamegraph)
-			return nil
+			if err = f.validateQueryCost(ctx, tenantIDs); err != nil {
+				return err
+			}
+			if onMerge == nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			completed++
+			return onMerge(completed, len(requests))
 		})
 	}
 
-	if err = g.Wait(); err != nil {
-		return nil, err
-	}
-
-	t := m.Tree()
-	return connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{
-		Flamegraph: phlaremodel.NewFlameGraph(t, c.Msg.GetMaxNodes()),
-	}), nil
+	return false, g.Wait()
 }
@@ -12,6 +12,7 @@ import (
 
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
 	validationutil "github.com/grafana/pyroscope/pkg/util/validation"
@@ -46,43 +47,63 @@ func (f *Frontend) SelectSeries(ctx context.Context,
 	c.Msg.Start = int64(validated.Start)
 	c.Msg.End = int64(validated.End)
 
+	queryStats, ctx := withQueryStats(ctx)
 	g, ctx := errgroup.WithContext(ctx)
 	if maxConcurrent := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.MaxQueryParallelism); maxConcurrent > 0 {
 		g.SetLimit(maxConcurrent)
 	}
 
-	m := phlaremodel.NewSeriesMerger(false)
+	// Series of different shards may fall into the same group: their points
+	// are summed, therefore only sum aggregations can be sharded.
+	var shards int
+	if c.Msg.GetAggregation() == typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM {
+		shards = validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.QueryShards)
+	}
+	selectors, err := ShardSelectors(c.Msg.LabelSelector, shards)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Points of different time intervals never overlap, as the intervals are
+	// aligned to the step.
+	m := phlaremodel.NewSeriesMerger(len(selectors) > 1)
 	interval := validationutil.MaxDurationOrZeroPerTenant(tenantIDs, f.limits.QuerySplitDuration)
 	intervals := NewTimeIntervalIterator(time.UnixMilli(c.Msg.Start), time.UnixMilli(c.Msg.End), interval,
 		WithAlignment(time.Second*time.Duration(c.Msg.Step)))
 
 	for intervals.Next() {
 		r := intervals.At()
-		g.Go(func() error {
-			req := connectgrpc.CloneRequest(c, &querierv1.SelectSeriesRequest{
-				ProfileTypeID:      c.Msg.ProfileTypeID,
-				LabelSelector:      c.Msg.LabelSelector,
-				Start:              r.Start.UnixMilli(),
-				End:                r.End.UnixMilli(),
-				GroupBy:            c.Msg.GroupBy,
-				Step:               c.Msg.Step,
-				Aggregation:        c.Msg.Aggregation,
-				StackTraceSelector: c.Msg.StackTraceSelector,
+		for _, selector := range selectors {
+			selector := selector
+			g.Go(func() error {
+				req := connectgrpc.CloneRequest(c, &querierv1.SelectSeriesRequest{
+					ProfileTypeID:       c.Msg.ProfileTypeID,
+					LabelSelector:       selector,
+					Start:               r.Start.UnixMilli(),
+					End:                 r.End.UnixMilli(),
+					GroupBy:             c.Msg.GroupBy,
+					Step:                c.Msg.Step,
+					Aggregation:         c.Msg.Aggregation,
+					StackTraceSelector:  c.Msg.StackTraceSelector,
+					SampleLabelSelector: c.Msg.SampleLabelSelector,
+				})
+				resp, err := connectgrpc.RoundTripUnary[
+					querierv1.SelectSeriesRequest,
+					querierv1.SelectSeriesResponse](ctx, f, req)
+				if err != nil {
+					return err
+				}
+				m.MergeSeries(resp.Msg.Series)
+				return f.validateQueryCost(ctx, tenantIDs)
 			})
-			resp, err := connectgrpc.RoundTripUnary[
-				querierv1.SelectSeriesRequest,
-				querierv1.SelectSeriesResponse](ctx, f, req)
-			if err != nil {
-				return err
-			}
-			m.MergeSeries(resp.Msg.Series)
-			return nil
-		})
+		}
 	}
 
 	if err = g.Wait(); err != nil {
 		return nil, err
 	}
 
-	return connect.NewResponse(&querierv1.SelectSeriesResponse{Series: m.Series()}), nil
+	resp := connect.NewResponse(&querierv1.SelectSeriesResponse{Series: m.Series()})
+	queryStats.SetHeaders(resp.Header())
+	return resp, nil
 }
@@ -0,0 +1,49 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
+)
+
+func (f *Frontend) SelectSourceLines(ctx context.Context, c *connect.Request[querierv1.SelectSourceLinesRequest]) (*connect.Response[querierv1.SelectSourceLinesResponse], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("profile_type", c.Msg.ProfileTypeID).
+		SetTag("function_name", c.Msg.FunctionName).
+		SetTag("file_name", c.Msg.FileName)
+
+	if c.Msg.FunctionName == "" && c.Msg.FileName == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("either function_name or file_name must be specified"))
+	}
+	ctx = connectgrpc.WithProcedure(ctx, querierv1connect.QuerierServiceSelectSourceLinesProcedure)
+	queryStats, ctx := withQueryStats(ctx)
+	// The lines are aggregated from the merged profile: the query is
+	// split, limited and accounted as the pprof export is.
+	profile, err := f.SelectMergeProfile(ctx, connect.NewRequest(&querierv1.SelectMergeProfileRequest{
+		ProfileTypeID: c.Msg.ProfileTypeID,
+		LabelSelector: c.Msg.LabelSelector,
+		Start:         c.Msg.Start,
+		End:           c.Msg.End,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	lines, total := pprof.SourceLines(profile.Msg, c.Msg.FunctionName, c.Msg.FileName)
+	resp := connect.NewResponse(&querierv1.SelectSourceLinesResponse{
+		Lines: lines,
+		Total: total,
+	})
+	queryStats.SetHeaders(resp.Header())
+	return resp, nil
+}
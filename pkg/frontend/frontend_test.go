@@ -26,6 +26,7 @@ import (
 	"github.com/grafana/dskit/services"
 	"github.com/grafana/dskit/test"
 	"github.com/grafana/dskit/user"
+	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
@@ -34,8 +35,12 @@ import (
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/frontend/frontendpb"
 	"github.com/grafana/pyroscope/pkg/frontend/frontendpb/frontendpbconnect"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/querier/stats"
 	"github.com/grafana/pyroscope/pkg/scheduler/schedulerdiscovery"
 	"github.com/grafana/pyroscope/pkg/scheduler/schedulerpb"
@@ -480,3 +485,156 @@ func checkStreamGoroutines() int {
 	goroutineStacks := string(buf[:stacklen])
 	return strings.Count(goroutineStacks, streamGoroutineStackFrameTrailer)
 }
+
+func TestFrontendQueryCostLimits(t *testing.T) {
+	const userID = "test"
+	f, _ := setupFrontend(t, nil, func(f *Frontend, msg *schedulerpb.FrontendToScheduler) *schedulerpb.SchedulerToFrontend {
+		go func() {
+			ctx := user.InjectOrgID(context.Background(), userID)
+			_, _ = f.QueryResult(ctx, connect.NewRequest(&frontendpb.QueryResultRequest{
+				QueryID:      msg.QueryID,
+				HttpResponse: &httpgrpc.HTTPResponse{Code: 200},
+				Stats: &stats.Stats{
+					FetchedProfilesCount:     3,
+					ReadBytes:                1024,
+					ScannedRows:              100,
+					ResolvedStacktracesCount: 42,
+				},
+			}))
+		}()
+		return &schedulerpb.SchedulerToFrontend{Status: schedulerpb.SchedulerToFrontendStatus_OK}
+	})
+
+	_, ctx := opentracing.StartSpanFromContext(user.InjectOrgID(context.Background(), userID), "test")
+	req := connect.NewRequest(&querierv1.SelectSeriesRequest{
+		LabelSelector: "{}",
+		Start:         0,
+		End:           time.Hour.Milliseconds(),
+		Step:          15,
+	})
+
+	t.Run("stats are returned in headers", func(t *testing.T) {
+		f.limits = validation.MockLimits{}
+		resp, err := f.SelectSeries(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "3", resp.Header().Get(stats.FetchedProfilesHeader))
+		assert.Equal(t, "1024", resp.Header().Get(stats.ReadBytesHeader))
+		assert.Equal(t, "100", resp.Header().Get(stats.ScannedRowsHeader))
+		assert.Equal(t, "42", resp.Header().Get(stats.ResolvedStacktracesHeader))
+	})
+
+	t.Run("query cost limit exceeded", func(t *testing.T) {
+		f.limits = validation.MockLimits{MaxQueryProfilesValue: 2}
+		_, err := f.SelectSeries(ctx, req)
+		require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+		require.Equal(t, validation.QueryLimit, validation.ReasonOf(err))
+	})
+}
+
+func TestFrontendSelectMergeStacktracesStream(t *testing.T) {
+	const userID = "test"
+	tree := new(phlaremodel.Tree)
+	tree.InsertStack(1, "b", "a")
+	body, err := (&querierv1.SelectMergeStacktracesResponse{
+		Flamegraph: phlaremodel.NewFlameGraph(tree, -1),
+	}).MarshalVT()
+	require.NoError(t, err)
+
+	f, _ := setupFrontend(t, nil, func(f *Frontend, msg *schedulerpb.FrontendToScheduler) *schedulerpb.SchedulerToFrontend {
+		go func() {
+			ctx := user.InjectOrgID(context.Background(), userID)
+			_, _ = f.QueryResult(ctx, connect.NewRequest(&frontendpb.QueryResultRequest{
+				QueryID:      msg.QueryID,
+				HttpResponse: &httpgrpc.HTTPResponse{Code: 200, Body: body},
+				Stats:        &stats.Stats{FetchedProfilesCount: 1},
+			}))
+		}()
+		return &schedulerpb.SchedulerToFrontend{Status: schedulerpb.SchedulerToFrontendStatus_OK}
+	})
+	f.limits = validation.MockLimits{
+		MaxQueryParallelismValue: 1,
+		QuerySplitDurationValue:  time.Hour,
+	}
+
+	_, handler := querierv1connect.NewQuerierServiceHandler(f)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ctx := opentracing.StartSpanFromContext(user.InjectOrgID(r.Context(), userID), "test")
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(s.Close)
+
+	client := querierv1connect.NewQuerierServiceClient(http.DefaultClient, s.URL)
+	stream, err := client.SelectMergeStacktracesStream(context.Background(), connect.NewRequest(&querierv1.SelectMergeStacktracesRequest{
+		ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+		LabelSelector: "{}",
+		Start:         0,
+		End:           3 * time.Hour.Milliseconds(),
+	}))
+	require.NoError(t, err)
+
+	var responses []*querierv1.SelectMergeStacktracesStreamResponse
+	for stream.Receive() {
+		responses = append(responses, stream.Msg())
+	}
+	require.NoError(t, stream.Err())
+	require.NoError(t, stream.Close())
+
+	require.Len(t, responses, 3)
+	for i, resp := range responses {
+		assert.Equal(t, int64(i+1), resp.Completed)
+		assert.Equal(t, int64(3), resp.Total)
+		assert.Equal(t, int64(i+1), resp.Flamegraph.Total)
+		assert.Equal(t, i == 2, resp.Final)
+	}
+	assert.Equal(t, "3", stream.ResponseTrailer().Get(stats.FetchedProfilesHeader))
+}
+
+func TestFrontendSelectSeriesQueryShards(t *testing.T) {
+	const userID = "test"
+	body, err := (&querierv1.SelectSeriesResponse{
+		Series: []*typesv1.Series{{
+			Labels: []*typesv1.LabelPair{{Name: "service_name", Value: "a"}},
+			Points: []*typesv1.Point{{Timestamp: 0, Value: 1}},
+		}},
+	}).MarshalVT()
+	require.NoError(t, err)
+
+	var queries atomic.Int64
+	f, _ := setupFrontend(t, nil, func(f *Frontend, msg *schedulerpb.FrontendToScheduler) *schedulerpb.SchedulerToFrontend {
+		queries.Inc()
+		go func() {
+			ctx := user.InjectOrgID(context.Background(), userID)
+			_, _ = f.QueryResult(ctx, connect.NewRequest(&frontendpb.QueryResultRequest{
+				QueryID:      msg.QueryID,
+				HttpResponse: &httpgrpc.HTTPResponse{Code: 200, Body: body},
+			}))
+		}()
+		return &schedulerpb.SchedulerToFrontend{Status: schedulerpb.SchedulerToFrontendStatus_OK}
+	})
+	f.limits = validation.MockLimits{QueryShardsValue: 4}
+
+	_, ctx := opentracing.StartSpanFromContext(user.InjectOrgID(context.Background(), userID), "test")
+	selectSeries := func(aggregation typesv1.TimeSeriesAggregationType) float64 {
+		queries.Store(0)
+		resp, err := f.SelectSeries(ctx, connect.NewRequest(&querierv1.SelectSeriesRequest{
+			LabelSelector: "{}",
+			Start:         0,
+			End:           time.Hour.Milliseconds(),
+			Step:          15,
+			GroupBy:       []string{"service_name"},
+			Aggregation:   &aggregation,
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Series, 1)
+		require.Len(t, resp.Msg.Series[0].Points, 1)
+		return resp.Msg.Series[0].Points[0].Value
+	}
+
+	// The points of the series of all the shards are summed.
+	assert.Equal(t, float64(4), selectSeries(typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM))
+	assert.Equal(t, int64(4), queries.Load())
+
+	// Averages can't be merged, the query is not sharded.
+	assert.Equal(t, float64(1), selectSeries(typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_AVERAGE))
+	assert.Equal(t, int64(1), queries.Load())
+}
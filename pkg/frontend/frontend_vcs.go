@@ -17,6 +17,14 @@ func (f *Frontend) GithubLogin(ctx context.Context, req *connect.Request[vcsv1.G
 	return connectgrpc.RoundTripUnary[vcsv1.GithubLoginRequest, vcsv1.GithubLoginResponse](ctx, f, req)
 }
 
+func (f *Frontend) GitlabApp(ctx context.Context, req *connect.Request[vcsv1.GitlabAppRequest]) (*connect.Response[vcsv1.GitlabAppResponse], error) {
+	return connectgrpc.RoundTripUnary[vcsv1.GitlabAppRequest, vcsv1.GitlabAppResponse](ctx, f, req)
+}
+
+func (f *Frontend) GitlabLogin(ctx context.Context, req *connect.Request[vcsv1.GitlabLoginRequest]) (*connect.Response[vcsv1.GitlabLoginResponse], error) {
+	return connectgrpc.RoundTripUnary[vcsv1.GitlabLoginRequest, vcsv1.GitlabLoginResponse](ctx, f, req)
+}
+
 func (f *Frontend) GetFile(ctx context.Context, req *connect.Request[vcsv1.GetFileRequest]) (*connect.Response[vcsv1.GetFileResponse], error) {
 	return connectgrpc.RoundTripUnary[vcsv1.GetFileRequest, vcsv1.GetFileResponse](ctx, f, req)
 }
@@ -0,0 +1,55 @@
+package frontend
+
+import (
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/pyroscope/pkg/phlaredb/sharding"
+)
+
+// ShardSelectors returns a label selector for each of the query shards:
+// the original selector is extended with the query shard matcher, so that
+// ingesters and store-gateways only scan series that belong to the shard.
+//
+// If the number of shards is less than two, or the selector already
+// references a shard, the original selector is returned as is.
+func ShardSelectors(selector string, shards int) ([]string, error) {
+	if shards < 2 {
+		return []string{selector}, nil
+	}
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	shard, _, err := sharding.ShardFromMatchers(matchers)
+	if err != nil {
+		return nil, err
+	}
+	if shard != nil {
+		return []string{selector}, nil
+	}
+	selectors := make([]string, shards)
+	sharded := make([]*labels.Matcher, len(matchers)+1)
+	copy(sharded, matchers)
+	for i := range selectors {
+		s := sharding.ShardSelector{ShardIndex: uint64(i), ShardCount: uint64(shards)}
+		sharded[len(matchers)] = s.Matcher()
+		selectors[i] = matchersString(sharded)
+	}
+	return selectors, nil
+}
+
+func matchersString(matchers []*labels.Matcher) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, m := range matchers {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(m.String())
+	}
+	b.WriteByte('}')
+	return b.String()
+}
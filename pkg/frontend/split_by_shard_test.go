@@ -0,0 +1,65 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShardSelectors(t *testing.T) {
+	type testCase struct {
+		description string
+		selector    string
+		shards      int
+		expected    []string
+	}
+
+	testCases := []testCase{
+		{
+			description: "sharding disabled",
+			selector:    `{service_name="foo"}`,
+			shards:      0,
+			expected:    []string{`{service_name="foo"}`},
+		},
+		{
+			description: "single shard",
+			selector:    `{service_name="foo"}`,
+			shards:      1,
+			expected:    []string{`{service_name="foo"}`},
+		},
+		{
+			description: "empty selector",
+			selector:    `{}`,
+			shards:      2,
+			expected: []string{
+				`{__query_shard__="1_of_2"}`,
+				`{__query_shard__="2_of_2"}`,
+			},
+		},
+		{
+			description: "multiple matchers",
+			selector:    `{service_name="foo",env=~"prod|dev"}`,
+			shards:      3,
+			expected: []string{
+				`{service_name="foo",env=~"prod|dev",__query_shard__="1_of_3"}`,
+				`{service_name="foo",env=~"prod|dev",__query_shard__="2_of_3"}`,
+				`{service_name="foo",env=~"prod|dev",__query_shard__="3_of_3"}`,
+			},
+		},
+		{
+			description: "already sharded",
+			selector:    `{service_name="foo",__query_shard__="1_of_2"}`,
+			shards:      4,
+			expected:    []string{`{service_name="foo",__query_shard__="1_of_2"}`},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := ShardSelectors(tc.selector, tc.shards)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
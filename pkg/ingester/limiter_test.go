@@ -31,6 +31,10 @@ func (f *fakeLimits) IngestionTenantShardSize(userID string) int {
 	return f.ingestionTenantShardSize
 }
 
+func (f *fakeLimits) MaxQueryBytes(userID string) int {
+	return 0
+}
+
 type fakeRingCount struct {
 	healthyInstancesCount int
 }
@@ -2,11 +2,15 @@ package ingester
 
 import (
 	"context"
+	"net/http"
 
 	"connectrpc.com/connect"
 
 	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb"
+	"github.com/grafana/pyroscope/pkg/phlaredb/query"
+	"github.com/grafana/pyroscope/pkg/querier/stats"
 )
 
 // LabelValues returns the possible label values for a given label name.
@@ -46,24 +50,41 @@ func (i *Ingester) BlockMetadata(ctx context.Context, req *connect.Request[inges
 
 func (i *Ingester) MergeProfilesStacktraces(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeProfilesStacktracesRequest, ingestv1.MergeProfilesStacktracesResponse]) error {
 	return i.forInstance(ctx, func(instance *instance) error {
-		return instance.MergeProfilesStacktraces(ctx, stream)
+		return i.limitReads(ctx, instance, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return instance.MergeProfilesStacktraces(ctx, stream)
+		})
 	})
 }
 
 func (i *Ingester) MergeProfilesLabels(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeProfilesLabelsRequest, ingestv1.MergeProfilesLabelsResponse]) error {
 	return i.forInstance(ctx, func(instance *instance) error {
-		return instance.MergeProfilesLabels(ctx, stream)
+		return i.limitReads(ctx, instance, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return instance.MergeProfilesLabels(ctx, stream)
+		})
 	})
 }
 
 func (i *Ingester) MergeProfilesPprof(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeProfilesPprofRequest, ingestv1.MergeProfilesPprofResponse]) error {
 	return i.forInstance(ctx, func(instance *instance) error {
-		return instance.MergeProfilesPprof(ctx, stream)
+		return i.limitReads(ctx, instance, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return instance.MergeProfilesPprof(ctx, stream)
+		})
 	})
 }
 
 func (i *Ingester) MergeSpanProfile(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeSpanProfileRequest, ingestv1.MergeSpanProfileResponse]) error {
 	return i.forInstance(ctx, func(instance *instance) error {
-		return instance.MergeSpanProfile(ctx, stream)
+		return i.limitReads(ctx, instance, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return instance.MergeSpanProfile(ctx, stream)
+		})
 	})
 }
+
+// limitReads enforces the max_query_bytes limit of the tenant on the
+// query, and reports the data read and the stack traces resolved to the
+// querier in the response trailer.
+func (i *Ingester) limitReads(ctx context.Context, instance *instance, trailer http.Header, fn func(context.Context) error) error {
+	return phlaredb.LimitReads(ctx, i.limits, instance.tenantID, func(r *query.ReadStats) {
+		stats.SetReadHeaders(trailer, r.Bytes(), r.Rows(), r.Stacktraces())
+	}, fn)
+}
@@ -185,8 +185,9 @@ func NewFlameGraphMerger() *FlameGraphMerger {
 }
 
 // MergeFlameGraph adds the flame graph stack traces to the resulting
-// flame graph. The call is thread-safe, but the resulting flame graph
-// or tree should be only accessed after all the samples are merged.
+// flame graph. The call is thread-safe, but the resulting tree should
+// be only accessed after all the samples are merged. FlameGraph can be
+// called concurrently to obtain the partial result.
 func (m *FlameGraphMerger) MergeFlameGraph(src *querierv1.FlameGraph) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -210,6 +211,8 @@ func (m *FlameGraphMerger) MergeFlameGraph(src *querierv1.FlameGraph) {
 func (m *FlameGraphMerger) Tree() *Tree { return m.t }
 
 func (m *FlameGraphMerger) FlameGraph(maxNodes int64) *querierv1.FlameGraph {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	t := m.t
 	if t == nil {
 		t = new(Tree)
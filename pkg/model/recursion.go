@@ -0,0 +1,64 @@
+package model
+
+import (
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// RecursionCollapser removes recursive calls from stack traces.
+// The zero value does not modify stack traces.
+// RecursionCollapser is not safe for concurrent use.
+type RecursionCollapser[K comparable] struct {
+	mode typesv1.RecursionCollapse
+	seen map[K]int
+}
+
+func NewRecursionCollapser[K comparable](mode typesv1.RecursionCollapse) *RecursionCollapser[K] {
+	return &RecursionCollapser[K]{mode: mode, seen: make(map[K]int)}
+}
+
+// Enabled reports whether the collapser modifies stack traces.
+func (c *RecursionCollapser[K]) Enabled() bool {
+	return c != nil && c.mode != typesv1.RecursionCollapse_RECURSION_COLLAPSE_NONE
+}
+
+// CollapseRecursion removes recursive calls from the stack trace in place, and
+// returns the resulting stack trace. The stack trace root is at stack[0].
+// Frames of the same function must have the same key.
+//
+// In the direct mode, consecutive frames of the same function are
+// replaced with the first one. In the indirect mode, if a function is
+// called again, all the frames after its first call are removed:
+// a;b;a;b;c is collapsed to a;b;c.
+func CollapseRecursion[T any, K comparable](c *RecursionCollapser[K], stack []T, key func(T) K) []T {
+	if !c.Enabled() || len(stack) < 2 {
+		return stack
+	}
+	if c.mode == typesv1.RecursionCollapse_RECURSION_COLLAPSE_DIRECT {
+		j := 1
+		for i := 1; i < len(stack); i++ {
+			if key(stack[i]) != key(stack[j-1]) {
+				stack[j] = stack[i]
+				j++
+			}
+		}
+		return stack[:j]
+	}
+	for k := range c.seen {
+		delete(c.seen, k)
+	}
+	j := 0
+	for i := range stack {
+		k := key(stack[i])
+		if p, ok := c.seen[k]; ok {
+			for _, x := range stack[p+1 : j] {
+				delete(c.seen, key(x))
+			}
+			j = p + 1
+			continue
+		}
+		c.seen[k] = j
+		stack[j] = stack[i]
+		j++
+	}
+	return stack[:j]
+}
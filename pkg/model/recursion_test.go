@@ -0,0 +1,46 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_CollapseRecursion(t *testing.T) {
+	type testCase struct {
+		mode     typesv1.RecursionCollapse
+		input    string
+		expected string
+	}
+
+	const (
+		none     = typesv1.RecursionCollapse_RECURSION_COLLAPSE_NONE
+		direct   = typesv1.RecursionCollapse_RECURSION_COLLAPSE_DIRECT
+		indirect = typesv1.RecursionCollapse_RECURSION_COLLAPSE_INDIRECT
+	)
+
+	testCases := []testCase{
+		{mode: none, input: "a;b;b;c", expected: "a;b;b;c"},
+		{mode: direct, input: "a", expected: "a"},
+		{mode: direct, input: "a;b;b;b;c", expected: "a;b;c"},
+		{mode: direct, input: "a;a;b;a;a", expected: "a;b;a"},
+		{mode: direct, input: "a;b;a;b;c", expected: "a;b;a;b;c"},
+		{mode: indirect, input: "a;b;b;b;c", expected: "a;b;c"},
+		{mode: indirect, input: "a;b;a;b;c", expected: "a;b;c"},
+		{mode: indirect, input: "a;b;c;b;d;c", expected: "a;b;d;c"},
+		{mode: indirect, input: "a;b;c;d", expected: "a;b;c;d"},
+	}
+
+	identity := func(s string) string { return s }
+	for _, tc := range testCases {
+		c := NewRecursionCollapser[string](tc.mode)
+		// The collapser is reused across stack traces.
+		for i := 0; i < 2; i++ {
+			actual := CollapseRecursion(c, strings.Split(tc.input, ";"), identity)
+			assert.Equal(t, tc.expected, strings.Join(actual, ";"), tc.input)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/dns"
@@ -30,19 +31,24 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"gopkg.in/yaml.v3"
 
+	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
 	statusv1 "github.com/grafana/pyroscope/api/gen/proto/go/status/v1"
 	"github.com/grafana/pyroscope/pkg/adhocprofiles"
 	apiversion "github.com/grafana/pyroscope/pkg/api/version"
 	"github.com/grafana/pyroscope/pkg/compactor"
+	"github.com/grafana/pyroscope/pkg/debuginfo"
 	"github.com/grafana/pyroscope/pkg/distributor"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
 	"github.com/grafana/pyroscope/pkg/frontend"
 	"github.com/grafana/pyroscope/pkg/ingester"
 	objstoreclient "github.com/grafana/pyroscope/pkg/objstore/client"
 	"github.com/grafana/pyroscope/pkg/objstore/providers/filesystem"
 	"github.com/grafana/pyroscope/pkg/operations"
 	phlarecontext "github.com/grafana/pyroscope/pkg/phlare/context"
+	"github.com/grafana/pyroscope/pkg/phlaredb"
 	"github.com/grafana/pyroscope/pkg/querier"
 	"github.com/grafana/pyroscope/pkg/querier/worker"
+	"github.com/grafana/pyroscope/pkg/savedviews"
 	"github.com/grafana/pyroscope/pkg/scheduler"
 	"github.com/grafana/pyroscope/pkg/settings"
 	"github.com/grafana/pyroscope/pkg/storegateway"
@@ -76,7 +82,10 @@ const (
 	Compactor         string = "compactor"
 	Admin             string = "admin"
 	TenantSettings    string = "tenant-settings"
+	SavedViews        string = "saved-views"
 	AdHocProfiles     string = "ad-hoc-profiles"
+	DebugInfo         string = "debuginfo"
+	Symbolizer        string = "symbolizer"
 
 	// QueryFrontendTripperware string = "query-frontend-tripperware"
 	// IndexGateway             string = "index-gateway"
@@ -104,7 +113,7 @@ func (f *Phlare) initQueryFrontend() (services.Service, error) {
 		return nil, err
 	}
 
-	f.API.RegisterPyroscopeHandlers(frontendSvc)
+	f.API.RegisterPyroscopeHandlers(querier.NewHandlerClient(frontendSvc), f.viewResolver())
 	f.API.RegisterQueryFrontend(frontendSvc)
 	f.API.RegisterQuerier(frontendSvc)
 
@@ -160,17 +169,80 @@ func (f *Phlare) initTenantSettings() (services.Service, error) {
 	return settings, nil
 }
 
+func (f *Phlare) initSavedViews() (services.Service, error) {
+	v := f.getSavedViews()
+	if v == nil {
+		level.Warn(f.logger).Log("msg", "no storage bucket configured, saved views are disabled")
+		return nil, nil
+	}
+	f.API.RegisterSavedViews(v)
+	return v, nil
+}
+
+// getSavedViews returns the saved views service, which is shared by the
+// saved views API and the render endpoints resolving the views. The views
+// are only kept in the storage bucket: it returns nil if none is configured.
+func (f *Phlare) getSavedViews() *savedviews.SavedViews {
+	if f.savedViews == nil && f.storageBucket != nil {
+		f.savedViews = savedviews.New(savedviews.NewBucketStore(f.storageBucket))
+	}
+	return f.savedViews
+}
+
+// viewResolver returns the saved views resolver of the render endpoints,
+// or nil if saved views are disabled.
+func (f *Phlare) viewResolver() querier.ViewResolver {
+	if v := f.getSavedViews(); v != nil {
+		return v
+	}
+	return nil
+}
+
 func (f *Phlare) initAdHocProfiles() (services.Service, error) {
 	if f.storageBucket == nil {
 		level.Warn(f.logger).Log("msg", "no storage bucket configured, ad hoc profiles will not be loaded")
 		return nil, nil
 	}
 
-	a := adhocprofiles.NewAdHocProfiles(f.storageBucket, f.logger, f.Overrides)
+	// Profiles are imported by the distributor, if it runs in the same
+	// process, otherwise importing them is not supported. It is not a
+	// dependency of the module, and it may be initialised after it, therefore
+	// it is resolved when a profile is imported.
+	var pusher adhocprofiles.PushService
+	if f.isModuleActive(Distributor) {
+		pusher = adhocprofiles.PushServiceFunc(func(ctx context.Context, req *distributormodel.PushRequest) (*connect.Response[pushv1.PushResponse], error) {
+			return f.distributor.PushParsed(ctx, req)
+		})
+	}
+	a := adhocprofiles.NewAdHocProfiles(f.storageBucket, f.logger, f.Overrides, pusher)
 	f.API.RegisterAdHocProfiles(a)
 	return a, nil
 }
 
+func (f *Phlare) initDebugInfo() (services.Service, error) {
+	if f.storageBucket == nil {
+		level.Warn(f.logger).Log("msg", "no storage bucket configured, debug info upload is disabled")
+		return nil, nil
+	}
+
+	d := debuginfo.New(f.Cfg.DebugInfo, f.storageBucket, log.With(f.logger, "component", DebugInfo))
+	f.API.RegisterDebugInfo(d)
+	return d, nil
+}
+
+func (f *Phlare) initSymbolizer() (services.Service, error) {
+	if f.storageBucket == nil {
+		return nil, nil
+	}
+
+	s, err := debuginfo.NewSymbolizer(f.Cfg.DebugInfo, f.storageBucket, log.With(f.logger, "component", Symbolizer))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init symbolizer")
+	}
+	f.symbolizer = s
+	return nil, nil
+}
+
 func (f *Phlare) initOverrides() (serv services.Service, err error) {
 	f.Overrides, err = validation.NewOverrides(f.Cfg.LimitsConfig, f.TenantLimits)
 	// overrides don't have operational state, nor do they need to do anything more in starting/stopping phase,
@@ -258,13 +330,13 @@ func (f *Phlare) initQuerier() (services.Service, error) {
 		}
 	}
 
-	querierSvc, err := querier.New(f.Cfg.Querier, f.ring, nil, storeGatewayQuerier, f.reg, log.With(f.logger, "component", "querier"), f.auth)
+	querierSvc, err := querier.New(f.Cfg.Querier, f.ring, nil, storeGatewayQuerier, f.Overrides, f.reg, log.With(f.logger, "component", "querier"), f.auth)
 	if err != nil {
 		return nil, err
 	}
 
 	if !f.isModuleActive(QueryFrontend) {
-		f.API.RegisterPyroscopeHandlers(querierSvc)
+		f.API.RegisterPyroscopeHandlers(querier.NewHandlerClient(querierSvc), f.viewResolver())
 		f.API.RegisterQuerier(querierSvc)
 	}
 	worker, err := worker.NewQuerierWorker(f.Cfg.Worker, querier.NewGRPCHandler(querierSvc), log.With(f.logger, "component", "querier-worker"), f.reg)
@@ -320,6 +392,7 @@ func (f *Phlare) initDistributor() (services.Service, error) {
 		return nil, err
 	}
 
+	f.distributor = d
 	f.API.RegisterDistributor(d)
 	return d, nil
 }
@@ -400,7 +473,8 @@ func (f *Phlare) context() context.Context {
 func (f *Phlare) initIngester() (_ services.Service, err error) {
 	f.Cfg.Ingester.LifecyclerConfig.ListenPort = f.Cfg.Server.HTTPListenPort
 
-	svc, err := ingester.New(f.context(), f.Cfg.Ingester, f.Cfg.PhlareDB, f.storageBucket, f.Overrides, f.Cfg.Querier.QueryStoreAfter)
+	phlarectx := phlaredb.ContextWithSymbolizer(f.context(), f.symbolizer)
+	svc, err := ingester.New(phlarectx, f.Cfg.Ingester, f.Cfg.PhlareDB, f.storageBucket, f.Overrides, f.Cfg.Querier.QueryStoreAfter)
 	if err != nil {
 		return nil, err
 	}
@@ -416,7 +490,7 @@ func (f *Phlare) initStoreGateway() (serv services.Service, err error) {
 		return nil, nil
 	}
 
-	svc, err := storegateway.NewStoreGateway(f.Cfg.StoreGateway, f.storageBucket, f.Overrides, f.logger, f.reg)
+	svc, err := storegateway.NewStoreGateway(f.Cfg.StoreGateway, f.storageBucket, f.Overrides, f.symbolizer, f.logger, f.reg)
 	if err != nil {
 		return nil, err
 	}
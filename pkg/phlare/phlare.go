@@ -41,6 +41,7 @@ import (
 	apiversion "github.com/grafana/pyroscope/pkg/api/version"
 	"github.com/grafana/pyroscope/pkg/cfg"
 	"github.com/grafana/pyroscope/pkg/compactor"
+	"github.com/grafana/pyroscope/pkg/debuginfo"
 	"github.com/grafana/pyroscope/pkg/distributor"
 	"github.com/grafana/pyroscope/pkg/frontend"
 	"github.com/grafana/pyroscope/pkg/ingester"
@@ -49,8 +50,10 @@ import (
 	"github.com/grafana/pyroscope/pkg/operations"
 	phlarecontext "github.com/grafana/pyroscope/pkg/phlare/context"
 	"github.com/grafana/pyroscope/pkg/phlaredb"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 	"github.com/grafana/pyroscope/pkg/querier"
 	"github.com/grafana/pyroscope/pkg/querier/worker"
+	"github.com/grafana/pyroscope/pkg/savedviews"
 	"github.com/grafana/pyroscope/pkg/scheduler"
 	"github.com/grafana/pyroscope/pkg/scheduler/schedulerdiscovery"
 	"github.com/grafana/pyroscope/pkg/storegateway"
@@ -81,6 +84,7 @@ type Config struct {
 	OverridesExporter exporter.Config        `yaml:"overrides_exporter" doc:"hidden"`
 	RuntimeConfig     runtimeconfig.Config   `yaml:"runtime_config"`
 	Compactor         compactor.Config       `yaml:"compactor"`
+	DebugInfo         debuginfo.Config       `yaml:"debuginfo"`
 
 	Storage       StorageConfig       `yaml:"storage"`
 	SelfProfiling SelfProfilingConfig `yaml:"self_profiling,omitempty"`
@@ -146,6 +150,7 @@ func (c *Config) RegisterFlagsWithContext(ctx context.Context, f *flag.FlagSet)
 	c.Analytics.RegisterFlags(f)
 	c.LimitsConfig.RegisterFlags(f)
 	c.Compactor.RegisterFlags(f, log.NewLogfmtLogger(os.Stderr))
+	c.DebugInfo.RegisterFlags(f)
 	c.API.RegisterFlags(f)
 }
 
@@ -227,12 +232,15 @@ type Phlare struct {
 	RuntimeConfig *runtimeconfig.Manager
 	Overrides     *validation.Overrides
 	Compactor     *compactor.MultitenantCompactor
+	distributor   *distributor.Distributor
 	admin         *operations.Admin
 	versions      *apiversion.Service
+	savedViews    *savedviews.SavedViews
 
 	TenantLimits validation.TenantLimits
 
 	storageBucket phlareobj.Bucket
+	symbolizer    symdb.Symbolizer
 
 	grpcGatewayMux *grpcgw.ServeMux
 
@@ -302,20 +310,23 @@ func (f *Phlare) setupModuleManager() error {
 	mm.RegisterModule(Admin, f.initAdmin)
 	mm.RegisterModule(All, nil)
 	mm.RegisterModule(TenantSettings, f.initTenantSettings)
+	mm.RegisterModule(SavedViews, f.initSavedViews)
 	mm.RegisterModule(AdHocProfiles, f.initAdHocProfiles)
+	mm.RegisterModule(DebugInfo, f.initDebugInfo)
+	mm.RegisterModule(Symbolizer, f.initSymbolizer, modules.UserInvisibleModule)
 
 	// Add dependencies
 	deps := map[string][]string{
-		All: {Ingester, Distributor, QueryScheduler, QueryFrontend, Querier, StoreGateway, Admin, TenantSettings, Compactor, AdHocProfiles},
+		All: {Ingester, Distributor, QueryScheduler, QueryFrontend, Querier, StoreGateway, Admin, TenantSettings, SavedViews, Compactor, AdHocProfiles, DebugInfo},
 
 		Server:            {GRPCGateway},
 		API:               {Server},
 		Distributor:       {Overrides, Ring, API, UsageReport},
-		Querier:           {Overrides, API, MemberlistKV, Ring, UsageReport, Version},
-		QueryFrontend:     {OverridesExporter, API, MemberlistKV, UsageReport, Version},
+		Querier:           {Overrides, API, MemberlistKV, Ring, Storage, UsageReport, Version},
+		QueryFrontend:     {OverridesExporter, API, MemberlistKV, Storage, UsageReport, Version},
 		QueryScheduler:    {Overrides, API, MemberlistKV, UsageReport},
-		Ingester:          {Overrides, API, MemberlistKV, Storage, UsageReport, Version},
-		StoreGateway:      {API, Storage, Overrides, MemberlistKV, UsageReport, Admin, Version},
+		Ingester:          {Overrides, API, MemberlistKV, Storage, Symbolizer, UsageReport, Version},
+		StoreGateway:      {API, Storage, Symbolizer, Overrides, MemberlistKV, UsageReport, Admin, Version},
 		Compactor:         {API, Storage, Overrides, MemberlistKV, UsageReport},
 		UsageReport:       {Storage, MemberlistKV},
 		Overrides:         {RuntimeConfig},
@@ -326,7 +337,10 @@ func (f *Phlare) setupModuleManager() error {
 		Admin:             {API, Storage},
 		Version:           {API, MemberlistKV},
 		TenantSettings:    {API, Storage},
+		SavedViews:        {API, Storage},
 		AdHocProfiles:     {API, Overrides, Storage},
+		DebugInfo:         {API, Storage},
+		Symbolizer:        {Storage},
 	}
 
 	for mod, targets := range deps {
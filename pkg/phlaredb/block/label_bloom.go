@@ -0,0 +1,81 @@
+package block
+
+import (
+	"sort"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/parquet-go/parquet-go/bloom"
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// labelBloomBitsPerValue gives a false positive rate of about 1%.
+const labelBloomBitsPerValue = 10
+
+// LabelBloom is a bloom filter over the values of some labels of the series
+// of a block. It tells whether a block may contain series with a given label
+// value without opening the block index.
+type LabelBloom struct {
+	// Names of the labels which values are in the filter.
+	Names  []string `json:"names"`
+	Filter []byte   `json:"filter"`
+}
+
+// NewLabelBloom returns a bloom filter over the values of the labels, keyed
+// by label name. Empty values are not added, as series without the label
+// match them.
+func NewLabelBloom(values map[string][]string) *LabelBloom {
+	b := &LabelBloom{Names: make([]string, 0, len(values))}
+	var n int64
+	for name, v := range values {
+		b.Names = append(b.Names, name)
+		n += int64(len(v))
+	}
+	sort.Strings(b.Names)
+	numBlocks := bloom.NumSplitBlocksOf(n, labelBloomBitsPerValue)
+	if numBlocks == 0 {
+		numBlocks = 1
+	}
+	filter := make(bloom.SplitBlockFilter, numBlocks)
+	for name, vs := range values {
+		for _, v := range vs {
+			if v != "" {
+				filter.Insert(labelBloomHash(name, v))
+			}
+		}
+	}
+	b.Filter = filter.Bytes()
+	return b
+}
+
+// MayMatch returns false if no series of the block can match the equality
+// matchers of the labels in the filter. A nil filter matches everything.
+func (b *LabelBloom) MayMatch(matchers ...*labels.Matcher) bool {
+	if b == nil {
+		return true
+	}
+	filter := bloom.MakeSplitBlockFilter(b.Filter)
+	if len(filter) == 0 {
+		return true
+	}
+	for _, m := range matchers {
+		if m.Type != labels.MatchEqual || m.Value == "" {
+			continue
+		}
+		i := sort.SearchStrings(b.Names, m.Name)
+		if i == len(b.Names) || b.Names[i] != m.Name {
+			continue
+		}
+		if !filter.Check(labelBloomHash(m.Name, m.Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+func labelBloomHash(name, value string) uint64 {
+	h := xxhash.New()
+	_, _ = h.WriteString(name)
+	_, _ = h.Write([]byte{0xff})
+	_, _ = h.WriteString(value)
+	return h.Sum64()
+}
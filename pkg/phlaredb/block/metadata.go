@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/multierror"
@@ -17,10 +18,12 @@ import (
 	"github.com/oklog/ulid"
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/tsdb"
 	"github.com/prometheus/prometheus/tsdb/fileutil"
 
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/sharding"
 )
 
 const (
@@ -155,6 +158,59 @@ type Meta struct {
 
 	// Downsample is a downsampling resolution of the block. 0 means no downsampling.
 	Downsample `json:"downsample"`
+
+	// LabelSplit is the label the series were split by during compaction,
+	// and the range of its values in the block. It is only set by compactors
+	// splitting blocks by label.
+	LabelSplit *LabelSplit `json:"labelSplit,omitempty"`
+
+	// LabelBloom is a bloom filter over the values of the labels the queries
+	// filter by, e.g. the service name and the profile type.
+	LabelBloom *LabelBloom `json:"labelBloom,omitempty"`
+}
+
+// MayMatch returns false if the label summaries of the block show that no
+// series of the block can match the matchers.
+func (m *Meta) MayMatch(matchers ...*labels.Matcher) bool {
+	return m.LabelSplit.MayMatch(m.Labels[sharding.CompactorShardIDLabel], matchers...) &&
+		m.LabelBloom.MayMatch(matchers...)
+}
+
+// LabelSplit describes how the series were split into the shards of a
+// compaction: a series belongs to the shard xxhash(value) % Shards, where
+// value is the value of the label, empty if the series doesn't have it.
+// Min and Max are the lowest and the highest values of the label the series
+// of the block have.
+type LabelSplit struct {
+	Name   string `json:"name"`
+	Shards uint64 `json:"shards"`
+	Min    string `json:"min,omitempty"`
+	Max    string `json:"max,omitempty"`
+}
+
+// MayMatch returns false if the equality matchers of the label select a
+// value outside of the value range of the block, or a value that belongs to
+// a shard other than the one of the block, given by its compactor shard ID
+// label. A nil split matches everything.
+func (s *LabelSplit) MayMatch(shardID string, matchers ...*labels.Matcher) bool {
+	if s == nil || s.Shards == 0 {
+		return true
+	}
+	index, count, err := sharding.ParseShardIDLabelValue(shardID)
+	sharded := err == nil && count == s.Shards
+	for _, m := range matchers {
+		if m.Name != s.Name || m.Type != labels.MatchEqual {
+			continue
+		}
+		// Blocks written before the range was recorded have no max.
+		if s.Max != "" && (m.Value < s.Min || m.Value > s.Max) {
+			return false
+		}
+		if sharded && xxhash.Sum64String(m.Value)%s.Shards != index {
+			return false
+		}
+	}
+	return true
 }
 
 type Downsample struct {
@@ -223,6 +279,46 @@ func (m *Meta) WriteBlockInfo(info *typesv1.BlockInfo) {
 			Value: v,
 		})
 	}
+	info.LabelSplit = nil
+	if s := m.LabelSplit; s != nil {
+		info.LabelSplit = &typesv1.BlockLabelSplit{
+			Name:   s.Name,
+			Shards: s.Shards,
+			Min:    s.Min,
+			Max:    s.Max,
+		}
+	}
+	info.LabelBloom = nil
+	if b := m.LabelBloom; b != nil {
+		info.LabelBloom = &typesv1.BlockLabelBloom{
+			Names:  b.Names,
+			Filter: b.Filter,
+		}
+	}
+}
+
+// BlockInfoMayMatch returns false if the label summaries of the block info
+// show that no series of the block can match the matchers.
+func BlockInfoMayMatch(info *typesv1.BlockInfo, matchers ...*labels.Matcher) bool {
+	m := Meta{Labels: make(map[string]string, len(info.Labels))}
+	for _, l := range info.Labels {
+		m.Labels[l.Name] = l.Value
+	}
+	if s := info.GetLabelSplit(); s != nil {
+		m.LabelSplit = &LabelSplit{
+			Name:   s.Name,
+			Shards: s.Shards,
+			Min:    s.Min,
+			Max:    s.Max,
+		}
+	}
+	if b := info.GetLabelBloom(); b != nil {
+		m.LabelBloom = &LabelBloom{
+			Names:  b.Names,
+			Filter: b.Filter,
+		}
+	}
+	return m.MayMatch(matchers...)
 }
 
 func generateULID() ulid.ULID {
@@ -0,0 +1,77 @@
+package phlaredb
+
+import (
+	"context"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/phlaredb/block"
+	"github.com/grafana/pyroscope/pkg/phlaredb/tsdb/index"
+)
+
+// labelBloomNames are the labels which values are summarized in the block
+// meta, as almost all queries filter by them. The query shard matcher is not
+// a series label, and it must not be summarized: it never prunes a block.
+var labelBloomNames = []string{phlaremodel.LabelNameProfileType, phlaremodel.LabelNameServiceName}
+
+// labelBloomFromIndex returns the bloom filter of the values of the
+// summarized labels of the series of the index file.
+func labelBloomFromIndex(path string) (*block.LabelBloom, error) {
+	r, err := index.NewFileReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	values := make(map[string][]string, len(labelBloomNames))
+	for _, name := range labelBloomNames {
+		if values[name], err = r.LabelValues(name); err != nil {
+			return nil, err
+		}
+	}
+	return block.NewLabelBloom(values), nil
+}
+
+// QueryLabelMatchers returns the series label matchers of a query, including
+// the profile type if set. An invalid selector is ignored here, as it is
+// rejected when the blocks are queried.
+func QueryLabelMatchers(selector, profileTypeID string) []*labels.Matcher {
+	var matchers []*labels.Matcher
+	if selector != "" {
+		var err error
+		if matchers, err = parser.ParseMetricSelector(selector); err != nil {
+			return nil
+		}
+	}
+	if profileTypeID != "" {
+		matchers = append(matchers, labels.MustNewMatcher(labels.MatchEqual, phlaremodel.LabelNameProfileType, profileTypeID))
+	}
+	return matchers
+}
+
+type queryLabelMatchersContextKey struct{}
+
+// withQueryLabelMatchers returns a context with the series label matchers
+// of the query: block getters use them to skip blocks which label summaries
+// show that they can't match the query.
+func withQueryLabelMatchers(ctx context.Context, selector string, profileType *typesv1.ProfileType) context.Context {
+	return context.WithValue(ctx, queryLabelMatchersContextKey{}, QueryLabelMatchers(selector, profileType.GetID()))
+}
+
+// LabelMatchersBlockSkipper returns a function reporting whether a block can
+// be skipped, because its label summaries show that no series can match.
+func LabelMatchersBlockSkipper(matchers []*labels.Matcher) func(meta *block.Meta) bool {
+	return func(meta *block.Meta) bool {
+		return !meta.MayMatch(matchers...)
+	}
+}
+
+// QueryLabelsBlockSkipper returns the LabelMatchersBlockSkipper of the
+// query of the context.
+func QueryLabelsBlockSkipper(ctx context.Context) func(meta *block.Meta) bool {
+	matchers, _ := ctx.Value(queryLabelMatchersContextKey{}).([]*labels.Matcher)
+	return LabelMatchersBlockSkipper(matchers)
+}
@@ -0,0 +1,128 @@
+package phlaredb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ingesterv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/block"
+	"github.com/grafana/pyroscope/pkg/phlaredb/sharding"
+	"github.com/grafana/pyroscope/pkg/pprof/testhelper"
+)
+
+func TestQueryLabelsBlockSkipper(t *testing.T) {
+	// The series of the last of three shards are the ones which values hash
+	// to 2, e.g. "a", "c" and "d", and the process_cpu and wall profiles.
+	lastShard := map[string]string{sharding.CompactorShardIDLabel: "3_of_3"}
+	blocks := map[string]*block.Meta{
+		"no split":      {},
+		"service split": {Labels: lastShard, LabelSplit: &block.LabelSplit{Name: "service_name", Shards: 3}},
+		"type split":    {Labels: lastShard, LabelSplit: &block.LabelSplit{Name: "__profile_type__", Shards: 3}},
+		"service bloom": {LabelBloom: block.NewLabelBloom(map[string][]string{"service_name": {"a", "c"}})},
+	}
+	profileType := &typesv1.ProfileType{ID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds"}
+
+	for _, tc := range []struct {
+		selector    string
+		profileType *typesv1.ProfileType
+		skipped     []string
+	}{
+		{selector: `{}`},
+		{selector: `{service_name="c"}`},
+		{selector: `{service_name="a"}`},
+		{selector: `{service_name="b"}`, skipped: []string{"service split", "service bloom"}},
+		{selector: `{service_name="d"}`, skipped: []string{"service bloom"}},
+		{selector: `{service_name="e"}`, skipped: []string{"service split", "service bloom"}},
+		{selector: `{service_name!="b"}`},
+		{selector: `{service_name=~"b|e"}`},
+		{selector: `{service_name="e"`},
+		{selector: `{}`, profileType: profileType},
+		{selector: `{}`, profileType: &typesv1.ProfileType{ID: "wall:wall:nanoseconds:cpu:nanoseconds"}},
+		{selector: `{}`, profileType: &typesv1.ProfileType{ID: "goroutines:goroutine:count:goroutine:count"}, skipped: []string{"type split"}},
+		{selector: `{service_name="e"}`, profileType: &typesv1.ProfileType{ID: "goroutines:goroutine:count:goroutine:count"}, skipped: []string{"service split", "type split", "service bloom"}},
+		{selector: `{service_name=""}`, skipped: []string{"service split"}},
+	} {
+		ctx := withQueryLabelMatchers(context.Background(), tc.selector, tc.profileType)
+		skip := QueryLabelsBlockSkipper(ctx)
+		var skipped []string
+		for _, name := range []string{"no split", "service split", "type split", "service bloom"} {
+			if skip(blocks[name]) {
+				skipped = append(skipped, name)
+			}
+		}
+		assert.Equal(t, tc.skipped, skipped, tc.selector)
+	}
+
+	assert.False(t, QueryLabelsBlockSkipper(context.Background())(blocks["service split"]))
+
+	// The split is ignored if it doesn't match the shard of the block.
+	otherSplit := &block.Meta{
+		Labels:     map[string]string{sharding.CompactorShardIDLabel: "3_of_4"},
+		LabelSplit: &block.LabelSplit{Name: "service_name", Shards: 3},
+	}
+	assert.False(t, LabelMatchersBlockSkipper(QueryLabelMatchers(`{service_name="b"}`, ""))(otherSplit))
+}
+
+func TestQueryShardWithLabelBloom(t *testing.T) {
+	ctx := context.Background()
+	querier := newBlock(t, func() (res []*testhelper.ProfileBuilder) {
+		for i := int64(0); i < 32; i++ {
+			res = append(res, testhelper.NewProfileBuilder(int64(time.Second)*i).
+				CPUProfile().
+				WithLabels(
+					"service_name", "a",
+					"instance", fmt.Sprint(i),
+				).ForStacktraceString("foo", "bar").AddSamples(1))
+		}
+		return res
+	})
+	require.NotNil(t, querier.meta.LabelBloom)
+
+	profileType := &typesv1.ProfileType{
+		ID:         "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+		Name:       "process_cpu",
+		SampleType: "cpu",
+		SampleUnit: "nanoseconds",
+		PeriodType: "cpu",
+		PeriodUnit: "nanoseconds",
+	}
+	selectTotal := func(selector string) int64 {
+		// The query shard is not summarized in the block meta: it must not
+		// prevent the block from being queried.
+		ctx := withQueryLabelMatchers(ctx, selector, profileType)
+		if QueryLabelsBlockSkipper(ctx)(querier.meta) {
+			return 0
+		}
+		merge, err := querier.SelectMergeByStacktraces(ctx, &ingesterv1.SelectProfilesRequest{
+			LabelSelector: selector,
+			Type:          profileType,
+			Start:         0,
+			End:           int64(model.TimeFromUnixNano(math.MaxInt64)),
+		})
+		require.NoError(t, err)
+		return merge.Total()
+	}
+
+	require.Equal(t, int64(32), selectTotal(`{service_name="a"}`))
+
+	const shards = 4
+	var total int64
+	for i := 1; i <= shards; i++ {
+		v := selectTotal(fmt.Sprintf(`{service_name="a",__query_shard__="%d_of_%d"}`, i, shards))
+		assert.Less(t, v, int64(32))
+		total += v
+	}
+	assert.Equal(t, int64(32), total)
+
+	// The bloom filter still prunes blocks of sharded queries.
+	assert.True(t, QueryLabelsBlockSkipper(withQueryLabelMatchers(ctx, `{service_name="b",__query_shard__="1_of_4"}`, profileType))(querier.meta))
+	require.NoError(t, querier.Close())
+}
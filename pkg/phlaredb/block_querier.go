@@ -15,7 +15,6 @@ import (
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
-	"github.com/gogo/status"
 	"github.com/grafana/dskit/multierror"
 	"github.com/grafana/dskit/runutil"
 	"github.com/oklog/ulid"
@@ -24,12 +23,10 @@ import (
 	"github.com/parquet-go/parquet-go"
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
-	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/samber/lo"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/grpc/codes"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
@@ -305,6 +302,8 @@ type singleBlockQuerier struct {
 	index    *index.Reader
 	profiles map[profileTableKey]*parquetReader[*schemav1.ProfilePersister]
 	symbols  symbolsResolver
+
+	symbolizer symdb.Symbolizer
 }
 
 type profileTableKey struct {
@@ -319,6 +318,8 @@ func NewSingleBlockQuerierFromMeta(phlarectx context.Context, bucketReader phlar
 		profiles: make(map[profileTableKey]*parquetReader[*schemav1.ProfilePersister], 3),
 		bucket:   phlareobj.NewPrefixedBucket(bucketReader, meta.ULID.String()),
 		meta:     meta,
+
+		symbolizer: symbolizerFromContext(phlarectx),
 	}
 	for _, f := range meta.Files {
 		k, ok := parseProfileTableName(f.RelPath)
@@ -331,6 +332,14 @@ func NewSingleBlockQuerierFromMeta(phlarectx context.Context, bucketReader phlar
 	return q
 }
 
+func (b *singleBlockQuerier) newResolver(ctx context.Context, opts ...symdb.ResolverOption) *symdb.Resolver {
+	opts = contextResolverOptions(ctx, opts)
+	return symdb.NewResolver(ctx, b.symbols, append(opts,
+		symdb.WithResolverSymbolizer(b.symbolizer),
+		symdb.WithResolverBlock(b.meta.ULID.String()),
+	)...)
+}
+
 func (b *singleBlockQuerier) Profiles() ProfileReader {
 	return b.profileSourceTable().file
 }
@@ -801,6 +810,10 @@ func MergeProfilesStacktraces(ctx context.Context, stream *connect.BidiStream[in
 		return connect.NewError(connect.CodeInvalidArgument, errors.New("missing initial select request"))
 	}
 	request := r.Request
+	if ctx, err = withSampleLabelSelector(ctx, request.SampleLabelSelector); err != nil {
+		return err
+	}
+	ctx = withRecursionCollapse(ctx, request.CollapseRecursion)
 	sp.LogFields(
 		otlog.String("start", model.Time(request.Start).Time().String()),
 		otlog.String("end", model.Time(request.End).Time().String()),
@@ -809,6 +822,7 @@ func MergeProfilesStacktraces(ctx context.Context, stream *connect.BidiStream[in
 		otlog.Object("hints", request.Hints),
 	)
 
+	ctx = withQueryLabelMatchers(ctx, request.LabelSelector, request.Type)
 	queriers, err := blockGetter(ctx, model.Time(request.Start), model.Time(request.End), request.Hints)
 	if err != nil {
 		return err
@@ -951,6 +965,7 @@ func MergeSpanProfile(ctx context.Context, stream *connect.BidiStream[ingestv1.M
 		return err
 	}
 
+	ctx = withQueryLabelMatchers(ctx, request.LabelSelector, request.Type)
 	queriers, err := blockGetter(ctx, model.Time(request.Start), model.Time(request.End), request.Hints)
 	if err != nil {
 		return err
@@ -1085,6 +1100,9 @@ func MergeProfilesLabels(ctx context.Context, stream *connect.BidiStream[ingestv
 		return connect.NewError(connect.CodeInvalidArgument, errors.New("missing initial select request"))
 	}
 	request := r.Request
+	if ctx, err = withSampleLabelSelector(ctx, request.SampleLabelSelector); err != nil {
+		return err
+	}
 	by := r.By
 	sort.Strings(by)
 	sp.LogFields(
@@ -1095,6 +1113,7 @@ func MergeProfilesLabels(ctx context.Context, stream *connect.BidiStream[ingestv
 		otlog.String("by", strings.Join(by, ",")),
 	)
 
+	ctx = withQueryLabelMatchers(ctx, request.LabelSelector, request.Type)
 	queriers, err := blockGetter(ctx, model.Time(request.Start), model.Time(request.End), request.Hints)
 	if err != nil {
 		return err
@@ -1208,6 +1227,11 @@ func MergeProfilesPprof(ctx context.Context, stream *connect.BidiStream[ingestv1
 	}
 
 	request := r.Request
+	if ctx, err = withSampleLabelSelector(ctx, request.SampleLabelSelector); err != nil {
+		return err
+	}
+	ctx = withRecursionCollapse(ctx, request.CollapseRecursion)
+	ctx = withRetainedSampleLabels(ctx, r.SampleLabels)
 	sp.SetTag("start", model.Time(request.Start).Time().String()).
 		SetTag("end", model.Time(request.End).Time().String()).
 		SetTag("selector", request.LabelSelector).
@@ -1215,6 +1239,7 @@ func MergeProfilesPprof(ctx context.Context, stream *connect.BidiStream[ingestv1
 		SetTag("max_nodes", r.GetMaxNodes())
 	sp.LogFields(otlog.Object("hints", request.Hints))
 
+	ctx = withQueryLabelMatchers(ctx, request.LabelSelector, request.Type)
 	queriers, err := blockGetter(ctx, model.Time(request.Start), model.Time(request.End), request.Hints)
 	if err != nil {
 		return err
@@ -1535,9 +1560,9 @@ func (b *singleBlockQuerier) SelectMatchingProfiles(ctx context.Context, params
 	b.queries.Add(1)
 	defer b.queries.Done()
 
-	matchers, err := parser.ParseMetricSelector(params.LabelSelector)
+	shard, matchers, err := parseShardedSelector(params.LabelSelector)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse label selectors: "+err.Error())
+		return nil, err
 	}
 	if params.Type == nil {
 		return nil, errors.New("no profileType given")
@@ -1561,6 +1586,9 @@ func (b *singleBlockQuerier) SelectMatchingProfiles(ctx context.Context, params
 		if err != nil {
 			return nil, err
 		}
+		if !inShard(shard, fp) {
+			continue
+		}
 		if _, exists := lblsPerRef[int64(chks[0].SeriesIndex)]; exists {
 			continue
 		}
@@ -1618,6 +1646,9 @@ func (b *singleBlockQuerier) SelectMatchingProfiles(ctx context.Context, params
 			rowNum:      res.RowNumber[0],
 		})
 	}
+	if err = pIt.Err(); err != nil {
+		return nil, err
+	}
 	if len(currentSeriesSlice) > 0 {
 		iters = append(iters, iter.NewSliceIterator(currentSeriesSlice))
 	}
@@ -1642,9 +1673,9 @@ func (b *singleBlockQuerier) SelectMergeByLabels(
 	b.queries.Add(1)
 	defer b.queries.Done()
 
-	matchers, err := parser.ParseMetricSelector(params.LabelSelector)
+	shard, matchers, err := parseShardedSelector(params.LabelSelector)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse label selectors: "+err.Error())
+		return nil, err
 	}
 	if params.Type == nil {
 		return nil, errors.New("no profileType given")
@@ -1666,6 +1697,9 @@ func (b *singleBlockQuerier) SelectMergeByLabels(
 		if err != nil {
 			return nil, err
 		}
+		if !inShard(shard, fp) {
+			continue
+		}
 
 		_, ok := lblsPerRef[int64(chks[0].SeriesIndex)]
 		if !ok {
@@ -1690,16 +1724,20 @@ func (b *singleBlockQuerier) SelectMergeByLabels(
 		if b.meta.Version == 1 {
 			columnName = "Samples.list.element.Value"
 		}
+		if b.meta.Version >= 2 && sampleLabelMatchersFromContext(ctx) != nil {
+			// Sample labels are resolved with the partition symbols.
+			it = query.NewBinaryJoinIterator(0, it, profiles.columnIter(ctx, "StacktracePartition", nil, "StacktracePartition"))
+		}
 		rows := profileBatchIteratorBySeriesIndex(it, lblsPerRef)
 		defer rows.Close()
-		return mergeByLabels[Profile](ctx, profiles.file, columnName, rows, by...)
+		return mergeByLabels[Profile](ctx, profiles.file, b.symbols, columnName, rows, by...)
 	}
 
 	if b.meta.Version < 2 {
 		return nil, nil
 	}
 
-	r := symdb.NewResolver(ctx, b.symbols,
+	r := b.newResolver(ctx,
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
 
@@ -1722,9 +1760,9 @@ func (b *singleBlockQuerier) SelectMergeByStacktraces(ctx context.Context, param
 	b.queries.Add(1)
 	defer b.queries.Done()
 
-	matchers, err := parser.ParseMetricSelector(params.LabelSelector)
+	shard, matchers, err := parseShardedSelector(params.LabelSelector)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse label selectors: "+err.Error())
+		return nil, err
 	}
 	if params.Type == nil {
 		return nil, errors.New("no profileType given")
@@ -1743,17 +1781,20 @@ func (b *singleBlockQuerier) SelectMergeByStacktraces(ctx context.Context, param
 
 	// get all relevant labels/fingerprints
 	for postings.Next() {
-		_, err := b.index.Series(postings.At(), nil, &chks)
+		fp, err := b.index.Series(postings.At(), nil, &chks)
 		if err != nil {
 			return nil, err
 		}
+		if !inShard(shard, fp) {
+			continue
+		}
 		lblsPerRef[int64(chks[0].SeriesIndex)] = struct{}{}
 	}
-	r := symdb.NewResolver(ctx, b.symbols)
+	r := b.newResolver(ctx)
 	defer r.Release()
 
 	g, ctx := errgroup.WithContext(ctx)
-	util.SplitTimeRangeByResolution(time.UnixMilli(params.Start), time.UnixMilli(params.End), b.downsampleResolutions(), func(tr util.TimeRange) {
+	util.SplitTimeRangeByResolution(time.UnixMilli(params.Start), time.UnixMilli(params.End), b.downsampleResolutions(ctx), func(tr util.TimeRange) {
 		g.Go(func() error {
 			profiles := b.profileTable(tr.Resolution, params.GetAggregation())
 			it := query.NewBinaryJoinIterator(
@@ -1791,9 +1832,9 @@ func (b *singleBlockQuerier) SelectMergeBySpans(ctx context.Context, params *ing
 	b.queries.Add(1)
 	defer b.queries.Done()
 
-	matchers, err := parser.ParseMetricSelector(params.LabelSelector)
+	shard, matchers, err := parseShardedSelector(params.LabelSelector)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse label selectors: "+err.Error())
+		return nil, err
 	}
 	if params.Type == nil {
 		return nil, errors.New("no profileType given")
@@ -1816,13 +1857,16 @@ func (b *singleBlockQuerier) SelectMergeBySpans(ctx context.Context, params *ing
 
 	// get all relevant labels/fingerprints
 	for postings.Next() {
-		_, err := b.index.Series(postings.At(), nil, &chks)
+		fp, err := b.index.Series(postings.At(), nil, &chks)
 		if err != nil {
 			return nil, err
 		}
+		if !inShard(shard, fp) {
+			continue
+		}
 		lblsPerRef[int64(chks[0].SeriesIndex)] = struct{}{}
 	}
-	r := symdb.NewResolver(ctx, b.symbols)
+	r := b.newResolver(ctx)
 	defer r.Release()
 
 	profiles := b.profileSourceTable()
@@ -1859,9 +1903,9 @@ func (b *singleBlockQuerier) SelectMergePprof(ctx context.Context, params *inges
 	b.queries.Add(1)
 	defer b.queries.Done()
 
-	matchers, err := parser.ParseMetricSelector(params.LabelSelector)
+	shard, matchers, err := parseShardedSelector(params.LabelSelector)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse label selectors: "+err.Error())
+		return nil, err
 	}
 	if params.Type == nil {
 		return nil, errors.New("no profileType given")
@@ -1880,19 +1924,22 @@ func (b *singleBlockQuerier) SelectMergePprof(ctx context.Context, params *inges
 
 	// get all relevant labels/fingerprints
 	for postings.Next() {
-		_, err := b.index.Series(postings.At(), nil, &chks)
+		fp, err := b.index.Series(postings.At(), nil, &chks)
 		if err != nil {
 			return nil, err
 		}
+		if !inShard(shard, fp) {
+			continue
+		}
 		lblsPerRef[int64(chks[0].SeriesIndex)] = struct{}{}
 	}
-	r := symdb.NewResolver(ctx, b.symbols,
+	r := b.newResolver(ctx,
 		symdb.WithResolverMaxNodes(maxNodes),
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
 
 	g, ctx := errgroup.WithContext(ctx)
-	util.SplitTimeRangeByResolution(time.UnixMilli(params.Start), time.UnixMilli(params.End), b.downsampleResolutions(), func(tr util.TimeRange) {
+	util.SplitTimeRangeByResolution(time.UnixMilli(params.Start), time.UnixMilli(params.End), b.downsampleResolutions(ctx), func(tr util.TimeRange) {
 		g.Go(func() error {
 			profiles := b.profileTable(tr.Resolution, params.GetAggregation())
 			it := query.NewBinaryJoinIterator(
@@ -2155,11 +2202,15 @@ func (b *singleBlockQuerier) profileTable(resolution time.Duration, aggregation
 	return b.profiles[profileTableKey{}]
 }
 
-func (b *singleBlockQuerier) downsampleResolutions() []time.Duration {
+func (b *singleBlockQuerier) downsampleResolutions(ctx context.Context) []time.Duration {
 	if len(b.profiles) < 2 {
 		// b.profiles contains only the table of original resolution.
 		return nil
 	}
+	if sampleLabelMatchersFromContext(ctx) != nil {
+		// Downsampled tables do not include sample labels.
+		return nil
+	}
 	resolutions := make([]time.Duration, 0, len(b.profiles)-1)
 	for k := range b.profiles {
 		if k.resolution > 0 {
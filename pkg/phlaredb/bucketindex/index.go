@@ -80,6 +80,10 @@ type Block struct {
 	// Block's compactor shard ID, copied from tsdb.CompactorShardIDExternalLabel label.
 	CompactorShardID string `json:"compactor_shard_id,omitempty"`
 	CompactionLevel  int    `json:"compaction_level,omitempty"`
+
+	// Label summaries of the block, copied from the meta.json.
+	LabelSplit *block.LabelSplit `json:"label_split,omitempty"`
+	LabelBloom *block.LabelBloom `json:"label_bloom,omitempty"`
 }
 
 // Within returns whether the block contains samples within the provided range.
@@ -118,6 +122,8 @@ func (m *Block) Meta() *block.Meta {
 		Compaction: block.BlockMetaCompaction{
 			Level: m.CompactionLevel,
 		},
+		LabelSplit: m.LabelSplit,
+		LabelBloom: m.LabelBloom,
 	}
 }
 
@@ -128,6 +134,8 @@ func BlockFromMeta(meta block.Meta) *Block {
 		MaxTime:          meta.MaxTime,
 		CompactorShardID: meta.Labels[sharding.CompactorShardIDLabel],
 		CompactionLevel:  meta.Compaction.Level,
+		LabelSplit:       meta.LabelSplit,
+		LabelBloom:       meta.LabelBloom,
 	}
 }
 
@@ -54,6 +54,22 @@ func TestBlockFromMeta(t *testing.T) {
 				CompactionLevel:  0,
 			},
 		},
+		"meta.json with label summaries": {
+			meta: block.Meta{
+				ULID:       blockID,
+				MinTime:    model.Time(10),
+				MaxTime:    model.Time(20),
+				LabelSplit: &block.LabelSplit{Name: "service_name", Shards: 4},
+				LabelBloom: block.NewLabelBloom(map[string][]string{"service_name": {"a", "b"}}),
+			},
+			expected: Block{
+				ID:         blockID,
+				MinTime:    model.Time(10),
+				MaxTime:    model.Time(20),
+				LabelSplit: &block.LabelSplit{Name: "service_name", Shards: 4},
+				LabelBloom: block.NewLabelBloom(map[string][]string{"service_name": {"a", "b"}}),
+			},
+		},
 	}
 
 	for testName, testData := range tests {
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"sort"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/multierror"
@@ -60,6 +61,12 @@ type CompactWithSplittingOpts struct {
 	SplitBy            SplitByFunc
 	DownsamplerEnabled bool
 	Logger             log.Logger
+
+	// SplitByLabel is the name of the label to split the series by. If set,
+	// SplitBy is ignored: the series are split by the hash of the label value,
+	// and the label and the shard count are recorded in the meta of each
+	// output block.
+	SplitByLabel string
 }
 
 func Compact(ctx context.Context, src []BlockReader, dst string) (meta block.Meta, err error) {
@@ -99,6 +106,9 @@ func CompactWithSplitting(ctx context.Context, opts CompactWithSplittingOpts) (
 	for i, b := range opts.Src {
 		srcMetas[i] = b.Meta()
 	}
+	if opts.SplitByLabel != "" {
+		opts.SplitBy = SplitByLabel(opts.SplitByLabel)
+	}
 
 	symbolsCompactor := newSymbolsCompactor(opts.Dst)
 	defer runutil.CloseWithLogOnErr(util.Logger, symbolsCompactor, "close symbols compactor")
@@ -111,6 +121,7 @@ func CompactWithSplitting(ctx context.Context, opts CompactWithSplittingOpts) (
 				meta:               outMeta,
 				splitCount:         opts.SplitCount,
 				shard:              idx,
+				splitLabel:         opts.SplitByLabel,
 				rewriterFn:         symbolsCompactor.Rewriter,
 				downsamplerEnabled: opts.DownsamplerEnabled,
 				logger:             opts.Logger,
@@ -161,6 +172,11 @@ func createBlockWriter(opts blockWriterOpts) (*blockWriter, error) {
 			meta.Labels = make(map[string]string)
 		}
 		meta.Labels[sharding.CompactorShardIDLabel] = sharding.FormatShardIDLabelValue(uint64(opts.shard), opts.splitCount)
+		// The split of the source blocks doesn't hold anymore.
+		meta.LabelSplit = nil
+		if opts.splitLabel != "" {
+			meta.LabelSplit = &block.LabelSplit{Name: opts.splitLabel, Shards: opts.splitCount}
+		}
 	}
 	opts.meta = *meta
 	return newBlockWriter(opts)
@@ -225,6 +241,16 @@ var SplitByStacktracePartition = func(r profileRow, shardsCount uint64) uint64 {
 	return r.row.StacktracePartitionID() % shardsCount
 }
 
+// SplitByLabel returns a SplitByFunc assigning the series to the shards by
+// the hash of the value of the label. The shard of a series only depends on
+// the label value, so it is the same in every compaction job, which the merge
+// of the split blocks relies on.
+func SplitByLabel(name string) SplitByFunc {
+	return func(r profileRow, shardsCount uint64) uint64 {
+		return xxhash.Sum64String(r.labels.Get(name)) % shardsCount
+	}
+}
+
 type blockWriter struct {
 	indexRewriter   *indexRewriter
 	symbolsRewriter SymbolsRewriter
@@ -246,6 +272,7 @@ type blockWriterOpts struct {
 	dst                string
 	splitCount         uint64
 	shard              int
+	splitLabel         string
 	meta               block.Meta
 	rewriterFn         SymbolsRewriterFn
 	downsamplerEnabled bool
@@ -302,6 +329,16 @@ func (bw *blockWriter) WriteRow(r profileRow) error {
 			return err
 		}
 	}
+	if s := bw.meta.LabelSplit; s != nil {
+		// Record the range of the label values written to the block.
+		v := r.labels.Get(s.Name)
+		if bw.totalProfiles == 0 || v < s.Min {
+			s.Min = v
+		}
+		if bw.totalProfiles == 0 || v > s.Max {
+			s.Max = v
+		}
+	}
 	bw.totalProfiles++
 	return nil
 }
@@ -331,6 +368,9 @@ func (bw *blockWriter) Close(ctx context.Context) error {
 	bw.meta.Stats.NumSeries = bw.indexRewriter.NumSeries()
 	bw.meta.Stats.NumSamples = numSamples
 	bw.meta.Compaction.Deletable = bw.totalProfiles == 0
+	if bw.meta.LabelBloom, err = labelBloomFromIndex(filepath.Join(bw.path, block.IndexFilename)); err != nil {
+		return err
+	}
 	if _, err := bw.meta.WriteToFile(util.Logger, bw.path); err != nil {
 		return err
 	}
@@ -565,10 +605,30 @@ func compactMetas(src ...block.Meta) block.Meta {
 	meta.MaxTime = maxTime
 	meta.MinTime = minTime
 	meta.Labels = labels
+	meta.LabelSplit = compactLabelSplits(src...)
 	meta.ULID = ulid.MustNew(uint64(minTime), rand.Reader)
 	return *meta
 }
 
+// compactLabelSplits returns the label split of the source blocks, if all of
+// them are the same shard of the same split: the series of the compacted
+// block then belong to that shard too. The value range is recorded by the
+// block writer.
+func compactLabelSplits(src ...block.Meta) *block.LabelSplit {
+	if len(src) == 0 || src[0].LabelSplit == nil {
+		return nil
+	}
+	split := block.LabelSplit{Name: src[0].LabelSplit.Name, Shards: src[0].LabelSplit.Shards}
+	shardID := src[0].Labels[sharding.CompactorShardIDLabel]
+	for _, b := range src[1:] {
+		if b.LabelSplit == nil || b.LabelSplit.Name != split.Name || b.LabelSplit.Shards != split.Shards ||
+			b.Labels[sharding.CompactorShardIDLabel] != shardID {
+			return nil
+		}
+	}
+	return &split
+}
+
 type profileRow struct {
 	timeNanos int64
 
@@ -728,6 +788,7 @@ type symbolsCompactor struct {
 	rewriters   map[BlockReader]*symdb.Rewriter
 	w           *symdb.SymDB
 	stacktraces []uint32
+	strings     []uint32
 
 	dst     string
 	flushed bool
@@ -780,13 +841,13 @@ func (s *symbolsCompactor) ReWriteRow(profile profileRow) (uint64, error) {
 		err              error
 		rewrittenSamples uint64
 	)
+	r, ok := s.rewriters[profile.blockReader]
+	if !ok {
+		r = symdb.NewRewriter(s.w, profile.blockReader.Symbols())
+		s.rewriters[profile.blockReader] = r
+	}
 	profile.row.ForStacktraceIDsValues(func(values []parquet.Value) {
 		s.loadStacktracesID(values)
-		r, ok := s.rewriters[profile.blockReader]
-		if !ok {
-			r = symdb.NewRewriter(s.w, profile.blockReader.Symbols())
-			s.rewriters[profile.blockReader] = r
-		}
 		if err = r.Rewrite(profile.row.StacktracePartitionID(), s.stacktraces); err != nil {
 			return
 		}
@@ -799,6 +860,35 @@ func (s *symbolsCompactor) ReWriteRow(profile profileRow) (uint64, error) {
 	if err != nil {
 		return rewrittenSamples, err
 	}
+	// Sample labels reference the partition string table.
+	profile.row.ForSampleLabels(func(keys, values []parquet.Value) {
+		s.strings = s.strings[:0]
+		for _, v := range [][]parquet.Value{keys, values} {
+			for i := range v {
+				if !v[i].IsNull() {
+					s.strings = append(s.strings, v[i].Uint32())
+				}
+			}
+		}
+		if len(s.strings) == 0 {
+			return
+		}
+		if err = r.RewriteStrings(profile.row.StacktracePartitionID(), s.strings); err != nil {
+			return
+		}
+		var j int
+		for _, v := range [][]parquet.Value{keys, values} {
+			for i := range v {
+				if !v[i].IsNull() {
+					v[i] = parquet.Int64Value(int64(s.strings[j])).Level(v[i].RepetitionLevel(), v[i].DefinitionLevel(), v[i].Column())
+					j++
+				}
+			}
+		}
+	})
+	if err != nil {
+		return rewrittenSamples, err
+	}
 	return rewrittenSamples, nil
 }
 
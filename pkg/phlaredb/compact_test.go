@@ -14,6 +14,7 @@ import (
 	"github.com/oklog/ulid"
 	"github.com/parquet-go/parquet-go"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -289,6 +290,122 @@ func TestCompactWithSplitting(t *testing.T) {
 	require.Equal(t, expected.String(), res.String())
 }
 
+func TestCompactWithSplittingByLabel(t *testing.T) {
+	ctx := context.Background()
+
+	b1 := newBlock(t, func() []*testhelper.ProfileBuilder {
+		return append(
+			profileSeriesGenerator(t, time.Unix(1, 0), time.Unix(10, 0), time.Second, "job", "a"),
+			profileSeriesGenerator(t, time.Unix(11, 0), time.Unix(20, 0), time.Second, "job", "b")...,
+		)
+	})
+	b2 := newBlock(t, func() []*testhelper.ProfileBuilder {
+		return append(
+			append(
+				profileSeriesGenerator(t, time.Unix(1, 0), time.Unix(10, 0), time.Second, "job", "c"),
+				profileSeriesGenerator(t, time.Unix(11, 0), time.Unix(20, 0), time.Second, "job", "d")...,
+			),
+			profileSeriesGenerator(t, time.Unix(1, 0), time.Unix(10, 0), time.Second, "job", "a")...,
+		)
+	})
+	dst := t.TempDir()
+	compacted, err := CompactWithSplitting(ctx, CompactWithSplittingOpts{
+		Src:          []BlockReader{b1, b2},
+		Dst:          dst,
+		SplitCount:   3,
+		SplitBy:      SplitByFingerprint,
+		SplitByLabel: "job",
+		Logger:       log.NewNopLogger(),
+	})
+	require.NoError(t, err)
+
+	// The shard of a series only depends on the hash of its value: b goes
+	// to the first shard, and a, c and d go to the last one.
+	require.Equal(t, 2, len(compacted))
+	// The blocks record the range of the values they have.
+	split := &block.LabelSplit{Name: "job", Shards: 3, Min: "a", Max: "d"}
+	require.Equal(t, "1_of_3", compacted[0].Labels[sharding.CompactorShardIDLabel])
+	require.Equal(t, &block.LabelSplit{Name: "job", Shards: 3, Min: "b", Max: "b"}, compacted[0].LabelSplit)
+	require.Equal(t, "3_of_3", compacted[1].Labels[sharding.CompactorShardIDLabel])
+	require.Equal(t, split, compacted[1].LabelSplit)
+
+	// The split is persisted in meta.json.
+	meta, _, err := block.MetaFromDir(filepath.Join(dst, compacted[1].ULID.String()))
+	require.NoError(t, err)
+	require.Equal(t, split, meta.LabelSplit)
+
+	// The blocks only match the values of their shard.
+	job := func(v string) *labels.Matcher { return labels.MustNewMatcher(labels.MatchEqual, "job", v) }
+	require.True(t, compacted[0].MayMatch(job("b")))
+	require.False(t, compacted[0].MayMatch(job("a")))
+	require.True(t, compacted[1].MayMatch(job("a")))
+	require.False(t, compacted[1].MayMatch(job("b")))
+	require.False(t, compacted[1].MayMatch(job("e")))
+
+	// Splitting other blocks assigns the series to the same shards.
+	singleDst := t.TempDir()
+	single, err := CompactWithSplitting(ctx, CompactWithSplittingOpts{
+		Src:          []BlockReader{b1},
+		Dst:          singleDst,
+		SplitCount:   3,
+		SplitBy:      SplitByFingerprint,
+		SplitByLabel: "job",
+		Logger:       log.NewNopLogger(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(single))
+	require.Equal(t, "1_of_3", single[0].Labels[sharding.CompactorShardIDLabel])
+	require.Equal(t, "3_of_3", single[1].Labels[sharding.CompactorShardIDLabel])
+
+	// Merging the blocks of the same shard keeps the split.
+	merged, err := CompactWithSplitting(ctx, CompactWithSplittingOpts{
+		Src: []BlockReader{
+			blockQuerierFromMeta(t, dst, compacted[1]),
+			blockQuerierFromMeta(t, singleDst, single[1]),
+		},
+		Dst:          t.TempDir(),
+		SplitCount:   1,
+		SplitBy:      SplitByFingerprint,
+		SplitByLabel: "job",
+		Logger:       log.NewNopLogger(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(merged))
+	require.Equal(t, "3_of_3", merged[0].Labels[sharding.CompactorShardIDLabel])
+	require.Equal(t, split, merged[0].LabelSplit)
+
+	// Merging a single block of the shard narrows the range to its values.
+	merged, err = CompactWithSplitting(ctx, CompactWithSplittingOpts{
+		Src:          []BlockReader{blockQuerierFromMeta(t, singleDst, single[1])},
+		Dst:          t.TempDir(),
+		SplitCount:   3,
+		SplitBy:      SplitByFingerprint,
+		SplitByLabel: "job",
+		Logger:       log.NewNopLogger(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(merged))
+	require.Equal(t, &block.LabelSplit{Name: "job", Shards: 3, Min: "a", Max: "a"}, merged[0].LabelSplit)
+	require.True(t, merged[0].MayMatch(job("a")))
+	require.False(t, merged[0].MayMatch(job("c")))
+
+	// Merging the blocks of different shards doesn't.
+	merged, err = CompactWithSplitting(ctx, CompactWithSplittingOpts{
+		Src: []BlockReader{
+			blockQuerierFromMeta(t, dst, compacted[0]),
+			blockQuerierFromMeta(t, dst, compacted[1]),
+		},
+		Dst:          t.TempDir(),
+		SplitCount:   1,
+		SplitBy:      SplitByFingerprint,
+		SplitByLabel: "job",
+		Logger:       log.NewNopLogger(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(merged))
+	require.Nil(t, merged[0].LabelSplit)
+}
+
 // nolint:unparam
 func profileSeriesGenerator(t *testing.T, from, through time.Time, interval time.Duration, lbls ...string) []*testhelper.ProfileBuilder {
 	t.Helper()
@@ -33,6 +33,7 @@ import (
 	"github.com/grafana/pyroscope/pkg/phlaredb/block"
 	phlarelabels "github.com/grafana/pyroscope/pkg/phlaredb/labels"
 	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/sharding"
 	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 )
 
@@ -71,8 +72,9 @@ type Head struct {
 	tables        []Table
 	delta         *deltaProfiles
 
-	limiter   TenantLimiter
-	updatedAt *atomic.Time
+	limiter    TenantLimiter
+	updatedAt  *atomic.Time
+	symbolizer symdb.Symbolizer
 }
 
 const (
@@ -96,6 +98,7 @@ func NewHead(phlarectx context.Context, cfg Config, limiter TenantLimiter) (*Hea
 		parquetConfig: &parquetConfig,
 		limiter:       limiter,
 		updatedAt:     atomic.NewTime(time.Now()),
+		symbolizer:    symbolizerFromContext(phlarectx),
 	}
 	h.headPath = filepath.Join(cfg.DataPath, pathHead, h.meta.ULID.String())
 	h.localPath = filepath.Join(cfg.DataPath, PathLocal, h.meta.ULID.String())
@@ -364,6 +367,11 @@ func (h *Head) Queriers() Queriers {
 	return queriers
 }
 
+func (h *Head) newResolver(ctx context.Context, opts ...symdb.ResolverOption) *symdb.Resolver {
+	opts = contextResolverOptions(ctx, opts)
+	return symdb.NewResolver(ctx, h.symdb, append(opts, symdb.WithResolverSymbolizer(h.symbolizer))...)
+}
+
 func (h *Head) Sort(in []Profile) []Profile {
 	return in
 }
@@ -436,6 +444,27 @@ func parseSelectors(selectorStrings []string) (selectors, error) {
 	return sels, nil
 }
 
+// parseShardedSelector parses the label selector and removes the query
+// shard matcher from the result, if present: the shard is not a series
+// label and can't be looked up in the index.
+func parseShardedSelector(selector string) (*sharding.ShardSelector, []*labels.Matcher, error) {
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "failed to parse label selectors: "+err.Error())
+	}
+	shard, matchers, err := sharding.RemoveShardFromMatchers(matchers)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "failed to parse query shard: "+err.Error())
+	}
+	return shard, matchers, nil
+}
+
+// inShard reports whether the series belongs to the query shard.
+// If no shard is specified, all series match.
+func inShard(shard *sharding.ShardSelector, fp uint64) bool {
+	return shard == nil || shard.Contains(fp)
+}
+
 func (sels selectors) matchesAll() bool {
 	if len(sels) == 0 {
 		return true
@@ -584,6 +613,11 @@ func (h *Head) flush(ctx context.Context) error {
 		h.metrics.flushedFileSizeBytes.WithLabelValues("tsdb").Observe(float64(f.SizeBytes))
 	}
 	files = append(files, f)
+	labelBloom, err := labelBloomFromIndex(filepath.Join(h.headPath, block.IndexFilename))
+	if err != nil {
+		return errors.Wrap(err, "building label bloom filter")
+	}
+	h.meta.LabelBloom = labelBloom
 
 	h.metrics.flushedBlockSizeBytes.Observe(float64(blockSize))
 	sort.Slice(files, func(i, j int) bool {
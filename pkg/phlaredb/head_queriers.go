@@ -6,6 +6,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/runutil"
 	"github.com/opentracing/opentracing-go"
 	"github.com/parquet-go/parquet-go"
 	"github.com/pkg/errors"
@@ -133,7 +134,7 @@ func (q *headOnDiskQuerier) SelectMergeByStacktraces(ctx context.Context, params
 	rows := profileRowBatchIterator(it)
 	defer rows.Close()
 
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
 
 	if err := mergeByStacktraces[rowProfile](ctx, q.rowGroup(), rows, r); err != nil {
@@ -178,7 +179,7 @@ func (q *headOnDiskQuerier) SelectMergeBySpans(ctx context.Context, params *inge
 	rows := profileRowBatchIterator(it)
 	defer rows.Close()
 
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
 
 	if err = mergeBySpans[rowProfile](ctx, q.rowGroup(), rows, r, spans); err != nil {
@@ -213,7 +214,7 @@ func (q *headOnDiskQuerier) SelectMergePprof(ctx context.Context, params *ingest
 	rows := profileRowBatchIterator(it)
 	defer rows.Close()
 
-	r := symdb.NewResolver(ctx, q.head.symdb,
+	r := q.head.newResolver(ctx,
 		symdb.WithResolverMaxNodes(maxNodes),
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
@@ -244,7 +245,7 @@ func (q *headOnDiskQuerier) LabelNames(ctx context.Context, req *connect.Request
 func (q *headOnDiskQuerier) MergeByStacktraces(ctx context.Context, rows iter.Iterator[Profile]) (*phlaremodel.Tree, error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "MergeByStacktraces")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
 	if err := mergeByStacktraces(ctx, q.rowGroup(), rows, r); err != nil {
 		return nil, err
@@ -255,7 +256,7 @@ func (q *headOnDiskQuerier) MergeByStacktraces(ctx context.Context, rows iter.It
 func (q *headOnDiskQuerier) MergePprof(ctx context.Context, rows iter.Iterator[Profile], maxNodes int64, sts *typesv1.StackTraceSelector) (*profilev1.Profile, error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "MergePprof")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb,
+	r := q.head.newResolver(ctx,
 		symdb.WithResolverMaxNodes(maxNodes),
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
@@ -269,9 +270,9 @@ func (q *headOnDiskQuerier) MergeByLabels(ctx context.Context, rows iter.Iterato
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "MergeByLabels - HeadOnDisk")
 	defer sp.Finish()
 	if len(sts.GetCallSite()) == 0 {
-		return mergeByLabels(ctx, q.rowGroup(), "TotalValue", rows, by...)
+		return mergeByLabels(ctx, q.rowGroup(), q.head.symdb, "TotalValue", rows, by...)
 	}
-	r := symdb.NewResolver(ctx, q.head.symdb,
+	r := q.head.newResolver(ctx,
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
 	return mergeByLabelsWithStackTraceSelector(ctx, q.rowGroup(), rows, r, by...)
@@ -299,12 +300,16 @@ func (q *headOnDiskQuerier) SelectMergeByLabels(ctx context.Context, params *ing
 	)
 
 	if len(sts.GetCallSite()) == 0 {
+		if sampleLabelMatchersFromContext(ctx) != nil {
+			// Sample labels are resolved with the partition symbols.
+			it = query.NewBinaryJoinIterator(0, it, q.rowGroup().columnIter(ctx, "StacktracePartition", nil, "StacktracePartition"))
+		}
 		rows := profileBatchIteratorByFingerprints(it, labelsPerFP)
 		defer rows.Close()
-		return mergeByLabels[Profile](ctx, q.rowGroup(), "TotalValue", rows, by...)
+		return mergeByLabels[Profile](ctx, q.rowGroup(), q.head.symdb, "TotalValue", rows, by...)
 	}
 
-	r := symdb.NewResolver(ctx, q.head.symdb,
+	r := q.head.newResolver(ctx,
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
 
@@ -323,7 +328,7 @@ func (q *headOnDiskQuerier) Series(ctx context.Context, params *ingestv1.SeriesR
 func (q *headOnDiskQuerier) MergeBySpans(ctx context.Context, rows iter.Iterator[Profile], spanSelector phlaremodel.SpanSelector) (*phlaremodel.Tree, error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "MergeBySpans")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
 	if err := mergeBySpans(ctx, q.rowGroup(), rows, r, spanSelector); err != nil {
 		return nil, err
@@ -399,11 +404,13 @@ func (q *headInMemoryQuerier) SelectMatchingProfiles(ctx context.Context, params
 	return iter.NewMergeIterator(maxBlockProfile, false, iters...), nil
 }
 
-func (q *headInMemoryQuerier) SelectMergeByStacktraces(ctx context.Context, params *ingestv1.SelectProfilesRequest) (*phlaremodel.Tree, error) {
+func (q *headInMemoryQuerier) SelectMergeByStacktraces(ctx context.Context, params *ingestv1.SelectProfilesRequest) (_ *phlaremodel.Tree, err error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectMergeByStacktraces - HeadInMemory")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
+	m := newSampleLabelsMatcher(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
 	index := q.head.profiles.index
 
 	ids, err := index.selectMatchingFPs(ctx, params)
@@ -432,7 +439,7 @@ func (q *headInMemoryQuerier) SelectMergeByStacktraces(ctx context.Context, para
 			if p.Timestamp() > end {
 				break
 			}
-			r.AddSamples(p.StacktracePartition, p.Samples)
+			r.AddSamples(p.StacktracePartition, m.filterSamples(p.StacktracePartition, p.Samples))
 		}
 	}
 	return r.Tree()
@@ -441,7 +448,7 @@ func (q *headInMemoryQuerier) SelectMergeByStacktraces(ctx context.Context, para
 func (q *headInMemoryQuerier) SelectMergeBySpans(ctx context.Context, params *ingestv1.SelectSpanProfileRequest) (*phlaremodel.Tree, error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectMergeBySpans - HeadInMemory")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
 	index := q.head.profiles.index
 
@@ -489,13 +496,17 @@ func (q *headInMemoryQuerier) SelectMergeBySpans(ctx context.Context, params *in
 	return r.Tree()
 }
 
-func (q *headInMemoryQuerier) SelectMergePprof(ctx context.Context, params *ingestv1.SelectProfilesRequest, maxNodes int64, sts *typesv1.StackTraceSelector) (*profilev1.Profile, error) {
+func (q *headInMemoryQuerier) SelectMergePprof(ctx context.Context, params *ingestv1.SelectProfilesRequest, maxNodes int64, sts *typesv1.StackTraceSelector) (_ *profilev1.Profile, err error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectMergePprof - HeadInMemory")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb,
+	r := q.head.newResolver(ctx,
 		symdb.WithResolverMaxNodes(maxNodes),
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
+	m := newSampleLabelsMatcher(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
+	c := newSampleLabelsCollector(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, c, "failed to release sample labels collector")
 	index := q.head.profiles.index
 
 	ids, err := index.selectMatchingFPs(ctx, params)
@@ -524,7 +535,7 @@ func (q *headInMemoryQuerier) SelectMergePprof(ctx context.Context, params *inge
 			if p.Timestamp() > end {
 				break
 			}
-			r.AddSamples(p.StacktracePartition, p.Samples)
+			c.addSamples(r, p.StacktracePartition, m.filterSamples(p.StacktracePartition, p.Samples))
 		}
 	}
 	return r.Pprof()
@@ -547,17 +558,19 @@ func (q *headInMemoryQuerier) LabelNames(ctx context.Context, req *connect.Reque
 	return q.head.LabelNames(ctx, req)
 }
 
-func (q *headInMemoryQuerier) MergeByStacktraces(ctx context.Context, rows iter.Iterator[Profile]) (*phlaremodel.Tree, error) {
+func (q *headInMemoryQuerier) MergeByStacktraces(ctx context.Context, rows iter.Iterator[Profile]) (_ *phlaremodel.Tree, err error) {
 	sp, _ := opentracing.StartSpanFromContext(ctx, "MergeByStacktraces - HeadInMemory")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
+	m := newSampleLabelsMatcher(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
 	for rows.Next() {
 		p, ok := rows.At().(ProfileWithLabels)
 		if !ok {
 			return nil, errors.New("expected ProfileWithLabels")
 		}
-		r.AddSamples(p.StacktracePartition(), p.Samples())
+		r.AddSamples(p.StacktracePartition(), m.filterSamples(p.StacktracePartition(), p.Samples()))
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -565,19 +578,23 @@ func (q *headInMemoryQuerier) MergeByStacktraces(ctx context.Context, rows iter.
 	return r.Tree()
 }
 
-func (q *headInMemoryQuerier) MergePprof(ctx context.Context, rows iter.Iterator[Profile], maxNodes int64, sts *typesv1.StackTraceSelector) (*profilev1.Profile, error) {
+func (q *headInMemoryQuerier) MergePprof(ctx context.Context, rows iter.Iterator[Profile], maxNodes int64, sts *typesv1.StackTraceSelector) (_ *profilev1.Profile, err error) {
 	sp, _ := opentracing.StartSpanFromContext(ctx, "MergePprof - HeadInMemory")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb,
+	r := q.head.newResolver(ctx,
 		symdb.WithResolverMaxNodes(maxNodes),
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
+	m := newSampleLabelsMatcher(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
+	c := newSampleLabelsCollector(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, c, "failed to release sample labels collector")
 	for rows.Next() {
 		p, ok := rows.At().(ProfileWithLabels)
 		if !ok {
 			return nil, errors.New("expected ProfileWithLabels")
 		}
-		r.AddSamples(p.StacktracePartition(), p.Samples())
+		c.addSamples(r, p.StacktracePartition(), m.filterSamples(p.StacktracePartition(), p.Samples()))
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -590,9 +607,11 @@ func (q *headInMemoryQuerier) MergeByLabels(
 	rows iter.Iterator[Profile],
 	sts *typesv1.StackTraceSelector,
 	by ...string,
-) ([]*typesv1.Series, error) {
+) (_ []*typesv1.Series, err error) {
 	sp, _ := opentracing.StartSpanFromContext(ctx, "MergeByLabels - HeadInMemory")
 	defer sp.Finish()
+	m := newSampleLabelsMatcher(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
 
 	seriesBuilder := seriesBuilder{}
 	seriesBuilder.init(by...)
@@ -603,10 +622,14 @@ func (q *headInMemoryQuerier) MergeByLabels(
 			if !ok {
 				return nil, errors.New("expected ProfileWithLabels")
 			}
-			seriesBuilder.add(p.Fingerprint(), p.Labels(), int64(p.Timestamp()), float64(p.Total()))
+			total := float64(p.Total())
+			if m != nil {
+				total = float64(m.filterSamples(p.StacktracePartition(), p.Samples()).Sum())
+			}
+			seriesBuilder.add(p.Fingerprint(), p.Labels(), int64(p.Timestamp()), total)
 		}
 	} else {
-		r := symdb.NewResolver(ctx, q.head.symdb,
+		r := q.head.newResolver(ctx,
 			symdb.WithResolverStackTraceSelector(sts))
 		defer r.Release()
 		var v symdb.CallSiteValues
@@ -615,7 +638,7 @@ func (q *headInMemoryQuerier) MergeByLabels(
 			if !ok {
 				return nil, errors.New("expected ProfileWithLabels")
 			}
-			if err := r.CallSiteValues(&v, p.StacktracePartition(), p.Samples()); err != nil {
+			if err := r.CallSiteValues(&v, p.StacktracePartition(), m.filterSamples(p.StacktracePartition(), p.Samples())); err != nil {
 				return nil, err
 			}
 			seriesBuilder.add(p.Fingerprint(), p.Labels(), int64(p.Timestamp()), float64(v.Total))
@@ -634,9 +657,11 @@ func (q *headInMemoryQuerier) SelectMergeByLabels(
 	params *ingestv1.SelectProfilesRequest,
 	sts *typesv1.StackTraceSelector,
 	by ...string,
-) ([]*typesv1.Series, error) {
+) (_ []*typesv1.Series, err error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectMergeByLabels - HeadInMemory")
 	defer sp.Finish()
+	m := newSampleLabelsMatcher(ctx, q.head.symdb)
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
 
 	index := q.head.profiles.index
 
@@ -669,11 +694,15 @@ func (q *headInMemoryQuerier) SelectMergeByLabels(
 				if p.Timestamp() > end {
 					break
 				}
-				seriesBuilder.add(fp, profileSeries.lbs, int64(p.Timestamp()), float64(p.Total()))
+				total := float64(p.Total())
+				if m != nil {
+					total = float64(m.filterSamples(p.StacktracePartition, p.Samples).Sum())
+				}
+				seriesBuilder.add(fp, profileSeries.lbs, int64(p.Timestamp()), total)
 			}
 		}
 	} else {
-		r := symdb.NewResolver(ctx, q.head.symdb,
+		r := q.head.newResolver(ctx,
 			symdb.WithResolverStackTraceSelector(sts))
 		defer r.Release()
 		var v symdb.CallSiteValues
@@ -689,7 +718,7 @@ func (q *headInMemoryQuerier) SelectMergeByLabels(
 				if p.Timestamp() > end {
 					break
 				}
-				if err = r.CallSiteValues(&v, p.StacktracePartition, p.Samples); err != nil {
+				if err = r.CallSiteValues(&v, p.StacktracePartition, m.filterSamples(p.StacktracePartition, p.Samples)); err != nil {
 					return nil, err
 				}
 				seriesBuilder.add(fp, profileSeries.lbs, int64(p.Timestamp()), float64(v.Total))
@@ -710,7 +739,7 @@ func (q *headInMemoryQuerier) Series(ctx context.Context, params *ingestv1.Serie
 func (q *headInMemoryQuerier) MergeBySpans(ctx context.Context, rows iter.Iterator[Profile], spanSelector phlaremodel.SpanSelector) (*phlaremodel.Tree, error) {
 	sp, _ := opentracing.StartSpanFromContext(ctx, "MergeBySpans - HeadInMemory")
 	defer sp.Finish()
-	r := symdb.NewResolver(ctx, q.head.symdb)
+	r := q.head.newResolver(ctx)
 	defer r.Release()
 	for rows.Next() {
 		p, ok := rows.At().(ProfileWithLabels)
@@ -13,6 +13,7 @@ import (
 	"github.com/oklog/ulid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
@@ -236,6 +237,47 @@ func TestHeadSeries(t *testing.T) {
 	require.Contains(t, res.Msg.LabelsSet, &typesv1.Labels{Labels: jobBar})
 }
 
+func TestHeadSelectMatchingFPs_QueryShard(t *testing.T) {
+	head := newTestHead(t)
+	for i := 0; i < 32; i++ {
+		lbs := phlaremodel.NewLabelsBuilder(nil).Set("job", "foo").Set("instance", fmt.Sprint(i)).Labels()
+		require.NoError(t, head.Ingest(context.Background(), newProfileFoo(), uuid.New(), lbs...))
+	}
+
+	profileType, err := phlaremodel.ParseProfileTypeSelector(":type:unit:type:unit")
+	require.NoError(t, err)
+	selectFPs := func(selector string) []model.Fingerprint {
+		fps, err := head.profiles.index.selectMatchingFPs(context.Background(), &ingestv1.SelectProfilesRequest{
+			LabelSelector: selector,
+			Type:          profileType,
+		})
+		require.NoError(t, err)
+		return fps
+	}
+
+	all := selectFPs(`{job="foo"}`)
+	require.Len(t, all, 32)
+
+	const shards = 4
+	seen := make(map[model.Fingerprint]struct{})
+	for i := 1; i <= shards; i++ {
+		fps := selectFPs(fmt.Sprintf(`{job="foo",__query_shard__="%d_of_%d"}`, i, shards))
+		for _, fp := range fps {
+			require.Equal(t, uint64(i-1), uint64(fp)%shards)
+			_, dup := seen[fp]
+			require.False(t, dup)
+			seen[fp] = struct{}{}
+		}
+	}
+	require.Len(t, seen, len(all))
+
+	_, err = head.profiles.index.selectMatchingFPs(context.Background(), &ingestv1.SelectProfilesRequest{
+		LabelSelector: `{__query_shard__="5_of_4"}`,
+		Type:          profileType,
+	})
+	require.Error(t, err)
+}
+
 func TestHeadProfileTypes(t *testing.T) {
 	head := newTestHead(t)
 	require.NoError(t, head.Ingest(context.Background(), newProfileFoo(), uuid.New(), &typesv1.LabelPair{Name: "__name__", Value: "foo"}, &typesv1.LabelPair{Name: "job", Value: "foo"}, &typesv1.LabelPair{Name: "namespace", Value: "phlare"}))
@@ -350,7 +392,8 @@ func TestHeadFlush(t *testing.T) {
 					head.meta.ULID,
 				},
 			},
-			Version: 3,
+			Version:    3,
+			LabelBloom: head.meta.LabelBloom,
 		},
 	}
 
@@ -365,6 +408,15 @@ func TestHeadFlush(t *testing.T) {
 	}
 
 	require.Equal(t, expectedMeta, metas)
+
+	// The label bloom filter summarizes the profile types of the block.
+	require.NotNil(t, head.meta.LabelBloom)
+	mayMatch := func(profileType string) bool {
+		return metas[0].MayMatch(labels.MustNewMatcher(labels.MatchEqual, phlaremodel.LabelNameProfileType, profileType))
+	}
+	require.True(t, mayMatch(":inuse_space:bytes:space:bytes"))
+	require.True(t, mayMatch(":cpu:nanoseconds:cpu:nanoseconds"))
+	require.False(t, mayMatch(":contentions:count:contentions:count"))
 }
 
 // TestHead_Concurrent_Ingest_Querying tests that the head can handle concurrent reads and writes.
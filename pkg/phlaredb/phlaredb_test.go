@@ -2,6 +2,7 @@ package phlaredb
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"os"
 	"testing"
@@ -22,6 +23,8 @@ import (
 	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/phlaredb/query"
+	"github.com/grafana/pyroscope/pkg/querier/stats"
 	"github.com/grafana/pyroscope/pkg/testhelper"
 )
 
@@ -532,6 +535,103 @@ func Test_FlushNotInitializedHead(t *testing.T) {
 	require.NoError(t, db.Flush(ctx, true, ""))
 }
 
+type readLimitedIngesterHandler struct {
+	*ingesterHandlerPhlareDB
+	maxBytes int
+}
+
+func (i *readLimitedIngesterHandler) MaxQueryBytes(string) int { return i.maxBytes }
+
+func (i *readLimitedIngesterHandler) MergeProfilesStacktraces(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeProfilesStacktracesRequest, ingestv1.MergeProfilesStacktracesResponse]) error {
+	return LimitReads(ctx, i, "tenant", func(r *query.ReadStats) {
+		stats.SetReadHeaders(stream.ResponseTrailer(), r.Bytes(), r.Rows(), r.Stacktraces())
+	}, func(ctx context.Context) error {
+		return MergeProfilesStacktraces(ctx, stream, i.forTimeRange)
+	})
+}
+
+func TestLimitReads(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	var (
+		ctx   = testContext(t)
+		end   = time.Unix(0, int64(time.Hour))
+		start = end.Add(-time.Minute)
+		step  = 15 * time.Second
+	)
+	db, err := New(ctx, Config{
+		DataPath:         contextDataDir(ctx),
+		MaxBlockDuration: time.Duration(100000) * time.Minute,
+	}, NoLimit, ctx.localBucketClient)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	ingestProfiles(t, db, cpuProfileGenerator, start.UnixNano(), end.UnixNano(), step,
+		&typesv1.LabelPair{Name: "pod", Value: "my-pod"},
+	)
+	require.NoError(t, db.Flush(ctx, true, ""))
+
+	newClient := func(maxBytes int) (ingesterv1connect.IngesterServiceClient, func()) {
+		mux := http.NewServeMux()
+		mux.Handle(ingesterv1connect.NewIngesterServiceHandler(&readLimitedIngesterHandler{
+			ingesterHandlerPhlareDB: &ingesterHandlerPhlareDB{db.queriers()},
+			maxBytes:                maxBytes,
+		}))
+		serv := testhelper.NewInMemoryServer(mux)
+		return ingesterv1connect.NewIngesterServiceClient(serv.Client(), serv.URL()), serv.Close
+	}
+	request := &ingestv1.MergeProfilesStacktracesRequest{
+		Request: &ingestv1.SelectProfilesRequest{
+			LabelSelector: `{pod="my-pod"}`,
+			Type:          mustParseProfileSelector(t, "process_cpu:cpu:nanoseconds:cpu:nanoseconds"),
+			Start:         start.UnixMilli(),
+			End:           end.UnixMilli(),
+		},
+	}
+
+	t.Run("data read is reported in the trailer", func(t *testing.T) {
+		client, cleanup := newClient(0)
+		defer cleanup()
+		bidi := client.MergeProfilesStacktraces(ctx)
+		require.NoError(t, bidi.Send(request))
+		resp, err := bidi.Receive()
+		require.NoError(t, err)
+		require.Len(t, resp.SelectedProfiles.Profiles, 5)
+		require.NoError(t, bidi.Send(&ingestv1.MergeProfilesStacktracesRequest{
+			Profiles: []bool{true, true, true, true, true},
+		}))
+		resp, err = bidi.Receive()
+		require.NoError(t, err)
+		require.Nil(t, resp.Result)
+		resp, err = bidi.Receive()
+		require.NoError(t, err)
+		require.NotNil(t, resp.Result)
+		_, err = bidi.Receive()
+		require.ErrorIs(t, err, io.EOF)
+
+		s := new(stats.Stats)
+		s.AddReadHeaders(bidi.ResponseTrailer())
+		require.NotZero(t, s.LoadReadBytes())
+		require.NotZero(t, s.LoadScannedRows())
+		require.NotZero(t, s.LoadResolvedStacktraces())
+		require.NoError(t, bidi.CloseRequest())
+		require.NoError(t, bidi.CloseResponse())
+	})
+
+	t.Run("query exceeding the limit fails", func(t *testing.T) {
+		client, cleanup := newClient(1)
+		defer cleanup()
+		bidi := client.MergeProfilesStacktraces(ctx)
+		require.NoError(t, bidi.Send(request))
+		_, err := bidi.Receive()
+		require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+		require.ErrorContains(t, err, "max_query_bytes")
+		require.NoError(t, bidi.CloseRequest())
+		require.NoError(t, bidi.CloseResponse())
+	})
+}
+
 func Test_endRangeForTimestamp(t *testing.T) {
 	for _, tt := range []struct {
 		name     string
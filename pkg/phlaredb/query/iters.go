@@ -846,6 +846,7 @@ type SyncIterator struct {
 	cancel          func()
 	span            opentracing.Span
 	metrics         *Metrics
+	readStats       *ReadStats
 	curr            RowNumber
 	currRowGroup    parquet.RowGroup
 	currRowGroupMin RowNumber
@@ -912,6 +913,7 @@ func NewSyncIterator(ctx context.Context, rgs []parquet.RowGroup, column int, co
 		ctx:        ctx,
 		cancel:     cancel,
 		metrics:    getMetricsFromContext(ctx),
+		readStats:  ReadStatsFromContext(ctx),
 		span:       span,
 		column:     column,
 		columnName: columnName,
@@ -1080,6 +1082,11 @@ func (c *SyncIterator) seekPages(seekTo RowNumber, definitionLevel int) (done bo
 				log.Int64("page_num_values", pg.NumValues()),
 				log.Int64("page_size", pg.Size()),
 			)
+			if err = c.readStats.addPage(pg); err != nil {
+				parquet.Release(pg)
+				c.closeCurrRowGroup()
+				return true, err
+			}
 
 			// Skip based on row number?
 			newRN := c.curr
@@ -1149,6 +1156,10 @@ func (c *SyncIterator) next() (RowNumber, *parquet.Value, error) {
 				log.Int64("page_num_values", pg.NumValues()),
 				log.Int64("page_size", pg.Size()),
 			)
+			if err = c.readStats.addPage(pg); err != nil {
+				parquet.Release(pg)
+				return EmptyRowNumber(), nil, err
+			}
 
 			if c.filter != nil && !c.filter.KeepPage(pg) {
 				// This page filtered out
@@ -267,6 +267,34 @@ func TestColumnIteratorExitEarly(t *testing.T) {
 	})
 }
 
+func TestColumnIteratorReadStats(t *testing.T) {
+	count := 10_000
+	pf := createTestFile(t, count)
+	idx, _ := GetColumnIndexByPath(pf.Root(), "A")
+
+	t.Run("accounted", func(t *testing.T) {
+		stats := NewReadStats(0)
+		iter := NewSyncIterator(AddReadStatsToContext(context.TODO(), stats), pf.RowGroups(), idx, "", 1000, nil, "A")
+		defer iter.Close()
+		n := 0
+		for iter.Next() {
+			n++
+		}
+		require.NoError(t, iter.Err())
+		require.Equal(t, count, n)
+		require.Equal(t, uint64(count), stats.Rows())
+		require.NotZero(t, stats.Bytes())
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		stats := NewReadStats(1)
+		iter := NewSyncIterator(AddReadStatsToContext(context.TODO(), stats), pf.RowGroups(), idx, "", 1000, nil, "A")
+		defer iter.Close()
+		require.False(t, iter.Next())
+		require.ErrorIs(t, iter.Err(), ErrReadBytesLimitExceeded)
+	})
+}
+
 func BenchmarkColumnIterator(b *testing.B) {
 	for _, tc := range iterTestCases {
 		b.Run(tc.name, func(b *testing.B) {
@@ -12,6 +12,7 @@ type contextKey uint8
 
 const (
 	metricsContextKey contextKey = iota
+	readStatsContextKey
 )
 
 type Metrics struct {
@@ -0,0 +1,78 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/parquet-go/parquet-go"
+	"go.uber.org/atomic"
+)
+
+// ErrReadBytesLimitExceeded is returned by the iterators of a query once
+// the data read from parquet pages exceeds the limit of the query.
+var ErrReadBytesLimitExceeded = errors.New("the query exceeds the read bytes limit")
+
+// ReadStats accounts the data read from parquet pages by the iterators of
+// a query. It is shared by all the iterators of the query, and fails them
+// once the number of bytes read exceeds the limit. The stack traces resolved
+// for the query are accounted as well.
+type ReadStats struct {
+	bytes       atomic.Uint64
+	rows        atomic.Uint64
+	stacktraces atomic.Uint64
+	maxBytes    uint64
+}
+
+// NewReadStats returns read stats limiting the number of bytes read to
+// maxBytes. The number of bytes read is not limited if maxBytes is 0.
+func NewReadStats(maxBytes int) *ReadStats {
+	s := &ReadStats{}
+	if maxBytes > 0 {
+		s.maxBytes = uint64(maxBytes)
+	}
+	return s
+}
+
+func AddReadStatsToContext(ctx context.Context, s *ReadStats) context.Context {
+	return context.WithValue(ctx, readStatsContextKey, s)
+}
+
+// ReadStatsFromContext returns the read stats of the query,
+// or nil if the data read is not accounted.
+func ReadStatsFromContext(ctx context.Context) *ReadStats {
+	s, _ := ctx.Value(readStatsContextKey).(*ReadStats)
+	return s
+}
+
+// Bytes returns the number of bytes of the pages read.
+func (s *ReadStats) Bytes() uint64 { return s.bytes.Load() }
+
+// Rows returns the number of rows of the pages read. Rows are
+// accounted once for every column read.
+func (s *ReadStats) Rows() uint64 { return s.rows.Load() }
+
+// Stacktraces returns the number of stack traces resolved.
+func (s *ReadStats) Stacktraces() uint64 { return s.stacktraces.Load() }
+
+// AddStacktraces accounts stack traces resolved.
+func (s *ReadStats) AddStacktraces(n int) {
+	if s == nil {
+		return
+	}
+	s.stacktraces.Add(uint64(n))
+}
+
+// addPage accounts the page read, and returns an error
+// if the query exceeds the limit of bytes read.
+func (s *ReadStats) addPage(pg parquet.Page) error {
+	if s == nil {
+		return nil
+	}
+	s.rows.Add(uint64(pg.NumRows()))
+	bytes := s.bytes.Add(uint64(pg.Size()))
+	if s.maxBytes > 0 && bytes > s.maxBytes {
+		return fmt.Errorf("%w (max_query_bytes, actual: %d, limit: %d)", ErrReadBytesLimitExceeded, bytes, s.maxBytes)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/grafana/pyroscope/pkg/iter"
+)
+
+// sampleLabelsRepetitionLevel is the repetition level of the
+// Samples.list.element.Labels.list.element columns: values of the
+// same sample have the level of 2, while the first label of a sample
+// has the repetition level of the sample itself (0 or 1).
+const sampleLabelsRepetitionLevel = 2
+
+type sampleLabelsFilterIterator[T any] struct {
+	iter.Iterator[RepeatedRow[T]]
+	match  func(row T, keys, values []parquet.Value) bool
+	values [][]parquet.Value
+	cur    RepeatedRow[T]
+}
+
+// NewSampleLabelsFilterIterator filters samples of the repeated rows
+// by their pprof labels. The last two columns of the rows must be the
+// sample label keys and values; they are not included in the rows
+// returned by the iterator. The match function is called for every
+// sample with its label keys and values, which are empty if the sample
+// has no labels; samples that do not match are removed from the other
+// columns.
+func NewSampleLabelsFilterIterator[T any](
+	it iter.Iterator[RepeatedRow[T]],
+	match func(row T, keys, values []parquet.Value) bool,
+) iter.Iterator[RepeatedRow[T]] {
+	return &sampleLabelsFilterIterator[T]{
+		Iterator: it,
+		match:    match,
+	}
+}
+
+func (x *sampleLabelsFilterIterator[T]) Next() bool {
+	if !x.Iterator.Next() {
+		return false
+	}
+	r := x.Iterator.At()
+	n := len(r.Values) - 2
+	keys, values := r.Values[n], r.Values[n+1]
+	x.values = append(x.values[:0], r.Values[:n]...)
+	var sample, kept int
+	for i := 0; i < len(keys); sample++ {
+		j := i + 1
+		for j < len(keys) && keys[j].RepetitionLevel() == sampleLabelsRepetitionLevel {
+			j++
+		}
+		k, v := keys[i:j], values[i:j]
+		if keys[i].DefinitionLevel() < sampleLabelsRepetitionLevel {
+			// The sample has no labels.
+			k, v = nil, nil
+		}
+		if x.match(r.Row, k, v) {
+			for c := range x.values {
+				x.values[c][kept] = x.values[c][sample]
+			}
+			kept++
+		}
+		i = j
+	}
+	for c := range x.values {
+		x.values[c] = x.values[c][:kept]
+	}
+	x.cur = RepeatedRow[T]{Row: r.Row, Values: x.values}
+	return true
+}
+
+func (x *sampleLabelsFilterIterator[T]) At() RepeatedRow[T] { return x.cur }
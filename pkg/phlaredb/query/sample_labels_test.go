@@ -0,0 +1,71 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/iter"
+)
+
+func Test_SampleLabelsFilterIterator(t *testing.T) {
+	// Sample values, label keys, and label values of a profile with
+	// three samples: {1: "a"}, {}, and {1: "b", 2: "c"}.
+	row := RepeatedRow[int]{
+		Row: 1,
+		Values: [][]parquet.Value{
+			{
+				parquet.Int64Value(10).Level(0, 1, 0),
+				parquet.Int64Value(20).Level(1, 1, 0),
+				parquet.Int64Value(30).Level(1, 1, 0),
+			},
+			{
+				parquet.Int64Value(1).Level(0, 2, 1),
+				parquet.Value{}.Level(1, 1, 1),
+				parquet.Int64Value(1).Level(1, 2, 1),
+				parquet.Int64Value(2).Level(2, 2, 1),
+			},
+			{
+				parquet.Int64Value(3).Level(0, 3, 2),
+				parquet.Value{}.Level(1, 1, 2),
+				parquet.Int64Value(4).Level(1, 3, 2),
+				parquet.Int64Value(5).Level(2, 3, 2),
+			},
+		},
+	}
+
+	type sample struct {
+		keys, values []int64
+	}
+
+	var samples []sample
+	it := NewSampleLabelsFilterIterator(
+		iter.NewSliceIterator([]RepeatedRow[int]{row}),
+		func(r int, keys, values []parquet.Value) bool {
+			require.Equal(t, 1, r)
+			var s sample
+			for i := range keys {
+				s.keys = append(s.keys, keys[i].Int64())
+				s.values = append(s.values, values[i].Int64())
+			}
+			samples = append(samples, s)
+			// Keep samples with the label 1.
+			return len(keys) > 0 && keys[0].Int64() == 1
+		},
+	)
+
+	require.True(t, it.Next())
+	actual := it.At()
+	require.Len(t, actual.Values, 1)
+	require.Equal(t, []int64{10, 30}, []int64{actual.Values[0][0].Int64(), actual.Values[0][1].Int64()})
+	require.Len(t, actual.Values[0], 2)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+
+	require.Equal(t, []sample{
+		{keys: []int64{1}, values: []int64{3}},
+		{},
+		{keys: []int64{1, 2}, values: []int64{4, 5}},
+	}, samples)
+}
@@ -0,0 +1,30 @@
+package phlaredb
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	"github.com/grafana/pyroscope/pkg/phlaredb/query"
+)
+
+// ReadLimits are the per-tenant limits of the data read by queries.
+type ReadLimits interface {
+	MaxQueryBytes(tenantID string) int
+}
+
+// LimitReads executes the query with the bytes it reads from parquet pages
+// limited to the max_query_bytes limit of the tenant, and calls report with
+// the data read once the query is done, even if it fails. The query fails
+// with a resource exhausted error as soon as it exceeds the limit. The bytes
+// read are not limited if the limit is 0.
+func LimitReads(ctx context.Context, limits ReadLimits, tenantID string, report func(*query.ReadStats), fn func(context.Context) error) error {
+	s := query.NewReadStats(limits.MaxQueryBytes(tenantID))
+	err := fn(query.AddReadStatsToContext(ctx, s))
+	report(s)
+	if errors.Is(err, query.ErrReadBytesLimitExceeded) {
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	return err
+}
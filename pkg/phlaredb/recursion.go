@@ -0,0 +1,33 @@
+package phlaredb
+
+import (
+	"context"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/query"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+type recursionCollapseContextKey struct{}
+
+// withRecursionCollapse returns a context with the recursion collapse
+// mode of the query: resolvers created with the context collapse the
+// recursive calls in the resulting profiles.
+func withRecursionCollapse(ctx context.Context, mode typesv1.RecursionCollapse) context.Context {
+	if mode == typesv1.RecursionCollapse_RECURSION_COLLAPSE_NONE {
+		return ctx
+	}
+	return context.WithValue(ctx, recursionCollapseContextKey{}, mode)
+}
+
+// contextResolverOptions appends the resolver options
+// specified in the query context to opts.
+func contextResolverOptions(ctx context.Context, opts []symdb.ResolverOption) []symdb.ResolverOption {
+	if mode, ok := ctx.Value(recursionCollapseContextKey{}).(typesv1.RecursionCollapse); ok {
+		opts = append(opts, symdb.WithResolverCollapseRecursion(mode))
+	}
+	if s := query.ReadStatsFromContext(ctx); s != nil {
+		opts = append(opts, symdb.WithResolverStacktracesCounter(s.AddStacktraces))
+	}
+	return opts
+}
@@ -0,0 +1,374 @@
+package phlaredb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/iter"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/phlaredb/query"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+type sampleLabelMatchersContextKey struct{}
+
+// withSampleLabelSelector returns a context with the sample label
+// matchers parsed from the selector: samples that do not match the
+// selector are excluded from the query results.
+func withSampleLabelSelector(ctx context.Context, selector string) (context.Context, error) {
+	if selector == "" {
+		return ctx, nil
+	}
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "failed to parse sample label selector: "+err.Error())
+	}
+	return context.WithValue(ctx, sampleLabelMatchersContextKey{}, matchers), nil
+}
+
+func sampleLabelMatchersFromContext(ctx context.Context) []*labels.Matcher {
+	m, _ := ctx.Value(sampleLabelMatchersContextKey{}).([]*labels.Matcher)
+	return m
+}
+
+// sampleLabelsMatcher matches sample labels against the sample label
+// matchers of the query. Sample labels reference the partition string
+// table, therefore the matcher label names are resolved per partition.
+// A label that is not present in the sample has an empty value.
+type sampleLabelsMatcher struct {
+	ctx        context.Context
+	symbols    symdb.SymbolsReader
+	matchers   []*labels.Matcher
+	partitions map[uint64]*sampleLabelsPartition
+	labels     []schemav1.SampleLabel
+	err        error
+}
+
+type sampleLabelsPartition struct {
+	reader  symdb.PartitionReader
+	strings []string
+	// References to the matcher label names;
+	// -1, if the name is not present in the partition.
+	names []int64
+}
+
+// newSampleLabelsMatcher returns nil, if the query
+// does not have sample label matchers.
+func newSampleLabelsMatcher(ctx context.Context, symbols symdb.SymbolsReader) *sampleLabelsMatcher {
+	matchers := sampleLabelMatchersFromContext(ctx)
+	if len(matchers) == 0 {
+		return nil
+	}
+	return &sampleLabelsMatcher{
+		ctx:        ctx,
+		symbols:    symbols,
+		matchers:   matchers,
+		partitions: make(map[uint64]*sampleLabelsPartition),
+	}
+}
+
+func (m *sampleLabelsMatcher) partition(partition uint64) *sampleLabelsPartition {
+	if p, ok := m.partitions[partition]; ok {
+		return p
+	}
+	names := make([]string, len(m.matchers))
+	for i, matcher := range m.matchers {
+		names[i] = matcher.Name
+	}
+	p, err := newSampleLabelsPartition(m.ctx, m.symbols, partition, names)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	m.partitions[partition] = p
+	return p
+}
+
+func newSampleLabelsPartition(ctx context.Context, symbols symdb.SymbolsReader, partition uint64, names []string) (*sampleLabelsPartition, error) {
+	r, err := symbols.Partition(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	p := &sampleLabelsPartition{
+		reader:  r,
+		strings: r.Symbols().Strings,
+		names:   make([]int64, len(names)),
+	}
+	for i, name := range names {
+		p.names[i] = -1
+		for j, s := range p.strings {
+			if s == name {
+				p.names[i] = int64(j)
+				break
+			}
+		}
+	}
+	return p, nil
+}
+
+func (m *sampleLabelsMatcher) matches(partition uint64, ls []schemav1.SampleLabel) bool {
+	p := m.partition(partition)
+	if p == nil {
+		return false
+	}
+	for i, matcher := range m.matchers {
+		var value string
+		for _, l := range ls {
+			if int64(l.Key) == p.names[i] {
+				value = p.strings[l.Value]
+				break
+			}
+		}
+		if !matcher.Matches(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *sampleLabelsMatcher) matchesParquet(partition uint64, keys, values []parquet.Value) bool {
+	m.labels = m.labels[:0]
+	for i := range keys {
+		m.labels = append(m.labels, schemav1.SampleLabel{
+			Key:   keys[i].Uint32(),
+			Value: values[i].Uint32(),
+		})
+	}
+	return m.matches(partition, m.labels)
+}
+
+// forColumns returns the matcher to filter the samples with the columns.
+// Blocks written before the sample labels were stored don't have the label
+// columns: their samples have no labels, therefore either all or none of
+// them match. In the former case, the matcher returned is nil; in the
+// latter, ok is false.
+func (m *sampleLabelsMatcher) forColumns(columns *schemav1.SampleColumns) (_ *sampleLabelsMatcher, ok bool) {
+	if m == nil || columns.HasLabels() {
+		return m, true
+	}
+	for _, matcher := range m.matchers {
+		if !matcher.Matches("") {
+			return nil, false
+		}
+	}
+	return nil, true
+}
+
+// filterSamples returns samples that match the selector. The source
+// samples are not modified. If the matcher is nil, the samples are
+// returned as is.
+func (m *sampleLabelsMatcher) filterSamples(partition uint64, samples schemav1.Samples) schemav1.Samples {
+	if m == nil {
+		return samples
+	}
+	filtered := schemav1.NewSamples(len(samples.StacktraceIDs))
+	for i := range samples.StacktraceIDs {
+		var ls []schemav1.SampleLabel
+		if len(samples.Labels) > 0 {
+			ls = samples.Labels[i]
+		}
+		if m.matches(partition, ls) {
+			filtered.StacktraceIDs = append(filtered.StacktraceIDs, samples.StacktraceIDs[i])
+			filtered.Values = append(filtered.Values, samples.Values[i])
+			if len(samples.Spans) > 0 {
+				filtered.Spans = append(filtered.Spans, samples.Spans[i])
+			}
+			if len(samples.Labels) > 0 {
+				filtered.Labels = append(filtered.Labels, samples.Labels[i])
+			}
+		}
+	}
+	return filtered
+}
+
+// Close releases the partitions acquired and returns
+// the first error occurred, if any.
+func (m *sampleLabelsMatcher) Close() error {
+	if m == nil {
+		return nil
+	}
+	for _, p := range m.partitions {
+		p.reader.Release()
+	}
+	return m.err
+}
+
+// filterSamplesByLabels filters samples of the profile rows with the
+// matcher. The rows must have the sample label key and value columns
+// as the last two ones; the columns are not included into the result.
+func filterSamplesByLabels[T interface{ StacktracePartition() uint64 }](
+	rows iter.Iterator[query.RepeatedRow[T]],
+	m *sampleLabelsMatcher,
+) iter.Iterator[query.RepeatedRow[T]] {
+	return query.NewSampleLabelsFilterIterator(rows, func(row T, keys, values []parquet.Value) bool {
+		return m.matchesParquet(row.StacktracePartition(), keys, values)
+	})
+}
+
+type retainedSampleLabelsContextKey struct{}
+
+// withRetainedSampleLabels returns a context with the names of the
+// sample labels to retain in the resulting pprof profile. The span ID
+// of samples is retained as the span_id label.
+func withRetainedSampleLabels(ctx context.Context, names []string) context.Context {
+	if len(names) == 0 {
+		return ctx
+	}
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+	return context.WithValue(ctx, retainedSampleLabelsContextKey{}, lo.Uniq(names))
+}
+
+func retainedSampleLabelsFromContext(ctx context.Context) []string {
+	names, _ := ctx.Value(retainedSampleLabelsContextKey{}).([]string)
+	return names
+}
+
+// sampleLabelsCollector resolves the sample labels to be retained in
+// the resulting profile. Similarly to sampleLabelsMatcher, the label
+// names are resolved per partition.
+type sampleLabelsCollector struct {
+	ctx        context.Context
+	symbols    symdb.SymbolsReader
+	names      []string // Sorted.
+	spanID     int      // Index of span_id in names; -1, if not retained.
+	partitions map[uint64]*sampleLabelsPartition
+	labels     []phlaremodel.Labels
+	err        error
+}
+
+// newSampleLabelsCollector returns nil, if the query
+// does not retain sample labels.
+func newSampleLabelsCollector(ctx context.Context, symbols symdb.SymbolsReader) *sampleLabelsCollector {
+	names := retainedSampleLabelsFromContext(ctx)
+	if len(names) == 0 {
+		return nil
+	}
+	c := &sampleLabelsCollector{
+		ctx:        ctx,
+		symbols:    symbols,
+		names:      names,
+		spanID:     -1,
+		partitions: make(map[uint64]*sampleLabelsPartition),
+	}
+	for i, name := range names {
+		if name == pprof.SpanIDLabelName {
+			c.spanID = i
+		}
+	}
+	return c
+}
+
+func (c *sampleLabelsCollector) partition(partition uint64) *sampleLabelsPartition {
+	if p, ok := c.partitions[partition]; ok {
+		return p
+	}
+	p, err := newSampleLabelsPartition(c.ctx, c.symbols, partition, c.names)
+	if err != nil {
+		c.err = err
+		return nil
+	}
+	c.partitions[partition] = p
+	return p
+}
+
+// sampleLabels returns the retained labels of the sample,
+// sorted by name. Labels with empty values are omitted.
+func (c *sampleLabelsCollector) sampleLabels(partition uint64, ls []schemav1.SampleLabel, spanID uint64) phlaremodel.Labels {
+	p := c.partition(partition)
+	if p == nil {
+		return nil
+	}
+	var labels phlaremodel.Labels
+	for i, name := range c.names {
+		var value string
+		if i == c.spanID {
+			if spanID != 0 {
+				value = pprof.SpanIDString(spanID)
+			}
+		} else if p.names[i] >= 0 {
+			for _, l := range ls {
+				if int64(l.Key) == p.names[i] {
+					value = p.strings[l.Value]
+					break
+				}
+			}
+		}
+		if value != "" {
+			labels = append(labels, &typesv1.LabelPair{Name: name, Value: value})
+		}
+	}
+	return labels
+}
+
+// collectParquet collects the labels of the next sample of the row;
+// the labels are accessible with the index of the sample in the row
+// until reset is called.
+func (c *sampleLabelsCollector) collectParquet(partition uint64, keys, values []parquet.Value) {
+	ls := make([]schemav1.SampleLabel, len(keys))
+	for i := range keys {
+		ls[i] = schemav1.SampleLabel{
+			Key:   keys[i].Uint32(),
+			Value: values[i].Uint32(),
+		}
+	}
+	c.labels = append(c.labels, c.sampleLabels(partition, ls, 0))
+}
+
+func (c *sampleLabelsCollector) reset() { c.labels = c.labels[:0] }
+
+// withSpanIDLabel returns a copy of the labels with the span_id label.
+func withSpanIDLabel(ls phlaremodel.Labels, spanID uint64) phlaremodel.Labels {
+	i := sort.Search(len(ls), func(i int) bool { return ls[i].Name > pprof.SpanIDLabelName })
+	labels := make(phlaremodel.Labels, 0, len(ls)+1)
+	labels = append(labels, ls[:i]...)
+	labels = append(labels, &typesv1.LabelPair{Name: pprof.SpanIDLabelName, Value: pprof.SpanIDString(spanID)})
+	return append(labels, ls[i:]...)
+}
+
+// addSamples adds the samples to the resolver with their labels retained.
+// If the collector is nil, the samples are added without labels.
+func (c *sampleLabelsCollector) addSamples(r *symdb.Resolver, partition uint64, samples schemav1.Samples) {
+	if c == nil {
+		r.AddSamples(partition, samples)
+		return
+	}
+	r.WithPartitionLabeledSamples(partition, func(s *symdb.LabeledSamples) {
+		for i, sid := range samples.StacktraceIDs {
+			if sid == 0 {
+				continue
+			}
+			var ls []schemav1.SampleLabel
+			if len(samples.Labels) > 0 {
+				ls = samples.Labels[i]
+			}
+			var spanID uint64
+			if len(samples.Spans) > 0 {
+				spanID = samples.Spans[i]
+			}
+			s.Add(c.sampleLabels(partition, ls, spanID), sid, int64(samples.Values[i]))
+		}
+	})
+}
+
+// Close releases the partitions acquired and returns
+// the first error occurred, if any.
+func (c *sampleLabelsCollector) Close() error {
+	if c == nil {
+		return nil
+	}
+	for _, p := range c.partitions {
+		p.reader.Release()
+	}
+	return c.err
+}
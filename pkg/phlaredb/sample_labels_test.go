@@ -0,0 +1,340 @@
+package phlaredb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/objstore/providers/filesystem"
+	"github.com/grafana/pyroscope/pkg/phlaredb/block"
+	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/pprof/testhelper"
+)
+
+func TestSelectMerge_SampleLabels(t *testing.T) {
+	ctx := testContext(t)
+	head, err := NewHead(ctx, Config{DataPath: t.TempDir()}, NoLimit)
+	require.NoError(t, err)
+
+	p := testhelper.NewProfileBuilder(int64(1))
+	p.CPUProfile()
+	p.ForStacktraceString("main", "checkout").AddSamples(1)
+	p.ForStacktraceString("main", "cart").AddSamples(2)
+	p.ForStacktraceString("main", "idle").AddSamples(4)
+	p.ForStacktraceString("main", "cart").AddSamples(8)
+	withSampleLabels(p.Profile, 0, "handler", "/checkout")
+	withSampleLabels(p.Profile, 1, "handler", "/cart", "user", "a")
+	withSampleLabels(p.Profile, 3, "handler", "/cart", "user", "b")
+	require.NoError(t, head.Ingest(ctx, p.Profile, p.UUID, p.Labels...))
+
+	params := &ingestv1.SelectProfilesRequest{
+		LabelSelector: `{}`,
+		Type:          mustParseProfileSelector(t, "process_cpu:cpu:nanoseconds:cpu:nanoseconds"),
+		Start:         0,
+		End:           1,
+	}
+
+	for _, tc := range []struct {
+		selector string
+		expected int64
+	}{
+		{selector: ``, expected: 15},
+		{selector: `{handler="/checkout"}`, expected: 1},
+		{selector: `{handler="/cart"}`, expected: 10},
+		{selector: `{handler="/cart", user="b"}`, expected: 8},
+		{selector: `{handler=~"/c.*"}`, expected: 11},
+		{selector: `{handler=""}`, expected: 4},
+		{selector: `{handler="/unknown"}`, expected: 0},
+	} {
+		tc := tc
+		t.Run(tc.selector, func(t *testing.T) {
+			queryCtx, err := withSampleLabelSelector(ctx, tc.selector)
+			require.NoError(t, err)
+			assertTotals := func(t *testing.T, queriers Queriers) {
+				var total int64
+				for _, q := range queriers {
+					tree, err := q.SelectMergeByStacktraces(queryCtx, params)
+					require.NoError(t, err)
+					total += tree.Total()
+				}
+				require.Equal(t, tc.expected, total)
+
+				var value float64
+				for _, q := range queriers {
+					series, err := q.SelectMergeByLabels(queryCtx, params, nil)
+					require.NoError(t, err)
+					for _, s := range series {
+						for _, x := range s.Points {
+							value += x.Value
+						}
+					}
+				}
+				require.Equal(t, float64(tc.expected), value)
+			}
+
+			t.Run("head", func(t *testing.T) {
+				assertTotals(t, head.Queriers())
+			})
+		})
+	}
+
+	require.NoError(t, head.Flush(ctx))
+	require.NoError(t, head.Move())
+	bucket, err := filesystem.NewBucket(filepath.Dir(head.localPath))
+	require.NoError(t, err)
+	q := NewBlockQuerier(ctx, bucket)
+	require.NoError(t, q.Sync(ctx))
+
+	for _, tc := range []struct {
+		selector string
+		expected int64
+	}{
+		{selector: ``, expected: 15},
+		{selector: `{handler="/cart", user="a"}`, expected: 2},
+		{selector: `{handler!="/cart"}`, expected: 5},
+	} {
+		queryCtx, err := withSampleLabelSelector(ctx, tc.selector)
+		require.NoError(t, err)
+		var total int64
+		for _, q := range q.Queriers() {
+			require.NoError(t, q.Open(ctx))
+			tree, err := q.SelectMergeByStacktraces(queryCtx, params)
+			require.NoError(t, err)
+			total += tree.Total()
+		}
+		require.Equal(t, tc.expected, total, tc.selector)
+	}
+
+	_, err = withSampleLabelSelector(context.Background(), `{handler=`)
+	require.Error(t, err)
+}
+
+func TestSelectMerge_SampleLabelsWithoutLabelColumns(t *testing.T) {
+	ctx := testContext(t)
+	head, err := NewHead(ctx, Config{DataPath: t.TempDir()}, NoLimit)
+	require.NoError(t, err)
+
+	p := testhelper.NewProfileBuilder(int64(1))
+	p.CPUProfile()
+	p.ForStacktraceString("main", "checkout").AddSamples(1)
+	p.ForStacktraceString("main", "cart").AddSamples(2)
+	require.NoError(t, head.Ingest(ctx, p.Profile, p.UUID, p.Labels...))
+	require.NoError(t, head.Flush(ctx))
+	require.NoError(t, head.Move())
+	dropSampleLabelColumns(t, head.localPath)
+
+	bucket, err := filesystem.NewBucket(filepath.Dir(head.localPath))
+	require.NoError(t, err)
+	q := NewBlockQuerier(ctx, bucket)
+	require.NoError(t, q.Sync(ctx))
+
+	params := &ingestv1.SelectProfilesRequest{
+		LabelSelector: `{}`,
+		Type:          mustParseProfileSelector(t, "process_cpu:cpu:nanoseconds:cpu:nanoseconds"),
+		Start:         0,
+		End:           1,
+	}
+	for _, tc := range []struct {
+		selector string
+		retain   []string
+		expected int64
+	}{
+		{selector: ``, expected: 3},
+		{selector: `{handler=""}`, expected: 3},
+		{selector: `{handler!="/cart"}`, retain: []string{"handler"}, expected: 3},
+		{selector: `{handler="/cart"}`, expected: 0},
+		{selector: `{handler="/cart"}`, retain: []string{"handler"}, expected: 0},
+	} {
+		queryCtx, err := withSampleLabelSelector(ctx, tc.selector)
+		require.NoError(t, err)
+		queryCtx = withRetainedSampleLabels(queryCtx, tc.retain)
+		var total int64
+		var value float64
+		for _, q := range q.Queriers() {
+			require.NoError(t, q.Open(ctx))
+			tree, err := q.SelectMergeByStacktraces(queryCtx, params)
+			require.NoError(t, err)
+			total += tree.Total()
+			series, err := q.SelectMergeByLabels(queryCtx, params, nil)
+			require.NoError(t, err)
+			for _, s := range series {
+				for _, x := range s.Points {
+					value += x.Value
+				}
+			}
+		}
+		require.Equal(t, tc.expected, total, tc.selector)
+		require.Equal(t, float64(tc.expected), value, tc.selector)
+	}
+}
+
+// profileWithoutSampleLabels is a profile stored without the sample labels.
+type profileWithoutSampleLabels struct {
+	ID                  uuid.UUID `parquet:",uuid"`
+	SeriesIndex         uint32    `parquet:",delta"`
+	StacktracePartition uint64    `parquet:",delta"`
+	TotalValue          uint64    `parquet:",delta"`
+	Samples             []struct {
+		StacktraceID uint64 `parquet:",delta"`
+		Value        int64  `parquet:",delta"`
+	} `parquet:",list"`
+	TimeNanos int64 `parquet:",timestamp(nanosecond)"`
+}
+
+// dropSampleLabelColumns rewrites the profiles of the block without the
+// sample label columns, as in the blocks written before they were stored.
+func dropSampleLabelColumns(t *testing.T, dir string) {
+	t.Helper()
+	path := filepath.Join(dir, "profiles.parquet")
+	profiles, err := parquet.ReadFile[profileWithoutSampleLabels](path)
+	require.NoError(t, err)
+	require.NoError(t, parquet.WriteFile(path, profiles))
+
+	meta, err := block.ReadMetaFromDir(dir)
+	require.NoError(t, err)
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	for i, f := range meta.Files {
+		if f.RelPath == "profiles.parquet" {
+			meta.Files[i].SizeBytes = uint64(stat.Size())
+			meta.Files[i].Parquet.NumRowGroups = 1
+		}
+	}
+	_, err = meta.WriteToFile(log.NewNopLogger(), dir)
+	require.NoError(t, err)
+}
+
+func TestSelectMergePprof_RetainSampleLabels(t *testing.T) {
+	ctx := testContext(t)
+	head, err := NewHead(ctx, Config{DataPath: t.TempDir()}, NoLimit)
+	require.NoError(t, err)
+
+	p := testhelper.NewProfileBuilder(int64(1))
+	p.CPUProfile()
+	p.ForStacktraceString("checkout", "main").AddSamples(1)
+	p.ForStacktraceString("cart", "main").AddSamples(2)
+	p.ForStacktraceString("idle", "main").AddSamples(4)
+	p.ForStacktraceString("cart", "main").AddSamples(8)
+	p.ForStacktraceString("cart", "main").AddSamples(16)
+	withSampleLabels(p.Profile, 0, "handler", "/checkout")
+	withSampleLabels(p.Profile, 1, "handler", "/cart", "user", "a")
+	withSampleLabels(p.Profile, 3, "handler", "/cart", "user", "b", "span_id", "0102030405060708")
+	withSampleLabels(p.Profile, 4, "handler", "/cart", "user", "c")
+	require.NoError(t, head.Ingest(ctx, p.Profile, p.UUID, p.Labels...))
+
+	params := &ingestv1.SelectProfilesRequest{
+		LabelSelector: `{}`,
+		Type:          mustParseProfileSelector(t, "process_cpu:cpu:nanoseconds:cpu:nanoseconds"),
+		Start:         0,
+		End:           1,
+	}
+
+	type testCase struct {
+		retain   []string
+		selector string
+		expected map[string]int64
+	}
+
+	testCases := []testCase{
+		{
+			expected: map[string]int64{"main;checkout {}": 1, "main;cart {}": 26, "main;idle {}": 4},
+		},
+		{
+			retain: []string{"handler"},
+			expected: map[string]int64{
+				`main;checkout {handler="/checkout"}`: 1,
+				`main;cart {handler="/cart"}`:         26,
+				`main;idle {}`:                        4,
+			},
+		},
+		{
+			retain:   []string{"user", "span_id"},
+			selector: `{handler="/cart"}`,
+			expected: map[string]int64{
+				`main;cart {user="a"}`:                             2,
+				`main;cart {span_id="0102030405060708", user="b"}`: 8,
+				`main;cart {user="c"}`:                             16,
+			},
+		},
+	}
+
+	assertProfiles := func(t *testing.T, queriers Queriers) {
+		for _, tc := range testCases {
+			queryCtx, err := withSampleLabelSelector(ctx, tc.selector)
+			require.NoError(t, err)
+			queryCtx = withRetainedSampleLabels(queryCtx, tc.retain)
+			var m pprof.ProfileMerge
+			for _, q := range queriers {
+				require.NoError(t, q.Open(ctx))
+				resolved, err := q.SelectMergePprof(queryCtx, params, 0, nil)
+				require.NoError(t, err)
+				require.NoError(t, m.Merge(resolved))
+			}
+			require.Equal(t, tc.expected, labeledStacks(m.Profile()), tc.retain)
+		}
+	}
+
+	t.Run("head", func(t *testing.T) {
+		assertProfiles(t, head.Queriers())
+	})
+
+	require.NoError(t, head.Flush(ctx))
+	require.NoError(t, head.Move())
+	bucket, err := filesystem.NewBucket(filepath.Dir(head.localPath))
+	require.NoError(t, err)
+	q := NewBlockQuerier(ctx, bucket)
+	require.NoError(t, q.Sync(ctx))
+
+	t.Run("block", func(t *testing.T) {
+		assertProfiles(t, q.Queriers())
+	})
+}
+
+// labeledStacks returns sample values by stack trace and labels,
+// formatted as "root;...;leaf {labels}".
+func labeledStacks(p *profilev1.Profile) map[string]int64 {
+	functions := make(map[uint64]string)
+	for _, fn := range p.Function {
+		functions[fn.Id] = p.StringTable[fn.Name]
+	}
+	locations := make(map[uint64]string)
+	for _, loc := range p.Location {
+		locations[loc.Id] = functions[loc.Line[0].FunctionId]
+	}
+	stacks := make(map[string]int64)
+	for _, s := range p.Sample {
+		frames := make([]string, len(s.LocationId))
+		for i, id := range s.LocationId {
+			frames[len(frames)-1-i] = locations[id]
+		}
+		ls := make(phlaremodel.Labels, len(s.Label))
+		for i, l := range s.Label {
+			ls[i] = &typesv1.LabelPair{Name: p.StringTable[l.Key], Value: p.StringTable[l.Str]}
+		}
+		sort.Sort(ls)
+		stacks[strings.Join(frames, ";")+" "+ls.ToPrometheusLabels().String()] += s.Value[0]
+	}
+	return stacks
+}
+
+func withSampleLabels(p *profilev1.Profile, sample int, kv ...string) {
+	for i := 0; i < len(kv); i += 2 {
+		p.Sample[sample].Label = append(p.Sample[sample].Label, &profilev1.Label{
+			Key: int64(len(p.StringTable)),
+			Str: int64(len(p.StringTable) + 1),
+		})
+		p.StringTable = append(p.StringTable, kv[i], kv[i+1])
+	}
+}
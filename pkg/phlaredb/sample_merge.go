@@ -32,7 +32,7 @@ func (b *singleBlockQuerier) MergeByStacktraces(ctx context.Context, rows iter.I
 	defer b.queries.Done()
 
 	ctx = query.AddMetricsToContext(ctx, b.metrics.query)
-	r := symdb.NewResolver(ctx, b.symbols)
+	r := b.newResolver(ctx)
 	defer r.Release()
 	if err := mergeByStacktraces(ctx, b.profileSourceTable().file, rows, r); err != nil {
 		return nil, err
@@ -52,7 +52,7 @@ func (b *singleBlockQuerier) MergePprof(ctx context.Context, rows iter.Iterator[
 	defer b.queries.Done()
 
 	ctx = query.AddMetricsToContext(ctx, b.metrics.query)
-	r := symdb.NewResolver(ctx, b.symbols,
+	r := b.newResolver(ctx,
 		symdb.WithResolverMaxNodes(maxNodes),
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
@@ -79,9 +79,9 @@ func (b *singleBlockQuerier) MergeByLabels(ctx context.Context, rows iter.Iterat
 		if b.meta.Version == 1 {
 			columnName = "Samples.list.element.Value"
 		}
-		return mergeByLabels(ctx, b.profileSourceTable().file, columnName, rows, by...)
+		return mergeByLabels(ctx, b.profileSourceTable().file, b.symbols, columnName, rows, by...)
 	}
-	r := symdb.NewResolver(ctx, b.symbols,
+	r := b.newResolver(ctx,
 		symdb.WithResolverStackTraceSelector(sts))
 	defer r.Release()
 	return mergeByLabelsWithStackTraceSelector(ctx, b.profileSourceTable().file, rows, r, by...)
@@ -99,7 +99,7 @@ func (b *singleBlockQuerier) MergeBySpans(ctx context.Context, rows iter.Iterato
 	defer b.queries.Done()
 
 	ctx = query.AddMetricsToContext(ctx, b.metrics.query)
-	r := symdb.NewResolver(ctx, b.symbols)
+	r := b.newResolver(ctx)
 	defer r.Release()
 	if err := mergeBySpans(ctx, b.profileSourceTable().file, rows, r, spanSelector); err != nil {
 		return nil, err
@@ -120,11 +120,26 @@ func mergeByStacktraces[T interface{ StacktracePartition() uint64 }](ctx context
 	if err = columns.Resolve(profileSource.Schema()); err != nil {
 		return err
 	}
+	m := newSampleLabelsMatcher(ctx, r.Symbols())
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
+	m, ok := m.forColumns(&columns)
+	if !ok {
+		return nil
+	}
+	if c := newSampleLabelsCollector(ctx, r.Symbols()); c != nil {
+		defer runutil.CloseWithErrCapture(&err, c, "failed to release sample labels collector")
+		return mergeByStacktracesWithSampleLabels(ctx, profileSource, &columns, rows, r, m, c)
+	}
 	profiles := query.NewRepeatedRowIterator(ctx, rows, profileSource.RowGroups(),
-		columns.StacktraceID.ColumnIndex,
-		columns.Value.ColumnIndex,
+		sampleColumnIndices(&columns, m,
+			columns.StacktraceID.ColumnIndex,
+			columns.Value.ColumnIndex,
+		)...,
 	)
 	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+	if m != nil {
+		profiles = filterSamplesByLabels(profiles, m)
+	}
 	for profiles.Next() {
 		p := profiles.At()
 		r.AddSamplesFromParquetRow(p.Row.StacktracePartition(), p.Values[0], p.Values[1])
@@ -132,6 +147,72 @@ func mergeByStacktraces[T interface{ StacktracePartition() uint64 }](ctx context
 	return profiles.Err()
 }
 
+// mergeByStacktracesWithSampleLabels adds samples to the resolver with
+// the labels collected by c retained. The matcher m is optional, and must
+// be nil if the columns don't include the sample labels.
+func mergeByStacktracesWithSampleLabels[T interface{ StacktracePartition() uint64 }](
+	ctx context.Context,
+	profileSource Source,
+	columns *v1.SampleColumns,
+	rows iter.Iterator[T],
+	r *symdb.Resolver,
+	m *sampleLabelsMatcher,
+	c *sampleLabelsCollector,
+) (err error) {
+	indices := []int{columns.StacktraceID.ColumnIndex, columns.Value.ColumnIndex}
+	spans := c.spanID >= 0 && columns.HasSpanID()
+	if spans {
+		indices = append(indices, columns.SpanID.ColumnIndex)
+	}
+	labels := columns.HasLabels()
+	if labels {
+		indices = append(indices, columns.LabelKey.ColumnIndex, columns.LabelValue.ColumnIndex)
+	}
+	profiles := query.NewRepeatedRowIterator(ctx, rows, profileSource.RowGroups(), indices...)
+	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+	if labels {
+		profiles = query.NewSampleLabelsFilterIterator(profiles, func(row T, keys, values []parquet.Value) bool {
+			partition := row.StacktracePartition()
+			if m != nil && !m.matchesParquet(partition, keys, values) {
+				return false
+			}
+			c.collectParquet(partition, keys, values)
+			return true
+		})
+	}
+	for profiles.Next() {
+		p := profiles.At()
+		partition := p.Row.StacktracePartition()
+		stacktraces, values := p.Values[0], p.Values[1]
+		r.WithPartitionLabeledSamples(partition, func(s *symdb.LabeledSamples) {
+			for i, sid := range stacktraces {
+				var ls phlaremodel.Labels
+				if labels {
+					ls = c.labels[i]
+				}
+				if spans {
+					if spanID := p.Values[2][i].Uint64(); spanID != 0 {
+						ls = withSpanIDLabel(ls, spanID)
+					}
+				}
+				s.Add(ls, sid.Uint32(), values[i].Int64())
+			}
+		})
+		c.reset()
+	}
+	return profiles.Err()
+}
+
+// sampleColumnIndices returns the indices of the sample columns to
+// read. If the samples are to be matched by labels, the label key and
+// value columns are appended, if the block has them.
+func sampleColumnIndices(columns *v1.SampleColumns, m *sampleLabelsMatcher, indices ...int) []int {
+	if m == nil || !columns.HasLabels() {
+		return indices
+	}
+	return append(indices, columns.LabelKey.ColumnIndex, columns.LabelValue.ColumnIndex)
+}
+
 func mergeBySpans[T interface{ StacktracePartition() uint64 }](ctx context.Context, profileSource Source, rows iter.Iterator[T], r *symdb.Resolver, spanSelector phlaremodel.SpanSelector) (err error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "mergeBySpans")
 	defer sp.Finish()
@@ -233,10 +314,17 @@ func (s *seriesBuilder) build() []*typesv1.Series {
 func mergeByLabels[T Profile](
 	ctx context.Context,
 	profileSource Source,
+	symbols symdb.SymbolsReader,
 	columnName string,
 	rows iter.Iterator[T],
 	by ...string,
-) ([]*typesv1.Series, error) {
+) (s []*typesv1.Series, err error) {
+	if m := newSampleLabelsMatcher(ctx, symbols); m != nil {
+		// Only the matching samples are accounted,
+		// therefore the total value can't be used.
+		defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
+		return mergeByLabelsWithSampleLabelsMatcher(ctx, profileSource, m, rows, by...)
+	}
 	column, err := v1.ResolveColumnByPath(profileSource.Schema(), strings.Split(columnName, "."))
 	if err != nil {
 		return nil, err
@@ -260,6 +348,44 @@ func mergeByLabels[T Profile](
 	return seriesBuilder.build(), profiles.Err()
 }
 
+func mergeByLabelsWithSampleLabelsMatcher[T Profile](
+	ctx context.Context,
+	profileSource Source,
+	m *sampleLabelsMatcher,
+	rows iter.Iterator[T],
+	by ...string,
+) (s []*typesv1.Series, err error) {
+	var columns v1.SampleColumns
+	if err = columns.Resolve(profileSource.Schema()); err != nil {
+		return nil, err
+	}
+	m, ok := m.forColumns(&columns)
+	if !ok {
+		return nil, nil
+	}
+	profiles := query.NewRepeatedRowIterator(ctx, rows, profileSource.RowGroups(),
+		sampleColumnIndices(&columns, m, columns.Value.ColumnIndex)...)
+	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+	filtered := profiles
+	if m != nil {
+		filtered = filterSamplesByLabels(profiles, m)
+	}
+
+	seriesBuilder := seriesBuilder{}
+	seriesBuilder.init(by...)
+
+	for filtered.Next() {
+		row := filtered.At()
+		p := row.Row
+		var total int64
+		for _, v := range row.Values[0] {
+			total += v.Int64()
+		}
+		seriesBuilder.add(p.Fingerprint(), p.Labels(), int64(p.Timestamp()), float64(total))
+	}
+	return seriesBuilder.build(), filtered.Err()
+}
+
 func mergeByLabelsWithStackTraceSelector[T Profile](
 	ctx context.Context,
 	profileSource Source,
@@ -271,15 +397,26 @@ func mergeByLabelsWithStackTraceSelector[T Profile](
 	if err = columns.Resolve(profileSource.Schema()); err != nil {
 		return nil, err
 	}
+	m := newSampleLabelsMatcher(ctx, r.Symbols())
+	defer runutil.CloseWithErrCapture(&err, m, "failed to release sample labels matcher")
+	m, ok := m.forColumns(&columns)
+	if !ok {
+		return nil, nil
+	}
 	profiles := query.NewRepeatedRowIterator(ctx, rows, profileSource.RowGroups(),
-		columns.StacktraceID.ColumnIndex,
-		columns.Value.ColumnIndex,
+		sampleColumnIndices(&columns, m,
+			columns.StacktraceID.ColumnIndex,
+			columns.Value.ColumnIndex,
+		)...,
 	)
 
 	seriesBuilder := seriesBuilder{}
 	seriesBuilder.init(by...)
 
 	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+	if m != nil {
+		profiles = filterSamplesByLabels(profiles, m)
+	}
 	var v symdb.CallSiteValues
 	for profiles.Next() {
 		row := profiles.At()
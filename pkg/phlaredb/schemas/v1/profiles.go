@@ -67,6 +67,8 @@ var (
 	sampleStacktraceIDColumnPath = strings.Split("Samples.list.element.StacktraceID", ".")
 	SampleValueColumnPath        = strings.Split("Samples.list.element.Value", ".")
 	sampleSpanIDColumnPath       = strings.Split("Samples.list.element.SpanID", ".")
+	sampleLabelKeyColumnPath     = strings.Split("Samples.list.element.Labels.list.element.Key", ".")
+	sampleLabelStrColumnPath     = strings.Split("Samples.list.element.Labels.list.element.Str", ".")
 
 	maxProfileRow               parquet.Row
 	seriesIndexColIndex         int
@@ -74,6 +76,8 @@ var (
 	valueColIndex               int
 	timeNanoColIndex            int
 	stacktracePartitionColIndex int
+	sampleLabelKeyColIndex      int
+	sampleLabelStrColIndex      int
 
 	downsampledValueColIndex int
 
@@ -110,6 +114,16 @@ func init() {
 		panic(fmt.Errorf("StacktracePartition column not found"))
 	}
 	stacktracePartitionColIndex = stacktracePartitionCol.ColumnIndex
+	sampleLabelKeyCol, ok := ProfilesSchema.Lookup(sampleLabelKeyColumnPath...)
+	if !ok {
+		panic(fmt.Errorf("Sample.Labels.Key column not found"))
+	}
+	sampleLabelKeyColIndex = sampleLabelKeyCol.ColumnIndex
+	sampleLabelStrCol, ok := ProfilesSchema.Lookup(sampleLabelStrColumnPath...)
+	if !ok {
+		panic(fmt.Errorf("Sample.Labels.Str column not found"))
+	}
+	sampleLabelStrColIndex = sampleLabelStrCol.ColumnIndex
 
 	downsampledValueCol, ok := DownsampledProfilesSchema.Lookup(SampleValueColumnPath...)
 	if !ok {
@@ -122,6 +136,8 @@ type SampleColumns struct {
 	StacktraceID parquet.LeafColumn
 	Value        parquet.LeafColumn
 	SpanID       parquet.LeafColumn
+	LabelKey     parquet.LeafColumn
+	LabelValue   parquet.LeafColumn
 }
 
 func (c *SampleColumns) Resolve(schema *parquet.Schema) error {
@@ -134,6 +150,8 @@ func (c *SampleColumns) Resolve(schema *parquet.Schema) error {
 	}
 	// Optional.
 	c.SpanID, _ = ResolveColumnByPath(schema, sampleSpanIDColumnPath)
+	c.LabelKey, _ = ResolveColumnByPath(schema, sampleLabelKeyColumnPath)
+	c.LabelValue, _ = ResolveColumnByPath(schema, sampleLabelStrColumnPath)
 	return nil
 }
 
@@ -141,6 +159,10 @@ func (c *SampleColumns) HasSpanID() bool {
 	return c.SpanID.Node != nil
 }
 
+func (c *SampleColumns) HasLabels() bool {
+	return c.LabelKey.Node != nil && c.LabelValue.Node != nil
+}
+
 func ResolveColumnByPath(schema *parquet.Schema, path []string) (parquet.LeafColumn, error) {
 	if c, ok := schema.Lookup(path...); ok {
 		return c, nil
@@ -321,6 +343,16 @@ type Samples struct {
 	// Span associated with samples.
 	// Optional: Spans == nil, if not present.
 	Spans []uint64
+	// Labels associated with samples.
+	// Optional: Labels == nil, if not present.
+	Labels [][]SampleLabel
+}
+
+// SampleLabel is a pprof label stored at the sample level.
+// Key and Value are references to the partition string table.
+type SampleLabel struct {
+	Key   uint32
+	Value uint32
 }
 
 func NewSamples(size int) Samples {
@@ -387,6 +419,9 @@ func trimZeroAndNegativeSamples(samples Samples) Samples {
 			if len(samples.Spans) > 0 {
 				samples.Spans[n] = samples.Spans[j]
 			}
+			if len(samples.Labels) > 0 {
+				samples.Labels[n] = samples.Labels[j]
+			}
 			n++
 		}
 	}
@@ -397,6 +432,9 @@ func trimZeroAndNegativeSamples(samples Samples) Samples {
 	if len(samples.Spans) > 0 {
 		s.Spans = samples.Spans[:n]
 	}
+	if len(samples.Labels) > 0 {
+		s.Labels = samples.Labels[:n]
+	}
 	return s
 }
 
@@ -405,9 +443,21 @@ func cloneSamples(samples Samples) Samples {
 		StacktraceIDs: copySlice(samples.StacktraceIDs),
 		Values:        copySlice(samples.Values),
 		Spans:         copySlice(samples.Spans),
+		Labels:        cloneSampleLabels(samples.Labels),
 	}
 }
 
+func cloneSampleLabels(labels [][]SampleLabel) [][]SampleLabel {
+	if len(labels) == 0 {
+		return nil
+	}
+	out := make([][]SampleLabel, len(labels))
+	for i, ls := range labels {
+		out[i] = copySlice(ls)
+	}
+	return out
+}
+
 func (s Samples) Less(i, j int) bool {
 	return s.StacktraceIDs[i] < s.StacktraceIDs[j]
 }
@@ -418,6 +468,9 @@ func (s Samples) Swap(i, j int) {
 	if len(s.Spans) > 0 {
 		s.Spans[i], s.Spans[j] = s.Spans[j], s.Spans[i]
 	}
+	if len(s.Labels) > 0 {
+		s.Labels[i], s.Labels[j] = s.Labels[j], s.Labels[i]
+	}
 }
 
 func (s Samples) Len() int {
@@ -436,6 +489,9 @@ func (s SamplesBySpanID) Swap(i, j int) {
 	if len(s.Spans) > 0 {
 		s.Spans[i], s.Spans[j] = s.Spans[j], s.Spans[i]
 	}
+	if len(s.Labels) > 0 {
+		s.Labels[i], s.Labels[j] = s.Labels[j], s.Labels[i]
+	}
 }
 
 func (s SamplesBySpanID) Len() int {
@@ -490,7 +546,12 @@ const profileSize = uint64(unsafe.Sizeof(InMemoryProfile{}))
 func (p InMemoryProfile) Size() uint64 {
 	size := profileSize + uint64(cap(p.Comments)*8)
 	// 4 bytes for stacktrace id and 8 bytes for each stacktrace value
-	return size + uint64(cap(p.Samples.StacktraceIDs)*(4+8))
+	size += uint64(cap(p.Samples.StacktraceIDs) * (4 + 8))
+	for _, ls := range p.Samples.Labels {
+		// 24 bytes for the slice header and 8 bytes for each label.
+		size += uint64(24 + cap(ls)*8)
+	}
+	return size
 }
 
 func (p InMemoryProfile) Timestamp() model.Time {
@@ -569,11 +630,30 @@ func deconstructMemoryProfile(imp InMemoryProfile, row parquet.Row) parquet.Row
 		if len(imp.Samples.Values) == 0 {
 			row = append(row, parquet.Value{}.Level(0, 0, col))
 		}
-		for range imp.Samples.Values {
+		for j := range imp.Samples.Values {
 			if repetition < 1 {
 				repetition++
 			}
-			row = append(row, parquet.Value{}.Level(repetition, 1, col))
+			if len(imp.Samples.Labels) == 0 || len(imp.Samples.Labels[j]) == 0 {
+				row = append(row, parquet.Value{}.Level(repetition, 1, col))
+				continue
+			}
+			// Only label keys (i == 0) and string values (i == 1)
+			// are stored, numeric labels are not supported.
+			for k, l := range imp.Samples.Labels[j] {
+				r := repetition
+				if k > 0 {
+					r = 2
+				}
+				switch i {
+				case 0:
+					row = append(row, parquet.Int64Value(int64(l.Key)).Level(r, 2, col))
+				case 1:
+					row = append(row, parquet.Int64Value(int64(l.Value)).Level(r, 3, col))
+				default:
+					row = append(row, parquet.Value{}.Level(r, 2, col))
+				}
+			}
 		}
 	}
 
@@ -747,6 +827,29 @@ func (p ProfileRow) ForStacktraceIdsAndValues(fn func([]parquet.Value, []parquet
 	}
 }
 
+// ForSampleLabels calls fn with the values of the sample label key and
+// string value columns. Samples without labels are represented with null
+// values. The values can be modified in place.
+func (p ProfileRow) ForSampleLabels(fn func(keys, values []parquet.Value)) {
+	start := -1
+	var i int
+	for i = 0; i < len(p); i++ {
+		col := p[i].Column()
+		if col == sampleLabelKeyColIndex && start == -1 {
+			start = i
+		}
+		if col > sampleLabelStrColIndex {
+			break
+		}
+	}
+	if start == -1 {
+		return
+	}
+	// Keys and values columns have the same number of entries.
+	n := (i - start) / 2
+	fn(p[start:start+n], p[start+n:i])
+}
+
 type DownsampledProfileRow parquet.Row
 
 func (p DownsampledProfileRow) ForValues(fn func([]parquet.Value)) {
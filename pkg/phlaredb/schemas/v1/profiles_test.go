@@ -12,6 +12,7 @@ import (
 	"github.com/parquet-go/parquet-go"
 	"github.com/stretchr/testify/require"
 
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	phlareparquet "github.com/grafana/pyroscope/pkg/parquet"
 )
 
@@ -121,6 +122,27 @@ func TestRoundtripProfile(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, expected, actual)
 	})
+	t.Run("SampleLabels", func(t *testing.T) {
+		profiles := generateProfiles(1)
+		inMemoryProfiles := generateMemoryProfiles(1)
+		for i, p := range profiles {
+			labels := make([][]SampleLabel, len(p.Samples))
+			for j, x := range p.Samples {
+				// Every third sample has no labels.
+				for k := 0; k < j%3; k++ {
+					key, value := rand.Int63n(100)+1, rand.Int63n(100)+1
+					x.Labels = append(x.Labels, &profilev1.Label{Key: key, Str: value})
+					labels[j] = append(labels[j], SampleLabel{Key: uint32(key), Value: uint32(value)})
+				}
+			}
+			inMemoryProfiles[i].Samples.Labels = labels
+		}
+		expected, err := phlareparquet.ReadAll(NewProfilesRowReader(profiles))
+		require.NoError(t, err)
+		actual, err := phlareparquet.ReadAll(NewInMemoryProfilesRowReader(inMemoryProfiles))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
 }
 
 func TestCompactSamples(t *testing.T) {
@@ -44,6 +44,13 @@ func (shard ShardSelector) Matcher() *labels.Matcher {
 	return labels.MustNewMatcher(labels.MatchEqual, ShardLabel, shard.LabelValue())
 }
 
+// Contains reports whether the series with the given fingerprint belongs to
+// the shard. Series are distributed among shards the same way the
+// split-and-merge compactor distributes them among the output blocks.
+func (shard ShardSelector) Contains(fp uint64) bool {
+	return fp%shard.ShardCount == shard.ShardIndex
+}
+
 // ShardFromMatchers extracts a ShardSelector and the index it was pulled from the matcher list.
 func ShardFromMatchers(matchers []*labels.Matcher) (shard *ShardSelector, idx int, err error) {
 	for i, matcher := range matchers {
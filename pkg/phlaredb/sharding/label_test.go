@@ -206,3 +206,21 @@ func TestFormatAndParseShardId(t *testing.T) {
 		require.Equal(t, uint64(count), ncount)
 	}
 }
+
+func TestShardSelector_Contains(t *testing.T) {
+	shards := []ShardSelector{
+		{ShardIndex: 0, ShardCount: 3},
+		{ShardIndex: 1, ShardCount: 3},
+		{ShardIndex: 2, ShardCount: 3},
+	}
+	for fp := uint64(0); fp < 100; fp++ {
+		var n int
+		for _, shard := range shards {
+			if shard.Contains(fp) {
+				require.Equal(t, fp%3, shard.ShardIndex)
+				n++
+			}
+		}
+		require.Equal(t, 1, n)
+	}
+}
@@ -0,0 +1,20 @@
+package phlaredb
+
+import (
+	"context"
+
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+type symbolizerContextKey struct{}
+
+// ContextWithSymbolizer returns a context with the symbolizer that
+// queriers use to resolve locations not symbolized by the client.
+func ContextWithSymbolizer(ctx context.Context, s symdb.Symbolizer) context.Context {
+	return context.WithValue(ctx, symbolizerContextKey{}, s)
+}
+
+func symbolizerFromContext(ctx context.Context) symdb.Symbolizer {
+	s, _ := ctx.Value(symbolizerContextKey{}).(symdb.Symbolizer)
+	return s
+}
@@ -34,6 +34,7 @@ func (p *PartitionWriter) WriteProfileSymbols(profile *profilev1.Profile) []sche
 	rewrites := &rewriter{}
 
 	spans := pprof.ProfileSpans(profile)
+	spanIDLabel := pprof.LabelID(profile, pprof.SpanIDLabelName)
 	pprof.ZeroLabelStrings(profile)
 
 	p.strings.ingest(profile.StringTable, rewrites)
@@ -85,7 +86,7 @@ func (p *PartitionWriter) WriteProfileSymbols(profile *profilev1.Profile) []sche
 	}
 
 	p.locations.ingest(locs, rewrites)
-	samplesPerType := p.convertSamples(rewrites, profile.Sample, spans)
+	samplesPerType := p.convertSamples(rewrites, profile.Sample, spans, spanIDLabel)
 
 	profiles := make([]schemav1.InMemoryProfile, len(samplesPerType))
 	for idxType := range samplesPerType {
@@ -104,7 +105,7 @@ func (p *PartitionWriter) WriteProfileSymbols(profile *profilev1.Profile) []sche
 	return profiles
 }
 
-func (p *PartitionWriter) convertSamples(r *rewriter, in []*profilev1.Sample, spans []uint64) []schemav1.Samples {
+func (p *PartitionWriter) convertSamples(r *rewriter, in []*profilev1.Sample, spans []uint64, spanIDLabel int64) []schemav1.Samples {
 	if len(in) == 0 {
 		return nil
 	}
@@ -127,6 +128,14 @@ func (p *PartitionWriter) convertSamples(r *rewriter, in []*profilev1.Sample, sp
 		samplesByType[i] = s
 	}
 
+	// Sample labels (except span IDs) are shared by all the sample types.
+	if labels := convertSampleLabels(r, in, spanIDLabel); labels != nil {
+		for i := range samplesByType {
+			samplesByType[i].Labels = make([][]schemav1.SampleLabel, len(labels))
+			copy(samplesByType[i].Labels, labels)
+		}
+	}
+
 	for idxSample := range in {
 		// populate samples
 		src := in[idxSample]
@@ -157,6 +166,25 @@ func (p *PartitionWriter) convertSamples(r *rewriter, in []*profilev1.Sample, sp
 	return samplesByType
 }
 
+func convertSampleLabels(r *rewriter, in []*profilev1.Sample, spanIDLabel int64) [][]schemav1.SampleLabel {
+	var labels [][]schemav1.SampleLabel
+	for i, x := range in {
+		for _, l := range x.Label {
+			if l.Key == spanIDLabel || l.Str == 0 {
+				continue
+			}
+			if labels == nil {
+				labels = make([][]schemav1.SampleLabel, len(in))
+			}
+			labels[i] = append(labels[i], schemav1.SampleLabel{
+				Key:   uint32(r.strings[l.Key]),
+				Value: uint32(r.strings[l.Str]),
+			})
+		}
+	}
+	return labels
+}
+
 func copySlice[T any](in []T) []T {
 	out := make([]T, len(in))
 	copy(out, in)
@@ -34,8 +34,30 @@ type Resolver struct {
 	m sync.RWMutex
 	p map[uint64]*lazyPartition
 
-	maxNodes int64
-	sts      *typesv1.StackTraceSelector
+	maxNodes     int64
+	maxLabelSets int
+	sts          *typesv1.StackTraceSelector
+	symbolizer   Symbolizer
+	block        string
+	recursion    typesv1.RecursionCollapse
+	resolved     func(stacktraces int)
+}
+
+// Symbolizer resolves locations that have not been symbolized
+// by the client, e.g., addresses of native stripped binaries.
+type Symbolizer interface {
+	// Symbolize returns symbols where the unsymbolized locations are
+	// resolved, if possible. The source symbols must not be modified.
+	// The symbols of a block partition never change: if the key has
+	// the block ID, the result may be cached by the key.
+	Symbolize(ctx context.Context, key SymbolsKey, symbols *Symbols) (*Symbols, error)
+}
+
+// SymbolsKey identifies the symbols of a partition. The block
+// ID is empty, if the symbols may change, e.g., in the head.
+type SymbolsKey struct {
+	Block     string
+	Partition uint64
 }
 
 type ResolverOption func(*Resolver)
@@ -56,6 +78,14 @@ func WithResolverMaxNodes(n int64) ResolverOption {
 	}
 }
 
+// WithResolverMaxLabelSets specifies the maximum number of distinct
+// sets of labels retained per partition, see LabeledSamples.
+func WithResolverMaxLabelSets(n int) ResolverOption {
+	return func(r *Resolver) {
+		r.maxLabelSets = n
+	}
+}
+
 // WithResolverStackTraceSelector specifies the stack trace selector.
 // Only stack traces that belong to the callSite (have the prefix provided)
 // will be selected. If empty, the filter is ignored.
@@ -66,24 +96,68 @@ func WithResolverStackTraceSelector(sts *typesv1.StackTraceSelector) ResolverOpt
 	}
 }
 
+// WithResolverSymbolizer specifies the symbolizer to be used for
+// locations that have no function names. If nil, locations are
+// resolved as is.
+func WithResolverSymbolizer(s Symbolizer) ResolverOption {
+	return func(r *Resolver) {
+		r.symbolizer = s
+	}
+}
+
+// WithResolverBlock specifies the ID of the block the symbols
+// belong to, which allows the symbolizer to cache the results.
+func WithResolverBlock(id string) ResolverOption {
+	return func(r *Resolver) {
+		r.block = id
+	}
+}
+
+// WithResolverStacktracesCounter specifies the function called
+// with the number of stack traces resolved in each partition.
+func WithResolverStacktracesCounter(fn func(stacktraces int)) ResolverOption {
+	return func(r *Resolver) {
+		r.resolved = fn
+	}
+}
+
+// WithResolverCollapseRecursion specifies how recursive
+// calls are collapsed in the resulting profile.
+func WithResolverCollapseRecursion(mode typesv1.RecursionCollapse) ResolverOption {
+	return func(r *Resolver) {
+		r.recursion = mode
+	}
+}
+
 type lazyPartition struct {
 	id uint64
 
 	m       sync.Mutex
 	samples map[uint32]int64
+	labeled *LabeledSamples
 
 	fetchOnce sync.Once
 	resolver  *Resolver
 	reader    PartitionReader
+	symbols   *Symbols
 	selection *SelectedStackTraces
 	err       error
 }
 
 func (p *lazyPartition) fetch(ctx context.Context) error {
 	p.fetchOnce.Do(func() {
-		p.reader, p.err = p.resolver.s.Partition(ctx, p.id)
-		if p.err == nil && p.resolver.sts != nil {
-			p.selection = SelectStackTraces(p.reader.Symbols(), p.resolver.sts)
+		if p.reader, p.err = p.resolver.s.Partition(ctx, p.id); p.err != nil {
+			return
+		}
+		p.symbols = p.reader.Symbols()
+		if p.resolver.symbolizer != nil {
+			key := SymbolsKey{Block: p.resolver.block, Partition: p.id}
+			if p.symbols, p.err = p.resolver.symbolizer.Symbolize(ctx, key, p.symbols); p.err != nil {
+				return
+			}
+		}
+		if p.resolver.sts != nil {
+			p.selection = SelectStackTraces(p.symbols, p.resolver.sts)
 		}
 	})
 	return p.err
@@ -91,9 +165,10 @@ func (p *lazyPartition) fetch(ctx context.Context) error {
 
 func NewResolver(ctx context.Context, s SymbolsReader, opts ...ResolverOption) *Resolver {
 	r := Resolver{
-		s: s,
-		c: runtime.GOMAXPROCS(-1),
-		p: make(map[uint64]*lazyPartition),
+		s:            s,
+		c:            runtime.GOMAXPROCS(-1),
+		p:            make(map[uint64]*lazyPartition),
+		maxLabelSets: DefaultMaxLabelSets,
 	}
 	for _, opt := range opts {
 		opt(&r)
@@ -126,6 +201,9 @@ func (r *Resolver) Release() {
 	r.span.Finish()
 }
 
+// Symbols returns the symbols reader the resolver uses.
+func (r *Resolver) Symbols() SymbolsReader { return r.s }
+
 // AddSamples adds a collection of stack trace samples to the resolver.
 // Samples can be added to partitions concurrently.
 func (r *Resolver) AddSamples(partition uint64, s schemav1.Samples) {
@@ -163,6 +241,107 @@ func (r *Resolver) WithPartitionSamples(partition uint64, fn func(map[uint32]int
 	fn(p.samples)
 }
 
+// WithPartitionLabeledSamples is like WithPartitionSamples, but the
+// samples added retain their labels in the profile built with Pprof.
+// Labeled samples are accounted in the tree, but their labels are not.
+func (r *Resolver) WithPartitionLabeledSamples(partition uint64, fn func(*LabeledSamples)) {
+	p := r.partition(partition)
+	p.m.Lock()
+	defer p.m.Unlock()
+	fn(p.labeled)
+}
+
+// DefaultMaxLabelSets is the default maximum number of distinct
+// sets of labels retained per partition.
+const DefaultMaxLabelSets = 10000
+
+// LabeledSamples groups stack trace samples by their labels.
+//
+// The number of distinct sets of labels is limited: once the limit is
+// reached, the samples of new sets of labels are retained without labels,
+// similarly to the samples with no labels at all.
+type LabeledSamples struct {
+	sets      []model.Labels
+	hashes    map[uint64][]int32 // Label set indices by labels hash.
+	samples   map[labeledSample]int64
+	unlabeled map[uint32]int64
+	max       int
+}
+
+type labeledSample struct {
+	stacktraceID uint32
+	set          int32
+}
+
+func newLabeledSamples(unlabeled map[uint32]int64, max int) *LabeledSamples {
+	return &LabeledSamples{
+		hashes:    make(map[uint64][]int32),
+		samples:   make(map[labeledSample]int64),
+		unlabeled: unlabeled,
+		max:       max,
+	}
+}
+
+// Add adds the sample value. Samples are merged only if they have the
+// same stack trace and labels. Labels must be sorted by name. The labels
+// are retained by the LabeledSamples and must not be modified.
+func (s *LabeledSamples) Add(labels model.Labels, stacktraceID uint32, value int64) {
+	set := s.set(labels)
+	if set < 0 {
+		s.unlabeled[stacktraceID] += value
+		return
+	}
+	s.samples[labeledSample{stacktraceID: stacktraceID, set: set}] += value
+}
+
+// set returns the index of the set of labels,
+// or -1, if the samples are to be retained without labels.
+func (s *LabeledSamples) set(labels model.Labels) int32 {
+	if len(labels) == 0 {
+		return -1
+	}
+	h := labels.Hash()
+	sets := s.hashes[h]
+	for _, set := range sets {
+		// Different labels may have the same hash.
+		if model.CompareLabelPairs(s.sets[set], labels) == 0 {
+			return set
+		}
+	}
+	if s.max > 0 && len(s.sets) >= s.max {
+		return -1
+	}
+	set := int32(len(s.sets))
+	s.sets = append(s.sets, labels)
+	s.hashes[h] = append(sets, set)
+	return set
+}
+
+// addTo adds the values of the samples to the samples map, by stack trace.
+func (s *LabeledSamples) addTo(samples map[uint32]int64) {
+	for k, v := range s.samples {
+		samples[k.stacktraceID] += v
+	}
+}
+
+// pprofSampleLabels returns the labels of the samples to be attached to the
+// profile samples. The unlabeled sample values are included with no set.
+func (s *LabeledSamples) pprofSampleLabels() *pprofSampleLabels {
+	l := &pprofSampleLabels{
+		sets:   s.sets,
+		values: make(map[uint32][]labeledValue, len(s.samples)),
+	}
+	for k, v := range s.samples {
+		l.values[k.stacktraceID] = append(l.values[k.stacktraceID], labeledValue{set: k.set, value: v})
+	}
+	for sid, values := range l.values {
+		if v := s.unlabeled[sid]; v != 0 {
+			l.values[sid] = append(values, labeledValue{set: -1, value: v})
+		}
+	}
+	return l
+}
+
 func (r *Resolver) CallSiteValues(values *CallSiteValues, partition uint64, samples schemav1.Samples) error {
 	p := r.partition(partition)
 	if err := p.fetch(r.ctx); err != nil {
@@ -204,6 +383,7 @@ func (r *Resolver) partition(partition uint64) *lazyPartition {
 		samples:  make(map[uint32]int64),
 		resolver: r,
 	}
+	p.labeled = newLabeledSamples(p.samples, r.maxLabelSets)
 	r.p[partition] = p
 	r.m.Unlock()
 	// Fetch partition in the background, not blocking the caller.
@@ -221,7 +401,7 @@ func (r *Resolver) Tree() (*model.Tree, error) {
 	var lock sync.Mutex
 	tree := new(model.Tree)
 	err := r.withSymbols(ctx, func(symbols *Symbols, samples schemav1.Samples) error {
-		resolved, err := symbols.Tree(ctx, samples)
+		resolved, err := symbols.tree(ctx, samples, r.recursion)
 		if err != nil {
 			return err
 		}
@@ -238,8 +418,18 @@ func (r *Resolver) Pprof() (*googlev1.Profile, error) {
 	defer span.Finish()
 	var lock sync.Mutex
 	var p pprof.ProfileMerge
-	err := r.withSymbols(ctx, func(symbols *Symbols, samples schemav1.Samples) error {
-		resolved, err := symbols.Pprof(ctx, samples, r.maxNodes, SelectStackTraces(symbols, r.sts))
+	err := r.withPartitions(ctx, func(partition *lazyPartition) error {
+		var labels *pprofSampleLabels
+		samples := partition.samples
+		if len(partition.labeled.sets) > 0 {
+			// Stack traces are resolved once, and the labels are attached
+			// to the samples of the resolved stack traces.
+			labels = partition.labeled.pprofSampleLabels()
+			samples = partition.unionSamples()
+		}
+		selection := SelectStackTraces(partition.symbols, r.sts)
+		r.addResolved(len(samples))
+		resolved, err := partition.symbols.pprof(ctx, schemav1.NewSamplesFromMap(samples), r.maxNodes, selection, labels)
 		if err != nil {
 			return err
 		}
@@ -247,10 +437,40 @@ func (r *Resolver) Pprof() (*googlev1.Profile, error) {
 		defer lock.Unlock()
 		return p.Merge(resolved)
 	})
-	return p.Profile(), err
+	if err != nil {
+		return nil, err
+	}
+	return pprof.CollapseRecursion(p.Profile(), r.recursion), nil
 }
 
 func (r *Resolver) withSymbols(ctx context.Context, fn func(*Symbols, schemav1.Samples) error) error {
+	return r.withPartitions(ctx, func(p *lazyPartition) error {
+		samples := p.unionSamples()
+		r.addResolved(len(samples))
+		return fn(p.symbols, schemav1.NewSamplesFromMap(samples))
+	})
+}
+
+func (r *Resolver) addResolved(stacktraces int) {
+	if r.resolved != nil {
+		r.resolved(stacktraces)
+	}
+}
+
+// unionSamples returns the samples of the partition, labeled or not.
+func (p *lazyPartition) unionSamples() map[uint32]int64 {
+	if len(p.labeled.samples) == 0 {
+		return p.samples
+	}
+	samples := make(map[uint32]int64, len(p.samples))
+	for sid, v := range p.samples {
+		samples[sid] = v
+	}
+	p.labeled.addTo(samples)
+	return samples
+}
+
+func (r *Resolver) withPartitions(ctx context.Context, fn func(*lazyPartition) error) error {
 	g, _ := errgroup.WithContext(ctx)
 	g.SetLimit(r.c)
 	for _, p := range r.p {
@@ -259,8 +479,7 @@ func (r *Resolver) withSymbols(ctx context.Context, fn func(*Symbols, schemav1.S
 			if err := p.fetch(ctx); err != nil {
 				return err
 			}
-			m := schemav1.NewSamplesFromMap(p.samples)
-			return fn(p.reader.Symbols(), m)
+			return fn(p)
 		})
 	}
 	return g.Wait()
@@ -277,11 +496,23 @@ func (r *Symbols) Pprof(
 	samples schemav1.Samples,
 	maxNodes int64,
 	selection *SelectedStackTraces,
+) (*googlev1.Profile, error) {
+	return r.pprof(ctx, samples, maxNodes, selection, nil)
+}
+
+// pprof is like Pprof, but the samples of the stack traces
+// are split by labels, if labels are provided.
+func (r *Symbols) pprof(
+	ctx context.Context,
+	samples schemav1.Samples,
+	maxNodes int64,
+	selection *SelectedStackTraces,
+	labels *pprofSampleLabels,
 ) (*googlev1.Profile, error) {
 	// By default, we use a builder that's optimized
 	// for the simplest case: we take all the source
 	// stack traces unchanged.
-	var b pprofBuilder = new(pprofProtoSymbols)
+	var b pprofBuilder = &pprofProtoSymbols{labels: labels}
 	// If a stack trace selector is specified,
 	// check if such a profile can exist at all.
 	if !selection.IsValid() {
@@ -295,6 +526,7 @@ func (r *Symbols) Pprof(
 		b = &pprofProtoTruncatedSymbols{
 			maxNodes:  maxNodes,
 			selection: selection,
+			labels:    labels,
 		}
 	}
 	b.init(r, samples)
@@ -305,9 +537,13 @@ func (r *Symbols) Pprof(
 }
 
 func (r *Symbols) Tree(ctx context.Context, samples schemav1.Samples) (*model.Tree, error) {
+	return r.tree(ctx, samples, typesv1.RecursionCollapse_RECURSION_COLLAPSE_NONE)
+}
+
+func (r *Symbols) tree(ctx context.Context, samples schemav1.Samples, recursion typesv1.RecursionCollapse) (*model.Tree, error) {
 	t := treeSymbolsFromPool()
 	defer t.reset()
-	t.init(r, samples)
+	t.init(r, samples, recursion)
 	if err := r.Stacktraces.ResolveStacktraceLocations(ctx, t, samples.StacktraceIDs); err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package symdb
 
 import (
 	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	"github.com/grafana/pyroscope/pkg/model"
 	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
 	"github.com/grafana/pyroscope/pkg/slices"
 )
@@ -12,24 +13,42 @@ type pprofProtoSymbols struct {
 	samples *schemav1.Samples
 	lut     []uint32
 	cur     int
+
+	labels *pprofSampleLabels
+	sets   []int32 // Label set index of each profile sample.
 }
 
 func (r *pprofProtoSymbols) init(symbols *Symbols, samples schemav1.Samples) {
 	r.symbols = symbols
 	r.samples = &samples
-	r.profile.Sample = make([]*googlev1.Sample, samples.Len())
+	r.profile.Sample = make([]*googlev1.Sample, 0, samples.Len())
 }
 
-func (r *pprofProtoSymbols) InsertStacktrace(_ uint32, locations []int32) {
+func (r *pprofProtoSymbols) InsertStacktrace(stacktraceID uint32, locations []int32) {
+	value := int64(r.samples.Values[r.cur])
+	r.cur++
+	values, ok := r.labels.stacktraceValues(stacktraceID)
+	if !ok {
+		r.addSample(locations, -1, value)
+		return
+	}
+	for _, v := range values {
+		r.addSample(locations, v.set, v.value)
+	}
+}
+
+func (r *pprofProtoSymbols) addSample(locations []int32, set int32, value int64) {
 	s := &googlev1.Sample{
 		LocationId: make([]uint64, len(locations)),
-		Value:      []int64{int64(r.samples.Values[r.cur])},
+		Value:      []int64{value},
 	}
 	for i, v := range locations {
 		s.LocationId[i] = uint64(v)
 	}
-	r.profile.Sample[r.cur] = s
-	r.cur++
+	r.profile.Sample = append(r.profile.Sample, s)
+	if r.labels != nil {
+		r.sets = append(r.sets, set)
+	}
 }
 
 func (r *pprofProtoSymbols) buildPprof() *googlev1.Profile {
@@ -40,9 +59,78 @@ func (r *pprofProtoSymbols) buildPprof() *googlev1.Profile {
 		copyMappings(&r.profile, r.symbols, r.lut)
 		copyStrings(&r.profile, r.symbols, r.lut)
 	}
+	setSampleLabels(&r.profile, r.labels, r.sets)
 	return &r.profile
 }
 
+// pprofSampleLabels describes how the samples of stack traces
+// are split by labels, when the profile samples retain labels.
+type pprofSampleLabels struct {
+	sets   []model.Labels
+	values map[uint32][]labeledValue // By stack trace ID.
+}
+
+// labeledValue is the value of the samples with the labels of the set.
+// The set is -1 for the samples without labels.
+type labeledValue struct {
+	set   int32
+	value int64
+}
+
+// stacktraceValues returns the values of the stack trace samples by label
+// set. If the stack trace has no labeled samples, or no labels are
+// retained, the function returns false.
+func (l *pprofSampleLabels) stacktraceValues(stacktraceID uint32) ([]labeledValue, bool) {
+	if l == nil {
+		return nil, false
+	}
+	values, ok := l.values[stacktraceID]
+	return values, ok
+}
+
+// setSampleLabels sets the labels of the profile samples: sets
+// specifies the label set index of each sample, if any.
+func setSampleLabels(profile *googlev1.Profile, labels *pprofSampleLabels, sets []int32) {
+	if labels == nil || len(sets) == 0 {
+		return
+	}
+	if len(profile.StringTable) == 0 {
+		profile.StringTable = append(profile.StringTable, "")
+	}
+	strings := make(map[string]int64, len(profile.StringTable))
+	for i, x := range profile.StringTable {
+		strings[x] = int64(i)
+	}
+	stringIndex := func(x string) int64 {
+		i, ok := strings[x]
+		if !ok {
+			i = int64(len(profile.StringTable))
+			profile.StringTable = append(profile.StringTable, x)
+			strings[x] = i
+		}
+		return i
+	}
+	// Labels of a set are resolved once, when first referenced.
+	resolved := make([][]*googlev1.Label, len(labels.sets))
+	for i, s := range profile.Sample {
+		set := sets[i]
+		if set < 0 {
+			continue
+		}
+		if resolved[set] == nil {
+			ls := labels.sets[set]
+			resolved[set] = make([]*googlev1.Label, len(ls))
+			for j, l := range ls {
+				resolved[set][j] = &googlev1.Label{Key: stringIndex(l.Name), Str: stringIndex(l.Value)}
+			}
+		}
+		s.Label = make([]*googlev1.Label, len(resolved[set]))
+		for j, l := range resolved[set] {
+			s.Label[j] = l.CloneVT()
+		}
+	}
+}
+
 func createSampleTypeStub(profile *googlev1.Profile) {
 	profile.PeriodType = new(googlev1.ValueType)
 	profile.SampleType = []*googlev1.ValueType{new(googlev1.ValueType)}
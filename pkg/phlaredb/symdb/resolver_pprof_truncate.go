@@ -42,6 +42,17 @@ type pprofProtoTruncatedSymbols struct {
 	// As an optimisation, we merge all the stack trace samples that were
 	// fully truncated to a single sample.
 	fullyTruncated int64
+
+	// If sample labels are retained, samples are deduplicated
+	// by both the stack trace and the label set.
+	labels         *pprofSampleLabels
+	labeledSamples map[truncatedSampleKey]*googlev1.Sample
+	sets           []int32
+}
+
+type truncatedSampleKey struct {
+	locations string
+	set       int32
 }
 
 type truncatedStacktraceSample struct {
@@ -58,6 +69,9 @@ func (r *pprofProtoTruncatedSymbols) init(symbols *Symbols, samples schemav1.Sam
 	r.functionTree = model.NewStacktraceTree(samples.Len() * 2)
 	r.stacktraces = make([]truncatedStacktraceSample, 0, samples.Len())
 	r.sampleMap = make(map[string]*googlev1.Sample, samples.Len())
+	if r.labels != nil {
+		r.labeledSamples = make(map[truncatedSampleKey]*googlev1.Sample, samples.Len())
+	}
 	if r.selection != nil && len(r.selection.callSite) > 0 {
 		r.fnNames = r.locFunctionsFiltered
 	} else {
@@ -130,6 +144,7 @@ func (r *pprofProtoTruncatedSymbols) buildPprof() *googlev1.Profile {
 	if r.truncated > 0 || r.fullyTruncated > 0 {
 		createLocationStub(&r.profile)
 	}
+	setSampleLabels(&r.profile, r.labels, r.sets)
 	return &r.profile
 }
 
@@ -151,17 +166,30 @@ func (r *pprofProtoTruncatedSymbols) addSample(n truncatedStacktraceSample) {
 	// locations based on the truncated functions.
 	var off int
 	r.functionsBuf, off = r.buildFunctionsStack(r.functionsBuf, n.functionNodeIdx)
+	values, labeled := r.labels.stacktraceValues(n.stacktraceID)
 	if off < 0 {
 		// The stack has no functions without the truncation mark.
-		r.fullyTruncated += n.value
-		return
+		if !labeled {
+			r.fullyTruncated += n.value
+			return
+		}
+		// Labeled samples are not merged into the stub sample,
+		// as it has no labels.
+		r.locationsBuf = append(r.locationsBuf[:0], truncationMark)
+	} else {
+		r.locationsBuf = r.symbols.Stacktraces.LookupLocations(r.locationsBuf, n.stacktraceID)
 	}
-	r.locationsBuf = r.symbols.Stacktraces.LookupLocations(r.locationsBuf, n.stacktraceID)
 	if off > 0 {
 		// Some functions were truncated.
 		r.locationsBuf = truncateLocations(r.locationsBuf, r.functionsBuf, off, r.symbols)
 		// Otherwise, if the offset is zero, the stack can be taken as is.
 	}
+	if labeled {
+		for _, v := range values {
+			r.addLabeledSample(v)
+		}
+		return
+	}
 	// Truncation may result in vast duplication of stack traces.
 	// Even if a particular stack trace is not truncated, we still
 	// remember it, as there might be another truncated stack trace
@@ -181,6 +209,20 @@ func (r *pprofProtoTruncatedSymbols) addSample(n truncatedStacktraceSample) {
 	r.sampleMap[uint64sliceString(locationsCopy)] = s
 }
 
+// addLabeledSample is like addSample, but samples are deduplicated
+// by both the truncated stack trace in r.locationsBuf and the labels.
+func (r *pprofProtoTruncatedSymbols) addLabeledSample(v labeledValue) {
+	k := truncatedSampleKey{locations: uint64sliceString(r.locationsBuf), set: v.set}
+	if s, dup := r.labeledSamples[k]; dup {
+		s.Value[0] += v.value
+		return
+	}
+	locationsCopy := make([]uint64, len(r.locationsBuf))
+	copy(locationsCopy, r.locationsBuf)
+	k.locations = uint64sliceString(locationsCopy)
+	r.labeledSamples[k] = &googlev1.Sample{LocationId: locationsCopy, Value: []int64{v.value}}
+}
+
 func (r *pprofProtoTruncatedSymbols) buildFunctionsStack(funcs []int32, idx int32) ([]int32, int) {
 	offset := -1
 	funcs = funcs[:0]
@@ -196,15 +238,29 @@ func (r *pprofProtoTruncatedSymbols) buildFunctionsStack(funcs []int32, idx int3
 }
 
 func (r *pprofProtoTruncatedSymbols) createSamples() {
-	samples := len(r.sampleMap)
+	samples := len(r.sampleMap) + len(r.labeledSamples)
 	r.profile.Sample = make([]*googlev1.Sample, samples, samples+1)
 	var i int
 	for _, s := range r.sampleMap {
 		r.profile.Sample[i] = s
 		i++
 	}
+	if r.labels != nil {
+		r.sets = make([]int32, samples, samples+1)
+		for j := range r.sets[:i] {
+			r.sets[j] = -1
+		}
+		for k, s := range r.labeledSamples {
+			r.profile.Sample[i] = s
+			r.sets[i] = k.set
+			i++
+		}
+	}
 	if r.fullyTruncated > 0 {
 		r.createStubSample()
+		if r.labels != nil {
+			r.sets = append(r.sets, -1)
+		}
 	}
 }
 
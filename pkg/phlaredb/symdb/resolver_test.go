@@ -3,6 +3,7 @@ package symdb
 import (
 	"context"
 	"io"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/pyroscope/pkg/model"
 	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
 )
 
@@ -44,6 +46,139 @@ func Test_Resolver_Error_Propagation(t *testing.T) {
 	r.Release()
 }
 
+type symbolizerFunc func(context.Context, SymbolsKey, *Symbols) (*Symbols, error)
+
+func (f symbolizerFunc) Symbolize(ctx context.Context, key SymbolsKey, s *Symbols) (*Symbols, error) {
+	return f(ctx, key, s)
+}
+
+func Test_Resolver_Symbolizer(t *testing.T) {
+	s := newBlockSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	defer s.teardown()
+
+	symbolizer := symbolizerFunc(func(_ context.Context, key SymbolsKey, symbols *Symbols) (*Symbols, error) {
+		require.Equal(t, SymbolsKey{Block: "block", Partition: 0}, key)
+		c := *symbols
+		c.Strings = make([]string, len(symbols.Strings))
+		for i, x := range symbols.Strings {
+			c.Strings[i] = "symbolized:" + x
+		}
+		return &c, nil
+	})
+
+	r := NewResolver(context.Background(), s.reader, WithResolverSymbolizer(symbolizer), WithResolverBlock("block"))
+	r.AddSamples(0, s.indexed[0][0].Samples)
+	symbolized, err := r.Tree()
+	require.NoError(t, err)
+	r.Release()
+	symbolized.IterateStacks(func(name string, _ int64, _ []string) {
+		require.True(t, strings.HasPrefix(name, "symbolized:"), name)
+	})
+
+	// The source symbols must not be affected.
+	r = NewResolver(context.Background(), s.reader)
+	r.AddSamples(0, s.indexed[0][0].Samples)
+	expected, err := r.Tree()
+	require.NoError(t, err)
+	r.Release()
+	expected.FormatNodeNames(func(name string) string { return "symbolized:" + name })
+	require.Equal(t, expected.String(), symbolized.String())
+}
+
+func Test_Resolver_LabeledSamples(t *testing.T) {
+	s := newMemSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	samples := s.indexed[0][0].Samples
+
+	r := NewResolver(context.Background(), s.db)
+	r.AddSamples(0, samples)
+	expected, err := r.Tree()
+	require.NoError(t, err)
+	r.Release()
+
+	labels := []model.Labels{
+		{{Name: "handler", Value: "/cart"}},
+		{{Name: "handler", Value: "/checkout"}},
+		nil,
+	}
+	totals := make(map[string]int64)
+	for i := range samples.StacktraceIDs {
+		totals[labels[i%3].Get("handler")] += int64(samples.Values[i])
+	}
+
+	for _, tc := range []struct {
+		name     string
+		options  []ResolverOption
+		expected map[string]int64
+	}{
+		{
+			name:     "all stack traces",
+			expected: totals,
+		},
+		{
+			name:     "truncated stack traces",
+			options:  []ResolverOption{WithResolverMaxNodes(16)},
+			expected: totals,
+		},
+		{
+			name:    "label sets limit",
+			options: []ResolverOption{WithResolverMaxLabelSets(1)},
+			expected: map[string]int64{
+				"/cart": totals["/cart"],
+				"":      totals["/checkout"] + totals[""],
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewResolver(context.Background(), s.db, tc.options...)
+			defer r.Release()
+			r.WithPartitionLabeledSamples(0, func(l *LabeledSamples) {
+				for i, sid := range samples.StacktraceIDs {
+					l.Add(labels[i%3], sid, int64(samples.Values[i]))
+				}
+			})
+
+			// Labels are not retained in the tree.
+			tree, err := r.Tree()
+			require.NoError(t, err)
+			require.Equal(t, expected.String(), tree.String())
+
+			p, err := r.Pprof()
+			require.NoError(t, err)
+			actual := make(map[string]int64)
+			for _, x := range p.Sample {
+				var handler string
+				if len(x.Label) > 0 {
+					require.Len(t, x.Label, 1)
+					require.Equal(t, "handler", p.StringTable[x.Label[0].Key])
+					handler = p.StringTable[x.Label[0].Str]
+				}
+				actual[handler] += x.Value[0]
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_LabeledSamples_HashCollision(t *testing.T) {
+	a := model.Labels{{Name: "handler", Value: "/cart"}}
+	b := model.Labels{{Name: "handler", Value: "/checkout"}}
+	unlabeled := make(map[uint32]int64)
+	s := newLabeledSamples(unlabeled, 0)
+	s.Add(a, 1, 1)
+	// Simulate a collision: the set of a is found by the hash of b.
+	s.hashes[b.Hash()] = s.hashes[a.Hash()]
+	s.Add(b, 1, 2)
+	s.Add(model.Labels{{Name: "handler", Value: "/cart"}}, 1, 4)
+
+	require.Equal(t, []model.Labels{a, b}, s.sets)
+	require.Equal(t, map[labeledSample]int64{
+		{stacktraceID: 1, set: 0}: 5,
+		{stacktraceID: 1, set: 1}: 2,
+	}, s.samples)
+	require.Empty(t, unlabeled)
+}
+
 func Test_Resolver_Cancellation(t *testing.T) {
 	s := newBlockSuite(t, [][]string{{"testdata/profile.pb.gz"}})
 	defer s.teardown()
@@ -3,6 +3,7 @@ package symdb
 import (
 	"sync"
 
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/model"
 	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
 )
@@ -13,6 +14,8 @@ type treeSymbols struct {
 	tree    *model.Tree
 	lines   []string
 	cur     int
+
+	recursion *model.RecursionCollapser[string]
 }
 
 var treeSymbolsPool = sync.Pool{
@@ -27,15 +30,19 @@ func (r *treeSymbols) reset() {
 	r.symbols = nil
 	r.samples = nil
 	r.tree = nil
+	r.recursion = nil
 	r.lines = r.lines[:0]
 	r.cur = 0
 	treeSymbolsPool.Put(r)
 }
 
-func (r *treeSymbols) init(symbols *Symbols, samples schemav1.Samples) {
+func (r *treeSymbols) init(symbols *Symbols, samples schemav1.Samples, recursion typesv1.RecursionCollapse) {
 	r.symbols = symbols
 	r.samples = &samples
 	r.tree = new(model.Tree)
+	if recursion != typesv1.RecursionCollapse_RECURSION_COLLAPSE_NONE {
+		r.recursion = model.NewRecursionCollapser[string](recursion)
+	}
 }
 
 func (r *treeSymbols) InsertStacktrace(_ uint32, locations []int32) {
@@ -47,6 +54,9 @@ func (r *treeSymbols) InsertStacktrace(_ uint32, locations []int32) {
 			r.lines = append(r.lines, r.symbols.Strings[f.Name])
 		}
 	}
+	r.lines = model.CollapseRecursion(r.recursion, r.lines, functionName)
 	r.tree.InsertStack(int64(r.samples.Values[r.cur]), r.lines...)
 	r.cur++
 }
+
+func functionName(name string) string { return name }
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 )
 
 func Test_memory_Resolver_ResolveTree(t *testing.T) {
@@ -53,3 +55,37 @@ func Benchmark_block_Resolver_ResolveTree_Big(b *testing.B) {
 		_, _ = r.Tree()
 	}
 }
+
+func Test_Resolver_ResolveTree_CollapseRecursion(t *testing.T) {
+	s := newMemSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	r := NewResolver(context.Background(), s.db)
+	defer r.Release()
+	r.AddSamples(0, s.indexed[0][0].Samples)
+	expected, err := r.Tree()
+	require.NoError(t, err)
+
+	for _, mode := range []typesv1.RecursionCollapse{
+		typesv1.RecursionCollapse_RECURSION_COLLAPSE_DIRECT,
+		typesv1.RecursionCollapse_RECURSION_COLLAPSE_INDIRECT,
+	} {
+		r := NewResolver(context.Background(), s.db, WithResolverCollapseRecursion(mode))
+		r.AddSamples(0, s.indexed[0][0].Samples)
+		resolved, err := r.Tree()
+		require.NoError(t, err)
+		r.Release()
+
+		require.Equal(t, expected.Total(), resolved.Total())
+		resolved.IterateStacks(func(_ string, _ int64, stack []string) {
+			seen := make(map[string]struct{}, len(stack))
+			for i, name := range stack {
+				if mode == typesv1.RecursionCollapse_RECURSION_COLLAPSE_DIRECT {
+					require.False(t, i > 0 && stack[i-1] == name, stack)
+					continue
+				}
+				_, ok := seen[name]
+				require.False(t, ok, stack)
+				seen[name] = struct{}{}
+			}
+		})
+	}
+}
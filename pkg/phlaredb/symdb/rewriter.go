@@ -36,6 +36,30 @@ func (r *Rewriter) Rewrite(partition uint64, stacktraces []uint32) error {
 	return nil
 }
 
+// RewriteStrings rewrites references to the partition string
+// table, such as sample label keys and values.
+func (r *Rewriter) RewriteStrings(partition uint64, strings []uint32) error {
+	p, err := r.init(partition)
+	if err != nil {
+		return err
+	}
+	for i, v := range strings {
+		strings[i] = p.strings.tryLookup(v)
+	}
+	if len(p.strings.unresolved) > 0 {
+		unresolvedStrings := p.strings.iter()
+		for unresolvedStrings.Next() {
+			unresolvedStrings.setValue(p.src.Strings[unresolvedStrings.At()])
+		}
+		p.dst.AppendStrings(p.strings.buf, p.strings.values)
+		p.strings.updateResolved()
+	}
+	for i, v := range strings {
+		strings[i] = p.strings.lookupResolved(v)
+	}
+	return nil
+}
+
 func (r *Rewriter) init(partition uint64) (p *partitionRewriter, err error) {
 	if r.partitions == nil {
 		r.partitions = make(map[uint64]*partitionRewriter)
@@ -75,6 +75,10 @@ func (m *ProfileMerge) merge(p *profilev1.Profile, clone bool) error {
 	m.sampleTable.Index(m.tmp, p.Sample)
 
 	for i, idx := range m.tmp {
+		if m.sampleTable.s[idx] == p.Sample[i] {
+			// The sample is borrowed, its values are already there.
+			continue
+		}
 		dst := m.sampleTable.s[idx].Value
 		src := p.Sample[i].Value
 		for j, v := range src {
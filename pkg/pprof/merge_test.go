@@ -17,6 +17,16 @@ func Test_Merge_Single(t *testing.T) {
 	testhelper.EqualProto(t, p.Profile, m.Profile())
 }
 
+func Test_MergeNoClone_Single(t *testing.T) {
+	p, err := OpenFile("testdata/go.cpu.labels.pprof")
+	require.NoError(t, err)
+	var c ProfileMerge
+	require.NoError(t, c.Merge(p.Profile.CloneVT()))
+	var m ProfileMerge
+	require.NoError(t, m.MergeNoClone(p.Profile))
+	testhelper.EqualProto(t, c.Profile(), m.Profile())
+}
+
 func Test_Merge_Self(t *testing.T) {
 	p, err := OpenFile("testdata/go.cpu.labels.pprof")
 	require.NoError(t, err)
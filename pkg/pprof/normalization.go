@@ -0,0 +1,402 @@
+package pprof
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/slices"
+)
+
+type NormalizationAction string
+
+const (
+	// NormalizationDrop removes the frames of the functions
+	// with names matching the regex.
+	NormalizationDrop NormalizationAction = "drop"
+	// NormalizationKeep removes the frames of the functions
+	// with names not matching the regex.
+	NormalizationKeep NormalizationAction = "keep"
+	// NormalizationRename replaces the matches of the regex in the
+	// function names with the replacement. The replacement may refer
+	// to the regex capture groups, e.g. $1.
+	NormalizationRename NormalizationAction = "rename"
+	// NormalizationCollapseRecursion replaces consecutive frames
+	// of the same function with a single one.
+	NormalizationCollapseRecursion NormalizationAction = "collapse_recursion"
+	// NormalizationTrim truncates the stack traces to max_depth frames.
+	// The deepest frames are removed.
+	NormalizationTrim NormalizationAction = "trim"
+)
+
+// NormalizationRule is a declarative stack trace transformation applied
+// to profiles at ingestion. Rules are applied in the order they are
+// specified: a rule observes the result of the preceding ones.
+type NormalizationRule struct {
+	Action      NormalizationAction `yaml:"action" json:"action"`
+	Regex       string              `yaml:"regex,omitempty" json:"regex,omitempty"`
+	Replacement string              `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+	MaxDepth    int                 `yaml:"max_depth,omitempty" json:"max_depth,omitempty"`
+
+	regex *regexp.Regexp
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *NormalizationRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain NormalizationRule
+	if err := unmarshal((*plain)(r)); err != nil {
+		return err
+	}
+	return r.Validate()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *NormalizationRule) UnmarshalJSON(data []byte) error {
+	type plain NormalizationRule
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	return r.Validate()
+}
+
+// Validate checks the rule and compiles its regex. Rules must be
+// validated before they are applied; rules unmarshalled from YAML
+// or JSON are validated automatically.
+func (r *NormalizationRule) Validate() (err error) {
+	switch r.Action {
+	case NormalizationDrop, NormalizationKeep, NormalizationRename:
+		if r.Regex == "" {
+			return fmt.Errorf("normalization rule %q: regex is required", r.Action)
+		}
+		if r.regex, err = regexp.Compile(r.Regex); err != nil {
+			return fmt.Errorf("normalization rule %q: %w", r.Action, err)
+		}
+	case NormalizationCollapseRecursion:
+	case NormalizationTrim:
+		if r.MaxDepth <= 0 {
+			return fmt.Errorf("normalization rule %q: max_depth must be positive", r.Action)
+		}
+	default:
+		return fmt.Errorf("unknown normalization action %q", r.Action)
+	}
+	return nil
+}
+
+// ApplyNormalizationRules applies the rules to the stack traces of the
+// profile. Samples left without frames are removed. If any rule has been
+// applied, the profile is normalized, and the new profile is returned.
+// Otherwise, the profile is returned unchanged.
+//
+// Function names are matched against the regexes. Frames of inlined
+// functions are handled individually: a location is removed once all
+// its frames are removed.
+func ApplyNormalizationRules(p *profilev1.Profile, rules []*NormalizationRule) *profilev1.Profile {
+	if len(rules) == 0 {
+		return p
+	}
+	n := normalizer{Profile: p}
+	for _, r := range rules {
+		switch r.Action {
+		case NormalizationDrop:
+			n.dropFunctions(r.regex, true)
+		case NormalizationKeep:
+			n.dropFunctions(r.regex, false)
+		case NormalizationRename:
+			n.renameFunctions(r.regex, r.Replacement)
+		case NormalizationCollapseRecursion:
+			n.collapseRecursion(typesv1.RecursionCollapse_RECURSION_COLLAPSE_DIRECT)
+		case NormalizationTrim:
+			n.trim(r.MaxDepth)
+		}
+	}
+	return n.profile()
+}
+
+// CollapseRecursion removes recursive calls from the stack traces of the
+// profile, see model.CollapseRecursion. If any stack trace has changed,
+// the profile is normalized, and the new profile is returned.
+func CollapseRecursion(p *profilev1.Profile, mode typesv1.RecursionCollapse) *profilev1.Profile {
+	n := normalizer{Profile: p}
+	n.collapseRecursion(mode)
+	return n.profile()
+}
+
+func (n *normalizer) profile() *profilev1.Profile {
+	if !n.modified {
+		return n.Profile
+	}
+	if n.PeriodType == nil {
+		n.PeriodType = new(profilev1.ValueType)
+	}
+	n.removeUnused()
+	// Merging with self merges duplicate stack traces.
+	var m ProfileMerge
+	_ = m.MergeNoClone(n.Profile)
+	return m.Profile()
+}
+
+type normalizer struct {
+	*profilev1.Profile
+	modified  bool
+	strings   map[string]int64
+	locations map[uint64]*profilev1.Location
+}
+
+func (n *normalizer) functionNames() map[uint64]string {
+	names := make(map[uint64]string, len(n.Function))
+	for _, fn := range n.Function {
+		names[fn.Id] = n.StringTable[fn.Name]
+	}
+	return names
+}
+
+func (n *normalizer) location(id uint64) *profilev1.Location {
+	if n.locations == nil {
+		n.locations = make(map[uint64]*profilev1.Location, len(n.Location))
+		for _, loc := range n.Location {
+			n.locations[loc.Id] = loc
+		}
+	}
+	return n.locations[id]
+}
+
+func (n *normalizer) dropFunctions(re *regexp.Regexp, match bool) {
+	if re == nil {
+		return
+	}
+	drop := make(map[uint64]struct{})
+	for id, name := range n.functionNames() {
+		if re.MatchString(name) == match {
+			drop[id] = struct{}{}
+		}
+	}
+	if len(drop) == 0 {
+		return
+	}
+	n.dropLines(func(line *profilev1.Line) bool {
+		_, ok := drop[line.FunctionId]
+		return ok
+	})
+}
+
+// dropLines removes the location lines for which the drop function
+// returns true. Locations without lines are removed from the samples.
+// Locations that have no lines originally are not affected.
+func (n *normalizer) dropLines(drop func(*profilev1.Line) bool) {
+	emptyLocations := make(map[uint64]struct{})
+	for _, loc := range n.Location {
+		if len(loc.Line) == 0 {
+			continue
+		}
+		j := 0
+		for _, line := range loc.Line {
+			if !drop(line) {
+				loc.Line[j] = line
+				j++
+			}
+		}
+		if j == len(loc.Line) {
+			continue
+		}
+		n.modified = true
+		loc.Line = loc.Line[:j]
+		if j == 0 {
+			emptyLocations[loc.Id] = struct{}{}
+		}
+	}
+	n.removeLocations(emptyLocations)
+}
+
+// removeLocations removes the locations from the samples.
+// Samples left without locations are removed.
+func (n *normalizer) removeLocations(locations map[uint64]struct{}) {
+	if len(locations) == 0 {
+		return
+	}
+	n.modified = true
+	for _, s := range n.Sample {
+		j := 0
+		for _, id := range s.LocationId {
+			if _, ok := locations[id]; !ok {
+				s.LocationId[j] = id
+				j++
+			}
+		}
+		s.LocationId = s.LocationId[:j]
+	}
+	n.removeEmptySamples()
+}
+
+func (n *normalizer) renameFunctions(re *regexp.Regexp, replacement string) {
+	if re == nil {
+		return
+	}
+	for _, fn := range n.Function {
+		name := n.StringTable[fn.Name]
+		renamed := re.ReplaceAllString(name, replacement)
+		if renamed != name {
+			fn.Name = n.addString(renamed)
+			n.modified = true
+		}
+	}
+}
+
+func (n *normalizer) addString(s string) int64 {
+	if n.strings == nil {
+		n.strings = make(map[string]int64, len(n.StringTable))
+		for i, x := range n.StringTable {
+			if _, ok := n.strings[x]; !ok {
+				n.strings[x] = int64(i)
+			}
+		}
+	}
+	if i, ok := n.strings[s]; ok {
+		return i
+	}
+	i := int64(len(n.StringTable))
+	n.StringTable = append(n.StringTable, s)
+	n.strings[s] = i
+	return i
+}
+
+// collapseRecursion removes recursive calls. Functions are compared by
+// name: the locations of the recursive calls usually differ. Locations
+// without lines, e.g., not symbolized ones, are compared by ID: their keys
+// have no zero byte, so they can't be equal to the key of other locations.
+func (n *normalizer) collapseRecursion(mode typesv1.RecursionCollapse) {
+	c := model.NewRecursionCollapser[string](mode)
+	if !c.Enabled() {
+		return
+	}
+	names := n.functionNames()
+	keys := make(map[uint64]string)
+	key := func(id uint64) string {
+		k, ok := keys[id]
+		if !ok {
+			k = strconv.FormatUint(id, 10)
+			if loc := n.location(id); loc != nil && len(loc.Line) > 0 {
+				var b strings.Builder
+				for _, line := range loc.Line {
+					b.WriteString(names[line.FunctionId])
+					b.WriteByte(0)
+				}
+				k = b.String()
+			}
+			keys[id] = k
+		}
+		return k
+	}
+	for _, s := range n.Sample {
+		if len(s.LocationId) < 2 {
+			continue
+		}
+		size := len(s.LocationId)
+		// s.LocationId[0] is the leaf.
+		slices.Reverse(s.LocationId)
+		s.LocationId = model.CollapseRecursion(c, s.LocationId, key)
+		slices.Reverse(s.LocationId)
+		if len(s.LocationId) != size {
+			n.modified = true
+		}
+	}
+}
+
+func (n *normalizer) trim(maxDepth int) {
+	for _, s := range n.Sample {
+		if len(s.LocationId) > maxDepth {
+			// Truncate the deepest frames: s.LocationId[0] is the leaf.
+			s.LocationId = s.LocationId[len(s.LocationId)-maxDepth:]
+			n.modified = true
+		}
+	}
+}
+
+func (n *normalizer) removeEmptySamples() {
+	j := 0
+	for _, s := range n.Sample {
+		if len(s.LocationId) > 0 {
+			n.Sample[j] = s
+			j++
+		}
+	}
+	n.Sample = n.Sample[:j]
+}
+
+// removeUnused removes the locations, functions, and strings
+// not referenced anymore: merging does not remove them.
+func (n *normalizer) removeUnused() {
+	locations := make(map[uint64]struct{}, len(n.Location))
+	for _, s := range n.Sample {
+		for _, id := range s.LocationId {
+			locations[id] = struct{}{}
+		}
+	}
+	functions := make(map[uint64]struct{}, len(n.Function))
+	n.Location = slices.RemoveInPlace(n.Location, func(loc *profilev1.Location, _ int) bool {
+		if _, ok := locations[loc.Id]; !ok {
+			return true
+		}
+		for _, line := range loc.Line {
+			functions[line.FunctionId] = struct{}{}
+		}
+		return false
+	})
+	n.Function = slices.RemoveInPlace(n.Function, func(fn *profilev1.Function, _ int) bool {
+		_, ok := functions[fn.Id]
+		return !ok
+	})
+
+	used := make([]bool, len(n.StringTable))
+	mark := func(i int64) {
+		if i >= 0 && int(i) < len(used) {
+			used[i] = true
+		}
+	}
+	mark(0)
+	mark(n.DropFrames)
+	mark(n.KeepFrames)
+	mark(n.DefaultSampleType)
+	mark(n.PeriodType.Type)
+	mark(n.PeriodType.Unit)
+	for _, st := range n.SampleType {
+		mark(st.Type)
+		mark(st.Unit)
+	}
+	for _, c := range n.Comment {
+		mark(c)
+	}
+	for _, s := range n.Sample {
+		for _, l := range s.Label {
+			mark(l.Key)
+			mark(l.Str)
+		}
+	}
+	for _, m := range n.Mapping {
+		mark(m.Filename)
+		mark(m.BuildId)
+	}
+	for _, fn := range n.Function {
+		mark(fn.Name)
+		mark(fn.SystemName)
+		mark(fn.Filename)
+	}
+	idx := make([]uint32, len(n.StringTable))
+	j := 0
+	for i, ok := range used {
+		if ok {
+			n.StringTable[j] = n.StringTable[i]
+			idx[i] = uint32(j)
+			j++
+		}
+	}
+	if j == len(n.StringTable) {
+		return
+	}
+	n.StringTable = n.StringTable[:j]
+	RewriteStrings(n.Profile, idx)
+}
@@ -0,0 +1,299 @@
+package pprof
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// normalizationTestProfile creates a profile from the stack traces
+// given as "root;...;leaf" strings. Every frame gets its own location,
+// as recursive calls usually do.
+func normalizationTestProfile(stacks map[string]int64) *profilev1.Profile {
+	p := &profilev1.Profile{
+		SampleType:  []*profilev1.ValueType{{Type: 1, Unit: 2}},
+		StringTable: []string{"", "cpu", "nanoseconds"},
+		Mapping:     []*profilev1.Mapping{{Id: 1, HasFunctions: true}},
+	}
+	functions := make(map[string]uint64)
+	for stack, v := range stacks {
+		frames := strings.Split(stack, ";")
+		s := &profilev1.Sample{Value: []int64{v}}
+		for i := len(frames) - 1; i >= 0; i-- {
+			fn, ok := functions[frames[i]]
+			if !ok {
+				p.StringTable = append(p.StringTable, frames[i])
+				fn = uint64(len(p.Function) + 1)
+				p.Function = append(p.Function, &profilev1.Function{Id: fn, Name: int64(len(p.StringTable) - 1)})
+				functions[frames[i]] = fn
+			}
+			loc := &profilev1.Location{
+				Id:        uint64(len(p.Location) + 1),
+				MappingId: 1,
+				Line:      []*profilev1.Line{{FunctionId: fn}},
+			}
+			p.Location = append(p.Location, loc)
+			s.LocationId = append(s.LocationId, loc.Id)
+		}
+		p.Sample = append(p.Sample, s)
+	}
+	return p
+}
+
+func normalizationTestStacks(t *testing.T, p *profilev1.Profile) map[string]int64 {
+	functions := make(map[uint64]string)
+	for _, fn := range p.Function {
+		functions[fn.Id] = p.StringTable[fn.Name]
+	}
+	locations := make(map[uint64]*profilev1.Location)
+	for _, loc := range p.Location {
+		locations[loc.Id] = loc
+	}
+	used := make(map[uint64]struct{})
+	for _, loc := range p.Location {
+		for _, line := range loc.Line {
+			used[line.FunctionId] = struct{}{}
+		}
+	}
+	for _, fn := range p.Function {
+		_, ok := used[fn.Id]
+		require.True(t, ok, "unused function %s", p.StringTable[fn.Name])
+	}
+	stacks := make(map[string]int64)
+	for _, s := range p.Sample {
+		var frames []string
+		for i := len(s.LocationId) - 1; i >= 0; i-- {
+			lines := locations[s.LocationId[i]].Line
+			for j := len(lines) - 1; j >= 0; j-- {
+				frames = append(frames, functions[lines[j].FunctionId])
+			}
+		}
+		stacks[strings.Join(frames, ";")] += s.Value[0]
+	}
+	return stacks
+}
+
+func Test_ApplyNormalizationRules(t *testing.T) {
+	type testCase struct {
+		name     string
+		rules    []*NormalizationRule
+		input    map[string]int64
+		expected map[string]int64
+	}
+
+	testCases := []testCase{
+		{
+			name:     "no rules",
+			input:    map[string]int64{"main;foo": 1},
+			expected: map[string]int64{"main;foo": 1},
+		},
+		{
+			name: "drop",
+			rules: []*NormalizationRule{
+				{Action: NormalizationDrop, Regex: `^reflect\.`},
+			},
+			input: map[string]int64{
+				"main;reflect.Value.Call;reflect.Value.call;handler": 1,
+				"main;handler":       2,
+				"reflect.Value.Call": 3,
+			},
+			expected: map[string]int64{"main;handler": 3},
+		},
+		{
+			name: "keep",
+			rules: []*NormalizationRule{
+				{Action: NormalizationKeep, Regex: `^(main|app\.)`},
+			},
+			input: map[string]int64{
+				"main;runtime.goexit;app.handler;net/http.serve": 1,
+			},
+			expected: map[string]int64{"main;app.handler": 1},
+		},
+		{
+			name: "rename",
+			rules: []*NormalizationRule{
+				{Action: NormalizationRename, Regex: `\$\$Lambda\$\d+/0x[0-9a-f]+`, Replacement: "$$$$Lambda"},
+				{Action: NormalizationRename, Regex: `0x[0-9a-f]+`, Replacement: "0x?"},
+			},
+			input: map[string]int64{
+				"main;App$$Lambda$12/0x0000000800c0b1f8.run": 1,
+				"main;App$$Lambda$13/0x0000000800c0b438.run": 2,
+				"main;jit_0x7f00;jit_0x7f10":                 3,
+			},
+			expected: map[string]int64{
+				"main;App$$Lambda.run": 3,
+				"main;jit_0x?;jit_0x?": 3,
+			},
+		},
+		{
+			name: "collapse recursion",
+			rules: []*NormalizationRule{
+				{Action: NormalizationCollapseRecursion},
+			},
+			input: map[string]int64{
+				"main;walk;walk;walk;visit": 1,
+				"main;walk;visit":           2,
+				"main;walk;visit;walk":      3,
+			},
+			expected: map[string]int64{
+				"main;walk;visit":      3,
+				"main;walk;visit;walk": 3,
+			},
+		},
+		{
+			name: "trim",
+			rules: []*NormalizationRule{
+				{Action: NormalizationTrim, MaxDepth: 2},
+			},
+			input: map[string]int64{
+				"main;a;b;c": 1,
+				"main;a;d":   2,
+				"main":       3,
+			},
+			expected: map[string]int64{
+				"main;a": 3,
+				"main":   3,
+			},
+		},
+		{
+			name: "rules are applied in order",
+			rules: []*NormalizationRule{
+				{Action: NormalizationRename, Regex: `^wrapper\d+$`, Replacement: "wrapper"},
+				{Action: NormalizationCollapseRecursion},
+				{Action: NormalizationTrim, MaxDepth: 3},
+			},
+			input: map[string]int64{
+				"main;wrapper1;wrapper2;wrapper3;handler;leaf": 1,
+			},
+			expected: map[string]int64{"main;wrapper;handler": 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for _, r := range tc.rules {
+				require.NoError(t, r.Validate())
+			}
+			p := ApplyNormalizationRules(normalizationTestProfile(tc.input), tc.rules)
+			assert.Equal(t, tc.expected, normalizationTestStacks(t, p))
+		})
+	}
+}
+
+func Test_ApplyNormalizationRules_InlinedFrames(t *testing.T) {
+	p := normalizationTestProfile(map[string]int64{"main;handler": 1})
+	// Inline reflect.call into the handler location.
+	p.StringTable = append(p.StringTable, "reflect.call")
+	p.Function = append(p.Function, &profilev1.Function{Id: 3, Name: int64(len(p.StringTable) - 1)})
+	p.Location[0].Line = append(p.Location[0].Line, &profilev1.Line{FunctionId: 3})
+	require.Equal(t, map[string]int64{"main;reflect.call;handler": 1}, normalizationTestStacks(t, p))
+
+	rule := &NormalizationRule{Action: NormalizationDrop, Regex: `^reflect\.`}
+	require.NoError(t, rule.Validate())
+	p = ApplyNormalizationRules(p, []*NormalizationRule{rule})
+	assert.Equal(t, map[string]int64{"main;handler": 1}, normalizationTestStacks(t, p))
+}
+
+func Test_NormalizationRule_UnmarshalYAML(t *testing.T) {
+	var rules []*NormalizationRule
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- action: drop
+  regex: ^reflect\.
+- action: rename
+  regex: 0x[0-9a-f]+
+  replacement: 0x?
+- action: trim
+  max_depth: 100
+`), &rules))
+	require.Len(t, rules, 3)
+	assert.NotNil(t, rules[0].regex)
+	assert.Equal(t, "0x?", rules[1].Replacement)
+	assert.Equal(t, 100, rules[2].MaxDepth)
+
+	for _, invalid := range []string{
+		`[{action: drop}]`,
+		`[{action: rename, regex: "("}]`,
+		`[{action: trim}]`,
+		`[{action: unknown}]`,
+	} {
+		assert.Error(t, yaml.Unmarshal([]byte(invalid), &rules), invalid)
+	}
+}
+
+func Test_NormalizationRule_UnmarshalJSON(t *testing.T) {
+	var rules []*NormalizationRule
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"action": "drop", "regex": "^reflect\\."},
+		{"action": "trim", "max_depth": 100}
+	]`), &rules))
+	require.Len(t, rules, 2)
+	assert.NotNil(t, rules[0].regex)
+	assert.Equal(t, 100, rules[1].MaxDepth)
+
+	p := normalizationTestProfile(map[string]int64{"main;reflect.Call;handler": 1})
+	p = ApplyNormalizationRules(p, rules)
+	assert.Equal(t, map[string]int64{"main;handler": 1}, normalizationTestStacks(t, p))
+
+	for _, invalid := range []string{
+		`[{"action": "drop"}]`,
+		`[{"action": "rename", "regex": "("}]`,
+		`[{"action": "trim"}]`,
+		`[{"action": "unknown"}]`,
+	} {
+		var rules []*NormalizationRule
+		assert.Error(t, json.Unmarshal([]byte(invalid), &rules), invalid)
+	}
+}
+
+func Test_CollapseRecursion(t *testing.T) {
+	input := map[string]int64{
+		"main;parse;expr;term;expr;term;number": 1,
+		"main;parse;expr;expr;term":             2,
+		"main;parse":                            3,
+	}
+	p := CollapseRecursion(normalizationTestProfile(input), typesv1.RecursionCollapse_RECURSION_COLLAPSE_DIRECT)
+	assert.Equal(t, map[string]int64{
+		"main;parse;expr;term;expr;term;number": 1,
+		"main;parse;expr;term":                  2,
+		"main;parse":                            3,
+	}, normalizationTestStacks(t, p))
+
+	p = CollapseRecursion(normalizationTestProfile(input), typesv1.RecursionCollapse_RECURSION_COLLAPSE_INDIRECT)
+	assert.Equal(t, map[string]int64{
+		"main;parse;expr;term;number": 1,
+		"main;parse;expr;term":        2,
+		"main;parse":                  3,
+	}, normalizationTestStacks(t, p))
+}
+
+func Test_CollapseRecursion_AddressOnlyLocations(t *testing.T) {
+	for _, mode := range []typesv1.RecursionCollapse{
+		typesv1.RecursionCollapse_RECURSION_COLLAPSE_DIRECT,
+		typesv1.RecursionCollapse_RECURSION_COLLAPSE_INDIRECT,
+	} {
+		p := normalizationTestProfile(map[string]int64{"main;0x10;0x20;handler;0x30;leaf": 1})
+		// Strip the lines of the 0x frames, as if they were not symbolized.
+		for _, loc := range p.Location {
+			name := p.StringTable[p.Function[loc.Line[0].FunctionId-1].Name]
+			if strings.HasPrefix(name, "0x") {
+				loc.Address, _ = strconv.ParseUint(name[2:], 16, 64)
+				loc.Line = nil
+			}
+		}
+		locations := append([]uint64(nil), p.Sample[0].LocationId...)
+
+		// Distinct frames without lines are neither collapsed together,
+		// nor taken as the same function around other frames.
+		p = CollapseRecursion(p, mode)
+		assert.Equal(t, locations, p.Sample[0].LocationId, mode.String())
+	}
+}
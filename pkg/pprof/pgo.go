@@ -0,0 +1,124 @@
+package pprof
+
+import (
+	"strings"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+)
+
+// PGOOptions specifies how a profile is prepared for
+// Go profile-guided optimization (PGO).
+type PGOOptions struct {
+	// MinEdgeFraction is the minimal weight of a call edge relative
+	// to the profile total. Stack traces are truncated at the first
+	// edge (from the root) colder than that. Zero disables pruning.
+	MinEdgeFraction float64
+}
+
+// PGOProfile converts a CPU profile into a compact profile suitable for
+// use as a default.pgo file: only frames of Go functions with the file
+// and line information are kept, sample labels are removed, and cold
+// call edges are optionally pruned. The source profile is modified.
+func PGOProfile(p *profilev1.Profile, opts PGOOptions) *profilev1.Profile {
+	n := normalizer{Profile: p}
+	// Unsymbolized locations carry no information
+	// the compiler could use.
+	unsymbolized := make(map[uint64]struct{})
+	for _, loc := range n.Location {
+		if len(loc.Line) == 0 {
+			unsymbolized[loc.Id] = struct{}{}
+		}
+	}
+	n.removeLocations(unsymbolized)
+	files := make(map[uint64]string, len(n.Function))
+	for _, fn := range n.Function {
+		files[fn.Id] = n.StringTable[fn.Filename]
+	}
+	n.dropLines(func(line *profilev1.Line) bool {
+		return line.Line <= 0 || !strings.HasSuffix(files[line.FunctionId], ".go")
+	})
+	for _, s := range n.Sample {
+		s.Label = nil
+	}
+	if opts.MinEdgeFraction > 0 {
+		n.pruneColdEdges(opts.MinEdgeFraction)
+	}
+	n.Comment = nil
+	// The profile is always rebuilt to drop unused symbols.
+	n.modified = true
+	return n.profile()
+}
+
+type pgoEdge struct {
+	caller     uint64
+	callerLine int64
+	callee     uint64
+}
+
+// pgoFrame is a stack trace frame: a location line.
+type pgoFrame struct {
+	line     *profilev1.Line
+	location int // Index of the location in the sample, from the root.
+}
+
+func (n *normalizer) pruneColdEdges(minFraction float64) {
+	var total int64
+	for _, s := range n.Sample {
+		total += s.Value[0]
+	}
+	threshold := int64(float64(total) * minFraction)
+	if threshold <= 0 {
+		return
+	}
+	var frames []pgoFrame
+	sampleFrames := func(s *profilev1.Sample) []pgoFrame {
+		frames = frames[:0]
+		// Both sample locations and location lines are ordered
+		// from the leaf: the last line of a location is the caller
+		// of the inlined functions.
+		for i := len(s.LocationId) - 1; i >= 0; i-- {
+			loc := n.location(s.LocationId[i])
+			for j := len(loc.Line) - 1; j >= 0; j-- {
+				frames = append(frames, pgoFrame{
+					line:     loc.Line[j],
+					location: len(s.LocationId) - 1 - i,
+				})
+			}
+		}
+		return frames
+	}
+	edgeOf := func(caller, callee pgoFrame) pgoEdge {
+		return pgoEdge{
+			caller:     caller.line.FunctionId,
+			callerLine: caller.line.Line,
+			callee:     callee.line.FunctionId,
+		}
+	}
+
+	weights := make(map[pgoEdge]int64)
+	for _, s := range n.Sample {
+		frames = sampleFrames(s)
+		for i := 1; i < len(frames); i++ {
+			weights[edgeOf(frames[i-1], frames[i])] += s.Value[0]
+		}
+	}
+	for _, s := range n.Sample {
+		frames = sampleFrames(s)
+		for i := 1; i < len(frames); i++ {
+			if weights[edgeOf(frames[i-1], frames[i])] >= threshold {
+				continue
+			}
+			// Locations can't be split: if the callee is inlined
+			// into the caller, the whole location is retained.
+			keep := frames[i].location
+			if frames[i-1].location == keep {
+				keep++
+			}
+			if keep < len(s.LocationId) {
+				// s.LocationId[0] is the leaf.
+				s.LocationId = s.LocationId[len(s.LocationId)-keep:]
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package pprof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+)
+
+func pgoTestProfile() *profilev1.Profile {
+	p := normalizationTestProfile(map[string]int64{
+		"main;handler;json.Marshal":      90,
+		"main;handler;log":               5,
+		"main;runtime.cgocall;libc_read": 5,
+	})
+	p.StringTable = append(p.StringTable, "main.go", "read.c")
+	for _, fn := range p.Function {
+		fn.Filename = int64(len(p.StringTable) - 2)
+		if p.StringTable[fn.Name] == "libc_read" {
+			fn.Filename = int64(len(p.StringTable) - 1)
+		}
+	}
+	for _, loc := range p.Location {
+		loc.Line[0].Line = 10
+	}
+	// An unsymbolized leaf location.
+	p.Location = append(p.Location, &profilev1.Location{Id: uint64(len(p.Location) + 1), MappingId: 1, Address: 0x1000})
+	s := p.Sample[0]
+	s.LocationId = append([]uint64{uint64(len(p.Location))}, s.LocationId...)
+	s.Label = []*profilev1.Label{{Key: 1, Str: 2}}
+	return p
+}
+
+func Test_PGOProfile(t *testing.T) {
+	p := PGOProfile(pgoTestProfile(), PGOOptions{})
+	assert.Equal(t, map[string]int64{
+		"main;handler;json.Marshal": 90,
+		"main;handler;log":          5,
+		"main;runtime.cgocall":      5,
+	}, normalizationTestStacks(t, p))
+	for _, s := range p.Sample {
+		assert.Empty(t, s.Label)
+	}
+	for _, loc := range p.Location {
+		require.NotEmpty(t, loc.Line)
+	}
+	assert.NotContains(t, p.StringTable, "read.c")
+}
+
+func Test_PGOProfile_PruneColdEdges(t *testing.T) {
+	p := PGOProfile(pgoTestProfile(), PGOOptions{MinEdgeFraction: 0.1})
+	assert.Equal(t, map[string]int64{
+		"main;handler;json.Marshal": 90,
+		"main;handler":              5,
+		"main":                      5,
+	}, normalizationTestStacks(t, p))
+	assert.NotContains(t, p.StringTable, "log")
+}
@@ -1014,6 +1014,14 @@ func spanIDFromLabels(tmp []byte, labelIdx int64, stringTable []string, labels [
 	return 0
 }
 
+// SpanIDString returns the span ID label value: the inverse of the
+// decoding performed by ProfileSpans.
+func SpanIDString(spanID uint64) string {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], spanID)
+	return hex.EncodeToString(b[:])
+}
+
 func decodeSpanID(tmp []byte, s string) bool {
 	if len(s) != 16 {
 		return false
@@ -1052,6 +1060,9 @@ func RenameLabel(p *profilev1.Profile, oldName, newName string) {
 	}
 }
 
+// ZeroLabelStrings zeroes strings that are not referenced by the profile
+// symbols or by the sample labels, except span IDs: the span ID labels
+// are expected to be extracted with ProfileSpans beforehand.
 func ZeroLabelStrings(p *profilev1.Profile) {
 	// TODO: A true bitmap should be used instead.
 	st := slices.GrowLen(uint32SlicePool.Get(), len(p.StringTable))
@@ -1075,6 +1086,15 @@ func ZeroLabelStrings(p *profilev1.Profile) {
 	}
 	st[p.KeepFrames] = 1
 	st[p.DropFrames] = 1
+	spanIDLabel := LabelID(p, SpanIDLabelName)
+	for _, x := range p.Sample {
+		for _, l := range x.Label {
+			if l.Key != spanIDLabel {
+				st[l.Key] = 1
+				st[l.Str] = 1
+			}
+		}
+	}
 	var zeroString string
 	for i, v := range st {
 		if v == 0 {
@@ -0,0 +1,98 @@
+package pprof
+
+import (
+	"sort"
+	"strings"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+)
+
+type sourceLineKey struct {
+	file string
+	line int64
+}
+
+// SourceLines returns the self and total values of the source lines of
+// the function or the file, similarly to pprof -list. The function name
+// must match exactly, while the file name matches file names it is a path
+// suffix of. An empty name matches any function or file. Lines are ordered
+// by the file name and line number. The profile total is returned as well.
+func SourceLines(p *profilev1.Profile, functionName, fileName string) ([]*querierv1.SourceLine, int64) {
+	// Files of the functions that match the request.
+	files := make(map[uint64]string, len(p.Function))
+	for _, fn := range p.Function {
+		if functionName != "" && p.StringTable[fn.Name] != functionName {
+			continue
+		}
+		file := p.StringTable[fn.Filename]
+		if fileName != "" && !hasPathSuffix(file, fileName) {
+			continue
+		}
+		files[fn.Id] = file
+	}
+	locations := make(map[uint64]*profilev1.Location, len(p.Location))
+	for _, loc := range p.Location {
+		locations[loc.Id] = loc
+	}
+
+	var total int64
+	lines := make(map[sourceLineKey]*querierv1.SourceLine)
+	// A line is accounted once per sample in its total,
+	// even if it appears multiple times in the stack.
+	seen := make(map[sourceLineKey]struct{})
+	for _, s := range p.Sample {
+		if len(s.Value) == 0 {
+			continue
+		}
+		v := s.Value[0]
+		total += v
+		for k := range seen {
+			delete(seen, k)
+		}
+		leaf := true
+		for _, id := range s.LocationId {
+			loc, ok := locations[id]
+			if !ok {
+				continue
+			}
+			// Inlined functions come first.
+			for _, line := range loc.Line {
+				file, ok := files[line.FunctionId]
+				if ok && line.Line > 0 {
+					k := sourceLineKey{file: file, line: line.Line}
+					sl := lines[k]
+					if sl == nil {
+						sl = &querierv1.SourceLine{FileName: file, Line: line.Line}
+						lines[k] = sl
+					}
+					if leaf {
+						sl.Self += v
+					}
+					if _, dup := seen[k]; !dup {
+						seen[k] = struct{}{}
+						sl.Total += v
+					}
+				}
+				leaf = false
+			}
+		}
+	}
+
+	result := make([]*querierv1.SourceLine, 0, len(lines))
+	for _, sl := range lines {
+		result = append(result, sl)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FileName != result[j].FileName {
+			return result[i].FileName < result[j].FileName
+		}
+		return result[i].Line < result[j].Line
+	})
+	return result, total
+}
+
+func hasPathSuffix(path, suffix string) bool {
+	suffix = strings.TrimLeft(suffix, "/")
+	return path == suffix || strings.HasSuffix(path, "/"+suffix)
+}
@@ -0,0 +1,48 @@
+package pprof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+)
+
+func sourceLinesTestProfile() *profilev1.Profile {
+	p := normalizationTestProfile(map[string]int64{
+		"main;handler;json.Marshal": 90,
+		"main;handler;handler":      5,
+		"main;handler":              5,
+	})
+	p.StringTable = append(p.StringTable, "/src/app/main.go", "/go/src/encoding/json/encode.go")
+	for _, fn := range p.Function {
+		fn.Filename = int64(len(p.StringTable) - 2)
+		if p.StringTable[fn.Name] == "json.Marshal" {
+			fn.Filename = int64(len(p.StringTable) - 1)
+		}
+	}
+	fnLines := map[string]int64{"main": 10, "handler": 20, "json.Marshal": 30}
+	for _, loc := range p.Location {
+		loc.Line[0].Line = fnLines[p.StringTable[p.Function[loc.Line[0].FunctionId-1].Name]]
+	}
+	return p
+}
+
+func Test_SourceLines(t *testing.T) {
+	lines, total := SourceLines(sourceLinesTestProfile(), "", "app/main.go")
+	assert.Equal(t, int64(100), total)
+	assert.Equal(t, []*querierv1.SourceLine{
+		{FileName: "/src/app/main.go", Line: 10, Self: 0, Total: 100},
+		// Recursive calls are accounted once.
+		{FileName: "/src/app/main.go", Line: 20, Self: 10, Total: 100},
+	}, lines)
+
+	lines, _ = SourceLines(sourceLinesTestProfile(), "json.Marshal", "")
+	assert.Equal(t, []*querierv1.SourceLine{
+		{FileName: "/go/src/encoding/json/encode.go", Line: 30, Self: 90, Total: 90},
+	}, lines)
+
+	lines, _ = SourceLines(sourceLinesTestProfile(), "", "pp/main.go")
+	assert.Empty(t, lines)
+}
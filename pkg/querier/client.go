@@ -0,0 +1,50 @@
+package querier
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+)
+
+// The client and the handler of the querier service only differ in the
+// signature of the streaming methods. The adapters below allow to use one
+// in place of the other.
+
+// NewHandlerClient returns a client calling the handler in process, e.g. to
+// serve the HTTP API from the querier or the query-frontend. Streaming
+// methods are not supported by the returned client.
+func NewHandlerClient(h querierv1connect.QuerierServiceHandler) querierv1connect.QuerierServiceClient {
+	return handlerClient{h}
+}
+
+type handlerClient struct {
+	querierv1connect.QuerierServiceHandler
+}
+
+func (handlerClient) SelectMergeStacktracesStream(context.Context, *connect.Request[querierv1.SelectMergeStacktracesRequest]) (*connect.ServerStreamForClient[querierv1.SelectMergeStacktracesStreamResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("streaming is not supported by in-process clients"))
+}
+
+// clientHandler serves the handler methods by forwarding the requests to the
+// client.
+type clientHandler struct {
+	querierv1connect.QuerierServiceClient
+}
+
+func (h clientHandler) SelectMergeStacktracesStream(ctx context.Context, req *connect.Request[querierv1.SelectMergeStacktracesRequest], stream *connect.ServerStream[querierv1.SelectMergeStacktracesStreamResponse]) error {
+	res, err := h.QuerierServiceClient.SelectMergeStacktracesStream(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for res.Receive() {
+		if err = stream.Send(res.Msg()); err != nil {
+			return err
+		}
+	}
+	return res.Err()
+}
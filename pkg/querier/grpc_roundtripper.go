@@ -8,9 +8,9 @@ import (
 )
 
 func NewGRPCRoundTripper(transport connectgrpc.GRPCRoundTripper) querierv1connect.QuerierServiceHandler {
-	return querierv1connect.NewQuerierServiceClient(
+	return clientHandler{querierv1connect.NewQuerierServiceClient(
 		connectgrpc.NewClient(transport),
 		"http://httpgrpc",
 		connect.WithGRPCWeb(),
-	)
+	)}
 }
@@ -0,0 +1,325 @@
+package querier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log/level"
+
+	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
+	"github.com/grafana/pyroscope/pkg/clientpool"
+	"github.com/grafana/pyroscope/pkg/util"
+)
+
+// hedgingOptions control how requests to ingesters and store-gateways
+// are hedged and retried.
+type hedgingOptions struct {
+	// delay after which a request is also sent to an alternative replica
+	// if the primary one has not responded yet. 0 disables hedging.
+	delay time.Duration
+	// maxRetries is the maximum number of times a request is retried
+	// if the replica is unavailable. 0 disables retries.
+	maxRetries int
+	// immutable indicates that all the replicas serve the same data,
+	// and a stream can be replayed at any point. Otherwise, a request
+	// can only be retried before the first response is received.
+	immutable bool
+}
+
+func (o hedgingOptions) enabled() bool {
+	return o.delay > 0 || o.maxRetries > 0
+}
+
+func isRetryable(err error) bool {
+	return connect.CodeOf(err) == connect.CodeUnavailable
+}
+
+// retryUnary calls f until it succeeds, fails with a non-retryable error,
+// or the number of retries is exhausted.
+func retryUnary[T any](ctx context.Context, opts hedgingOptions, f func(context.Context) (T, error)) (T, error) {
+	var (
+		res T
+		err error
+	)
+	for attempt := 0; attempt <= opts.maxRetries; attempt++ {
+		if res, err = f(ctx); err == nil || !isRetryable(err) || ctx.Err() != nil {
+			return res, err
+		}
+	}
+	return res, err
+}
+
+// withHedging wraps the bidi merge stream created by open, so that the
+// request is hedged and retried according to the options. Alternatives
+// are the replicas that can serve the same request as the primary one.
+// Results other than bidi merge streams are returned as is.
+func withHedging[Result any](
+	ctx context.Context,
+	opts hedgingOptions,
+	replica string,
+	alternatives []string,
+	open func(context.Context, string) (Result, error),
+) (Result, error) {
+	if !opts.enabled() {
+		return open(ctx, replica)
+	}
+	replicas := append([]string{replica}, alternatives...)
+	var h any
+	switch any((*Result)(nil)).(type) {
+	case *clientpool.BidiClientMergeProfilesStacktraces:
+		h = newHedgedStream[*ingestv1.MergeProfilesStacktracesRequest, *ingestv1.MergeProfilesStacktracesResponse](ctx, opts, replicas,
+			func(ctx context.Context, addr string) (BidiClientMerge[*ingestv1.MergeProfilesStacktracesRequest, *ingestv1.MergeProfilesStacktracesResponse], error) {
+				return openAs[clientpool.BidiClientMergeProfilesStacktraces](ctx, addr, open)
+			})
+	case *clientpool.BidiClientMergeProfilesLabels:
+		h = newHedgedStream[*ingestv1.MergeProfilesLabelsRequest, *ingestv1.MergeProfilesLabelsResponse](ctx, opts, replicas,
+			func(ctx context.Context, addr string) (BidiClientMerge[*ingestv1.MergeProfilesLabelsRequest, *ingestv1.MergeProfilesLabelsResponse], error) {
+				return openAs[clientpool.BidiClientMergeProfilesLabels](ctx, addr, open)
+			})
+	case *clientpool.BidiClientMergeProfilesPprof:
+		h = newHedgedStream[*ingestv1.MergeProfilesPprofRequest, *ingestv1.MergeProfilesPprofResponse](ctx, opts, replicas,
+			func(ctx context.Context, addr string) (BidiClientMerge[*ingestv1.MergeProfilesPprofRequest, *ingestv1.MergeProfilesPprofResponse], error) {
+				return openAs[clientpool.BidiClientMergeProfilesPprof](ctx, addr, open)
+			})
+	case *clientpool.BidiClientMergeSpanProfile:
+		h = newHedgedStream[*ingestv1.MergeSpanProfileRequest, *ingestv1.MergeSpanProfileResponse](ctx, opts, replicas,
+			func(ctx context.Context, addr string) (BidiClientMerge[*ingestv1.MergeSpanProfileRequest, *ingestv1.MergeSpanProfileResponse], error) {
+				return openAs[clientpool.BidiClientMergeSpanProfile](ctx, addr, open)
+			})
+	default:
+		return retryUnary(ctx, opts, func(ctx context.Context) (Result, error) {
+			return open(ctx, replica)
+		})
+	}
+	return h.(Result), nil
+}
+
+func openAs[S any, Result any](ctx context.Context, addr string, open func(context.Context, string) (Result, error)) (S, error) {
+	r, err := open(ctx, addr)
+	if err != nil {
+		var s S
+		return s, err
+	}
+	return any(r).(S), nil
+}
+
+// hedgedStream is a bidi merge stream that is backed by one or more
+// streams to replicas serving the same request.
+//
+// If the current replica does not respond within the hedging delay, the
+// request is also sent to an alternative replica, and the stream that
+// responds first is used from then on. If the replica is unavailable,
+// the request is retried, preferring alternative replicas.
+//
+// To switch to another replica, all the messages sent so far are replayed,
+// and the responses already consumed are skipped. While the messages can be
+// replayed, failures to open the stream or to send a message are reported
+// by Receive, which retries the request.
+//
+// Once the retries are exhausted and Receive fails, the stream is neither
+// hedged nor retried anymore: later calls return the same error.
+type hedgedStream[Req interface{ CloneVT() Req }, Res any] struct {
+	ctx      context.Context
+	opts     hedgingOptions
+	open     func(context.Context, string) (BidiClientMerge[Req, Res], error)
+	replicas []string // The first one is the primary replica.
+	next     int      // Next alternative replica to use.
+	retries  int
+
+	current       *streamAttempt[Req, Res]
+	sent          []Req
+	received      int
+	requestClosed bool
+	// err is the error the stream failed with, once neither
+	// hedging nor retries could get a response.
+	err error
+}
+
+type streamAttempt[Req any, Res any] struct {
+	addr   string
+	stream BidiClientMerge[Req, Res]
+	cancel context.CancelFunc
+	err    error
+
+	inflight  sync.WaitGroup
+	closeOnce sync.Once
+}
+
+type streamResult[Req any, Res any] struct {
+	attempt  *streamAttempt[Req, Res]
+	response Res
+	err      error
+}
+
+func newHedgedStream[Req interface{ CloneVT() Req }, Res any](
+	ctx context.Context,
+	opts hedgingOptions,
+	replicas []string,
+	open func(context.Context, string) (BidiClientMerge[Req, Res], error),
+) *hedgedStream[Req, Res] {
+	s := &hedgedStream[Req, Res]{
+		ctx:      ctx,
+		opts:     opts,
+		open:     open,
+		replicas: replicas,
+		next:     1,
+	}
+	s.current = s.start(replicas[0])
+	return s
+}
+
+// start opens a new stream to the replica and replays
+// all the messages sent so far.
+func (s *hedgedStream[Req, Res]) start(addr string) *streamAttempt[Req, Res] {
+	ctx, cancel := context.WithCancel(s.ctx)
+	a := &streamAttempt[Req, Res]{addr: addr, cancel: cancel}
+	if a.stream, a.err = s.open(ctx, addr); a.err != nil {
+		return a
+	}
+	for _, r := range s.sent {
+		if a.err = a.stream.Send(r); a.err != nil {
+			return a
+		}
+	}
+	if s.requestClosed {
+		a.err = a.stream.CloseRequest()
+	}
+	return a
+}
+
+func (s *hedgedStream[Req, Res]) replayable() bool {
+	return s.opts.immutable || s.received == 0
+}
+
+func (s *hedgedStream[Req, Res]) Send(r Req) error {
+	replayable := s.replayable()
+	if replayable {
+		s.sent = append(s.sent, r.CloneVT())
+	}
+	err := s.current.err
+	if err == nil {
+		err = s.current.stream.Send(r)
+	}
+	if replayable {
+		// The message is replayed if the request is retried:
+		// the error is returned by the next Receive call.
+		return nil
+	}
+	return err
+}
+
+func (s *hedgedStream[Req, Res]) Receive() (Res, error) {
+	if s.err != nil {
+		var res Res
+		return res, s.err
+	}
+	results := make(chan streamResult[Req, Res], 1+len(s.replicas)+s.opts.maxRetries)
+	attempts := make([]*streamAttempt[Req, Res], 0, 2)
+	receive := func(a *streamAttempt[Req, Res], skip int) {
+		attempts = append(attempts, a)
+		a.inflight.Add(1)
+		go func() {
+			defer a.inflight.Done()
+			r := streamResult[Req, Res]{attempt: a, err: a.err}
+			for i := 0; i <= skip && r.err == nil; i++ {
+				r.response, r.err = a.stream.Receive()
+			}
+			results <- r
+		}()
+	}
+	receive(s.current, 0)
+
+	var hedge <-chan time.Time
+	if s.opts.delay > 0 && s.next < len(s.replicas) && s.replayable() {
+		t := time.NewTimer(s.opts.delay)
+		defer t.Stop()
+		hedge = t.C
+	}
+
+	var err error
+	for pending := 1; pending > 0; {
+		select {
+		case <-hedge:
+			hedge = nil
+			addr := s.replicas[s.next]
+			s.next++
+			level.Debug(util.Logger).Log("msg", "hedging request", "replica", s.current.addr, "alternative", addr)
+			receive(s.start(addr), s.received)
+			pending++
+
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				s.current = r.attempt
+				s.received++
+				for _, a := range attempts {
+					if a != r.attempt {
+						a.close()
+					}
+				}
+				return r.response, nil
+			}
+			err = r.err
+			if isRetryable(r.err) && s.retries < s.opts.maxRetries && s.replayable() {
+				s.retries++
+				addr := r.attempt.addr
+				if s.next < len(s.replicas) {
+					addr = s.replicas[s.next]
+					s.next++
+				}
+				level.Debug(util.Logger).Log("msg", "retrying request", "replica", r.attempt.addr, "retry", addr, "err", r.err)
+				r.attempt.close()
+				receive(s.start(addr), s.received)
+				pending++
+			}
+		}
+	}
+
+	for _, a := range attempts {
+		if a != s.current {
+			a.close()
+		}
+	}
+	s.err = err
+	var res Res
+	return res, err
+}
+
+func (s *hedgedStream[Req, Res]) CloseRequest() error {
+	s.requestClosed = true
+	if s.current.err != nil {
+		return nil
+	}
+	return s.current.stream.CloseRequest()
+}
+
+func (s *hedgedStream[Req, Res]) CloseResponse() error {
+	defer s.current.cancel()
+	if s.current.err != nil {
+		return nil
+	}
+	return s.current.stream.CloseResponse()
+}
+
+// currentStream returns the stream to the replica in use.
+func (s *hedgedStream[Req, Res]) currentStream() any {
+	return s.current.stream
+}
+
+// close cancels the attempt, and closes the stream once
+// the in-flight Receive call, if any, returns.
+func (a *streamAttempt[Req, Res]) close() {
+	a.closeOnce.Do(func() {
+		a.cancel()
+		if a.stream == nil {
+			return
+		}
+		go func() {
+			a.inflight.Wait()
+			_ = a.stream.CloseRequest()
+			_ = a.stream.CloseResponse()
+		}()
+	})
+}
@@ -0,0 +1,282 @@
+package querier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/ring"
+	"github.com/grafana/dskit/ring/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
+	"github.com/grafana/pyroscope/pkg/clientpool"
+	"github.com/grafana/pyroscope/pkg/testhelper"
+)
+
+// fakeMergeStream responds to every request sent with the next response,
+// after the delay. If err is set, Receive fails with it.
+type fakeMergeStream struct {
+	ctx   context.Context
+	delay time.Duration
+	err   error
+
+	mu        sync.Mutex
+	sent      []*ingestv1.MergeProfilesStacktracesRequest
+	responses []*ingestv1.MergeProfilesStacktracesResponse
+	received  int
+	closed    bool
+}
+
+func (f *fakeMergeStream) Send(r *ingestv1.MergeProfilesStacktracesRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, r)
+	return nil
+}
+
+func (f *fakeMergeStream) Receive() (*ingestv1.MergeProfilesStacktracesResponse, error) {
+	select {
+	case <-f.ctx.Done():
+		return nil, connect.NewError(connect.CodeCanceled, f.ctx.Err())
+	case <-time.After(f.delay):
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.received >= len(f.sent) {
+		return nil, errors.New("no request")
+	}
+	r := f.responses[f.received]
+	f.received++
+	return r, nil
+}
+
+func (f *fakeMergeStream) CloseRequest() error { return nil }
+
+func (f *fakeMergeStream) CloseResponse() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+type fakeReplicas struct {
+	mu      sync.Mutex
+	streams map[string][]*fakeMergeStream
+	setup   func(addr string, s *fakeMergeStream)
+	// openErr fails opening streams to the replicas.
+	openErr map[string]error
+}
+
+func (r *fakeReplicas) open(ctx context.Context, addr string) (clientpool.BidiClientMergeProfilesStacktraces, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.openErr[addr]; err != nil {
+		return nil, err
+	}
+	s := &fakeMergeStream{
+		ctx: ctx,
+		responses: []*ingestv1.MergeProfilesStacktracesResponse{
+			{SelectedProfiles: &ingestv1.ProfileSets{}},
+			{Result: &ingestv1.MergeProfilesStacktracesResult{TreeBytes: []byte(addr)}},
+		},
+	}
+	r.setup(addr, s)
+	if r.streams == nil {
+		r.streams = make(map[string][]*fakeMergeStream)
+	}
+	r.streams[addr] = append(r.streams[addr], s)
+	return s, nil
+}
+
+func Test_HedgedStream_Hedging(t *testing.T) {
+	replicas := &fakeReplicas{setup: func(addr string, s *fakeMergeStream) {
+		if addr == "slow" {
+			s.delay = time.Hour
+		}
+	}}
+	opts := hedgingOptions{delay: 10 * time.Millisecond, immutable: true}
+	stream, err := withHedging(context.Background(), opts, "slow", []string{"fast"}, replicas.open)
+	require.NoError(t, err)
+
+	req := &ingestv1.MergeProfilesStacktracesRequest{Request: &ingestv1.SelectProfilesRequest{LabelSelector: "{}"}}
+	require.NoError(t, stream.Send(req))
+	_, err = stream.Receive()
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+	require.NoError(t, stream.CloseRequest())
+	res, err := stream.Receive()
+	require.NoError(t, err)
+	require.Equal(t, "fast", string(res.Result.TreeBytes))
+	require.NoError(t, stream.CloseResponse())
+
+	replicas.mu.Lock()
+	defer replicas.mu.Unlock()
+	require.Len(t, replicas.streams["slow"], 1)
+	require.Len(t, replicas.streams["fast"], 1)
+	// The initial request was replayed to the alternative replica.
+	require.Equal(t, "{}", replicas.streams["fast"][0].sent[0].Request.LabelSelector)
+	require.ErrorIs(t, replicas.streams["slow"][0].ctx.Err(), context.Canceled)
+}
+
+func Test_HedgedStream_Retry(t *testing.T) {
+	unavailable := connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))
+	replicas := &fakeReplicas{setup: func(addr string, s *fakeMergeStream) {
+		if addr == "a" {
+			s.err = unavailable
+		}
+	}}
+
+	t.Run("retried on alternative replica", func(t *testing.T) {
+		opts := hedgingOptions{maxRetries: 1, immutable: true}
+		stream, err := withHedging(context.Background(), opts, "a", []string{"b"}, replicas.open)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		_, err = stream.Receive()
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		res, err := stream.Receive()
+		require.NoError(t, err)
+		require.Equal(t, "b", string(res.Result.TreeBytes))
+	})
+
+	t.Run("retried if the primary replica can't be opened", func(t *testing.T) {
+		replicas := &fakeReplicas{
+			setup:   func(string, *fakeMergeStream) {},
+			openErr: map[string]error{"a": unavailable},
+		}
+		opts := hedgingOptions{maxRetries: 1}
+		stream, err := withHedging(context.Background(), opts, "a", []string{"b"}, replicas.open)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		_, err = stream.Receive()
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		res, err := stream.Receive()
+		require.NoError(t, err)
+		require.Equal(t, "b", string(res.Result.TreeBytes))
+		require.Len(t, replicas.streams["b"][0].sent, 2)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		opts := hedgingOptions{maxRetries: 2}
+		stream, err := withHedging(context.Background(), opts, "a", nil, replicas.open)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		_, err = stream.Receive()
+		require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+	})
+
+	t.Run("not retried after the first response", func(t *testing.T) {
+		replicas := &fakeReplicas{setup: func(string, *fakeMergeStream) {}}
+		opts := hedgingOptions{maxRetries: 1}
+		stream, err := withHedging(context.Background(), opts, "a", nil, replicas.open)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		_, err = stream.Receive()
+		require.NoError(t, err)
+		replicas.streams["a"][0].err = unavailable
+		require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		_, err = stream.Receive()
+		require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+		require.Len(t, replicas.streams["a"], 1)
+	})
+}
+
+func Test_WithHedging_Unary(t *testing.T) {
+	var calls int
+	res, err := withHedging(context.Background(), hedgingOptions{maxRetries: 2}, "a", nil,
+		func(context.Context, string) ([]string, error) {
+			calls++
+			if calls < 3 {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))
+			}
+			return []string{"foo"}, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo"}, res)
+	require.Equal(t, 3, calls)
+}
+
+func Test_HedgedStream_RetriesExhausted(t *testing.T) {
+	unavailable := connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))
+	replicas := &fakeReplicas{setup: func(addr string, s *fakeMergeStream) {
+		if addr != "c" {
+			s.err = unavailable
+		}
+	}}
+	opts := hedgingOptions{delay: 10 * time.Millisecond, maxRetries: 1, immutable: true}
+	stream, err := withHedging(context.Background(), opts, "a", []string{"b", "c"}, replicas.open)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+	_, err = stream.Receive()
+	require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+
+	// The remaining alternative is neither hedged to nor retried on.
+	_, err = stream.Receive()
+	require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+	time.Sleep(5 * opts.delay)
+	replicas.mu.Lock()
+	defer replicas.mu.Unlock()
+	require.Len(t, replicas.streams["a"], 1)
+	require.Len(t, replicas.streams["b"], 1)
+	require.Empty(t, replicas.streams["c"])
+}
+
+// fakeReplicaIngester opens the merge streams of the replicas.
+type fakeReplicaIngester struct {
+	*fakeQuerierIngester
+	addr     string
+	replicas *fakeReplicas
+}
+
+func (i *fakeReplicaIngester) MergeProfilesStacktraces(ctx context.Context) clientpool.BidiClientMergeProfilesStacktraces {
+	s, _ := i.replicas.open(ctx, i.addr)
+	return s
+}
+
+func Test_ForAllPlannedIngesters_RetriedOnSameIngester(t *testing.T) {
+	unavailable := connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))
+	replicas := &fakeReplicas{}
+	replicas.setup = func(addr string, s *fakeMergeStream) {
+		if addr == "1" && len(replicas.streams[addr]) == 0 {
+			s.err = unavailable
+		}
+	}
+	ring := testhelper.NewMockRing([]ring.InstanceDesc{{Addr: "1"}, {Addr: "2"}, {Addr: "3"}}, 3)
+	pool := clientpool.NewIngesterPool(clientpool.PoolConfig{}, ring, &poolFactory{f: func(addr string) (client.PoolClient, error) {
+		return &fakeReplicaIngester{fakeQuerierIngester: newFakeQuerier(), addr: addr, replicas: replicas}, nil
+	}}, prometheus.NewGauge(prometheus.GaugeOpts{Name: "clients"}), log.NewNopLogger())
+	q := NewIngesterQuerier(pool, ring)
+	q.hedging = hedgingOptions{delay: time.Millisecond, maxRetries: 1}
+
+	plan := blockPlan{"1": {Ulids: []string{"a"}}, "2": {Ulids: []string{"b"}}}
+	responses, err := forAllPlannedIngesters(context.Background(), q, plan, func(ctx context.Context, ic IngesterQueryClient, _ *ingestv1.Hints) (clientpool.BidiClientMergeProfilesStacktraces, error) {
+		return ic.MergeProfilesStacktraces(ctx), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	for _, r := range responses {
+		require.NoError(t, r.response.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		_, err = r.response.Receive()
+		require.NoError(t, err)
+		require.NoError(t, r.response.Send(&ingestv1.MergeProfilesStacktracesRequest{}))
+		res, err := r.response.Receive()
+		require.NoError(t, err)
+		require.Equal(t, r.addr, string(res.Result.TreeBytes))
+	}
+
+	replicas.mu.Lock()
+	defer replicas.mu.Unlock()
+	require.Len(t, replicas.streams["1"], 2)
+	require.Len(t, replicas.streams["2"], 1)
+	require.Empty(t, replicas.streams["3"])
+}
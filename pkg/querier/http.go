@@ -1,17 +1,20 @@
 package querier
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/gogo/status"
 	"github.com/google/pprof/profile"
+	"github.com/grafana/dskit/tenant"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql/parser"
@@ -21,22 +24,33 @@ import (
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	savedviewsv1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/frontend/dot/graph"
 	"github.com/grafana/pyroscope/pkg/frontend/dot/report"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
 	"github.com/grafana/pyroscope/pkg/og/util/attime"
+	"github.com/grafana/pyroscope/pkg/pprof"
 	"github.com/grafana/pyroscope/pkg/querier/timeline"
 	httputil "github.com/grafana/pyroscope/pkg/util/http"
 )
 
-func NewHTTPHandlers(client querierv1connect.QuerierServiceClient) *QueryHandlers {
-	return &QueryHandlers{client}
+// ViewResolver returns the saved views referenced by the view parameter of
+// the render endpoints.
+type ViewResolver interface {
+	Resolve(ctx context.Context, tenantID, id string) (*savedviewsv1.SavedView, error)
+}
+
+// NewHTTPHandlers returns the handlers of the Pyroscope HTTP API. views may
+// be nil, in which case requests referencing a saved view are rejected.
+func NewHTTPHandlers(client querierv1connect.QuerierServiceClient, views ViewResolver) *QueryHandlers {
+	return &QueryHandlers{client, views}
 }
 
 type QueryHandlers struct {
 	client querierv1connect.QuerierServiceClient
+	views  ViewResolver
 }
 
 // LabelValues only returns the label values for the given label name.
@@ -76,6 +90,10 @@ func (q *QueryHandlers) LabelValues(w http.ResponseWriter, req *http.Request) {
 }
 
 func (q *QueryHandlers) RenderDiff(w http.ResponseWriter, req *http.Request) {
+	if err := q.resolveView(req, true); err != nil {
+		httputil.Error(w, err)
+		return
+	}
 	if err := req.ParseForm(); err != nil {
 		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
 		return
@@ -124,6 +142,10 @@ func (q *QueryHandlers) RenderDiff(w http.ResponseWriter, req *http.Request) {
 }
 
 func (q *QueryHandlers) Render(w http.ResponseWriter, req *http.Request) {
+	if err := q.resolveView(req, false); err != nil {
+		httputil.Error(w, err)
+		return
+	}
 	if err := req.ParseForm(); err != nil {
 		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
 		return
@@ -240,6 +262,61 @@ func (q *QueryHandlers) Render(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// PGO returns the merged CPU profile prepared for use in Go
+// profile-guided optimization as a default.pgo file.
+// The minEdgeFraction parameter specifies the threshold
+// for pruning cold call edges, see pprof.PGOOptions.
+func (q *QueryHandlers) PGO(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+		return
+	}
+	selectParams, profileType, err := parseSelectProfilesRequest(renderRequestFieldNames{}, req)
+	if err != nil {
+		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+		return
+	}
+	if !isPGOProfileType(profileType) {
+		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("profile type %s can't be used for PGO: a CPU profile is required", profileType.ID)))
+		return
+	}
+	var opts pprof.PGOOptions
+	if v := req.Form.Get("minEdgeFraction"); v != "" {
+		if opts.MinEdgeFraction, err = strconv.ParseFloat(v, 64); err != nil || opts.MinEdgeFraction < 0 || opts.MinEdgeFraction >= 1 {
+			httputil.Error(w, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("invalid minEdgeFraction %q: a number in the range [0, 1) is expected", v)))
+			return
+		}
+	}
+
+	resp, err := q.client.SelectMergeProfile(req.Context(), connect.NewRequest(&querierv1.SelectMergeProfileRequest{
+		Start:         selectParams.Start,
+		End:           selectParams.End,
+		ProfileTypeID: selectParams.ProfileTypeID,
+		LabelSelector: selectParams.LabelSelector,
+	}))
+	if err != nil {
+		httputil.Error(w, err)
+		return
+	}
+	data, err := pprof.Marshal(pprof.PGOProfile(resp.Msg, opts), true)
+	if err != nil {
+		httputil.Error(w, connect.NewError(connect.CodeInternal, err))
+		return
+	}
+	w.Header().Add("Content-Type", "application/octet-stream")
+	w.Header().Add("Content-Disposition", `attachment; filename="default.pgo"`)
+	_, _ = w.Write(data)
+}
+
+// isPGOProfileType reports whether profiles of the type can be used
+// for PGO: the Go compiler expects CPU time or CPU sample counts.
+func isPGOProfileType(t *typesv1.ProfileType) bool {
+	return (t.SampleType == "cpu" && t.SampleUnit == "nanoseconds") ||
+		(t.SampleType == "samples" && t.SampleUnit == "count")
+}
+
 func pprofToDotProfile(w io.Writer, p *profilev1.Profile, maxNodes int) error {
 	data, err := p.MarshalVT()
 	if err != nil {
@@ -255,6 +332,56 @@ func pprofToDotProfile(w io.Writer, p *profilev1.Profile, maxNodes int) error {
 	return nil
 }
 
+// resolveView adds the parameters of the saved view referenced by the view
+// parameter to the request, if any. Parameters of the request take
+// precedence over the ones of the view. For diffs, the baseline of the view
+// is the left side and its query the right side.
+func (q *QueryHandlers) resolveView(req *http.Request, diff bool) error {
+	params := req.URL.Query()
+	id := params.Get("view")
+	if id == "" {
+		return nil
+	}
+	if q.views == nil {
+		return connect.NewError(connect.CodeUnimplemented, errors.New("saved views are not enabled"))
+	}
+	tenantID, err := tenant.TenantID(req.Context())
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	view, err := q.views.Resolve(req.Context(), tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	if !diff {
+		setViewParams(params, renderRequestFieldNames{query: "query", from: "from", until: "until"}, view.Query)
+	} else {
+		if view.Baseline == nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("view %s has no baseline", id))
+		}
+		setViewParams(params, renderRequestFieldNames{query: "leftQuery", from: "leftFrom", until: "leftUntil"}, view.Baseline)
+		setViewParams(params, renderRequestFieldNames{query: "rightQuery", from: "rightFrom", until: "rightUntil"}, view.Query)
+	}
+	req.URL.RawQuery = params.Encode()
+	return nil
+}
+
+func setViewParams(params url.Values, fieldNames renderRequestFieldNames, query *savedviewsv1.ViewQuery) {
+	setDefault := func(key, value string) {
+		if value != "" && !params.Has(key) {
+			params.Set(key, value)
+		}
+	}
+	selector := query.GetLabelSelector()
+	if selector == "" {
+		selector = "{}"
+	}
+	setDefault(fieldNames.query, query.GetProfileTypeId()+selector)
+	setDefault(fieldNames.from, query.GetFrom())
+	setDefault(fieldNames.until, query.GetUntil())
+}
+
 type renderRequestFieldNames struct {
 	query string
 	from  string
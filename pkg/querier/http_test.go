@@ -1,16 +1,25 @@
 package querier
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
+	"github.com/google/pprof/profile"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	savedviewsv1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/tenant"
 )
 
 func Test_ParseQuery(t *testing.T) {
@@ -40,3 +49,152 @@ func Test_ParseQuery(t *testing.T) {
 
 	require.Equal(t, `{foo="bar",bar=~"buzz"}`, queryRequest.LabelSelector)
 }
+
+type pgoTestQuerier struct {
+	querierv1connect.UnimplementedQuerierServiceHandler
+	req *querierv1.SelectMergeProfileRequest
+}
+
+func (q *pgoTestQuerier) SelectMergeProfile(_ context.Context, req *connect.Request[querierv1.SelectMergeProfileRequest]) (*connect.Response[profilev1.Profile], error) {
+	q.req = req.Msg
+	return connect.NewResponse(&profilev1.Profile{
+		SampleType:  []*profilev1.ValueType{{Type: 1, Unit: 2}},
+		PeriodType:  &profilev1.ValueType{Type: 1, Unit: 2},
+		Mapping:     []*profilev1.Mapping{{Id: 1}},
+		Function:    []*profilev1.Function{{Id: 1, Name: 3, Filename: 4}},
+		Location:    []*profilev1.Location{{Id: 1, MappingId: 1, Line: []*profilev1.Line{{FunctionId: 1, Line: 10}}}},
+		Sample:      []*profilev1.Sample{{LocationId: []uint64{1}, Value: []int64{100}, Label: []*profilev1.Label{{Key: 5, Str: 6}}}},
+		StringTable: []string{"", "cpu", "nanoseconds", "main.main", "main.go", "handler", "/"},
+	}), nil
+}
+
+func Test_PGO(t *testing.T) {
+	q := new(pgoTestQuerier)
+	h := NewHTTPHandlers(NewHandlerClient(q), nil)
+
+	v := url.Values{
+		"query":           []string{`process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="app"}`},
+		"from":            []string{"now-1h"},
+		"until":           []string{"now"},
+		"minEdgeFraction": []string{"0.01"},
+	}
+	w := httptest.NewRecorder()
+	h.PGO(w, httptest.NewRequest("GET", "/pyroscope/pgo?"+v.Encode(), nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, `{service_name="app"}`, q.req.LabelSelector)
+
+	p, err := profile.Parse(w.Body)
+	require.NoError(t, err)
+	require.Len(t, p.Sample, 1)
+	require.Empty(t, p.Sample[0].Label)
+	require.Equal(t, "main.main", p.Sample[0].Location[0].Line[0].Function.Name)
+
+	for _, invalid := range []url.Values{
+		{"query": []string{`memory:alloc_space:bytes:space:bytes{}`}},
+		{"query": v["query"], "minEdgeFraction": []string{"2"}},
+	} {
+		w = httptest.NewRecorder()
+		h.PGO(w, httptest.NewRequest("GET", "/pyroscope/pgo?"+invalid.Encode(), nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, invalid.Encode())
+	}
+}
+
+type testViews map[string]*savedviewsv1.SavedView
+
+func (v testViews) Resolve(_ context.Context, tenantID, id string) (*savedviewsv1.SavedView, error) {
+	if view, ok := v[tenantID+"/"+id]; ok {
+		return view, nil
+	}
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("view %s not found", id))
+}
+
+type diffTestQuerier struct {
+	querierv1connect.UnimplementedQuerierServiceHandler
+	req *querierv1.DiffRequest
+}
+
+func (q *diffTestQuerier) Diff(_ context.Context, req *connect.Request[querierv1.DiffRequest]) (*connect.Response[querierv1.DiffResponse], error) {
+	q.req = req.Msg
+	return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("no data"))
+}
+
+func Test_RenderSavedView(t *testing.T) {
+	views := testViews{
+		"tenant/abc": {
+			Id: "abc",
+			Query: &savedviewsv1.ViewQuery{
+				ProfileTypeId: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+				LabelSelector: `{service_name="app",version="2"}`,
+				From:          "1700000000",
+				Until:         "1700003600",
+			},
+			Baseline: &savedviewsv1.ViewQuery{
+				ProfileTypeId: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+				LabelSelector: `{service_name="app",version="1"}`,
+				From:          "1600000000",
+				Until:         "1600003600",
+			},
+		},
+		"tenant/nobaseline": {
+			Id:    "nobaseline",
+			Query: &savedviewsv1.ViewQuery{ProfileTypeId: "process_cpu:cpu:nanoseconds:cpu:nanoseconds"},
+		},
+	}
+	ctx := tenant.InjectTenantID(context.Background(), "tenant")
+	request := func(path string, v url.Values) *http.Request {
+		return httptest.NewRequest("GET", path+"?"+v.Encode(), nil).WithContext(ctx)
+	}
+
+	t.Run("render", func(t *testing.T) {
+		q := new(pgoTestQuerier)
+		w := httptest.NewRecorder()
+		NewHTTPHandlers(NewHandlerClient(q), views).Render(w, request("/pyroscope/render", url.Values{
+			"view":   []string{"abc"},
+			"until":  []string{"1700001800"},
+			"format": []string{"dot"},
+		}))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "process_cpu:cpu:nanoseconds:cpu:nanoseconds", q.req.ProfileTypeID)
+		require.Equal(t, `{service_name="app",version="2"}`, q.req.LabelSelector)
+		require.Equal(t, int64(1700000000000), q.req.Start)
+		// Parameters of the request take precedence over the view.
+		require.Equal(t, int64(1700001800000), q.req.End)
+	})
+
+	t.Run("render diff", func(t *testing.T) {
+		q := new(diffTestQuerier)
+		w := httptest.NewRecorder()
+		NewHTTPHandlers(NewHandlerClient(q), views).RenderDiff(w, request("/pyroscope/render-diff", url.Values{
+			"view": []string{"abc"},
+		}))
+		require.Equal(t, http.StatusServiceUnavailable, w.Code, w.Body.String())
+		require.Equal(t, `{service_name="app",version="1"}`, q.req.Left.LabelSelector)
+		require.Equal(t, int64(1600000000000), q.req.Left.Start)
+		require.Equal(t, `{service_name="app",version="2"}`, q.req.Right.LabelSelector)
+		require.Equal(t, int64(1700003600000), q.req.Right.End)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		views   ViewResolver
+		path    string
+		id      string
+		wantErr string
+	}{
+		{name: "unknown view", views: views, path: "/pyroscope/render", id: "xyz", wantErr: "view xyz not found"},
+		{name: "diff without baseline", views: views, path: "/pyroscope/render-diff", id: "nobaseline", wantErr: "view nobaseline has no baseline"},
+		{name: "views disabled", path: "/pyroscope/render", id: "abc", wantErr: "saved views are not enabled"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHTTPHandlers(NewHandlerClient(new(pgoTestQuerier)), tc.views)
+			handler := h.Render
+			if tc.path == "/pyroscope/render-diff" {
+				handler = h.RenderDiff
+			}
+			w := httptest.NewRecorder()
+			handler(w, request(tc.path, url.Values{"view": []string{tc.id}}))
+			require.NotEqual(t, http.StatusOK, w.Code)
+			require.Contains(t, w.Body.String(), tc.wantErr)
+		})
+	}
+}
@@ -36,8 +36,9 @@ type IngesterQueryClient interface {
 
 // IngesterQuerier helps with querying the ingesters.
 type IngesterQuerier struct {
-	ring ring.ReadRing
-	pool *ring_client.Pool
+	ring    ring.ReadRing
+	pool    *ring_client.Pool
+	hedging hedgingOptions
 }
 
 func NewIngesterQuerier(pool *ring_client.Pool, ring ring.ReadRing) *IngesterQuerier {
@@ -63,10 +64,14 @@ func forAllIngesters[T any](ctx context.Context, ingesterQuerier *IngesterQuerie
 			return nil, err
 		}
 		return client.(IngesterQueryClient), nil
-	}, replicationSet, f)
+	}, replicationSet, ingesterQuerier.hedging, f)
 }
 
-// forAllPlannedIngesters runs f, in parallel, for all ingesters part of the plan
+// forAllPlannedIngesters runs f, in parallel, for all ingesters part of the plan.
+// The requests are not hedged to other ingesters: the series are replicated,
+// but every ingester writes them to its own blocks, so no other ingester
+// holds the planned blocks. Requests to an unavailable ingester are retried
+// on the same ingester.
 func forAllPlannedIngesters[T any](ctx context.Context, ingesterQuerier *IngesterQuerier, plan blockPlan, f QueryReplicaWithHintsFn[T, IngesterQueryClient]) ([]ResponseFromReplica[T], error) {
 	replicationSet, err := ingesterQuerier.ring.GetReplicationSetForOperation(readNoExtend)
 	if err != nil {
@@ -79,7 +84,7 @@ func forAllPlannedIngesters[T any](ctx context.Context, ingesterQuerier *Ingeste
 			return nil, err
 		}
 		return client.(IngesterQueryClient), nil
-	}, replicationSet, f)
+	}, replicationSet, ingesterQuerier.hedging, nil, f)
 }
 
 func (q *Querier) selectTreeFromIngesters(ctx context.Context, req *querierv1.SelectMergeStacktracesRequest, plan blockPlan) (*phlaremodel.Tree, error) {
@@ -121,11 +126,13 @@ func (q *Querier) selectTreeFromIngesters(ctx context.Context, req *querierv1.Se
 		g.Go(util.RecoverPanic(func() error {
 			return r.response.Send(&ingestv1.MergeProfilesStacktracesRequest{
 				Request: &ingestv1.SelectProfilesRequest{
-					LabelSelector: req.LabelSelector,
-					Start:         req.Start,
-					End:           req.End,
-					Type:          profileType,
-					Hints:         &ingestv1.Hints{Block: hints},
+					LabelSelector:       req.LabelSelector,
+					Start:               req.Start,
+					End:                 req.End,
+					Type:                profileType,
+					Hints:               &ingestv1.Hints{Block: hints},
+					SampleLabelSelector: req.SampleLabelSelector,
+					CollapseRecursion:   req.CollapseRecursion,
 				},
 				MaxNodes: req.MaxNodes,
 			})
@@ -178,14 +185,17 @@ func (q *Querier) selectProfileFromIngesters(ctx context.Context, req *querierv1
 		g.Go(util.RecoverPanic(func() error {
 			return r.response.Send(&ingestv1.MergeProfilesPprofRequest{
 				Request: &ingestv1.SelectProfilesRequest{
-					LabelSelector: req.LabelSelector,
-					Start:         req.Start,
-					End:           req.End,
-					Type:          profileType,
-					Hints:         &ingestv1.Hints{Block: hints},
+					LabelSelector:       req.LabelSelector,
+					Start:               req.Start,
+					End:                 req.End,
+					Type:                profileType,
+					Hints:               &ingestv1.Hints{Block: hints},
+					SampleLabelSelector: req.SampleLabelSelector,
+					CollapseRecursion:   req.CollapseRecursion,
 				},
 				MaxNodes:           req.MaxNodes,
 				StackTraceSelector: req.StackTraceSelector,
+				SampleLabels:       req.SampleLabels,
 			})
 		}))
 	}
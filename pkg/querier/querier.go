@@ -33,21 +33,27 @@ import (
 	"github.com/grafana/pyroscope/pkg/clientpool"
 	"github.com/grafana/pyroscope/pkg/iter"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/phlaredb"
 	"github.com/grafana/pyroscope/pkg/pprof"
 	"github.com/grafana/pyroscope/pkg/querier/vcs"
 	"github.com/grafana/pyroscope/pkg/util/math"
 	"github.com/grafana/pyroscope/pkg/util/spanlogger"
+	"github.com/grafana/pyroscope/pkg/validation"
 )
 
 type Config struct {
 	PoolConfig      clientpool.PoolConfig `yaml:"pool_config,omitempty"`
 	QueryStoreAfter time.Duration         `yaml:"query_store_after" category:"advanced"`
+	HedgingDelay    time.Duration         `yaml:"hedging_delay" category:"advanced"`
+	MaxRetries      int                   `yaml:"max_retries" category:"advanced"`
 }
 
 // RegisterFlags registers distributor-related flags.
 func (cfg *Config) RegisterFlags(fs *flag.FlagSet) {
 	cfg.PoolConfig.RegisterFlagsWithPrefix("querier", fs)
 	fs.DurationVar(&cfg.QueryStoreAfter, "querier.query-store-after", 4*time.Hour, "The time after which a metric should be queried from storage and not just ingesters. 0 means all queries are sent to store. If this option is enabled, the time range of the query sent to the store-gateway will be manipulated to ensure the query end is not more recent than 'now - query-store-after'.")
+	fs.DurationVar(&cfg.HedgingDelay, "querier.hedging-delay", 0, "The delay after which a request to an ingester or store-gateway is also sent to another replica, if the first one has not responded yet. Store-gateway requests are only hedged to replicas that own the same blocks. Requests for the planned blocks of an ingester are not hedged, as no other ingester holds them. 0 to disable hedging.")
+	fs.IntVar(&cfg.MaxRetries, "querier.max-retries", 0, "The maximum number of times a request to an unavailable ingester or store-gateway is retried. Store-gateway requests are retried on other replicas owning the same blocks, if any. Requests for the planned blocks of an ingester are retried on the same ingester. 0 to disable retries.")
 }
 
 type Querier struct {
@@ -60,6 +66,7 @@ type Querier struct {
 
 	ingesterQuerier     *IngesterQuerier
 	storeGatewayQuerier *StoreGatewayQuerier
+	limits              Limits
 
 	vcsv1connect.VCSServiceHandler
 }
@@ -71,7 +78,7 @@ type Querier struct {
 // querier frontend sets the limit.
 const maxNodesDefault = int64(2048)
 
-func New(cfg Config, ingestersRing ring.ReadRing, factory ring_client.PoolFactory, storeGatewayQuerier *StoreGatewayQuerier, reg prometheus.Registerer, logger log.Logger, clientsOptions ...connect.ClientOption) (*Querier, error) {
+func New(cfg Config, ingestersRing ring.ReadRing, factory ring_client.PoolFactory, storeGatewayQuerier *StoreGatewayQuerier, limits Limits, reg prometheus.Registerer, logger log.Logger, clientsOptions ...connect.ClientOption) (*Querier, error) {
 	// disable gzip compression for querier-ingester communication as most of payload are not benefit from it.
 	clientsMetrics := promauto.With(reg).NewGauge(prometheus.GaugeOpts{
 		Namespace: "pyroscope",
@@ -87,11 +94,23 @@ func New(cfg Config, ingestersRing ring.ReadRing, factory ring_client.PoolFactor
 			ingestersRing,
 		),
 		storeGatewayQuerier: storeGatewayQuerier,
+		limits:              limits,
 		VCSServiceHandler:   vcs.New(logger),
 	}
+	q.ingesterQuerier.hedging = hedgingOptions{
+		delay:      cfg.HedgingDelay,
+		maxRetries: cfg.MaxRetries,
+	}
 	var err error
 	svcs := []services.Service{q.ingesterQuerier.pool}
 	if storeGatewayQuerier != nil {
+		// Blocks are immutable: a stream to a store-gateway
+		// can be replayed on any replica owning the blocks.
+		storeGatewayQuerier.hedging = hedgingOptions{
+			delay:      cfg.HedgingDelay,
+			maxRetries: cfg.MaxRetries,
+			immutable:  true,
+		}
 		svcs = append(svcs, storeGatewayQuerier)
 	}
 	// should we watch for the ring module status ?
@@ -298,13 +317,17 @@ func (q *Querier) LabelNames(ctx context.Context, req *connect.Request[typesv1.L
 	}), nil
 }
 
-func (q *Querier) blockSelect(ctx context.Context, start, end model.Time) (blockPlan, error) {
+// blockSelect returns the blocks to query. The label selector and the profile
+// type allow to leave out the blocks which can't contain matching series.
+func (q *Querier) blockSelect(ctx context.Context, start, end model.Time, labelSelector, profileTypeID string) (blockPlan, error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "blockSelect")
 	defer sp.Finish()
 
 	sp.LogFields(
 		otlog.String("start", start.Time().String()),
 		otlog.String("end", end.Time().String()),
+		otlog.String("selector", labelSelector),
+		otlog.String("profile_type_id", profileTypeID),
 	)
 
 	ingesterReq := &ingestv1.BlockMetadataRequest{
@@ -332,7 +355,7 @@ func (q *Querier) blockSelect(ctx context.Context, start, end model.Time) (block
 		results.add(res, ingesterInstance)
 	}
 
-	return results.blockPlan(ctx), nil
+	return results.blockPlan(ctx, phlaredb.QueryLabelMatchers(labelSelector, profileTypeID)...), nil
 }
 
 func (q *Querier) Series(ctx context.Context, req *connect.Request[querierv1.SeriesRequest]) (*connect.Response[querierv1.SeriesResponse], error) {
@@ -428,6 +451,7 @@ func (q *Querier) Series(ctx context.Context, req *connect.Request[querierv1.Ser
 // FIXME(kolesnikovae): The method is never used and should be removed.
 func (q *Querier) Diff(ctx context.Context, req *connect.Request[querierv1.DiffRequest]) (*connect.Response[querierv1.DiffResponse], error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "Diff")
+	ctx = withQueryLimiter(ctx, q.limits)
 	defer func() {
 		sp.LogFields(
 			otlog.String("leftStart", model.Time(req.Msg.Left.Start).Time().String()),
@@ -491,7 +515,11 @@ func (q *Querier) SelectMergeStacktraces(ctx context.Context, req *connect.Reque
 		mn := maxNodesDefault
 		req.Msg.MaxNodes = &mn
 	}
+	if err := validateSampleLabelSelector(req.Msg.SampleLabelSelector); err != nil {
+		return nil, err
+	}
 
+	ctx = withQueryLimiter(ctx, q.limits)
 	t, err := q.selectTree(ctx, req.Msg)
 	if err != nil {
 		return nil, err
@@ -502,6 +530,22 @@ func (q *Querier) SelectMergeStacktraces(ctx context.Context, req *connect.Reque
 	}), nil
 }
 
+// SelectMergeStacktracesStream sends the merged flamegraph in a single
+// final message: the querier does not split queries, the progressive
+// results are produced by the query-frontend.
+func (q *Querier) SelectMergeStacktracesStream(ctx context.Context, req *connect.Request[querierv1.SelectMergeStacktracesRequest], stream *connect.ServerStream[querierv1.SelectMergeStacktracesStreamResponse]) error {
+	resp, err := q.SelectMergeStacktraces(ctx, req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&querierv1.SelectMergeStacktracesStreamResponse{
+		Flamegraph: resp.Msg.Flamegraph,
+		Completed:  1,
+		Total:      1,
+		Final:      true,
+	})
+}
+
 func (q *Querier) SelectMergeSpanProfile(ctx context.Context, req *connect.Request[querierv1.SelectMergeSpanProfileRequest]) (*connect.Response[querierv1.SelectMergeSpanProfileResponse], error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectMergeSpanProfile")
 	level.Info(spanlogger.FromContext(ctx, q.logger)).Log(
@@ -519,6 +563,7 @@ func (q *Querier) SelectMergeSpanProfile(ctx context.Context, req *connect.Reque
 		req.Msg.MaxNodes = &mn
 	}
 
+	ctx = withQueryLimiter(ctx, q.limits)
 	t, err := q.selectSpanProfile(ctx, req.Msg)
 	if err != nil {
 		return nil, err
@@ -544,7 +589,7 @@ func isEndpointNotExistingErr(err error) bool {
 
 func (q *Querier) selectTree(ctx context.Context, req *querierv1.SelectMergeStacktracesRequest) (*phlaremodel.Tree, error) {
 	// determine the block hints
-	plan, err := q.blockSelect(ctx, model.Time(req.Start), model.Time(req.End))
+	plan, err := q.blockSelect(ctx, model.Time(req.Start), model.Time(req.End), req.LabelSelector, req.ProfileTypeID)
 	if isEndpointNotExistingErr(err) {
 		level.Warn(spanlogger.FromContext(ctx, q.logger)).Log(
 			"msg", "block select not supported on at least one component, fallback to use full dataset",
@@ -599,6 +644,18 @@ func (q *Querier) selectTree(ctx context.Context, req *querierv1.SelectMergeStac
 	return storegatewayTree, nil
 }
 
+// validateSampleLabelSelector checks the sample label selector,
+// if one is specified.
+func validateSampleLabelSelector(selector string) error {
+	if selector == "" {
+		return nil
+	}
+	if _, err := parser.ParseMetricSelector(selector); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return nil
+}
+
 type storeQuery struct {
 	start, end  model.Time
 	shouldQuery bool
@@ -606,22 +663,25 @@ type storeQuery struct {
 
 func (sq storeQuery) MergeStacktracesRequest(req *querierv1.SelectMergeStacktracesRequest) *querierv1.SelectMergeStacktracesRequest {
 	return &querierv1.SelectMergeStacktracesRequest{
-		Start:         int64(sq.start),
-		End:           int64(sq.end),
-		LabelSelector: req.LabelSelector,
-		ProfileTypeID: req.ProfileTypeID,
-		MaxNodes:      req.MaxNodes,
+		Start:               int64(sq.start),
+		End:                 int64(sq.end),
+		LabelSelector:       req.LabelSelector,
+		ProfileTypeID:       req.ProfileTypeID,
+		MaxNodes:            req.MaxNodes,
+		SampleLabelSelector: req.SampleLabelSelector,
+		CollapseRecursion:   req.CollapseRecursion,
 	}
 }
 
 func (sq storeQuery) MergeSeriesRequest(req *querierv1.SelectSeriesRequest, profileType *typesv1.ProfileType) *ingestv1.MergeProfilesLabelsRequest {
 	return &ingestv1.MergeProfilesLabelsRequest{
 		Request: &ingestv1.SelectProfilesRequest{
-			Type:          profileType,
-			LabelSelector: req.LabelSelector,
-			Start:         int64(sq.start),
-			End:           int64(sq.end),
-			Aggregation:   req.Aggregation,
+			Type:                profileType,
+			LabelSelector:       req.LabelSelector,
+			Start:               int64(sq.start),
+			End:                 int64(sq.end),
+			Aggregation:         req.Aggregation,
+			SampleLabelSelector: req.SampleLabelSelector,
 		},
 		By:                 req.GroupBy,
 		StackTraceSelector: req.StackTraceSelector,
@@ -641,12 +701,15 @@ func (sq storeQuery) MergeSpanProfileRequest(req *querierv1.SelectMergeSpanProfi
 
 func (sq storeQuery) MergeProfileRequest(req *querierv1.SelectMergeProfileRequest) *querierv1.SelectMergeProfileRequest {
 	return &querierv1.SelectMergeProfileRequest{
-		ProfileTypeID:      req.ProfileTypeID,
-		LabelSelector:      req.LabelSelector,
-		Start:              int64(sq.start),
-		End:                int64(sq.end),
-		MaxNodes:           req.MaxNodes,
-		StackTraceSelector: req.StackTraceSelector,
+		ProfileTypeID:       req.ProfileTypeID,
+		LabelSelector:       req.LabelSelector,
+		Start:               int64(sq.start),
+		End:                 int64(sq.end),
+		MaxNodes:            req.MaxNodes,
+		StackTraceSelector:  req.StackTraceSelector,
+		SampleLabelSelector: req.SampleLabelSelector,
+		CollapseRecursion:   req.CollapseRecursion,
+		SampleLabels:        req.SampleLabels,
 	}
 }
 
@@ -734,6 +797,10 @@ func (q *Querier) SelectMergeProfile(ctx context.Context, req *connect.Request[q
 		SetTag("profile_type", req.Msg.ProfileTypeID)
 	defer sp.Finish()
 
+	if err := validateSampleLabelSelector(req.Msg.SampleLabelSelector); err != nil {
+		return nil, err
+	}
+	ctx = withQueryLimiter(ctx, q.limits)
 	profile, err := q.selectProfile(ctx, req.Msg)
 	if err != nil {
 		return nil, err
@@ -745,7 +812,7 @@ func (q *Querier) SelectMergeProfile(ctx context.Context, req *connect.Request[q
 
 func (q *Querier) selectProfile(ctx context.Context, req *querierv1.SelectMergeProfileRequest) (*googlev1.Profile, error) {
 	// determine the block hints
-	plan, err := q.blockSelect(ctx, model.Time(req.Start), model.Time(req.End))
+	plan, err := q.blockSelect(ctx, model.Time(req.Start), model.Time(req.End), req.LabelSelector, req.ProfileTypeID)
 	if isEndpointNotExistingErr(err) {
 		level.Warn(spanlogger.FromContext(ctx, q.logger)).Log(
 			"msg", "block select not supported on at least one component, fallback to use full dataset",
@@ -803,6 +870,44 @@ func (q *Querier) selectProfile(ctx context.Context, req *querierv1.SelectMergeP
 	return merge.Profile(), nil
 }
 
+func (q *Querier) SelectSourceLines(ctx context.Context, req *connect.Request[querierv1.SelectSourceLinesRequest]) (*connect.Response[querierv1.SelectSourceLinesResponse], error) {
+	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectSourceLines")
+	sp.SetTag("start", model.Time(req.Msg.Start).Time().String()).
+		SetTag("end", model.Time(req.Msg.End).Time().String()).
+		SetTag("selector", req.Msg.LabelSelector).
+		SetTag("profile_type", req.Msg.ProfileTypeID).
+		SetTag("function_name", req.Msg.FunctionName).
+		SetTag("file_name", req.Msg.FileName)
+	defer sp.Finish()
+
+	if err := validateSourceLinesRequest(req.Msg); err != nil {
+		return nil, err
+	}
+	ctx = withQueryLimiter(ctx, q.limits)
+	profile, err := q.selectProfile(ctx, &querierv1.SelectMergeProfileRequest{
+		ProfileTypeID: req.Msg.ProfileTypeID,
+		LabelSelector: req.Msg.LabelSelector,
+		Start:         req.Msg.Start,
+		End:           req.Msg.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+	lines, total := pprof.SourceLines(profile, req.Msg.FunctionName, req.Msg.FileName)
+	return connect.NewResponse(&querierv1.SelectSourceLinesResponse{
+		Lines: lines,
+		Total: total,
+	}), nil
+}
+
+// validateSourceLinesRequest checks that the lines to annotate are specified.
+func validateSourceLinesRequest(req *querierv1.SelectSourceLinesRequest) error {
+	if req.FunctionName == "" && req.FileName == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("either function_name or file_name must be specified"))
+	}
+	return nil
+}
+
 func (q *Querier) SelectSeries(ctx context.Context, req *connect.Request[querierv1.SelectSeriesRequest]) (*connect.Response[querierv1.SelectSeriesResponse], error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectSeries")
 	defer func() {
@@ -821,6 +926,9 @@ func (q *Querier) SelectSeries(ctx context.Context, req *connect.Request[querier
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	if err = validateSampleLabelSelector(req.Msg.SampleLabelSelector); err != nil {
+		return nil, err
+	}
 
 	if req.Msg.Start > req.Msg.End {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("start must be before end"))
@@ -833,9 +941,10 @@ func (q *Querier) SelectSeries(ctx context.Context, req *connect.Request[querier
 	stepMs := time.Duration(req.Msg.Step * float64(time.Second)).Milliseconds()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	ctx = withQueryLimiter(ctx, q.limits)
 
 	// determine the block hints
-	plan, err := q.blockSelect(ctx, model.Time(req.Msg.Start), model.Time(req.Msg.End))
+	plan, err := q.blockSelect(ctx, model.Time(req.Msg.Start), model.Time(req.Msg.End), req.Msg.LabelSelector, req.Msg.ProfileTypeID)
 	if isEndpointNotExistingErr(err) {
 		level.Warn(spanlogger.FromContext(ctx, q.logger)).Log(
 			"msg", "block select not supported on at least one component, fallback to use full dataset",
@@ -852,6 +961,9 @@ func (q *Querier) SelectSeries(ctx context.Context, req *connect.Request[querier
 	}
 
 	it, err := selectMergeSeries(ctx, req.Msg.Aggregation, responses)
+	if validation.ReasonOf(err) == validation.QueryLimit {
+		return nil, err
+	}
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -882,11 +994,12 @@ func (q *Querier) selectSeries(ctx context.Context, req *connect.Request[querier
 	if q.storeGatewayQuerier == nil {
 		return q.selectSeriesFromIngesters(ctx, &ingestv1.MergeProfilesLabelsRequest{
 			Request: &ingestv1.SelectProfilesRequest{
-				LabelSelector: req.Msg.LabelSelector,
-				Start:         start,
-				End:           req.Msg.End,
-				Type:          profileType,
-				Aggregation:   req.Msg.Aggregation,
+				LabelSelector:       req.Msg.LabelSelector,
+				Start:               start,
+				End:                 req.Msg.End,
+				Type:                profileType,
+				Aggregation:         req.Msg.Aggregation,
+				SampleLabelSelector: req.Msg.SampleLabelSelector,
 			},
 			By:                 req.Msg.GroupBy,
 			StackTraceSelector: req.Msg.StackTraceSelector,
@@ -1013,7 +1126,7 @@ func uniqueSortedStrings(responses []ResponseFromReplica[[]string]) []string {
 
 func (q *Querier) selectSpanProfile(ctx context.Context, req *querierv1.SelectMergeSpanProfileRequest) (*phlaremodel.Tree, error) {
 	// determine the block hints
-	plan, err := q.blockSelect(ctx, model.Time(req.Start), model.Time(req.End))
+	plan, err := q.blockSelect(ctx, model.Time(req.Start), model.Time(req.End), req.LabelSelector, req.ProfileTypeID)
 	if isEndpointNotExistingErr(err) {
 		level.Warn(spanlogger.FromContext(ctx, q.logger)).Log(
 			"msg", "block select not supported on at least one component, fallback to use full dataset",
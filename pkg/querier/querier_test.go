@@ -76,7 +76,7 @@ func Test_QuerySampleType(t *testing.T) {
 				}), nil)
 		}
 		return q, nil
-	}}, nil, nil, log.NewLogfmtLogger(os.Stdout))
+	}}, nil, nil, nil, log.NewLogfmtLogger(os.Stdout))
 
 	require.NoError(t, err)
 	out, err := querier.ProfileTypes(context.Background(), connect.NewRequest(&querierv1.ProfileTypesRequest{}))
@@ -107,7 +107,7 @@ func Test_QueryLabelValues(t *testing.T) {
 			q.On("LabelValues", mock.Anything, mock.Anything).Return(connect.NewResponse(&typesv1.LabelValuesResponse{Names: []string{"buzz", "foo"}}), nil)
 		}
 		return q, nil
-	}}, nil, nil, log.NewLogfmtLogger(os.Stdout))
+	}}, nil, nil, nil, log.NewLogfmtLogger(os.Stdout))
 
 	require.NoError(t, err)
 	out, err := querier.LabelValues(context.Background(), req)
@@ -134,7 +134,7 @@ func Test_QueryLabelNames(t *testing.T) {
 			q.On("LabelNames", mock.Anything, mock.Anything).Return(connect.NewResponse(&typesv1.LabelNamesResponse{Names: []string{"buzz", "foo"}}), nil)
 		}
 		return q, nil
-	}}, nil, nil, log.NewLogfmtLogger(os.Stdout))
+	}}, nil, nil, nil, log.NewLogfmtLogger(os.Stdout))
 
 	require.NoError(t, err)
 	out, err := querier.LabelNames(context.Background(), req)
@@ -167,7 +167,7 @@ func Test_Series(t *testing.T) {
 			q.On("Series", mock.Anything, mock.Anything).Return(ingesterReponse, nil)
 		}
 		return q, nil
-	}}, nil, nil, log.NewLogfmtLogger(os.Stdout))
+	}}, nil, nil, nil, log.NewLogfmtLogger(os.Stdout))
 
 	require.NoError(t, err)
 	out, err := querier.Series(context.Background(), req)
@@ -290,7 +290,7 @@ func Test_SelectMergeStacktraces(t *testing.T) {
 					q.mockMergeStacktraces(bidi3, []string{"c", "d"}, tc.blockSelect)
 				}
 				return q, nil
-			}}, nil, nil, log.NewLogfmtLogger(os.Stdout))
+			}}, nil, nil, nil, log.NewLogfmtLogger(os.Stdout))
 			require.NoError(t, err)
 			flame, err := querier.SelectMergeStacktraces(context.Background(), req)
 			require.NoError(t, err)
@@ -415,7 +415,7 @@ func Test_SelectMergeProfiles(t *testing.T) {
 					q.On("MergeProfilesPprof", mock.Anything).Once().Return(bidi3)
 				}
 				return q, nil
-			}}, nil, nil, log.NewLogfmtLogger(os.Stdout))
+			}}, nil, nil, nil, log.NewLogfmtLogger(os.Stdout))
 			require.NoError(t, err)
 			res, err := querier.SelectMergeProfile(context.Background(), req)
 			require.NoError(t, err)
@@ -541,7 +541,7 @@ func TestSelectSeries(t *testing.T) {
 					q.mockMergeLabels(bidi3, []string{"c", "d"}, tc.blockSelect)
 				}
 				return q, nil
-			}}, nil, nil, log.NewLogfmtLogger(os.Stdout))
+			}}, nil, nil, nil, log.NewLogfmtLogger(os.Stdout))
 			require.NoError(t, err)
 			res, err := querier.SelectSeries(context.Background(), req)
 			require.NoError(t, err)
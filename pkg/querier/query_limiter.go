@@ -0,0 +1,89 @@
+package querier
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+
+	"github.com/grafana/pyroscope/pkg/querier/stats"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+// Limits are the per-tenant limits enforced by the querier.
+type Limits interface {
+	validation.QueryCostLimits
+}
+
+type queryLimiterContextKey struct{}
+
+// queryLimiter accounts the profiles fetched by a query from ingesters and
+// store-gateways and the data they read, and fails the query once the
+// per-tenant limits are exceeded. The accounting is done in the query
+// stats, so that it is reported to the frontend.
+type queryLimiter struct {
+	stats     *stats.Stats
+	limits    Limits
+	tenantIDs []string
+}
+
+// withQueryLimiter returns a context with a query limiter enforcing the
+// limits of the tenants of the request. If the query stats are not enabled
+// in the context, they are initialised.
+func withQueryLimiter(ctx context.Context, limits Limits) context.Context {
+	if limits == nil {
+		return ctx
+	}
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return ctx
+	}
+	s := stats.FromContext(ctx)
+	if s == nil {
+		s, ctx = stats.ContextWithEmptyStats(ctx)
+	}
+	return context.WithValue(ctx, queryLimiterContextKey{}, &queryLimiter{
+		stats:     s,
+		limits:    limits,
+		tenantIDs: tenantIDs,
+	})
+}
+
+// queryLimiterFromContext returns the query limiter of the context.
+// The limiter is nil if the query is not limited.
+func queryLimiterFromContext(ctx context.Context) *queryLimiter {
+	l, _ := ctx.Value(queryLimiterContextKey{}).(*queryLimiter)
+	return l
+}
+
+// addProfiles accounts profiles fetched, and returns
+// an error if the query exceeds the limits.
+func (l *queryLimiter) addProfiles(profiles int) error {
+	if l == nil {
+		return nil
+	}
+	l.stats.AddFetchedProfiles(uint64(profiles))
+	return l.check()
+}
+
+// addRead accounts the data read and the stack traces resolved by an
+// ingester or a store-gateway, as reported in the trailer of its response,
+// and returns an error if the query exceeds the limits. Each of them also
+// enforces the limit of bytes read on its own part of the query while
+// reading.
+func (l *queryLimiter) addRead(trailer http.Header) error {
+	if l == nil {
+		return nil
+	}
+	l.stats.AddReadHeaders(trailer)
+	return l.check()
+}
+
+func (l *queryLimiter) check() error {
+	err := validation.ValidateQueryCost(l.limits, l.tenantIDs, l.stats.LoadReadBytes(), l.stats.LoadFetchedProfiles())
+	if err != nil {
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	return nil
+}
@@ -12,11 +12,13 @@ import (
 	"github.com/grafana/dskit/ring"
 	"github.com/opentracing/opentracing-go"
 	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 
 	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/block"
 	"github.com/grafana/pyroscope/pkg/phlaredb/sharding"
 	"github.com/grafana/pyroscope/pkg/util"
 	"github.com/grafana/pyroscope/pkg/util/spanlogger"
@@ -58,21 +60,27 @@ func cleanupStreams[Result any](result ResponseFromReplica[Result]) {
 
 // forGivenReplicationSet runs f, in parallel, for given replica set.
 // Under the hood it returns only enough responses to satisfy the quorum.
-func forGivenReplicationSet[Result any, Querier any](ctx context.Context, clientFactory func(string) (Querier, error), replicationSet ring.ReplicationSet, f QueryReplicaFn[Result, Querier]) ([]ResponseFromReplica[Result], error) {
+func forGivenReplicationSet[Result any, Querier any](ctx context.Context, clientFactory func(string) (Querier, error), replicationSet ring.ReplicationSet, opts hedgingOptions, f QueryReplicaFn[Result, Querier]) ([]ResponseFromReplica[Result], error) {
+	// Without block hints, the set of blocks queried may change
+	// between attempts, therefore streams can't be replayed.
+	opts.immutable = false
 	results, err := ring.DoUntilQuorumWithoutSuccessfulContextCancellation(
 		ctx,
 		replicationSet,
 		ring.DoUntilQuorumConfig{
 			MinimizeRequests: true,
+			HedgingDelay:     opts.delay,
 		},
 		func(ctx context.Context, ingester *ring.InstanceDesc, _ context.CancelCauseFunc) (ResponseFromReplica[Result], error) {
 			var res ResponseFromReplica[Result]
-			client, err := clientFactory(ingester.Addr)
-			if err != nil {
-				return res, err
-			}
-
-			resp, err := f(ctx, client)
+			resp, err := withHedging(ctx, opts, ingester.Addr, nil, func(ctx context.Context, addr string) (Result, error) {
+				var res Result
+				client, err := clientFactory(addr)
+				if err != nil {
+					return res, err
+				}
+				return f(ctx, client)
+			})
 			if err != nil {
 				return res, err
 			}
@@ -88,8 +96,10 @@ func forGivenReplicationSet[Result any, Querier any](ctx context.Context, client
 	return results, err
 }
 
-// forGivenPlan runs f, in parallel, for given plan.
-func forGivenPlan[Result any, Querier any](ctx context.Context, plan map[string]*ingestv1.BlockHints, clientFactory func(string) (Querier, error), replicationSet ring.ReplicationSet, f QueryReplicaWithHintsFn[Result, Querier]) ([]ResponseFromReplica[Result], error) {
+// forGivenPlan runs f, in parallel, for given plan. If alternatives is not nil,
+// it returns the replicas that can serve the planned blocks of the replica;
+// they are used to hedge and retry the request.
+func forGivenPlan[Result any, Querier any](ctx context.Context, plan map[string]*ingestv1.BlockHints, clientFactory func(string) (Querier, error), replicationSet ring.ReplicationSet, opts hedgingOptions, alternatives func(string, *ingestv1.BlockHints) []string, f QueryReplicaWithHintsFn[Result, Querier]) ([]ResponseFromReplica[Result], error) {
 	g, _ := errgroup.WithContext(ctx)
 
 	var (
@@ -108,12 +118,18 @@ func forGivenPlan[Result any, Querier any](ctx context.Context, plan map[string]
 		)
 		idx++
 		g.Go(func() error {
-			client, err := clientFactory(r)
-			if err != nil {
-				return err
+			var alt []string
+			if alternatives != nil && opts.enabled() {
+				alt = alternatives(r, h)
 			}
-
-			resp, err := f(ctx, client, &ingestv1.Hints{Block: h})
+			resp, err := withHedging(ctx, opts, r, alt, func(ctx context.Context, addr string) (Result, error) {
+				var res Result
+				client, err := clientFactory(addr)
+				if err != nil {
+					return res, err
+				}
+				return f(ctx, client, &ingestv1.Hints{Block: h})
+			})
 			if err != nil {
 				return err
 			}
@@ -320,7 +336,11 @@ func (p blockPlan) String() string {
 	return string(data)
 }
 
-func (r *replicasPerBlockID) blockPlan(ctx context.Context) map[string]*ingestv1.BlockHints {
+// blockPlan chooses the replicas to query for every block. Blocks which label
+// summaries show that they have no series matching the matchers are left
+// out. This is done only after the pruning of incomplete sharded and
+// superseded blocks, which needs to see all the blocks.
+func (r *replicasPerBlockID) blockPlan(ctx context.Context, matchers ...*labels.Matcher) map[string]*ingestv1.BlockHints {
 	sp, _ := opentracing.StartSpanFromContext(ctx, "blockPlan")
 	defer sp.Finish()
 
@@ -329,6 +349,7 @@ func (r *replicasPerBlockID) blockPlan(ctx context.Context) map[string]*ingestv1
 		hash                    = xxhash.New()
 		plan                    = make(map[string]*ingestv1.BlockHints)
 		smallestCompactionLevel = int32(0)
+		skippedBlocks           = 0
 	)
 
 	if err := r.pruneIncompleteShardedBlocks(); err != nil {
@@ -351,6 +372,11 @@ func (r *replicasPerBlockID) blockPlan(ctx context.Context) map[string]*ingestv1
 		if !ok {
 			continue
 		}
+		// skip the block if it can't contain series matching the query
+		if !block.BlockInfoMayMatch(meta, matchers...) {
+			skippedBlocks++
+			continue
+		}
 		// when we see a block with CompactionLevel <=1 or a block without compaction section, we want the queriers to deduplicate
 		if meta.Compaction == nil || meta.Compaction.Level <= 1 {
 			deduplicate = true
@@ -423,6 +449,7 @@ func (r *replicasPerBlockID) blockPlan(ctx context.Context) map[string]*ingestv1
 		otlog.Int32("smallest_compaction_level", smallestCompactionLevel),
 		otlog.Int("planned_blocks_ingesters", plannedIngesterBlocks),
 		otlog.Int("planned_blocks_store_gateways", plannedStoreGatwayBlocks),
+		otlog.Int("skipped_blocks", skippedBlocks),
 	)
 
 	level.Debug(spanlogger.FromContext(ctx, r.logger)).Log(
@@ -431,6 +458,7 @@ func (r *replicasPerBlockID) blockPlan(ctx context.Context) map[string]*ingestv1
 		"smallest_compaction_level", smallestCompactionLevel,
 		"planned_blocks_ingesters", plannedIngesterBlocks,
 		"planned_blocks_store_gateways", plannedStoreGatwayBlocks,
+		"skipped_blocks", skippedBlocks,
 		"plan", blockPlan(plan),
 	)
 
@@ -9,10 +9,12 @@ import (
 
 	"github.com/go-kit/log"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 
 	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/block"
 	"github.com/grafana/pyroscope/pkg/phlaredb/sharding"
 )
 
@@ -67,6 +69,23 @@ func (b *blockInfo) withCompactorShard(shard, shardsCount uint64) *blockInfo {
 	)
 }
 
+func (b *blockInfo) withLabelSplit(name string, shards uint64) *blockInfo {
+	b.i.LabelSplit = &typesv1.BlockLabelSplit{
+		Name:   name,
+		Shards: shards,
+	}
+	return b
+}
+
+func (b *blockInfo) withLabelBloom(name string, values ...string) *blockInfo {
+	bloom := block.NewLabelBloom(map[string][]string{name: values})
+	b.i.LabelBloom = &typesv1.BlockLabelBloom{
+		Names:  bloom.Names,
+		Filter: bloom.Filter,
+	}
+	return b
+}
+
 func (b *blockInfo) info() *typesv1.BlockInfo {
 	return &b.i
 }
@@ -98,6 +117,7 @@ func Test_replicasPerBlockID_blockPlan(t *testing.T) {
 	for _, tc := range []struct {
 		name       string
 		inputs     func(r *replicasPerBlockID)
+		matchers   []*labels.Matcher
 		validators []validatorFunc
 	}{
 		{
@@ -233,12 +253,49 @@ func Test_replicasPerBlockID_blockPlan(t *testing.T) {
 				validatePlanBlocksOnReplica("ingester-0", "b"),
 			},
 		},
+		{
+			// The shards which can't contain matching series are skipped only
+			// after the incomplete sharded blocks are pruned: otherwise the
+			// matching shard would be pruned along with its group.
+			name: "skip sharded blocks not matching the selector",
+			inputs: func(r *replicasPerBlockID) {
+				t1, _ := time.Parse(time.RFC3339, "2021-01-01T00:00:00Z")
+				r.add([]ResponseFromReplica[[]*typesv1.BlockInfo]{
+					{
+						addr: "ingester-0",
+						response: []*typesv1.BlockInfo{
+							newBlockInfo("a").withMinTime(t1, time.Hour-time.Second).info(),
+						},
+					},
+				}, ingesterInstance)
+				r.add([]ResponseFromReplica[[]*typesv1.BlockInfo]{
+					{
+						addr: "store-gateway-0",
+						response: []*typesv1.BlockInfo{
+							newBlockInfo("a-1").withCompactionLevel(2).withCompactionSources("a").withCompactionParents("a").withCompactorShard(0, 3).withMinTime(t1, time.Hour-time.Second).
+								withLabelBloom("service_name", "svc-a", "svc-b").info(),
+							newBlockInfo("a-2").withCompactionLevel(2).withCompactionSources("a").withCompactionParents("a").withCompactorShard(2, 3).withMinTime(t1, time.Hour-time.Second).
+								withLabelBloom("service_name", "rare").info(),
+							newBlockInfo("a-3").withCompactionLevel(2).withCompactionSources("a").withCompactionParents("a").withCompactorShard(1, 3).withMinTime(t1, time.Hour-time.Second).
+								withLabelSplit("service_name", 3).info(),
+						},
+					},
+				}, storeGatewayInstance)
+			},
+			matchers: []*labels.Matcher{
+				labels.MustNewMatcher(labels.MatchEqual, "service_name", "rare"),
+			},
+			validators: []validatorFunc{
+				validatePlanBlockIDs("a-2"),
+				validatePlanBlocksOnReplica("store-gateway-0", "a-2"),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			r := newReplicasPerBlockID(log.NewNopLogger())
 			tc.inputs(r)
 
-			plan := r.blockPlan(context.TODO())
+			plan := r.blockPlan(context.TODO(), tc.matchers...)
 			for _, v := range tc.validators {
 				v(t, plan)
 			}
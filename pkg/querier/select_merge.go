@@ -2,8 +2,11 @@ package querier
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"sync"
 	"time"
 
@@ -79,6 +82,7 @@ type mergeIterator[R any, Req Request, Res Response] struct {
 	currentProfile *ProfileWithLabels
 
 	response keepResponse
+	limiter  *queryLimiter
 }
 
 // NewMergeIterator return a new iterator that stream profiles and allows to filter them using `Keep` to keep
@@ -103,6 +107,7 @@ func NewMergeIterator[
 			MergeProfilesPprofRequest:       &ingestv1.MergeProfilesPprofRequest{},
 			MergeSpanProfileRequest:         &ingestv1.MergeSpanProfileRequest{},
 		},
+		limiter: queryLimiterFromContext(ctx),
 	}
 	it.fetchBatch()
 	return it
@@ -181,6 +186,11 @@ func (s *mergeIterator[R, Req, Res]) fetchBatch() {
 		}
 		selectedProfiles = res.SelectedProfiles
 	}
+	if err := s.limiter.addProfiles(len(selectedProfiles.GetProfiles())); err != nil {
+		s.err = err
+		s.curr = nil
+		return
+	}
 	s.curr = selectedProfiles
 	if s.curr == nil {
 		return
@@ -210,18 +220,50 @@ func (s *mergeIterator[R, Req, Res]) Result() (R, error) {
 		s.err = err
 		return *new(R), err
 	}
-	switch result := any(res).(type) {
+	var result R
+	switch r := any(res).(type) {
 	case *ingestv1.MergeProfilesStacktracesResponse:
-		return any(result.Result).(R), nil
+		result = any(r.Result).(R)
 	case *ingestv1.MergeProfilesLabelsResponse:
-		return any(result.Series).(R), nil
+		result = any(r.Series).(R)
 	case *ingestv1.MergeProfilesPprofResponse:
-		return any(result.Result).(R), nil
+		result = any(r.Result).(R)
 	case *ingestv1.MergeSpanProfileResponse:
-		return any(result.Result).(R), nil
+		result = any(r.Result).(R)
 	default:
-		return *new(R), fmt.Errorf("unexpected response type %T", result)
+		return *new(R), fmt.Errorf("unexpected response type %T", r)
+	}
+	if err = s.addRead(); err != nil {
+		s.err = err
+		return *new(R), err
+	}
+	return result, nil
+}
+
+// addRead accounts the data read and the stack traces resolved by the
+// replica to compute the result. They are reported in the trailer of the response, which is only received
+// once the stream is drained.
+func (s *mergeIterator[R, Req, Res]) addRead() error {
+	if s.limiter == nil {
+		return nil
+	}
+	var stream any = s.bidi
+	if h, ok := stream.(interface{ currentStream() any }); ok {
+		stream = h.currentStream()
+	}
+	t, ok := stream.(interface{ ResponseTrailer() http.Header })
+	if !ok {
+		return nil
+	}
+	_, err := stream.(BidiClientMerge[Req, Res]).Receive()
+	if err == nil {
+		// The stream is not drained: no trailer.
+		return nil
+	}
+	if !errors.Is(err, io.EOF) {
+		return err
 	}
+	return s.limiter.addRead(t.ResponseTrailer())
 }
 
 func (s *mergeIterator[R, Req, Res]) Err() error {
@@ -281,7 +323,9 @@ func skipDuplicates(ctx context.Context, its []MergeIterator) error {
 	span.LogFields(otlog.Int("duplicates", duplicates))
 	span.LogFields(otlog.Int("total", total))
 	if err := tree.Err(); err != nil {
-		errors.Add(err)
+		// The error is returned as is to retain its status code,
+		// e.g., if the query limits are exceeded.
+		return err
 	}
 
 	return errors.Err()
@@ -2,10 +2,14 @@ package querier
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
 	"sort"
 	"strconv"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/stretchr/testify/require"
 
 	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
@@ -14,7 +18,10 @@ import (
 	"github.com/grafana/pyroscope/pkg/iter"
 	"github.com/grafana/pyroscope/pkg/model"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/querier/stats"
+	"github.com/grafana/pyroscope/pkg/tenant"
 	"github.com/grafana/pyroscope/pkg/testhelper"
+	"github.com/grafana/pyroscope/pkg/validation"
 )
 
 var (
@@ -117,6 +124,96 @@ func TestSelectMergeStacktraces(t *testing.T) {
 	requireFakeMergeProfilesStacktracesResultTree(t, res)
 }
 
+func TestSelectMergeStacktraces_QueryLimits(t *testing.T) {
+	newResponsesWithError := func(err error) []ResponseFromReplica[clientpool.BidiClientMergeProfilesStacktraces] {
+		trailer := make(http.Header)
+		stats.SetReadHeaders(trailer, 4096, 100, 3)
+		return []ResponseFromReplica[clientpool.BidiClientMergeProfilesStacktraces]{{
+			response: &fakeBidiClientStacktracesWithTrailer{trailer: trailer, err: err, fakeBidiClientStacktraces: newFakeBidiClientStacktraces([]*ingestv1.ProfileSets{
+				{
+					LabelsSets: []*typesv1.Labels{{Labels: foobarlabels}},
+					Profiles: []*ingestv1.SeriesProfile{
+						{LabelIndex: 0, Timestamp: 1},
+						{LabelIndex: 0, Timestamp: 2},
+						{LabelIndex: 0, Timestamp: 3},
+					},
+				},
+				{
+					LabelsSets: []*typesv1.Labels{{Labels: foobarlabels}},
+					Profiles: []*ingestv1.SeriesProfile{
+						{LabelIndex: 0, Timestamp: 4},
+						{LabelIndex: 0, Timestamp: 5},
+					},
+				},
+			})},
+		}}
+	}
+	newResponses := func() []ResponseFromReplica[clientpool.BidiClientMergeProfilesStacktraces] {
+		return newResponsesWithError(nil)
+	}
+	ctx := tenant.InjectTenantID(context.Background(), "tenant")
+
+	t.Run("within limits", func(t *testing.T) {
+		ctx := withQueryLimiter(ctx, validation.MockLimits{MaxQueryProfilesValue: 5})
+		res, err := selectMergeTree(ctx, newResponses())
+		require.NoError(t, err)
+		requireFakeMergeProfilesStacktracesResultTree(t, res)
+		s := stats.FromContext(ctx)
+		require.Equal(t, uint64(5), s.LoadFetchedProfiles())
+		require.Equal(t, uint64(4096), s.LoadReadBytes())
+		require.Equal(t, uint64(100), s.LoadScannedRows())
+		require.Equal(t, uint64(3), s.LoadResolvedStacktraces())
+	})
+
+	t.Run("profiles limit exceeded", func(t *testing.T) {
+		ctx := withQueryLimiter(ctx, validation.MockLimits{MaxQueryProfilesValue: 4})
+		_, err := selectMergeTree(ctx, newResponses())
+		require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+		require.Equal(t, validation.QueryLimit, validation.ReasonOf(err))
+	})
+
+	t.Run("bytes limit exceeded", func(t *testing.T) {
+		ctx := withQueryLimiter(ctx, validation.MockLimits{MaxQueryBytesValue: 4095})
+		_, err := selectMergeTree(ctx, newResponses())
+		require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+		require.ErrorContains(t, err, "max_query_bytes")
+	})
+
+	t.Run("replica fails after the result", func(t *testing.T) {
+		ctx := withQueryLimiter(ctx, validation.MockLimits{})
+		_, err := selectMergeTree(ctx, newResponsesWithError(connect.NewError(connect.CodeResourceExhausted, errors.New("limit exceeded"))))
+		require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	})
+}
+
+// fakeBidiClientStacktracesWithTrailer reports the data read in the
+// trailer of the response, received once the stream is drained. If
+// err is set, it is returned instead of the end of the stream.
+type fakeBidiClientStacktracesWithTrailer struct {
+	*fakeBidiClientStacktraces
+	trailer http.Header
+	results int
+	err     error
+}
+
+func (f *fakeBidiClientStacktracesWithTrailer) Receive() (*ingestv1.MergeProfilesStacktracesResponse, error) {
+	// The fake client responds with the result both to signal
+	// the end of the profile streaming and as the final result.
+	if f.results == 2 {
+		if f.err != nil {
+			return nil, f.err
+		}
+		return nil, io.EOF
+	}
+	r, err := f.fakeBidiClientStacktraces.Receive()
+	if r.GetResult() != nil {
+		f.results++
+	}
+	return r, err
+}
+
+func (f *fakeBidiClientStacktracesWithTrailer) ResponseTrailer() http.Header { return f.trailer }
+
 func TestSelectMergeStacktracesWithBlockDeduplication(t *testing.T) {
 }
 
@@ -7,12 +7,22 @@ package stats
 
 import (
 	"context"
+	"net/http"
+	"strconv"
 	"sync/atomic" //lint:ignore faillint we can't use go.uber.org/atomic with a protobuf struct without wrapping it.
 	"time"
 
 	"github.com/grafana/pyroscope/pkg/util/httpgrpc"
 )
 
+// Response headers carrying the query stats.
+const (
+	FetchedProfilesHeader     = "X-Pyroscope-Fetched-Profiles"
+	ReadBytesHeader           = "X-Pyroscope-Read-Bytes"
+	ScannedRowsHeader         = "X-Pyroscope-Scanned-Rows"
+	ResolvedStacktracesHeader = "X-Pyroscope-Resolved-Stacktraces"
+)
+
 type contextKey int
 
 var ctxKey = contextKey(0)
@@ -155,6 +165,70 @@ func (s *Stats) LoadSplitQueries() uint32 {
 	return atomic.LoadUint32(&s.SplitQueries)
 }
 
+func (s *Stats) AddFetchedProfiles(profiles uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.FetchedProfilesCount, profiles)
+}
+
+func (s *Stats) LoadFetchedProfiles() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.FetchedProfilesCount)
+}
+
+func (s *Stats) AddReadBytes(bytes uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.ReadBytes, bytes)
+}
+
+func (s *Stats) LoadReadBytes() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.ReadBytes)
+}
+
+func (s *Stats) AddScannedRows(rows uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.ScannedRows, rows)
+}
+
+func (s *Stats) LoadScannedRows() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.ScannedRows)
+}
+
+func (s *Stats) AddResolvedStacktraces(stacktraces uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.ResolvedStacktracesCount, stacktraces)
+}
+
+func (s *Stats) LoadResolvedStacktraces() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.ResolvedStacktracesCount)
+}
+
 // Merge the provided Stats into this one.
 func (s *Stats) Merge(other *Stats) {
 	if s == nil || other == nil {
@@ -168,6 +242,45 @@ func (s *Stats) Merge(other *Stats) {
 	s.AddShardedQueries(other.LoadShardedQueries())
 	s.AddSplitQueries(other.LoadSplitQueries())
 	s.AddFetchedIndexBytes(other.LoadFetchedIndexBytes())
+	s.AddFetchedProfiles(other.LoadFetchedProfiles())
+	s.AddReadBytes(other.LoadReadBytes())
+	s.AddScannedRows(other.LoadScannedRows())
+	s.AddResolvedStacktraces(other.LoadResolvedStacktraces())
+}
+
+// SetHeaders sets the query stats response headers.
+func (s *Stats) SetHeaders(h http.Header) {
+	if s == nil {
+		return
+	}
+
+	h.Set(FetchedProfilesHeader, strconv.FormatUint(s.LoadFetchedProfiles(), 10))
+	SetReadHeaders(h, s.LoadReadBytes(), s.LoadScannedRows(), s.LoadResolvedStacktraces())
+}
+
+// SetReadHeaders sets the headers reporting the data read for a query.
+// Ingesters and store-gateways report it in the trailer of their responses.
+func SetReadHeaders(h http.Header, bytes, rows, stacktraces uint64) {
+	h.Set(ReadBytesHeader, strconv.FormatUint(bytes, 10))
+	h.Set(ScannedRowsHeader, strconv.FormatUint(rows, 10))
+	h.Set(ResolvedStacktracesHeader, strconv.FormatUint(stacktraces, 10))
+}
+
+// AddReadHeaders accounts the data read reported in the headers.
+func (s *Stats) AddReadHeaders(h http.Header) {
+	if s == nil {
+		return
+	}
+
+	if bytes, err := strconv.ParseUint(h.Get(ReadBytesHeader), 10, 64); err == nil {
+		s.AddReadBytes(bytes)
+	}
+	if rows, err := strconv.ParseUint(h.Get(ScannedRowsHeader), 10, 64); err == nil {
+		s.AddScannedRows(rows)
+	}
+	if stacktraces, err := strconv.ParseUint(h.Get(ResolvedStacktracesHeader), 10, 64); err == nil {
+		s.AddResolvedStacktraces(stacktraces)
+	}
 }
 
 func ShouldTrackHTTPGRPCResponse(r *httpgrpc.HTTPResponse) bool {
@@ -7,6 +7,7 @@ package stats
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -115,6 +116,104 @@ func TestStats_AddSplitQueries(t *testing.T) {
 	})
 }
 
+func TestStats_AddFetchedProfiles(t *testing.T) {
+	t.Run("add and load profiles", func(t *testing.T) {
+		stats, _ := ContextWithEmptyStats(context.Background())
+		stats.AddFetchedProfiles(100)
+		stats.AddFetchedProfiles(50)
+
+		assert.Equal(t, uint64(150), stats.LoadFetchedProfiles())
+	})
+
+	t.Run("add and load profiles nil receiver", func(t *testing.T) {
+		var stats *Stats
+		stats.AddFetchedProfiles(50)
+
+		assert.Equal(t, uint64(0), stats.LoadFetchedProfiles())
+	})
+}
+
+func TestStats_AddReadBytes(t *testing.T) {
+	t.Run("add and load bytes", func(t *testing.T) {
+		stats, _ := ContextWithEmptyStats(context.Background())
+		stats.AddReadBytes(4096)
+		stats.AddReadBytes(4096)
+
+		assert.Equal(t, uint64(8192), stats.LoadReadBytes())
+	})
+
+	t.Run("add and load bytes nil receiver", func(t *testing.T) {
+		var stats *Stats
+		stats.AddReadBytes(1024)
+
+		assert.Equal(t, uint64(0), stats.LoadReadBytes())
+	})
+}
+
+func TestStats_AddScannedRows(t *testing.T) {
+	t.Run("add and load rows", func(t *testing.T) {
+		stats, _ := ContextWithEmptyStats(context.Background())
+		stats.AddScannedRows(100)
+		stats.AddScannedRows(50)
+
+		assert.Equal(t, uint64(150), stats.LoadScannedRows())
+	})
+
+	t.Run("add and load rows nil receiver", func(t *testing.T) {
+		var stats *Stats
+		stats.AddScannedRows(50)
+
+		assert.Equal(t, uint64(0), stats.LoadScannedRows())
+	})
+}
+
+func TestStats_AddResolvedStacktraces(t *testing.T) {
+	t.Run("add and load stacktraces", func(t *testing.T) {
+		stats, _ := ContextWithEmptyStats(context.Background())
+		stats.AddResolvedStacktraces(20)
+		stats.AddResolvedStacktraces(22)
+
+		assert.Equal(t, uint64(42), stats.LoadResolvedStacktraces())
+	})
+
+	t.Run("add and load stacktraces nil receiver", func(t *testing.T) {
+		var stats *Stats
+		stats.AddResolvedStacktraces(3)
+
+		assert.Equal(t, uint64(0), stats.LoadResolvedStacktraces())
+	})
+}
+
+func TestStats_SetHeaders(t *testing.T) {
+	stats := &Stats{}
+	stats.AddFetchedProfiles(10)
+	stats.AddReadBytes(4096)
+	stats.AddScannedRows(100)
+	stats.AddResolvedStacktraces(42)
+
+	h := make(http.Header)
+	stats.SetHeaders(h)
+
+	assert.Equal(t, "10", h.Get(FetchedProfilesHeader))
+	assert.Equal(t, "4096", h.Get(ReadBytesHeader))
+	assert.Equal(t, "100", h.Get(ScannedRowsHeader))
+	assert.Equal(t, "42", h.Get(ResolvedStacktracesHeader))
+}
+
+func TestStats_AddReadHeaders(t *testing.T) {
+	h := make(http.Header)
+	SetReadHeaders(h, 4096, 100, 42)
+
+	stats := &Stats{}
+	stats.AddReadHeaders(h)
+	stats.AddReadHeaders(h)
+	stats.AddReadHeaders(make(http.Header))
+
+	assert.Equal(t, uint64(8192), stats.LoadReadBytes())
+	assert.Equal(t, uint64(200), stats.LoadScannedRows())
+	assert.Equal(t, uint64(84), stats.LoadResolvedStacktraces())
+}
+
 func TestStats_Merge(t *testing.T) {
 	t.Run("merge two stats objects", func(t *testing.T) {
 		stats1 := &Stats{}
@@ -124,6 +223,10 @@ func TestStats_Merge(t *testing.T) {
 		stats1.AddFetchedChunks(10)
 		stats1.AddShardedQueries(20)
 		stats1.AddSplitQueries(10)
+		stats1.AddFetchedProfiles(5)
+		stats1.AddReadBytes(1024)
+		stats1.AddScannedRows(10)
+		stats1.AddResolvedStacktraces(7)
 
 		stats2 := &Stats{}
 		stats2.AddWallTime(time.Second)
@@ -132,6 +235,10 @@ func TestStats_Merge(t *testing.T) {
 		stats2.AddFetchedChunks(11)
 		stats2.AddShardedQueries(21)
 		stats2.AddSplitQueries(11)
+		stats2.AddFetchedProfiles(6)
+		stats2.AddReadBytes(2048)
+		stats2.AddScannedRows(20)
+		stats2.AddResolvedStacktraces(8)
 
 		stats1.Merge(stats2)
 
@@ -141,6 +248,10 @@ func TestStats_Merge(t *testing.T) {
 		assert.Equal(t, uint64(21), stats1.LoadFetchedChunks())
 		assert.Equal(t, uint32(41), stats1.LoadShardedQueries())
 		assert.Equal(t, uint32(21), stats1.LoadSplitQueries())
+		assert.Equal(t, uint64(11), stats1.LoadFetchedProfiles())
+		assert.Equal(t, uint64(3072), stats1.LoadReadBytes())
+		assert.Equal(t, uint64(30), stats1.LoadScannedRows())
+		assert.Equal(t, uint64(15), stats1.LoadResolvedStacktraces())
 	})
 
 	t.Run("merge two nil stats objects", func(t *testing.T) {
@@ -48,6 +48,26 @@ func (m *Stats) MarshalToSizedBufferVT(dAtA []byte) (int, error) {
 		i -= len(m.unknownFields)
 		copy(dAtA[i:], m.unknownFields)
 	}
+	if m.ScannedRows != 0 {
+		i = encodeVarint(dAtA, i, uint64(m.ScannedRows))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.ResolvedStacktracesCount != 0 {
+		i = encodeVarint(dAtA, i, uint64(m.ResolvedStacktracesCount))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.ReadBytes != 0 {
+		i = encodeVarint(dAtA, i, uint64(m.ReadBytes))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.FetchedProfilesCount != 0 {
+		i = encodeVarint(dAtA, i, uint64(m.FetchedProfilesCount))
+		i--
+		dAtA[i] = 0x40
+	}
 	if m.FetchedIndexBytes != 0 {
 		i = encodeVarint(dAtA, i, uint64(m.FetchedIndexBytes))
 		i--
@@ -124,6 +144,18 @@ func (m *Stats) SizeVT() (n int) {
 	if m.FetchedIndexBytes != 0 {
 		n += 1 + sov(uint64(m.FetchedIndexBytes))
 	}
+	if m.FetchedProfilesCount != 0 {
+		n += 1 + sov(uint64(m.FetchedProfilesCount))
+	}
+	if m.ReadBytes != 0 {
+		n += 1 + sov(uint64(m.ReadBytes))
+	}
+	if m.ResolvedStacktracesCount != 0 {
+		n += 1 + sov(uint64(m.ResolvedStacktracesCount))
+	}
+	if m.ScannedRows != 0 {
+		n += 1 + sov(uint64(m.ScannedRows))
+	}
 	n += len(m.unknownFields)
 	return n
 }
@@ -296,6 +328,82 @@ func (m *Stats) UnmarshalVT(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedProfilesCount", wireType)
+			}
+			m.FetchedProfilesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedProfilesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadBytes", wireType)
+			}
+			m.ReadBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolvedStacktracesCount", wireType)
+			}
+			m.ResolvedStacktracesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResolvedStacktracesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScannedRows", wireType)
+			}
+			m.ScannedRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScannedRows |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skip(dAtA[iNdEx:])
@@ -3,6 +3,7 @@ package querier
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
@@ -10,6 +11,7 @@ import (
 	"github.com/grafana/dskit/ring"
 	ring_client "github.com/grafana/dskit/ring/client"
 	"github.com/grafana/dskit/services"
+	"github.com/oklog/ulid"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,6 +26,7 @@ import (
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/clientpool"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/phlaredb/block"
 	"github.com/grafana/pyroscope/pkg/storegateway"
 	"github.com/grafana/pyroscope/pkg/tenant"
 	"github.com/grafana/pyroscope/pkg/util"
@@ -46,9 +49,10 @@ type StoreGatewayLimits interface {
 }
 
 type StoreGatewayQuerier struct {
-	ring   ring.ReadRing
-	pool   *ring_client.Pool
-	limits StoreGatewayLimits
+	ring    ring.ReadRing
+	pool    *ring_client.Pool
+	limits  StoreGatewayLimits
+	hedging hedgingOptions
 
 	services.Service
 	// Subservices manager.
@@ -141,23 +145,60 @@ func forAllStoreGateways[T any](ctx context.Context, tenantID string, storegatew
 			return nil, err
 		}
 		return client.(StoreGatewayQueryClient), nil
-	}, replicationSet, f)
+	}, replicationSet, storegatewayQuerier.hedging, f)
 }
 
 // forAllPlannedStoreGatway runs f, in parallel, for all store-gateways part of the plan
-func forAllPlannedStoreGateways[T any](ctx context.Context, _ string, storegatewayQuerier *StoreGatewayQuerier, plan map[string]*ingestv1.BlockHints, f QueryReplicaWithHintsFn[T, StoreGatewayQueryClient]) ([]ResponseFromReplica[T], error) {
+func forAllPlannedStoreGateways[T any](ctx context.Context, tenantID string, storegatewayQuerier *StoreGatewayQuerier, plan map[string]*ingestv1.BlockHints, f QueryReplicaWithHintsFn[T, StoreGatewayQueryClient]) ([]ResponseFromReplica[T], error) {
 	replicationSet, err := storegatewayQuerier.ring.GetReplicationSetForOperation(readNoExtend)
 	if err != nil {
 		return nil, err
 	}
 
+	subring := GetShuffleShardingSubring(storegatewayQuerier.ring, tenantID, storegatewayQuerier.limits)
 	return forGivenPlan(ctx, plan, func(addr string) (StoreGatewayQueryClient, error) {
 		client, err := storegatewayQuerier.pool.GetClientFor(addr)
 		if err != nil {
 			return nil, err
 		}
 		return client.(StoreGatewayQueryClient), nil
-	}, replicationSet, f)
+	}, replicationSet, storegatewayQuerier.hedging, func(replica string, hints *ingestv1.BlockHints) []string {
+		return alternativeStoreGateways(subring, replica, hints)
+	}, f)
+}
+
+// alternativeStoreGateways returns store-gateways, other than the replica
+// given, that own all the blocks referenced by the hints.
+func alternativeStoreGateways(r ring.ReadRing, replica string, hints *ingestv1.BlockHints) []string {
+	var (
+		bufDescs [5]ring.InstanceDesc
+		bufHosts [5]string
+		bufZones [5]string
+		owners   = make(map[string]int)
+	)
+	for _, id := range hints.GetUlids() {
+		blockID, err := ulid.Parse(id)
+		if err != nil {
+			return nil
+		}
+		set, err := r.Get(block.HashBlockID(blockID), storegateway.BlocksRead, bufDescs[:0], bufHosts[:0], bufZones[:0])
+		if err != nil {
+			return nil
+		}
+		for _, instance := range set.Instances {
+			if instance.Addr != replica {
+				owners[instance.Addr]++
+			}
+		}
+	}
+	alternatives := make([]string, 0, len(owners))
+	for addr, blocks := range owners {
+		if blocks == len(hints.GetUlids()) {
+			alternatives = append(alternatives, addr)
+		}
+	}
+	sort.Strings(alternatives)
+	return alternatives
 }
 
 // GetShuffleShardingSubring returns the subring to be used for a given user. This function
@@ -216,11 +257,13 @@ func (q *Querier) selectTreeFromStoreGateway(ctx context.Context, req *querierv1
 		g.Go(util.RecoverPanic(func() error {
 			return r.response.Send(&ingestv1.MergeProfilesStacktracesRequest{
 				Request: &ingestv1.SelectProfilesRequest{
-					LabelSelector: req.LabelSelector,
-					Start:         req.Start,
-					End:           req.End,
-					Type:          profileType,
-					Hints:         &ingestv1.Hints{Block: hints},
+					LabelSelector:       req.LabelSelector,
+					Start:               req.Start,
+					End:                 req.End,
+					Type:                profileType,
+					Hints:               &ingestv1.Hints{Block: hints},
+					SampleLabelSelector: req.SampleLabelSelector,
+					CollapseRecursion:   req.CollapseRecursion,
 				},
 				MaxNodes: req.MaxNodes,
 			})
@@ -276,14 +319,17 @@ func (q *Querier) selectProfileFromStoreGateway(ctx context.Context, req *querie
 		g.Go(util.RecoverPanic(func() error {
 			return r.response.Send(&ingestv1.MergeProfilesPprofRequest{
 				Request: &ingestv1.SelectProfilesRequest{
-					LabelSelector: req.LabelSelector,
-					Start:         req.Start,
-					End:           req.End,
-					Type:          profileType,
-					Hints:         &ingestv1.Hints{Block: hints},
+					LabelSelector:       req.LabelSelector,
+					Start:               req.Start,
+					End:                 req.End,
+					Type:                profileType,
+					Hints:               &ingestv1.Hints{Block: hints},
+					SampleLabelSelector: req.SampleLabelSelector,
+					CollapseRecursion:   req.CollapseRecursion,
 				},
 				MaxNodes:           req.MaxNodes,
 				StackTraceSelector: req.StackTraceSelector,
+				SampleLabels:       req.SampleLabels,
 			})
 		}))
 	}
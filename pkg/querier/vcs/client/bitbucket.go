@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+const (
+	bitbucketURL    = "https://bitbucket.org"
+	bitbucketAPIURL = "https://api.bitbucket.org/2.0"
+)
+
+var bitbucketToken = os.Getenv("BITBUCKET_TOKEN")
+
+// BitbucketClient returns a bitbucket client authenticated with BITBUCKET_TOKEN.
+// Only public repositories can be accessed if the token is not set.
+func BitbucketClient() *bitbucketClient {
+	return newBitbucketClient(bitbucketURL, bitbucketAPIURL, bitbucketToken, http.DefaultClient)
+}
+
+// bitbucketClient talks to the Bitbucket Cloud REST API 2.0.
+type bitbucketClient struct {
+	webURL     string
+	apiURL     string
+	header     http.Header
+	httpClient *http.Client
+}
+
+func newBitbucketClient(webURL, apiURL, token string, httpClient *http.Client) *bitbucketClient {
+	header := make(http.Header)
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return &bitbucketClient{
+		webURL:     strings.TrimRight(webURL, "/"),
+		apiURL:     strings.TrimRight(apiURL, "/"),
+		header:     header,
+		httpClient: httpClient,
+	}
+}
+
+func (bb *bitbucketClient) repoURL(owner, repo string) string {
+	return bb.apiURL + "/repositories/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+type bitbucketLink struct {
+	Href string `json:"href"`
+}
+
+type bitbucketCommit struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+	Author  struct {
+		Raw  string `json:"raw"`
+		User *struct {
+			Nickname string `json:"nickname"`
+			Links    struct {
+				Avatar bitbucketLink `json:"avatar"`
+			} `json:"links"`
+		} `json:"user"`
+	} `json:"author"`
+	Links struct {
+		HTML bitbucketLink `json:"html"`
+	} `json:"links"`
+}
+
+type bitbucketRepository struct {
+	MainBranch struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+}
+
+func (bb *bitbucketClient) GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.GetCommitResponse, error) {
+	var commit bitbucketCommit
+	if err := getJSON(ctx, bb.httpClient, bb.repoURL(owner, repo)+"/commit/"+url.PathEscape(ref), bb.header, &commit); err != nil {
+		return nil, err
+	}
+	author := &vcsv1.CommitAuthor{Login: commit.Author.Raw}
+	if commit.Author.User != nil {
+		author.Login = commit.Author.User.Nickname
+		author.AvatarURL = commit.Author.User.Links.Avatar.Href
+	}
+	return &vcsv1.GetCommitResponse{
+		Sha:     commit.Hash,
+		Message: commit.Message,
+		Author:  author,
+		Date:    commit.Date.Format(time.RFC3339),
+		URL:     commit.Links.HTML.Href,
+	}, nil
+}
+
+func (bb *bitbucketClient) GetFile(ctx context.Context, req FileRequest) (File, error) {
+	ref := req.Ref
+	// The src endpoint does not resolve HEAD.
+	if ref == "" || ref == "HEAD" {
+		var repository bitbucketRepository
+		if err := getJSON(ctx, bb.httpClient, bb.repoURL(req.Owner, req.Repo), bb.header, &repository); err != nil {
+			return File{}, err
+		}
+		ref = repository.MainBranch.Name
+	}
+	path := url.PathEscape(ref) + "/" + escapePath(req.Path)
+	content, err := get(ctx, bb.httpClient, bb.repoURL(req.Owner, req.Repo)+"/src/"+path, bb.header)
+	if err != nil {
+		return File{}, err
+	}
+	return File{
+		Content: string(content),
+		URL:     strings.Join([]string{bb.webURL, req.Owner, req.Repo, "src", path}, "/"),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_bitbucketClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repositories/workspace/repo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"mainbranch":{"name":"develop"}}`))
+	})
+	mux.HandleFunc("/repositories/workspace/repo/src/develop/pkg/main.go", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("package main"))
+	})
+	mux.HandleFunc("/repositories/workspace/repo/commit/abc", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"hash":"abc","message":"fix","date":"2024-01-02T03:04:05Z","author":{"raw":"Jane <jane@example.com>","user":{"nickname":"jane","links":{"avatar":{"href":"https://bitbucket.org/avatar/jane"}}}},"links":{"html":{"href":"https://bitbucket.org/workspace/repo/commits/abc"}}}`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	c := newBitbucketClient("https://bitbucket.org", s.URL, "secret", s.Client())
+
+	file, err := c.GetFile(context.Background(), FileRequest{Owner: "workspace", Repo: "repo", Path: "pkg/main.go", Ref: "HEAD"})
+	require.NoError(t, err)
+	require.Equal(t, "package main", file.Content)
+	require.Equal(t, "https://bitbucket.org/workspace/repo/src/develop/pkg/main.go", file.URL)
+
+	commit, err := c.GetCommit(context.Background(), "workspace", "repo", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "abc", commit.Sha)
+	require.Equal(t, "jane", commit.Author.Login)
+	require.Equal(t, "https://bitbucket.org/avatar/jane", commit.Author.AvatarURL)
+	require.Equal(t, "https://bitbucket.org/workspace/repo/commits/abc", commit.URL)
+}
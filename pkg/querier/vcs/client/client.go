@@ -1,11 +1,26 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"connectrpc.com/connect"
+	giturl "github.com/kubescape/go-git-url"
+	"github.com/kubescape/go-git-url/apis"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
 )
 
 var ErrNotFound = errors.New("file not found")
 
+// ProviderGitea is the provider name of Gitea repositories.
+// Gitea is not recognized by giturl and only selected for GITEA_URL.
+const ProviderGitea = "gitea"
+
 type File struct {
 	Content string
 	URL     string
@@ -17,3 +32,59 @@ type FileRequest struct {
 	Path  string
 	Ref   string
 }
+
+// Client is implemented by every supported VCS provider.
+type Client interface {
+	GetFile(ctx context.Context, req FileRequest) (File, error)
+	GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.GetCommitResponse, error)
+}
+
+// New returns the VCS client of the repository provider.
+func New(ctx context.Context, repo giturl.IGitURL, requestHeaders http.Header) (Client, error) {
+	switch repo.GetProvider() {
+	case apis.ProviderGitHub.String():
+		return GithubClient(ctx, requestHeaders)
+	case apis.ProviderGitLab.String():
+		return GitlabClient(ctx, requestHeaders)
+	case apis.ProviderBitBucket.String():
+		return BitbucketClient(), nil
+	case ProviderGitea:
+		return GiteaClient(), nil
+	}
+	return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported repository provider: %s", repo.GetProvider()))
+}
+
+// getJSON fetches the URL and decodes the JSON response into v.
+func getJSON(ctx context.Context, httpClient *http.Client, url string, header http.Header, v any) error {
+	body, err := get(ctx, httpClient, url, header)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// get fetches the URL and returns the response body.
+// ErrNotFound is returned if the server responds with 404.
+func get(ctx context.Context, httpClient *http.Client, url string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, url)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to fetch %s: %s", url, resp.Status))
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
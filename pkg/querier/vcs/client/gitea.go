@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+var (
+	// GiteaURL is the base URL of the Gitea instance.
+	// Repositories are only recognized as Gitea ones if it is set.
+	GiteaURL   = os.Getenv("GITEA_URL")
+	giteaToken = os.Getenv("GITEA_TOKEN")
+)
+
+// GiteaClient returns a gitea client authenticated with GITEA_TOKEN.
+// Only public repositories can be accessed if the token is not set.
+func GiteaClient() *giteaClient {
+	return newGiteaClient(GiteaURL, giteaToken, http.DefaultClient)
+}
+
+// giteaClient talks to the Gitea REST API v1.
+type giteaClient struct {
+	baseURL    string
+	header     http.Header
+	httpClient *http.Client
+}
+
+func newGiteaClient(baseURL, token string, httpClient *http.Client) *giteaClient {
+	header := make(http.Header)
+	if token != "" {
+		header.Set("Authorization", "token "+token)
+	}
+	return &giteaClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		header:     header,
+		httpClient: httpClient,
+	}
+}
+
+func (gt *giteaClient) repoURL(owner, repo string) string {
+	return gt.baseURL + "/api/v1/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+type giteaFile struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type giteaCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	Author *struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"author"`
+}
+
+func (gt *giteaClient) GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.GetCommitResponse, error) {
+	var commit giteaCommit
+	u := gt.repoURL(owner, repo) + "/git/commits/" + url.PathEscape(ref)
+	if err := getJSON(ctx, gt.httpClient, u, gt.header, &commit); err != nil {
+		return nil, err
+	}
+	author := &vcsv1.CommitAuthor{}
+	if commit.Author != nil {
+		author.Login = commit.Author.Login
+		author.AvatarURL = commit.Author.AvatarURL
+	}
+	return &vcsv1.GetCommitResponse{
+		Sha:     commit.SHA,
+		Message: commit.Commit.Message,
+		Author:  author,
+		Date:    commit.Commit.Author.Date.Format(time.RFC3339),
+		URL:     commit.HTMLURL,
+	}, nil
+}
+
+func (gt *giteaClient) GetFile(ctx context.Context, req FileRequest) (File, error) {
+	var file giteaFile
+	u := gt.repoURL(req.Owner, req.Repo) + "/contents/" + escapePath(req.Path)
+	// Gitea resolves an empty ref to the default branch.
+	if req.Ref != "" && req.Ref != "HEAD" {
+		u += "?ref=" + url.QueryEscape(req.Ref)
+	}
+	if err := getJSON(ctx, gt.httpClient, u, gt.header, &file); err != nil {
+		return File{}, err
+	}
+	// We only support files retrieval.
+	if file.Type != "file" {
+		return File{}, connect.NewError(connect.CodeInvalidArgument, errors.New("path is not a file"))
+	}
+	content, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil {
+		return File{}, err
+	}
+	return File{
+		Content: string(content),
+		URL:     file.HTMLURL,
+	}, nil
+}
+
+// escapePath escapes each segment of the slash separated path.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
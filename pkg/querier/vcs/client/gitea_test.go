@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_giteaClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/owner/repo/contents/pkg/main.go", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "token secret", r.Header.Get("Authorization"))
+		require.Empty(t, r.URL.Query().Get("ref"))
+		_, _ = w.Write([]byte(`{"type":"file","encoding":"base64","content":"` + base64.StdEncoding.EncodeToString([]byte("package main")) + `","html_url":"https://gitea.example.com/owner/repo/src/branch/main/pkg/main.go"}`))
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/contents/pkg", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"type":"dir"}`))
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/git/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sha":"abc","html_url":"https://gitea.example.com/owner/repo/commit/abc","commit":{"message":"fix","author":{"date":"2024-01-02T03:04:05Z"}},"author":{"login":"jane","avatar_url":"https://gitea.example.com/avatar/jane"}}`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	c := newGiteaClient(s.URL, "secret", s.Client())
+
+	file, err := c.GetFile(context.Background(), FileRequest{Owner: "owner", Repo: "repo", Path: "pkg/main.go", Ref: "HEAD"})
+	require.NoError(t, err)
+	require.Equal(t, "package main", file.Content)
+	require.Equal(t, "https://gitea.example.com/owner/repo/src/branch/main/pkg/main.go", file.URL)
+
+	_, err = c.GetFile(context.Background(), FileRequest{Owner: "owner", Repo: "repo", Path: "pkg"})
+	require.EqualError(t, err, "invalid_argument: path is not a file")
+
+	_, err = c.GetFile(context.Background(), FileRequest{Owner: "owner", Repo: "repo", Path: "missing.go"})
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	commit, err := c.GetCommit(context.Background(), "owner", "repo", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "abc", commit.Sha)
+	require.Equal(t, "fix", commit.Message)
+	require.Equal(t, "jane", commit.Author.Login)
+	require.Equal(t, "https://gitea.example.com/avatar/jane", commit.Author.AvatarURL)
+	require.Equal(t, "2024-01-02T03:04:05Z", commit.Date)
+}
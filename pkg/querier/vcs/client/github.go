@@ -48,57 +48,21 @@ func GithubClient(ctx context.Context, requestHeaders http.Header) (*githubClien
 	if err != nil {
 		return nil, err
 	}
-	cookie, err := (&http.Request{Header: requestHeaders}).Cookie(gitHubCookieName)
+	token, err := tokenFromCookie(requestHeaders, gitHubCookieName, githubSessionSecret)
 	if err != nil {
 		return nil, err
 	}
-	token, err := decryptToken(cookie.Value, githubSessionSecret)
-	if err != nil {
-		return nil, unAuthorizeError(err, cookie)
-	}
-	if !token.Valid() {
-		return nil, unAuthorizeError(errors.New("invalid or expired token"), cookie)
-	}
 	return &githubClient{
 		client: github.NewClient(auth.Client(ctx, token)),
 	}, nil
 }
 
-func unAuthorizeError(err error, cookie *http.Cookie) error {
-	connectErr := connect.NewError(
-		connect.CodeUnauthenticated,
-		err,
-	)
-	cookie.Value = ""
-	cookie.MaxAge = -1
-	connectErr.Meta().Set("Set-Cookie", cookie.String())
-	return connectErr
-}
-
 func AuthorizeGithub(ctx context.Context, authorizationCode string) (string, error) {
 	auth, err := githubOAuth()
 	if err != nil {
 		return "", err
 	}
-	token, err := auth.Exchange(ctx, authorizationCode)
-	if err != nil {
-		return "", err
-	}
-	cookieValue, err := encryptToken(token, githubSessionSecret)
-	if err != nil {
-		return "", err
-	}
-	// Sets a cookie with the encrypted token.
-	// Only the server can decrypt the cookie.
-	cookie := http.Cookie{
-		Name:     gitHubCookieName,
-		Value:    cookieValue,
-		Expires:  token.Expiry.Add(-10 * time.Second),
-		HttpOnly: false,
-		Secure:   true,
-		SameSite: http.SameSiteLaxMode,
-	}
-	return cookie.String(), nil
+	return authorize(ctx, auth, authorizationCode, gitHubCookieName, githubSessionSecret)
 }
 
 type githubClient struct {
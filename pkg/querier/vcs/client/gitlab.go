@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+var (
+	GitlabAppClientID     = os.Getenv("GITLAB_CLIENT_ID")
+	gitlabAppClientSecret = os.Getenv("GITLAB_CLIENT_SECRET")
+	gitlabSessionSecret   = []byte(os.Getenv("GITLAB_SESSION_SECRET"))
+	// GitlabURL is the base URL of the GitLab instance.
+	// Self-hosted instances are recognized by its host.
+	GitlabURL = envOrDefault("GITLAB_URL", "https://gitlab.com")
+)
+
+const (
+	gitlabCookieName = "GitlabSession"
+)
+
+// gitlabOAuth returns a gitlab oauth2 config.
+// Returns an error if the environment variables are not set.
+func gitlabOAuth(redirectURI string) (*oauth2.Config, error) {
+	if GitlabAppClientID == "" {
+		return nil, errors.New("missing GITLAB_CLIENT_ID environment variable")
+	}
+	if gitlabAppClientSecret == "" {
+		return nil, errors.New("missing GITLAB_CLIENT_SECRET environment variable")
+	}
+	baseURL := strings.TrimRight(GitlabURL, "/")
+	return &oauth2.Config{
+		ClientID:     GitlabAppClientID,
+		ClientSecret: gitlabAppClientSecret,
+		RedirectURL:  redirectURI,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  baseURL + "/oauth/authorize",
+			TokenURL: baseURL + "/oauth/token",
+		},
+	}, nil
+}
+
+// GitlabClient returns a gitlab client for the given request headers.
+func GitlabClient(ctx context.Context, requestHeaders http.Header) (*gitlabClient, error) {
+	auth, err := gitlabOAuth("")
+	if err != nil {
+		return nil, err
+	}
+	token, err := tokenFromCookie(requestHeaders, gitlabCookieName, gitlabSessionSecret)
+	if err != nil {
+		return nil, err
+	}
+	return newGitlabClient(GitlabURL, auth.Client(ctx, token)), nil
+}
+
+func AuthorizeGitlab(ctx context.Context, authorizationCode, redirectURI string) (string, error) {
+	auth, err := gitlabOAuth(redirectURI)
+	if err != nil {
+		return "", err
+	}
+	return authorize(ctx, auth, authorizationCode, gitlabCookieName, gitlabSessionSecret)
+}
+
+// gitlabClient talks to the GitLab REST API v4.
+type gitlabClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newGitlabClient(baseURL string, httpClient *http.Client) *gitlabClient {
+	return &gitlabClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// projectURL returns the API URL of the project, owner may include subgroups.
+func (gl *gitlabClient) projectURL(owner, repo string) string {
+	return gl.baseURL + "/api/v4/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+type gitlabFile struct {
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+type gitlabCommit struct {
+	ID           string    `json:"id"`
+	Message      string    `json:"message"`
+	AuthorName   string    `json:"author_name"`
+	AuthoredDate time.Time `json:"authored_date"`
+	WebURL       string    `json:"web_url"`
+}
+
+func (gl *gitlabClient) GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.GetCommitResponse, error) {
+	var commit gitlabCommit
+	u := gl.projectURL(owner, repo) + "/repository/commits/" + url.PathEscape(ref)
+	if err := getJSON(ctx, gl.httpClient, u, nil, &commit); err != nil {
+		return nil, err
+	}
+	return &vcsv1.GetCommitResponse{
+		Sha:     commit.ID,
+		Message: commit.Message,
+		Author: &vcsv1.CommitAuthor{
+			Login: commit.AuthorName,
+		},
+		Date: commit.AuthoredDate.Format(time.RFC3339),
+		URL:  commit.WebURL,
+	}, nil
+}
+
+func (gl *gitlabClient) GetFile(ctx context.Context, req FileRequest) (File, error) {
+	var file gitlabFile
+	u := gl.projectURL(req.Owner, req.Repo) + "/repository/files/" + url.PathEscape(req.Path) + "?ref=" + url.QueryEscape(req.Ref)
+	if err := getJSON(ctx, gl.httpClient, u, nil, &file); err != nil {
+		return File{}, err
+	}
+	content := []byte(file.Content)
+	if file.Encoding == "base64" {
+		var err error
+		if content, err = base64.StdEncoding.DecodeString(file.Content); err != nil {
+			return File{}, err
+		}
+	}
+	return File{
+		Content: string(content),
+		URL:     strings.Join([]string{gl.baseURL, req.Owner, req.Repo, "-/blob", req.Ref, req.Path}, "/"),
+	}, nil
+}
+
+func envOrDefault(key, value string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return value
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gitlabClient(t *testing.T) {
+	// GitLab expects URL-encoded project IDs and file paths.
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fsubgroup%2Frepo/repository/files/pkg%2Fmain.go":
+			require.Equal(t, "main", r.URL.Query().Get("ref"))
+			_, _ = w.Write([]byte(`{"encoding":"base64","content":"` + base64.StdEncoding.EncodeToString([]byte("package main")) + `"}`))
+		case "/api/v4/projects/group%2Fsubgroup%2Frepo/repository/commits/abc":
+			_, _ = w.Write([]byte(`{"id":"abc","message":"fix","author_name":"jane","authored_date":"2024-01-02T03:04:05Z","web_url":"https://gitlab.example.com/c/abc"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer s.Close()
+	c := newGitlabClient(s.URL, s.Client())
+
+	file, err := c.GetFile(context.Background(), FileRequest{Owner: "group/subgroup", Repo: "repo", Path: "pkg/main.go", Ref: "main"})
+	require.NoError(t, err)
+	require.Equal(t, "package main", file.Content)
+	require.Equal(t, s.URL+"/group/subgroup/repo/-/blob/main/pkg/main.go", file.URL)
+
+	_, err = c.GetFile(context.Background(), FileRequest{Owner: "group/subgroup", Repo: "repo", Path: "missing.go", Ref: "main"})
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	commit, err := c.GetCommit(context.Background(), "group/subgroup", "repo", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "abc", commit.Sha)
+	require.Equal(t, "fix", commit.Message)
+	require.Equal(t, "jane", commit.Author.Login)
+	require.Equal(t, "2024-01-02T03:04:05Z", commit.Date)
+	require.Equal(t, "https://gitlab.example.com/c/abc", commit.URL)
+}
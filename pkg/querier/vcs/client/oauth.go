@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/oauth2"
+)
+
+// tokenFromCookie decrypts the OAuth token stored in the session cookie.
+func tokenFromCookie(requestHeaders http.Header, cookieName string, secret []byte) (*oauth2.Token, error) {
+	cookie, err := (&http.Request{Header: requestHeaders}).Cookie(cookieName)
+	if err != nil {
+		return nil, err
+	}
+	token, err := decryptToken(cookie.Value, secret)
+	if err != nil {
+		return nil, unAuthorizeError(err, cookie)
+	}
+	if !token.Valid() {
+		return nil, unAuthorizeError(errors.New("invalid or expired token"), cookie)
+	}
+	return token, nil
+}
+
+func unAuthorizeError(err error, cookie *http.Cookie) error {
+	connectErr := connect.NewError(
+		connect.CodeUnauthenticated,
+		err,
+	)
+	cookie.Value = ""
+	cookie.MaxAge = -1
+	connectErr.Meta().Set("Set-Cookie", cookie.String())
+	return connectErr
+}
+
+// authorize exchanges the authorization code for an OAuth token and
+// returns the session cookie holding the encrypted token.
+func authorize(ctx context.Context, auth *oauth2.Config, authorizationCode, cookieName string, secret []byte) (string, error) {
+	token, err := auth.Exchange(ctx, authorizationCode)
+	if err != nil {
+		return "", err
+	}
+	cookieValue, err := encryptToken(token, secret)
+	if err != nil {
+		return "", err
+	}
+	// Sets a cookie with the encrypted token.
+	// Only the server can decrypt the cookie.
+	cookie := http.Cookie{
+		Name:     cookieName,
+		Value:    cookieValue,
+		Expires:  token.Expiry.Add(-10 * time.Second),
+		HttpOnly: false,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	return cookie.String(), nil
+}
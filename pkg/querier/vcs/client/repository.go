@@ -0,0 +1,77 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+
+	giturl "github.com/kubescape/go-git-url"
+	"github.com/kubescape/go-git-url/apis"
+	gitlabparserv1 "github.com/kubescape/go-git-url/gitlabparser/v1"
+)
+
+// ParseRepositoryURL parses the repository URL and detects its provider.
+// In addition to the hosts known to giturl, repositories hosted on the
+// instances configured with GITLAB_URL and GITEA_URL are recognized.
+func ParseRepositoryURL(repositoryURL string) (giturl.IGitURL, error) {
+	host := hostname(repositoryURL)
+	switch {
+	case host != "" && host == hostname(GiteaURL):
+		return parseSelfHostedURL(repositoryURL, host, ProviderGitea)
+	case host != "" && host == hostname(GitlabURL):
+		return parseSelfHostedURL(repositoryURL, host, apis.ProviderGitLab.String())
+	}
+	return giturl.NewGitURL(repositoryURL)
+}
+
+// selfHostedURL overrides the host and the provider of a parsed URL:
+// giturl parsers assume the hosted version of the provider.
+type selfHostedURL struct {
+	giturl.IGitURL
+	host     string
+	provider string
+}
+
+func parseSelfHostedURL(repositoryURL, host, provider string) (giturl.IGitURL, error) {
+	// GitLab URLs are the most generic ones: the owner may include
+	// subgroups, which is also a valid superset of Gitea URLs.
+	u, err := gitlabparserv1.NewGitLabParserWithURL(repositoryURL)
+	if err != nil {
+		return nil, err
+	}
+	return &selfHostedURL{IGitURL: u, host: host, provider: provider}, nil
+}
+
+func (u *selfHostedURL) GetHostName() string { return u.host }
+func (u *selfHostedURL) GetProvider() string { return u.provider }
+
+func (u *selfHostedURL) GetURL() *url.URL {
+	return &url.URL{
+		Scheme: "https",
+		Host:   u.host,
+		Path:   u.GetOwnerName() + "/" + u.GetRepoName(),
+	}
+}
+
+func (u *selfHostedURL) GetHttpCloneURL() string {
+	return u.GetURL().String() + ".git"
+}
+
+// hostname returns the host of the URL, if any. Both HTTP(S) and SSH
+// (git@host:owner/repo) forms are accepted.
+func hostname(s string) string {
+	if s == "" {
+		return ""
+	}
+	if i := strings.Index(s, "://"); i < 0 {
+		if at := strings.Index(s, "@"); at >= 0 {
+			s = "ssh://" + strings.Replace(s[at+1:], ":", "/", 1)
+		} else {
+			s = "https://" + s
+		}
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepositoryURL(t *testing.T) {
+	defer func(gitlab, gitea string) { GitlabURL, GiteaURL = gitlab, gitea }(GitlabURL, GiteaURL)
+	GitlabURL = "https://gitlab.example.com"
+	GiteaURL = "https://gitea.example.com/"
+
+	for _, tc := range []struct {
+		url                   string
+		provider, host        string
+		owner, repo, cloneURL string
+	}{
+		{
+			url:      "https://github.com/grafana/pyroscope",
+			provider: "github", host: "github.com",
+			owner: "grafana", repo: "pyroscope",
+		},
+		{
+			url:      "https://gitlab.com/group/subgroup/repo",
+			provider: "gitlab", host: "gitlab.com",
+			owner: "group/subgroup", repo: "repo",
+		},
+		{
+			url:      "https://gitlab.example.com/group/subgroup/repo.git",
+			provider: "gitlab", host: "gitlab.example.com",
+			owner: "group/subgroup", repo: "repo",
+			cloneURL: "https://gitlab.example.com/group/subgroup/repo.git",
+		},
+		{
+			url:      "git@gitea.example.com:owner/repo.git",
+			provider: "gitea", host: "gitea.example.com",
+			owner: "owner", repo: "repo",
+			cloneURL: "https://gitea.example.com/owner/repo.git",
+		},
+		{
+			url:      "https://bitbucket.org/workspace/repo",
+			provider: "bitbucket", host: "bitbucket.org",
+			owner: "workspace", repo: "repo",
+		},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			u, err := ParseRepositoryURL(tc.url)
+			require.NoError(t, err)
+			require.Equal(t, tc.provider, u.GetProvider())
+			require.Equal(t, tc.host, u.GetHostName())
+			require.Equal(t, tc.owner, u.GetOwnerName())
+			require.Equal(t, tc.repo, u.GetRepoName())
+			if tc.cloneURL != "" {
+				require.Equal(t, tc.cloneURL, u.GetHttpCloneURL())
+			}
+		})
+	}
+
+	_, err := ParseRepositoryURL("https://git.example.com/owner/repo")
+	require.Error(t, err)
+}
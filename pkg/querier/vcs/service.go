@@ -8,8 +8,6 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
-	giturl "github.com/kubescape/go-git-url"
-	"github.com/kubescape/go-git-url/apis"
 
 	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
 	vcsv1connect "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1/vcsv1connect"
@@ -45,24 +43,36 @@ func (q *Service) GithubLogin(ctx context.Context, req *connect.Request[vcsv1.Gi
 	return resp, nil
 }
 
+func (q *Service) GitlabApp(ctx context.Context, req *connect.Request[vcsv1.GitlabAppRequest]) (*connect.Response[vcsv1.GitlabAppResponse], error) {
+	return connect.NewResponse(&vcsv1.GitlabAppResponse{
+		ClientID: client.GitlabAppClientID,
+		BaseURL:  client.GitlabURL,
+	}), nil
+}
+
+func (q *Service) GitlabLogin(ctx context.Context, req *connect.Request[vcsv1.GitlabLoginRequest]) (*connect.Response[vcsv1.GitlabLoginResponse], error) {
+	resp := connect.NewResponse(&vcsv1.GitlabLoginResponse{})
+	cookie, err := client.AuthorizeGitlab(ctx, req.Msg.AuthorizationCode, req.Msg.RedirectURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize gitlab: %w", err)
+	}
+	resp.Msg.Cookie = cookie
+	return resp, nil
+}
+
 func (q *Service) GetFile(ctx context.Context, req *connect.Request[vcsv1.GetFileRequest]) (*connect.Response[vcsv1.GetFileResponse], error) {
 	// initialize and parse the git repo URL
-	gitURL, err := giturl.NewGitURL(req.Msg.RepositoryURL)
+	gitURL, err := client.ParseRepositoryURL(req.Msg.RepositoryURL)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	if gitURL.GetProvider() != apis.ProviderGitHub.String() {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("only GitHub repositories are supported"))
-	}
-
-	// todo: we can support multiple provider: bitbucket, gitlab, etc.
-	ghClient, err := client.GithubClient(ctx, req.Header())
+	vcsClient, err := client.New(ctx, gitURL, req.Header())
 	if err != nil {
 		return nil, err
 	}
 	file, err := source.
 		NewFileFinder(
-			ghClient,
+			vcsClient,
 			gitURL,
 			req.Msg.LocalPath,
 			req.Msg.Ref,
@@ -79,19 +89,19 @@ func (q *Service) GetFile(ctx context.Context, req *connect.Request[vcsv1.GetFil
 }
 
 func (q *Service) GetCommit(ctx context.Context, req *connect.Request[vcsv1.GetCommitRequest]) (*connect.Response[vcsv1.GetCommitResponse], error) {
-	gitURL, err := giturl.NewGitURL(req.Msg.RepositoryURL)
+	gitURL, err := client.ParseRepositoryURL(req.Msg.RepositoryURL)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	if gitURL.GetProvider() != apis.ProviderGitHub.String() {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("only GitHub repositories are supported"))
-	}
-	ghClient, err := client.GithubClient(ctx, req.Header())
+	vcsClient, err := client.New(ctx, gitURL, req.Header())
 	if err != nil {
 		return nil, err
 	}
-	commit, err := ghClient.GetCommit(ctx, gitURL.GetOwnerName(), gitURL.GetRepoName(), req.Msg.Ref)
+	commit, err := vcsClient.GetCommit(ctx, gitURL.GetOwnerName(), gitURL.GetRepoName(), req.Msg.Ref)
 	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
 		return nil, err
 	}
 	return connect.NewResponse(commit), nil
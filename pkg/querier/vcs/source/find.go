@@ -1,12 +1,19 @@
 package source
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
@@ -16,6 +23,12 @@ import (
 	"github.com/grafana/pyroscope/pkg/querier/vcs/client"
 )
 
+const (
+	maxJSONSize    = 16 << 20
+	maxArchiveSize = 128 << 20
+	maxFileSize    = 16 << 20
+)
+
 type VCSClient interface {
 	GetFile(ctx context.Context, req client.FileRequest) (client.File, error)
 }
@@ -50,7 +63,12 @@ func (ff FileFinder) Find(ctx context.Context) (*vcsv1.GetFileResponse, error) {
 	switch filepath.Ext(ff.path) {
 	case ExtGo:
 		return ff.findGoFile(ctx)
-	// todo: add more languages support
+	case ExtPython:
+		return ff.findPythonFile(ctx)
+	case ExtJava:
+		return ff.findJavaFile(ctx)
+	case ExtRust:
+		return ff.findRustFile(ctx)
 	default:
 		// by default we return the file content at the given path without any processing.
 		content, err := ff.fetchRepoFile(ctx, ff.path, ff.ref)
@@ -75,6 +93,62 @@ func (arg FileFinder) fetchRepoFile(ctx context.Context, path, ref string) (*vcs
 	return newFileResponse(content.Content, content.URL)
 }
 
+// tryFindFile tries to find the file in the repo.
+// It tries to find the file in the repo by removing path segment after path segment.
+// maxAttempts is the maximum number of attempts to try to find the file in case the file path is very long.
+// For example, if the path is "github.com/grafana/grafana/pkg/infra/log/log.go", it will try to find the file at:
+// - github.com/grafana/grafana/pkg/infra/log/log.go
+// - grafana/grafana/pkg/infra/log/log.go
+// - pkg/infra/log/log.go
+// - infra/log/log.go
+// - log/log.go
+// - log.go
+func (ff FileFinder) tryFindFile(ctx context.Context, maxAttempts int) (*vcsv1.GetFileResponse, error) {
+	if maxAttempts <= 0 {
+		return nil, errors.New("invalid max attempts")
+	}
+	// Try to find the file in the repo.
+	path := strings.TrimPrefix(ff.path, strings.Join([]string{ff.repo.GetHostName(), ff.repo.GetOwnerName(), ff.repo.GetRepoName()}, "/"))
+	path = strings.TrimLeft(path, "/")
+	attempts := 0
+	for {
+		content, err := ff.client.GetFile(ctx, client.FileRequest{
+			Owner: ff.repo.GetOwnerName(),
+			Repo:  ff.repo.GetRepoName(),
+			Path:  path,
+			Ref:   ff.ref,
+		})
+		attempts++
+		if err != nil && errors.Is(err, client.ErrNotFound) && attempts < maxAttempts {
+			i := strings.Index(path, "/")
+			if i < 0 {
+				return nil, err
+			}
+			// remove the first path segment
+			path = path[i+1:]
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return newFileResponse(content.Content, content.URL)
+	}
+}
+
+// fetchFirstRepoFile fetches the first file found in the configured
+// repository among the given paths.
+func (ff FileFinder) fetchFirstRepoFile(ctx context.Context, paths ...string) (*vcsv1.GetFileResponse, error) {
+	err := error(client.ErrNotFound)
+	for _, path := range paths {
+		var file *vcsv1.GetFileResponse
+		file, err = ff.fetchRepoFile(ctx, path, ff.ref)
+		if err == nil || !errors.Is(err, client.ErrNotFound) {
+			return file, err
+		}
+	}
+	return nil, err
+}
+
 // fetchURL fetches the file content from the given URL.
 func (ff FileFinder) fetchURL(ctx context.Context, url string, decodeBase64 bool) (*vcsv1.GetFileResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -103,6 +177,156 @@ func (ff FileFinder) fetchURL(ctx context.Context, url string, decodeBase64 bool
 	return newFileResponse(string(decoded), url)
 }
 
+// fetchJSON fetches the given URL and decodes the JSON response into v.
+func (ff FileFinder) fetchJSON(ctx context.Context, url string, v any) error {
+	body, err := ff.get(ctx, url, maxJSONSize)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// fetchArchiveFile fetches the archive at the given URL and returns the
+// content of the first file the match function accepts. Zip archives
+// (.zip, .jar) and gzipped tarballs (.tar.gz, .tgz, .crate) are supported.
+// The returned response references sourceURL.
+//
+// Archives are not held in memory: tarballs are read as they are
+// downloaded, while zip archives, which can't be read sequentially,
+// are downloaded to a temporary file.
+func (ff FileFinder) fetchArchiveFile(ctx context.Context, archiveURL, sourceURL string, match func(name string) bool) (*vcsv1.GetFileResponse, error) {
+	ext := filepath.Ext(archiveURL)
+	switch ext {
+	case ".zip", ".jar", ".gz", ".tgz", ".crate":
+	default:
+		return nil, fmt.Errorf("unsupported archive format: %s", ext)
+	}
+	body, err := ff.open(ctx, archiveURL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	archive := &sizeLimitedReader{r: body, limit: maxArchiveSize, url: archiveURL}
+	var content []byte
+	switch ext {
+	case ".zip", ".jar":
+		content, err = zipFile(archive, match)
+	default:
+		content, err = tarGzFile(archive, match)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("file not found in %s", archiveURL))
+	}
+	return newFileResponse(string(content), sourceURL)
+}
+
+func zipFile(archive io.Reader, match func(name string) bool) ([]byte, error) {
+	tmp, err := os.CreateTemp("", "vcs-archive-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+	size, err := io.Copy(tmp, archive)
+	if err != nil {
+		return nil, err
+	}
+	r, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || !match(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(io.LimitReader(rc, maxFileSize))
+	}
+	return nil, nil
+}
+
+func tarGzFile(archive io.Reader, match func(name string) bool) ([]byte, error) {
+	gz, err := gzip.NewReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	r := tar.NewReader(gz)
+	for {
+		h, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg || !match(h.Name) {
+			continue
+		}
+		return io.ReadAll(io.LimitReader(r, maxFileSize))
+	}
+}
+
+// sizeLimitedReader fails once more than limit bytes are read.
+type sizeLimitedReader struct {
+	r     io.Reader
+	read  int64
+	limit int64
+	url   string
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	if l.read += int64(n); l.read > l.limit {
+		return n, fmt.Errorf("response of %s exceeds %d bytes", l.url, l.limit)
+	}
+	return n, err
+}
+
+// get fetches the given URL, reading at most limit bytes of the response.
+func (ff FileFinder) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	body, err := ff.open(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	content, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("response of %s exceeds %d bytes", url, limit)
+	}
+	return content, nil
+}
+
+// open fetches the given URL and returns the response body,
+// which must be closed by the caller.
+func (ff FileFinder) open(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ff.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("failed to fetch %s: %s", url, resp.Status))
+	}
+	return resp.Body, nil
+}
+
 func newFileResponse(content, url string) (*vcsv1.GetFileResponse, error) {
 	return &vcsv1.GetFileResponse{
 		Content: base64.StdEncoding.EncodeToString([]byte(content)),
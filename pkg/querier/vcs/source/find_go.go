@@ -2,13 +2,12 @@ package source
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"path"
-	"strings"
 	"time"
 
 	"github.com/go-kit/log/level"
+	"github.com/kubescape/go-git-url/apis"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/module"
 
@@ -46,7 +45,7 @@ func (ff FileFinder) findGoFile(ctx context.Context) (*vcsv1.GetFileResponse, er
 		}
 		return ff.fetchGoDependencyFile(ctx, modFile)
 	}
-	return ff.tryFindGoFile(ctx, 30)
+	return ff.tryFindFile(ctx, 30)
 }
 
 func (ff FileFinder) fetchGoMod(ctx context.Context) (*modfile.File, error) {
@@ -73,12 +72,14 @@ func (ff FileFinder) fetchGoDependencyFile(ctx context.Context, module golang.Mo
 }
 
 func (ff FileFinder) fetchGithubModuleFile(ctx context.Context, mod golang.Module) (*vcsv1.GetFileResponse, error) {
-	// todo: what if this is not a github repo?
-	// 		VSClient should support querying multiple repo providers.
 	githubFile, err := mod.GithubFile()
 	if err != nil {
 		return nil, err
 	}
+	if ff.repo.GetProvider() != apis.ProviderGitHub.String() {
+		// The client can't query GitHub: fallback to the raw content of public repositories.
+		return ff.fetchURL(ctx, githubFile.RawURL(), false)
+	}
 	content, err := ff.client.GetFile(ctx, client.FileRequest{
 		Owner: githubFile.Owner,
 		Repo:  githubFile.Repo,
@@ -98,45 +99,3 @@ func (ff FileFinder) fetchGoogleSourceDependencyFile(ctx context.Context, mod go
 	}
 	return ff.fetchURL(ctx, url, true)
 }
-
-// tryFindGoFile tries to find the go file in the repo.
-// It tries to find the file in the repo by removing path segment after path segment.
-// maxAttempts is the maximum number of attempts to try to find the file in case the file path is very long.
-// For example, if the path is "github.com/grafana/grafana/pkg/infra/log/log.go", it will try to find the file at:
-// - github.com/grafana/grafana/pkg/infra/log/log.go
-// - grafana/grafana/pkg/infra/log/log.go
-// - pkg/infra/log/log.go
-// - infra/log/log.go
-// - log/log.go
-// - log.go
-func (ff FileFinder) tryFindGoFile(ctx context.Context, maxAttempts int) (*vcsv1.GetFileResponse, error) {
-	if maxAttempts <= 0 {
-		return nil, errors.New("invalid max attempts")
-	}
-	// Try to find the file in the repo.
-	path := strings.TrimPrefix(ff.path, strings.Join([]string{ff.repo.GetHostName(), ff.repo.GetOwnerName(), ff.repo.GetRepoName()}, "/"))
-	path = strings.TrimLeft(path, "/")
-	attempts := 0
-	for {
-		content, err := ff.client.GetFile(ctx, client.FileRequest{
-			Owner: ff.repo.GetOwnerName(),
-			Repo:  ff.repo.GetRepoName(),
-			Path:  path,
-			Ref:   ff.ref,
-		})
-		attempts++
-		if err != nil && errors.Is(err, client.ErrNotFound) && attempts < maxAttempts {
-			i := strings.Index(path, "/")
-			if i < 0 {
-				return nil, err
-			}
-			// remove the first path segment
-			path = path[i+1:]
-			continue
-		}
-		if err != nil {
-			return nil, err
-		}
-		return newFileResponse(content.Content, content.URL)
-	}
-}
@@ -0,0 +1,125 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log/level"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+	"github.com/grafana/pyroscope/pkg/querier/vcs/client"
+	"github.com/grafana/pyroscope/pkg/querier/vcs/source/java"
+)
+
+const (
+	ExtJava = ".java"
+
+	mavenSearchURL = "https://search.maven.org/solrsearch/select"
+	// mavenSearchRows is the maximum number of artifacts
+	// containing a class that are matched with the project.
+	mavenSearchRows = 50
+)
+
+type mavenSearchResponse struct {
+	Response struct {
+		Docs []struct {
+			GroupID    string `json:"g"`
+			ArtifactID string `json:"a"`
+			Version    string `json:"v"`
+			Timestamp  int64  `json:"timestamp"`
+		} `json:"docs"`
+	} `json:"response"`
+}
+
+// findJavaFile finds a java file in a vcs repository or in the Maven
+// Central sources jar of the artifact declaring the class.
+func (ff FileFinder) findJavaFile(ctx context.Context) (*vcsv1.GetFileResponse, error) {
+	if url, ok := java.StandardLibraryURL(ff.path); ok {
+		return ff.fetchURL(ctx, url, false)
+	}
+
+	sourcePath := java.SourcePath(ff.path)
+	file, err := ff.fetchFirstRepoFile(ctx, "src/main/java/"+sourcePath, sourcePath)
+	if err == nil || !errors.Is(err, client.ErrNotFound) {
+		return file, err
+	}
+	artifact, err := ff.findMavenArtifact(ctx, java.ClassName(ff.path))
+	if err != nil {
+		return nil, err
+	}
+	jarURL := artifact.SourcesJarURL()
+	return ff.fetchArchiveFile(ctx, jarURL, jarURL, func(name string) bool {
+		return name == sourcePath
+	})
+}
+
+// findMavenArtifact searches Maven Central for the artifacts containing the
+// given class, and returns the one the project depends on: its groupId,
+// artifactId and version are taken from the dependencies declared in the
+// project file of the repository. If the project doesn't declare any of the
+// artifacts, the latest version is used only if all of them have the same
+// groupId and artifactId, as the class could belong to an unrelated one.
+func (ff FileFinder) findMavenArtifact(ctx context.Context, className string) (java.Artifact, error) {
+	query := url.Values{
+		"q":    []string{fmt.Sprintf("fc:%q", className)},
+		"rows": []string{strconv.Itoa(mavenSearchRows)},
+		"wt":   []string{"json"},
+	}
+	var resp mavenSearchResponse
+	if err := ff.fetchJSON(ctx, mavenSearchURL+"?"+query.Encode(), &resp); err != nil {
+		return java.Artifact{}, err
+	}
+	docs := resp.Response.Docs
+	if len(docs) == 0 {
+		return java.Artifact{}, connect.NewError(connect.CodeNotFound, fmt.Errorf("no maven artifact found for class %s", className))
+	}
+
+	dependencies, err := ff.fetchPOMDependencies(ctx)
+	if err != nil {
+		level.Warn(ff.logger).Log("msg", "failed to fetch maven project file", "err", err)
+	}
+	for _, d := range dependencies {
+		for _, doc := range docs {
+			if doc.GroupID == d.GroupID && doc.ArtifactID == d.ArtifactID && doc.Version == d.Version {
+				return d, nil
+			}
+		}
+	}
+	// The declared version may not be among the search results.
+	for _, d := range dependencies {
+		for _, doc := range docs {
+			if doc.GroupID == d.GroupID && doc.ArtifactID == d.ArtifactID {
+				return d, nil
+			}
+		}
+	}
+
+	latest := docs[0]
+	for _, doc := range docs[1:] {
+		if doc.GroupID != latest.GroupID || doc.ArtifactID != latest.ArtifactID {
+			return java.Artifact{}, connect.NewError(connect.CodeNotFound,
+				fmt.Errorf("class %s is provided by multiple maven artifacts, none of which is a dependency of the project", className))
+		}
+		if doc.Timestamp > latest.Timestamp {
+			latest = doc
+		}
+	}
+	return java.Artifact{GroupID: latest.GroupID, ArtifactID: latest.ArtifactID, Version: latest.Version}, nil
+}
+
+func (ff FileFinder) fetchPOMDependencies(ctx context.Context) ([]java.Artifact, error) {
+	content, err := ff.client.GetFile(ctx, client.FileRequest{
+		Owner: ff.repo.GetOwnerName(),
+		Repo:  ff.repo.GetRepoName(),
+		Path:  java.POM,
+		Ref:   ff.ref,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return java.ParsePOMDependencies([]byte(content.Content))
+}
@@ -0,0 +1,94 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log/level"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+	"github.com/grafana/pyroscope/pkg/querier/vcs/client"
+	"github.com/grafana/pyroscope/pkg/querier/vcs/source/python"
+)
+
+const (
+	ExtPython = ".py"
+
+	pypiURL = "https://pypi.org/pypi"
+)
+
+type pypiRelease struct {
+	Info struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"info"`
+	URLs []struct {
+		PackageType string `json:"packagetype"`
+		URL         string `json:"url"`
+	} `json:"urls"`
+}
+
+// findPythonFile finds a python file in a vcs repository or in the
+// source distribution of the package that installed it.
+func (ff FileFinder) findPythonFile(ctx context.Context) (*vcsv1.GetFileResponse, error) {
+	if url, ok := python.StandardLibraryURL(ff.path); ok {
+		return ff.fetchURL(ctx, url, false)
+	}
+
+	pkg, ok := python.ParseSitePackagesPath(ff.path)
+	if !ok {
+		return ff.tryFindFile(ctx, 30)
+	}
+	// The package may be the service itself, installed from the repository.
+	file, err := ff.fetchFirstRepoFile(ctx, pkg.FilePath, "src/"+pkg.FilePath)
+	if err == nil || !errors.Is(err, client.ErrNotFound) {
+		return file, err
+	}
+	return ff.fetchPythonPackageFile(ctx, pkg)
+}
+
+func (ff FileFinder) fetchPythonPackageFile(ctx context.Context, pkg python.Package) (*vcsv1.GetFileResponse, error) {
+	distribution := pkg.Distribution()
+	releaseURL := fmt.Sprintf("%s/%s/json", pypiURL, url.PathEscape(distribution))
+	requirements, err := ff.fetchRequirements(ctx)
+	if err != nil {
+		level.Warn(ff.logger).Log("msg", "failed to fetch requirements file", "err", err)
+	}
+	if version, ok := requirements[python.NormalizeName(distribution)]; ok {
+		releaseURL = fmt.Sprintf("%s/%s/%s/json", pypiURL, url.PathEscape(distribution), url.PathEscape(version))
+	}
+
+	var release pypiRelease
+	if err := ff.fetchJSON(ctx, releaseURL, &release); err != nil {
+		return nil, err
+	}
+	for _, u := range release.URLs {
+		if u.PackageType != "sdist" {
+			continue
+		}
+		return ff.fetchArchiveFile(ctx, u.URL, u.URL, func(name string) bool {
+			// Source distributions have a single top-level directory,
+			// and packages are either at its root or in a src directory.
+			_, name, _ = strings.Cut(name, "/")
+			return name == pkg.FilePath || name == "src/"+pkg.FilePath
+		})
+	}
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no source distribution for %s %s", release.Info.Name, release.Info.Version))
+}
+
+func (ff FileFinder) fetchRequirements(ctx context.Context) (map[string]string, error) {
+	content, err := ff.client.GetFile(ctx, client.FileRequest{
+		Owner: ff.repo.GetOwnerName(),
+		Repo:  ff.repo.GetRepoName(),
+		Path:  python.Requirements,
+		Ref:   ff.ref,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return python.ParseRequirements(content.Content), nil
+}
@@ -0,0 +1,28 @@
+package source
+
+import (
+	"context"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+	"github.com/grafana/pyroscope/pkg/querier/vcs/source/rust"
+)
+
+const (
+	ExtRust = ".rs"
+)
+
+// findRustFile finds a rust file in a vcs repository or in the crates.io
+// archive of the crate it belongs to.
+func (ff FileFinder) findRustFile(ctx context.Context) (*vcsv1.GetFileResponse, error) {
+	if url, ok := rust.StandardLibraryURL(ff.path); ok {
+		return ff.fetchURL(ctx, url, false)
+	}
+
+	if crate, ok := rust.ParseCrateFromPath(ff.path); ok {
+		archivePath := crate.ArchivePath()
+		return ff.fetchArchiveFile(ctx, crate.DownloadURL(), crate.SourceURL(), func(name string) bool {
+			return name == archivePath
+		})
+	}
+	return ff.tryFindFile(ctx, 30)
+}
@@ -0,0 +1,193 @@
+package source
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	giturl "github.com/kubescape/go-git-url"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/querier/vcs/client"
+)
+
+type repoMock map[string]string
+
+func (m repoMock) GetFile(_ context.Context, req client.FileRequest) (client.File, error) {
+	content, ok := m[req.Path]
+	if !ok {
+		return client.File{}, client.ErrNotFound
+	}
+	return client.File{Content: content, URL: "repo/" + req.Path}, nil
+}
+
+// redirectTransport sends every request to the test server, keeping the
+// original host in the path so handlers can tell them apart.
+type redirectTransport struct{ server *url.URL }
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Path = "/" + req.URL.Host + req.URL.Path
+	req.URL.Scheme = t.server.Scheme
+	req.URL.Host = t.server.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestFileFinder(t *testing.T, repo repoMock, path string, handler http.Handler) *FileFinder {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	gitURL, err := giturl.NewGitURL("https://github.com/grafana/app")
+	require.NoError(t, err)
+	httpClient := &http.Client{Transport: redirectTransport{server: serverURL}}
+	return NewFileFinder(repo, gitURL, path, "main", httpClient, log.NewNopLogger())
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func requireContent(t *testing.T, expected string, actual string) {
+	t.Helper()
+	decoded, err := base64.StdEncoding.DecodeString(actual)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(decoded))
+}
+
+func Test_FindRustFile(t *testing.T) {
+	archive := tarGz(t, map[string]string{
+		"tokio-1.35.1/Cargo.toml": "[package]",
+		"tokio-1.35.1/src/lib.rs": "// tokio",
+	})
+	ff := newTestFileFinder(t, repoMock{"src/main.rs": "fn main() {}"},
+		"/root/.cargo/registry/src/index.crates.io-6f17d22bba15001f/tokio-1.35.1/src/lib.rs",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/static.crates.io/crates/tokio/tokio-1.35.1.crate", r.URL.Path)
+			_, _ = w.Write(archive)
+		}))
+	file, err := ff.Find(context.Background())
+	require.NoError(t, err)
+	requireContent(t, "// tokio", file.Content)
+	require.Equal(t, "https://docs.rs/crate/tokio/1.35.1/source/src/lib.rs", file.URL)
+
+	ff.path = "/build/app/src/main.rs"
+	file, err = ff.Find(context.Background())
+	require.NoError(t, err)
+	requireContent(t, "fn main() {}", file.Content)
+}
+
+func Test_FindPythonFile(t *testing.T) {
+	sdist := tarGz(t, map[string]string{
+		"PyYAML-6.0.1/setup.py":             "setup()",
+		"PyYAML-6.0.1/lib/yaml/loader.py":   "# wrong",
+		"PyYAML-6.0.1/src/yaml/loader.py":   "# loader",
+		"PyYAML-6.0.1/tests/yaml/loader.py": "# test",
+	})
+	repo := repoMock{
+		"requirements.txt": "pyyaml==6.0.1\n",
+		"src/app/main.py":  "# app",
+	}
+	ff := newTestFileFinder(t, repo,
+		"/usr/local/lib/python3.11/site-packages/yaml/loader.py",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/pypi.org/pypi/PyYAML/6.0.1/json":
+				_, _ = w.Write([]byte(`{"info":{"name":"PyYAML","version":"6.0.1"},"urls":[
+					{"packagetype":"bdist_wheel","url":"https://files.pythonhosted.org/PyYAML-6.0.1-py3-none-any.whl"},
+					{"packagetype":"sdist","url":"https://files.pythonhosted.org/PyYAML-6.0.1.tar.gz"}]}`))
+			case "/files.pythonhosted.org/PyYAML-6.0.1.tar.gz":
+				_, _ = w.Write(sdist)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+	file, err := ff.Find(context.Background())
+	require.NoError(t, err)
+	requireContent(t, "# loader", file.Content)
+	require.Equal(t, "https://files.pythonhosted.org/PyYAML-6.0.1.tar.gz", file.URL)
+
+	// Packages installed from the repository are found in the repository.
+	ff.path = "/usr/local/lib/python3.11/site-packages/app/main.py"
+	file, err = ff.Find(context.Background())
+	require.NoError(t, err)
+	requireContent(t, "# app", file.Content)
+	require.Equal(t, "repo/src/app/main.py", file.URL)
+}
+
+func Test_FindJavaFile(t *testing.T) {
+	jar := zipArchive(t, map[string]string{
+		"META-INF/MANIFEST.MF":                      "Manifest-Version: 1.0",
+		"org/apache/commons/lang3/StringUtils.java": "// StringUtils",
+	})
+	repo := repoMock{
+		"src/main/java/com/example/App.java": "// App",
+		"pom.xml": `<project><dependencies><dependency>
+			<groupId>org.apache.commons</groupId><artifactId>commons-lang3</artifactId><version>3.12.0</version>
+			</dependency></dependencies></project>`,
+	}
+	ff := newTestFileFinder(t, repo,
+		"org/apache/commons/lang3/StringUtils.java",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/search.maven.org/solrsearch/select":
+				require.Equal(t, `fc:"org.apache.commons.lang3.StringUtils"`, r.URL.Query().Get("q"))
+				_, _ = w.Write([]byte(`{"response":{"docs":[
+					{"g":"org.apache.commons","a":"commons-lang3","v":"3.14.0","timestamp":3},
+					{"g":"org.apache.commons","a":"commons-lang3","v":"3.12.0","timestamp":2},
+					{"g":"com.example.shaded","a":"uber","v":"1.0.0","timestamp":1}]}}`))
+			case "/repo1.maven.org/maven2/org/apache/commons/commons-lang3/3.12.0/commons-lang3-3.12.0-sources.jar":
+				_, _ = w.Write(jar)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+	file, err := ff.Find(context.Background())
+	require.NoError(t, err)
+	requireContent(t, "// StringUtils", file.Content)
+	require.Equal(t, "https://repo1.maven.org/maven2/org/apache/commons/commons-lang3/3.12.0/commons-lang3-3.12.0-sources.jar", file.URL)
+
+	// Without the project file, the class is provided by unrelated artifacts.
+	delete(repo, "pom.xml")
+	_, err = ff.Find(context.Background())
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	ff.path = "com/example/App.java"
+	file, err = ff.Find(context.Background())
+	require.NoError(t, err)
+	requireContent(t, "// App", file.Content)
+	require.Equal(t, "repo/src/main/java/com/example/App.java", file.URL)
+}
@@ -238,6 +238,11 @@ type GitHubFile struct {
 	Owner, Repo, Ref, Path string
 }
 
+// RawURL returns the URL of the raw file content.
+func (f GitHubFile) RawURL() string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", f.Owner, f.Repo, f.Ref, f.Path)
+}
+
 // GithubFile returns the github file information.
 func (m Module) GithubFile() (GitHubFile, error) {
 	if !m.IsGitHub() {
@@ -0,0 +1,185 @@
+package java
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const (
+	// POM is the path of the Maven project file in the repository.
+	POM = "pom.xml"
+
+	sourceRoot        = "src/main/java/"
+	defaultJDKModule  = "java.base"
+	mavenRepository   = "https://repo1.maven.org/maven2"
+	sourcesJarSuffix  = "-sources.jar"
+	javaFileExtension = ".java"
+)
+
+// jdkModules maps package prefixes to the JDK module they belong to.
+// Packages of the JDK which are not listed belong to java.base.
+var jdkModules = map[string]string{
+	"java/awt/":             "java.desktop",
+	"java/beans/":           "java.desktop",
+	"java/lang/management/": "java.management",
+	"java/net/http/":        "java.net.http",
+	"java/rmi/":             "java.rmi",
+	"java/sql/":             "java.sql",
+	"java/util/logging/":    "java.logging",
+	"java/util/prefs/":      "java.prefs",
+	"javax/management/":     "java.management",
+	"javax/naming/":         "java.naming",
+	"javax/script/":         "java.scripting",
+	"javax/sql/":            "java.sql",
+	"javax/swing/":          "java.desktop",
+	"javax/xml/":            "java.xml",
+	"jdk/jfr/":              "jdk.jfr",
+	"sun/management/":       "java.management",
+}
+
+var jdkPackages = []string{"java/", "javax/", "jdk/", "sun/"}
+
+// SourcePath returns the path of the source file relative to the
+// root of the sources, for example com/example/App.java.
+// Paths of a Maven or Gradle source set are made relative to its root.
+func SourcePath(path string) string {
+	if idx := strings.LastIndex(path, sourceRoot); idx >= 0 {
+		return path[idx+len(sourceRoot):]
+	}
+	return strings.TrimLeft(path, "/")
+}
+
+// ClassName returns the fully qualified name of the top-level class
+// declared in the given source file.
+// For example:
+// com/example/App.java -> com.example.App
+func ClassName(path string) string {
+	path = strings.TrimSuffix(SourcePath(path), javaFileExtension)
+	return strings.ReplaceAll(path, "/", ".")
+}
+
+// StandardLibraryURL returns the URL of the JDK class source
+// from the given path if it belongs to the JDK.
+func StandardLibraryURL(path string) (string, bool) {
+	path = SourcePath(path)
+	isJDK := false
+	for _, prefix := range jdkPackages {
+		if strings.HasPrefix(path, prefix) {
+			isJDK = true
+			break
+		}
+	}
+	if !isJDK {
+		return "", false
+	}
+	module := defaultJDKModule
+	longest := 0
+	for prefix, m := range jdkModules {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			module, longest = m, len(prefix)
+		}
+	}
+	// Todo: JDK version is not known, we use the main branch.
+	return fmt.Sprintf(`https://raw.githubusercontent.com/openjdk/jdk/master/src/%s/share/classes/%s`, module, path), true
+}
+
+// Artifact represents a Maven artifact.
+type Artifact struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// SourcesJarURL returns the URL of the sources jar of the artifact in Maven Central.
+func (a Artifact) SourcesJarURL() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s-%s%s",
+		mavenRepository,
+		strings.ReplaceAll(a.GroupID, ".", "/"),
+		a.ArtifactID,
+		a.Version,
+		a.ArtifactID,
+		a.Version,
+		sourcesJarSuffix,
+	)
+}
+
+func (a Artifact) String() string {
+	return a.GroupID + ":" + a.ArtifactID + ":" + a.Version
+}
+
+type pom struct {
+	GroupID string `xml:"groupId"`
+	Version string `xml:"version"`
+	Parent  struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+// ParsePOMDependencies returns the dependencies declared in a Maven
+// project file, including the managed ones. Properties defined in the
+// file are substituted; dependencies which version can't be resolved,
+// e.g. because it is inherited from a parent project, are ignored.
+func ParsePOMDependencies(content []byte) ([]Artifact, error) {
+	var p pom
+	if err := xml.Unmarshal(content, &p); err != nil {
+		return nil, err
+	}
+	properties := map[string]string{
+		"project.groupId": p.GroupID,
+		"project.version": p.Version,
+	}
+	if p.GroupID == "" {
+		properties["project.groupId"] = p.Parent.GroupID
+	}
+	if p.Version == "" {
+		properties["project.version"] = p.Parent.Version
+	}
+	for _, e := range p.Properties.Entries {
+		properties[e.XMLName.Local] = strings.TrimSpace(e.Value)
+	}
+	expand := func(s string) string {
+		s = strings.TrimSpace(s)
+		if strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}") {
+			return properties[s[2:len(s)-1]]
+		}
+		return s
+	}
+
+	managed := make(map[string]string, len(p.DependencyManagement))
+	artifacts := make([]Artifact, 0, len(p.Dependencies)+len(p.DependencyManagement))
+	add := func(d pomDependency, version string) {
+		a := Artifact{GroupID: expand(d.GroupID), ArtifactID: expand(d.ArtifactID), Version: version}
+		if a.GroupID != "" && a.ArtifactID != "" && a.Version != "" && !strings.Contains(a.Version, "${") {
+			artifacts = append(artifacts, a)
+		}
+	}
+	for _, d := range p.DependencyManagement {
+		version := expand(d.Version)
+		managed[expand(d.GroupID)+":"+expand(d.ArtifactID)] = version
+		add(d, version)
+	}
+	for _, d := range p.Dependencies {
+		version := expand(d.Version)
+		if version == "" {
+			version = managed[expand(d.GroupID)+":"+expand(d.ArtifactID)]
+		}
+		add(d, version)
+	}
+	return artifacts, nil
+}
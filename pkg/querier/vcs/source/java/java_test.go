@@ -0,0 +1,123 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassName(t *testing.T) {
+	require.Equal(t, "com.example.App", ClassName("com/example/App.java"))
+	require.Equal(t, "com.example.App", ClassName("/build/service/src/main/java/com/example/App.java"))
+	require.Equal(t, "App", ClassName("App.java"))
+}
+
+func TestStandardLibraryURL(t *testing.T) {
+	for _, tt := range []struct {
+		input      string
+		expected   string
+		expectedOk bool
+	}{
+		{
+			input:      "java/lang/Thread.java",
+			expected:   "https://raw.githubusercontent.com/openjdk/jdk/master/src/java.base/share/classes/java/lang/Thread.java",
+			expectedOk: true,
+		},
+		{
+			input:      "java/util/concurrent/ThreadPoolExecutor.java",
+			expected:   "https://raw.githubusercontent.com/openjdk/jdk/master/src/java.base/share/classes/java/util/concurrent/ThreadPoolExecutor.java",
+			expectedOk: true,
+		},
+		{
+			input:      "java/util/logging/Logger.java",
+			expected:   "https://raw.githubusercontent.com/openjdk/jdk/master/src/java.logging/share/classes/java/util/logging/Logger.java",
+			expectedOk: true,
+		},
+		{
+			input:      "java/lang/management/ManagementFactory.java",
+			expected:   "https://raw.githubusercontent.com/openjdk/jdk/master/src/java.management/share/classes/java/lang/management/ManagementFactory.java",
+			expectedOk: true,
+		},
+		{
+			input:      "com/example/App.java",
+			expectedOk: false,
+		},
+		{
+			input:      "src/main/java/com/example/App.java",
+			expectedOk: false,
+		},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			actual, ok := StandardLibraryURL(tt.input)
+			require.Equal(t, tt.expectedOk, ok)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestArtifactSourcesJarURL(t *testing.T) {
+	a := Artifact{GroupID: "org.apache.commons", ArtifactID: "commons-lang3", Version: "3.14.0"}
+	require.Equal(t, "https://repo1.maven.org/maven2/org/apache/commons/commons-lang3/3.14.0/commons-lang3-3.14.0-sources.jar", a.SourcesJarURL())
+	require.Equal(t, "org.apache.commons:commons-lang3:3.14.0", a.String())
+}
+
+func TestParsePOMDependencies(t *testing.T) {
+	artifacts, err := ParsePOMDependencies([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.2.0</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <properties>
+    <guava.version>33.0.0-jre</guava.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.slf4j</groupId>
+        <artifactId>slf4j-api</artifactId>
+        <version>2.0.9</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.apache.commons</groupId>
+      <artifactId>commons-lang3</artifactId>
+      <version>3.14.0</version>
+    </dependency>
+    <dependency>
+      <groupId>com.google.guava</groupId>
+      <artifactId>guava</artifactId>
+      <version>${guava.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>lib</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>io.netty</groupId>
+      <artifactId>netty-all</artifactId>
+      <version>${netty.version}</version>
+    </dependency>
+  </dependencies>
+</project>`))
+	require.NoError(t, err)
+	require.Equal(t, []Artifact{
+		{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"},
+		{GroupID: "org.apache.commons", ArtifactID: "commons-lang3", Version: "3.14.0"},
+		{GroupID: "com.google.guava", ArtifactID: "guava", Version: "33.0.0-jre"},
+		{GroupID: "com.example", ArtifactID: "lib", Version: "1.2.0"},
+		{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"},
+	}, artifacts)
+
+	_, err = ParsePOMDependencies([]byte("<project>"))
+	require.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package python
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/grafana/regexp"
+)
+
+const Requirements = "requirements.txt"
+
+var (
+	stdLibRegex     = regexp.MustCompile(`(?:^|/)lib/python(?P<version>\d+\.\d+)/(?P<path>.*)`)
+	sitePackagesDir = []string{"/site-packages/", "/dist-packages/"}
+	nameNormalizer  = regexp.MustCompile(`[-_.]+`)
+
+	// distributions maps well known top-level modules to the name of
+	// the distribution that installs them, when they differ.
+	distributions = map[string]string{
+		"attr":     "attrs",
+		"bs4":      "beautifulsoup4",
+		"cv2":      "opencv-python",
+		"dateutil": "python-dateutil",
+		"dotenv":   "python-dotenv",
+		"jwt":      "PyJWT",
+		"PIL":      "Pillow",
+		"sklearn":  "scikit-learn",
+		"yaml":     "PyYAML",
+	}
+)
+
+// Package represents a file installed in a site-packages directory.
+type Package struct {
+	// Module is the top-level module the file belongs to.
+	Module string
+	// FilePath is the path of the file relative to the site-packages directory.
+	FilePath string
+}
+
+// Distribution returns the name of the PyPI distribution likely to
+// provide the package.
+func (p Package) Distribution() string {
+	if name, ok := distributions[p.Module]; ok {
+		return name
+	}
+	return p.Module
+}
+
+// ParseSitePackagesPath parses the package from the given path
+// if it is located in a site-packages or dist-packages directory.
+// For example:
+// /usr/local/lib/python3.11/site-packages/requests/api.py -> requests, requests/api.py
+func ParseSitePackagesPath(path string) (Package, bool) {
+	for _, dir := range sitePackagesDir {
+		idx := strings.LastIndex(path, dir)
+		if idx < 0 {
+			continue
+		}
+		filePath := path[idx+len(dir):]
+		module, _, _ := strings.Cut(filePath, "/")
+		module = strings.TrimSuffix(module, ".py")
+		if module == "" || module == filePath && !strings.HasSuffix(filePath, ".py") {
+			return Package{}, false
+		}
+		return Package{Module: module, FilePath: filePath}, true
+	}
+	return Package{}, false
+}
+
+// StandardLibraryURL returns the URL of the standard library module
+// from the given local path if it exists.
+func StandardLibraryURL(path string) (string, bool) {
+	if _, ok := ParseSitePackagesPath(path); ok {
+		return "", false
+	}
+	matches := stdLibRegex.FindStringSubmatch(path)
+	if matches == nil {
+		return "", false
+	}
+	version := matches[stdLibRegex.SubexpIndex("version")]
+	path = matches[stdLibRegex.SubexpIndex("path")]
+	return fmt.Sprintf(`https://raw.githubusercontent.com/python/cpython/%s/Lib/%s`, version, path), true
+}
+
+// NormalizeName returns the normalized form of a distribution name, as defined by PEP 503.
+func NormalizeName(name string) string {
+	return strings.ToLower(nameNormalizer.ReplaceAllString(name, "-"))
+}
+
+// ParseRequirements returns the pinned versions of a requirements file,
+// indexed by normalized distribution name.
+// Requirements without an exact version are ignored.
+func ParseRequirements(content string) map[string]string {
+	versions := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line, _, _ = strings.Cut(line, ";")
+		name, version, ok := strings.Cut(line, "==")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, "[")
+		name = strings.TrimSpace(name)
+		version = strings.TrimSpace(version)
+		if name == "" || version == "" || strings.ContainsAny(version, " ,*") {
+			continue
+		}
+		versions[NormalizeName(name)] = version
+	}
+	return versions
+}
@@ -0,0 +1,103 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSitePackagesPath(t *testing.T) {
+	for _, tt := range []struct {
+		input      string
+		expected   Package
+		expectedOk bool
+	}{
+		{
+			input:      "/usr/local/lib/python3.11/site-packages/requests/api.py",
+			expected:   Package{Module: "requests", FilePath: "requests/api.py"},
+			expectedOk: true,
+		},
+		{
+			input:      "/usr/lib/python3/dist-packages/yaml/loader.py",
+			expected:   Package{Module: "yaml", FilePath: "yaml/loader.py"},
+			expectedOk: true,
+		},
+		{
+			input:      "/app/.venv/lib/python3.12/site-packages/six.py",
+			expected:   Package{Module: "six", FilePath: "six.py"},
+			expectedOk: true,
+		},
+		{
+			input:      "/usr/lib/python3.11/json/decoder.py",
+			expectedOk: false,
+		},
+		{
+			input:      "app/main.py",
+			expectedOk: false,
+		},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			actual, ok := ParseSitePackagesPath(tt.input)
+			require.Equal(t, tt.expectedOk, ok)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestPackageDistribution(t *testing.T) {
+	require.Equal(t, "requests", Package{Module: "requests"}.Distribution())
+	require.Equal(t, "PyYAML", Package{Module: "yaml"}.Distribution())
+}
+
+func TestStandardLibraryURL(t *testing.T) {
+	for _, tt := range []struct {
+		input      string
+		expected   string
+		expectedOk bool
+	}{
+		{
+			input:      "/usr/lib/python3.11/json/decoder.py",
+			expected:   "https://raw.githubusercontent.com/python/cpython/3.11/Lib/json/decoder.py",
+			expectedOk: true,
+		},
+		{
+			input:      "/usr/local/lib/python3.9/threading.py",
+			expected:   "https://raw.githubusercontent.com/python/cpython/3.9/Lib/threading.py",
+			expectedOk: true,
+		},
+		{
+			input:      "/usr/local/lib/python3.11/site-packages/requests/api.py",
+			expectedOk: false,
+		},
+		{
+			input:      "app/main.py",
+			expectedOk: false,
+		},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			actual, ok := StandardLibraryURL(tt.input)
+			require.Equal(t, tt.expectedOk, ok)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParseRequirements(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"requests":     "2.31.0",
+		"pyyaml":       "6.0.1",
+		"flask-cors":   "4.0.0",
+		"uvicorn":      "0.24.0",
+		"typing-extra": "1.0",
+	}, ParseRequirements(`
+# pinned dependencies
+requests==2.31.0
+PyYAML == 6.0.1  # comment
+Flask_Cors==4.0.0
+uvicorn[standard]==0.24.0
+typing.extra==1.0 ; python_version < "3.8"
+django>=4.2
+numpy
+-r other.txt
+`))
+}
@@ -0,0 +1,66 @@
+package rust
+
+import (
+	"fmt"
+
+	"github.com/grafana/regexp"
+)
+
+var (
+	// Crates downloaded from a registry are extracted by cargo to
+	// $CARGO_HOME/registry/src/<registry>/<name>-<version>/, where
+	// $CARGO_HOME is usually ~/.cargo or /usr/local/cargo.
+	registryRegex = regexp.MustCompile(`(?:^|/)\.?cargo/registry/src/[^/]+/(?P<name>[^/]+?)-(?P<version>\d+\.\d+\.\d+(?:[-+][^/]*)?)/(?P<path>.*)`)
+	// The standard library paths are remapped by rustc to /rustc/<commit>/.
+	stdLibRegex = regexp.MustCompile(`^/rustc/(?P<commit>[0-9a-f]{40})/(?P<path>.*)`)
+)
+
+// Crate represents a crate with a file path in that crate.
+type Crate struct {
+	Name     string
+	Version  string
+	FilePath string
+}
+
+// ParseCrateFromPath parses the crate from the given path if it is
+// located in the cargo registry.
+// For example:
+// /root/.cargo/registry/src/index.crates.io-6f17d22bba15001f/tokio-1.35.1/src/runtime/park.rs -> tokio, 1.35.1, src/runtime/park.rs
+func ParseCrateFromPath(path string) (Crate, bool) {
+	matches := registryRegex.FindStringSubmatch(path)
+	if matches == nil {
+		return Crate{}, false
+	}
+	return Crate{
+		Name:     matches[registryRegex.SubexpIndex("name")],
+		Version:  matches[registryRegex.SubexpIndex("version")],
+		FilePath: matches[registryRegex.SubexpIndex("path")],
+	}, true
+}
+
+// DownloadURL returns the URL of the crate archive in crates.io.
+func (c Crate) DownloadURL() string {
+	return fmt.Sprintf("https://static.crates.io/crates/%s/%s-%s.crate", c.Name, c.Name, c.Version)
+}
+
+// ArchivePath returns the path of the file in the crate archive.
+func (c Crate) ArchivePath() string {
+	return fmt.Sprintf("%s-%s/%s", c.Name, c.Version, c.FilePath)
+}
+
+// SourceURL returns the URL of the file in the docs.rs source browser.
+func (c Crate) SourceURL() string {
+	return fmt.Sprintf("https://docs.rs/crate/%s/%s/source/%s", c.Name, c.Version, c.FilePath)
+}
+
+// StandardLibraryURL returns the URL of the standard library file
+// from the given path if it exists.
+func StandardLibraryURL(path string) (string, bool) {
+	matches := stdLibRegex.FindStringSubmatch(path)
+	if matches == nil {
+		return "", false
+	}
+	commit := matches[stdLibRegex.SubexpIndex("commit")]
+	path = matches[stdLibRegex.SubexpIndex("path")]
+	return fmt.Sprintf(`https://raw.githubusercontent.com/rust-lang/rust/%s/%s`, commit, path), true
+}
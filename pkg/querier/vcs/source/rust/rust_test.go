@@ -0,0 +1,57 @@
+package rust
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCrateFromPath(t *testing.T) {
+	for _, tt := range []struct {
+		input      string
+		expected   Crate
+		expectedOk bool
+	}{
+		{
+			input:      "/root/.cargo/registry/src/index.crates.io-6f17d22bba15001f/tokio-1.35.1/src/runtime/park.rs",
+			expected:   Crate{Name: "tokio", Version: "1.35.1", FilePath: "src/runtime/park.rs"},
+			expectedOk: true,
+		},
+		{
+			input:      "/home/user/.cargo/registry/src/github.com-1ecc6299db9ec823/tokio-util-0.7.10/src/codec/framed.rs",
+			expected:   Crate{Name: "tokio-util", Version: "0.7.10", FilePath: "src/codec/framed.rs"},
+			expectedOk: true,
+		},
+		{
+			input:      "/usr/local/cargo/registry/src/index.crates.io-6f17d22bba15001f/rustls-0.23.0-alpha.0/src/lib.rs",
+			expected:   Crate{Name: "rustls", Version: "0.23.0-alpha.0", FilePath: "src/lib.rs"},
+			expectedOk: true,
+		},
+		{
+			input:      "src/main.rs",
+			expectedOk: false,
+		},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			actual, ok := ParseCrateFromPath(tt.input)
+			require.Equal(t, tt.expectedOk, ok)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCrateURLs(t *testing.T) {
+	c := Crate{Name: "tokio", Version: "1.35.1", FilePath: "src/lib.rs"}
+	require.Equal(t, "https://static.crates.io/crates/tokio/tokio-1.35.1.crate", c.DownloadURL())
+	require.Equal(t, "tokio-1.35.1/src/lib.rs", c.ArchivePath())
+	require.Equal(t, "https://docs.rs/crate/tokio/1.35.1/source/src/lib.rs", c.SourceURL())
+}
+
+func TestStandardLibraryURL(t *testing.T) {
+	url, ok := StandardLibraryURL("/rustc/90b35a6239c3d8bdabc530a6a0816f7ff89a0aaf/library/std/src/thread/mod.rs")
+	require.True(t, ok)
+	require.Equal(t, "https://raw.githubusercontent.com/rust-lang/rust/90b35a6239c3d8bdabc530a6a0816f7ff89a0aaf/library/std/src/thread/mod.rs", url)
+
+	_, ok = StandardLibraryURL("src/main.rs")
+	require.False(t, ok)
+}
@@ -0,0 +1,103 @@
+package savedviews
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thanos-io/objstore"
+	"golang.org/x/exp/slices"
+
+	savedviewsv1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
+)
+
+var viewNotFoundErr = errors.New("view not found")
+
+// viewsDir is the directory of the views in the bucket of a tenant.
+const viewsDir = "saved-views"
+
+// NewMemoryStore will create a saved views store with an in-memory objstore
+// bucket.
+func NewMemoryStore() Store {
+	return NewBucketStore(objstore.NewInMemBucket())
+}
+
+// NewBucketStore will create a saved views store with an objstore bucket.
+// Every view is stored in its own object, the store does not keep any state
+// so it can be shared by multiple instances.
+func NewBucketStore(bucket objstore.Bucket) Store {
+	return &bucketStore{bucket: bucket}
+}
+
+type bucketStore struct {
+	bucket objstore.Bucket
+}
+
+func (s *bucketStore) Get(ctx context.Context, tenantID, id string) (*savedviewsv1.SavedView, error) {
+	reader, err := s.bucket.Get(ctx, viewPath(tenantID, id))
+	if err != nil {
+		if s.bucket.IsObjNotFoundErr(err) {
+			return nil, errors.Wrapf(viewNotFoundErr, "failed to get %s", id)
+		}
+		return nil, err
+	}
+	defer reader.Close()
+
+	view := new(savedviewsv1.SavedView)
+	err = json.NewDecoder(reader).Decode(view)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", id)
+	}
+	return view, nil
+}
+
+func (s *bucketStore) List(ctx context.Context, tenantID string) ([]*savedviewsv1.SavedView, error) {
+	views := make([]*savedviewsv1.SavedView, 0)
+	err := s.bucket.Iter(ctx, path.Join(tenantID, viewsDir)+"/", func(name string) error {
+		id := strings.TrimSuffix(path.Base(name), ".json")
+		view, err := s.Get(ctx, tenantID, id)
+		if err != nil {
+			if errors.Is(err, viewNotFoundErr) {
+				// The view was deleted in the meantime.
+				return nil
+			}
+			return err
+		}
+		views = append(views, view)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(views, func(a, b *savedviewsv1.SavedView) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+	return views, nil
+}
+
+func (s *bucketStore) Put(ctx context.Context, tenantID string, view *savedviewsv1.SavedView) error {
+	data, err := json.Marshal(view)
+	if err != nil {
+		return err
+	}
+	return s.bucket.Upload(ctx, viewPath(tenantID, view.Id), bytes.NewReader(data))
+}
+
+func (s *bucketStore) Delete(ctx context.Context, tenantID, id string) error {
+	err := s.bucket.Delete(ctx, viewPath(tenantID, id))
+	if err != nil && s.bucket.IsObjNotFoundErr(err) {
+		return errors.Wrapf(viewNotFoundErr, "failed to delete %s", id)
+	}
+	return err
+}
+
+func viewPath(tenantID, id string) string {
+	return path.Join(tenantID, viewsDir, id+".json")
+}
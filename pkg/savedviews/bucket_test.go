@@ -0,0 +1,38 @@
+package savedviews
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+
+	savedviewsv1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
+)
+
+func TestBucketStore(t *testing.T) {
+	ctx := context.Background()
+	bucket := objstore.NewInMemBucket()
+
+	view := testView("a")
+	view.Id = "abc"
+	require.NoError(t, NewBucketStore(bucket).Put(ctx, "tenant", view))
+
+	// The store is stateless, views are visible to other instances.
+	store := NewBucketStore(bucket)
+	got, err := store.Get(ctx, "tenant", "abc")
+	require.NoError(t, err)
+	require.Equal(t, view, got)
+
+	list, err := store.List(ctx, "tenant")
+	require.NoError(t, err)
+	require.Equal(t, []*savedviewsv1.SavedView{view}, list)
+
+	_, err = store.Get(ctx, "other", "abc")
+	require.ErrorIs(t, err, viewNotFoundErr)
+
+	require.NoError(t, store.Delete(ctx, "tenant", "abc"))
+	list, err = store.List(ctx, "tenant")
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
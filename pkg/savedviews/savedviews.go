@@ -0,0 +1,241 @@
+package savedviews
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/services"
+	"github.com/grafana/dskit/tenant"
+	"github.com/grafana/dskit/user"
+	"github.com/pkg/errors"
+
+	savedviewsv1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
+)
+
+const (
+	idAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	idLength   = 10
+
+	// maxIDAttempts is the number of identifiers tried before giving up
+	// creating a view.
+	maxIDAttempts = 5
+)
+
+type SavedViews struct {
+	services.Service
+
+	store Store
+	newID func() (string, error)
+}
+
+func New(store Store) *SavedViews {
+	v := &SavedViews{
+		store: store,
+		newID: newID,
+	}
+	v.Service = services.NewIdleService(nil, nil)
+	return v
+}
+
+func (v *SavedViews) Create(ctx context.Context, req *connect.Request[savedviewsv1.CreateSavedViewRequest]) (*connect.Response[savedviewsv1.CreateSavedViewResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	view := req.Msg.GetView()
+	if err = validateView(view); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	view.Id, err = v.unusedID(ctx, tenantID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	view.CreatedAt = time.Now().UnixMilli()
+	view.CreatedBy = authenticatedUser(ctx)
+	view.ModifiedAt = view.CreatedAt
+	view.ModifiedBy = view.CreatedBy
+
+	if err = v.store.Put(ctx, tenantID, view); err != nil {
+		return nil, storeError(err)
+	}
+
+	return connect.NewResponse(&savedviewsv1.CreateSavedViewResponse{
+		View: view,
+	}), nil
+}
+
+func (v *SavedViews) Get(ctx context.Context, req *connect.Request[savedviewsv1.GetSavedViewRequest]) (*connect.Response[savedviewsv1.GetSavedViewResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	view, err := v.Resolve(ctx, tenantID, req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&savedviewsv1.GetSavedViewResponse{
+		View: view,
+	}), nil
+}
+
+func (v *SavedViews) List(ctx context.Context, req *connect.Request[savedviewsv1.ListSavedViewsRequest]) (*connect.Response[savedviewsv1.ListSavedViewsResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	views, err := v.store.List(ctx, tenantID)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return connect.NewResponse(&savedviewsv1.ListSavedViewsResponse{
+		Views: views,
+	}), nil
+}
+
+func (v *SavedViews) Update(ctx context.Context, req *connect.Request[savedviewsv1.UpdateSavedViewRequest]) (*connect.Response[savedviewsv1.UpdateSavedViewResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	view := req.Msg.GetView()
+	if err = validateView(view); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	old, err := v.Resolve(ctx, tenantID, view.Id)
+	if err != nil {
+		return nil, err
+	}
+	view.CreatedAt = old.CreatedAt
+	view.CreatedBy = old.CreatedBy
+	view.ModifiedAt = time.Now().UnixMilli()
+	view.ModifiedBy = authenticatedUser(ctx)
+
+	if err = v.store.Put(ctx, tenantID, view); err != nil {
+		return nil, storeError(err)
+	}
+
+	return connect.NewResponse(&savedviewsv1.UpdateSavedViewResponse{
+		View: view,
+	}), nil
+}
+
+func (v *SavedViews) Delete(ctx context.Context, req *connect.Request[savedviewsv1.DeleteSavedViewRequest]) (*connect.Response[savedviewsv1.DeleteSavedViewResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err = validateID(req.Msg.Id); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err = v.store.Delete(ctx, tenantID, req.Msg.Id); err != nil {
+		return nil, storeError(err)
+	}
+
+	return connect.NewResponse(&savedviewsv1.DeleteSavedViewResponse{}), nil
+}
+
+// Resolve returns the view of a tenant with the given identifier.
+func (v *SavedViews) Resolve(ctx context.Context, tenantID, id string) (*savedviewsv1.SavedView, error) {
+	if err := validateID(id); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	view, err := v.store.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return view, nil
+}
+
+// unusedID returns a new identifier which is not used by any view of the
+// tenant yet.
+func (v *SavedViews) unusedID(ctx context.Context, tenantID string) (string, error) {
+	for i := 0; i < maxIDAttempts; i++ {
+		id, err := v.newID()
+		if err != nil {
+			return "", err
+		}
+		_, err = v.store.Get(ctx, tenantID, id)
+		if errors.Is(err, viewNotFoundErr) {
+			return id, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("failed to find an unused view id after %d attempts", maxIDAttempts)
+}
+
+// newID returns a random short identifier, which is safe to use in URLs.
+func newID() (string, error) {
+	var sb strings.Builder
+	n := big.NewInt(int64(len(idAlphabet)))
+	for i := 0; i < idLength; i++ {
+		c, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(idAlphabet[c.Int64()])
+	}
+	return sb.String(), nil
+}
+
+func validateID(id string) error {
+	if id == "" {
+		return fmt.Errorf("no view id provided")
+	}
+	for _, c := range id {
+		if !strings.ContainsRune(idAlphabet, c) {
+			return fmt.Errorf("invalid view id %q", id)
+		}
+	}
+	return nil
+}
+
+func validateView(view *savedviewsv1.SavedView) error {
+	if view == nil {
+		return fmt.Errorf("no view provided")
+	}
+	if view.Name == "" {
+		return fmt.Errorf("no view name provided")
+	}
+	if view.Query.GetProfileTypeId() == "" {
+		return fmt.Errorf("no profile type provided")
+	}
+	switch view.Mode {
+	case savedviewsv1.ViewMode_VIEW_MODE_COMPARISON, savedviewsv1.ViewMode_VIEW_MODE_DIFF:
+		if view.Baseline.GetProfileTypeId() == "" {
+			return fmt.Errorf("no baseline profile type provided for view mode %s", view.Mode)
+		}
+	}
+	return nil
+}
+
+// storeError maps an error of the store to a connect error.
+func storeError(err error) error {
+	if errors.Is(err, viewNotFoundErr) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// authenticatedUser returns the user the request was authenticated as, which
+// is forwarded with the tenant ID by the authenticating proxy. It is empty if
+// the proxy doesn't forward it, or multi-tenancy is disabled.
+func authenticatedUser(ctx context.Context) string {
+	userID, _ := user.ExtractUserID(ctx)
+	return userID
+}
@@ -0,0 +1,141 @@
+package savedviews
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/user"
+	"github.com/stretchr/testify/require"
+
+	savedviewsv1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
+	"github.com/grafana/pyroscope/pkg/tenant"
+)
+
+func testView(name string) *savedviewsv1.SavedView {
+	return &savedviewsv1.SavedView{
+		Name: name,
+		Query: &savedviewsv1.ViewQuery{
+			ProfileTypeId: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+			LabelSelector: `{service_name="app"}`,
+			From:          "now-1h",
+			Until:         "now",
+		},
+		Mode: savedviewsv1.ViewMode_VIEW_MODE_SINGLE,
+	}
+}
+
+func TestSavedViews_CRUD(t *testing.T) {
+	v := New(NewMemoryStore())
+	ctx := tenant.InjectTenantID(context.Background(), "1234")
+
+	req := connect.NewRequest(&savedviewsv1.CreateSavedViewRequest{View: testView("b")})
+	created, err := v.Create(user.InjectUserID(ctx, "alice"), req)
+	require.NoError(t, err)
+	view := created.Msg.View
+	require.Len(t, view.Id, idLength)
+	require.NoError(t, validateID(view.Id))
+	require.Equal(t, "alice", view.CreatedBy)
+	require.Equal(t, "alice", view.ModifiedBy)
+	require.NotZero(t, view.CreatedAt)
+
+	got, err := v.Get(ctx, connect.NewRequest(&savedviewsv1.GetSavedViewRequest{Id: view.Id}))
+	require.NoError(t, err)
+	require.Equal(t, view, got.Msg.View)
+
+	_, err = v.Create(ctx, connect.NewRequest(&savedviewsv1.CreateSavedViewRequest{View: testView("a")}))
+	require.NoError(t, err)
+	list, err := v.List(ctx, connect.NewRequest(&savedviewsv1.ListSavedViewsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Views, 2)
+	require.Equal(t, "a", list.Msg.Views[0].Name)
+	require.Equal(t, "b", list.Msg.Views[1].Name)
+
+	update := testView("c")
+	update.Id = view.Id
+	update.CreatedBy = "mallory"
+	updateReq := connect.NewRequest(&savedviewsv1.UpdateSavedViewRequest{View: update})
+	updated, err := v.Update(user.InjectUserID(ctx, "bob"), updateReq)
+	require.NoError(t, err)
+	require.Equal(t, "c", updated.Msg.View.Name)
+	require.Equal(t, view.CreatedAt, updated.Msg.View.CreatedAt)
+	require.Equal(t, "alice", updated.Msg.View.CreatedBy)
+	require.Equal(t, "bob", updated.Msg.View.ModifiedBy)
+
+	_, err = v.Delete(ctx, connect.NewRequest(&savedviewsv1.DeleteSavedViewRequest{Id: view.Id}))
+	require.NoError(t, err)
+	_, err = v.Get(ctx, connect.NewRequest(&savedviewsv1.GetSavedViewRequest{Id: view.Id}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = v.Delete(ctx, connect.NewRequest(&savedviewsv1.DeleteSavedViewRequest{Id: view.Id}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = v.Update(ctx, updateReq)
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// Views are not shared between tenants.
+	otherCtx := tenant.InjectTenantID(context.Background(), "other")
+	list, err = v.List(otherCtx, connect.NewRequest(&savedviewsv1.ListSavedViewsRequest{}))
+	require.NoError(t, err)
+	require.Empty(t, list.Msg.Views)
+}
+
+func TestSavedViews_Validation(t *testing.T) {
+	v := New(NewMemoryStore())
+	ctx := tenant.InjectTenantID(context.Background(), "1234")
+
+	for _, tc := range []struct {
+		name    string
+		view    *savedviewsv1.SavedView
+		wantErr string
+	}{
+		{
+			name:    "no view",
+			wantErr: "invalid_argument: no view provided",
+		},
+		{
+			name:    "no name",
+			view:    testView(""),
+			wantErr: "invalid_argument: no view name provided",
+		},
+		{
+			name:    "no profile type",
+			view:    &savedviewsv1.SavedView{Name: "a"},
+			wantErr: "invalid_argument: no profile type provided",
+		},
+		{
+			name: "diff without baseline",
+			view: func() *savedviewsv1.SavedView {
+				view := testView("a")
+				view.Mode = savedviewsv1.ViewMode_VIEW_MODE_DIFF
+				return view
+			}(),
+			wantErr: "invalid_argument: no baseline profile type provided for view mode VIEW_MODE_DIFF",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.Create(ctx, connect.NewRequest(&savedviewsv1.CreateSavedViewRequest{View: tc.view}))
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+
+	_, err := v.Get(ctx, connect.NewRequest(&savedviewsv1.GetSavedViewRequest{Id: "../settings"}))
+	require.EqualError(t, err, `invalid_argument: invalid view id "../settings"`)
+}
+
+func TestSavedViews_IDCollision(t *testing.T) {
+	v := New(NewMemoryStore())
+	ctx := tenant.InjectTenantID(context.Background(), "1234")
+
+	ids := []string{"aaaaaaaaaa", "aaaaaaaaaa", "bbbbbbbbbb"}
+	v.newID = func() (string, error) {
+		id := ids[0]
+		ids = ids[1:]
+		return id, nil
+	}
+
+	first, err := v.Create(ctx, connect.NewRequest(&savedviewsv1.CreateSavedViewRequest{View: testView("a")}))
+	require.NoError(t, err)
+	require.Equal(t, "aaaaaaaaaa", first.Msg.View.Id)
+	second, err := v.Create(ctx, connect.NewRequest(&savedviewsv1.CreateSavedViewRequest{View: testView("b")}))
+	require.NoError(t, err)
+	require.Equal(t, "bbbbbbbbbb", second.Msg.View.Id)
+}
@@ -0,0 +1,21 @@
+package savedviews
+
+import (
+	"context"
+
+	savedviewsv1 "github.com/grafana/pyroscope/api/gen/proto/go/savedviews/v1"
+)
+
+type Store interface {
+	// Get a view of a tenant by its identifier.
+	Get(ctx context.Context, tenantID, id string) (*savedviewsv1.SavedView, error)
+
+	// List the views of a tenant, sorted by name.
+	List(ctx context.Context, tenantID string) ([]*savedviewsv1.SavedView, error)
+
+	// Put creates or replaces a view of a tenant.
+	Put(ctx context.Context, tenantID string, view *savedviewsv1.SavedView) error
+
+	// Delete a view of a tenant.
+	Delete(ctx context.Context, tenantID, id string) error
+}
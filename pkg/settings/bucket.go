@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"path"
 	"strings"
 	"sync"
 
@@ -15,10 +16,20 @@ import (
 )
 
 var (
-	oldSettingErr    = errors.New("newer update already written")
+	oldSettingErr      = errors.New("newer update already written")
+	versionMismatchErr = errors.New("setting was modified concurrently")
+	settingNotFoundErr = errors.New("setting not found")
+
 	settingsFilename = "tenant_settings.json"
+	// historyFilename is the name of the object keeping the history of the
+	// changes of a tenant's settings, in the directory of the tenant.
+	historyFilename = "settings_history.json"
 )
 
+// maxHistoryLength is the number of changes kept per tenant, older changes
+// are dropped.
+const maxHistoryLength = 1000
+
 // NewMemoryStore will create a settings store with an in-memory objstore
 // bucket.
 func NewMemoryStore() (Store, error) {
@@ -35,6 +46,12 @@ func NewBucketStore(bucket objstore.Bucket) (Store, error) {
 	return store, nil
 }
 
+// bucketStore keeps the settings of all the tenants in one object of the
+// bucket, and the history of the changes of each tenant in an object of the
+// tenant's directory. The objects are read again before every change, so the
+// versions are checked against the stored settings. Reading and rewriting
+// the objects is not atomic though: changes are serialized by a mutex of the
+// process only, and concurrent writers could overwrite each other's changes.
 type bucketStore struct {
 	rw sync.Mutex
 
@@ -67,7 +84,7 @@ func (s *bucketStore) Get(ctx context.Context, tenantID string) ([]*settingsv1.S
 	return settings, nil
 }
 
-func (s *bucketStore) Set(ctx context.Context, tenantID string, setting *settingsv1.Setting) (*settingsv1.Setting, error) {
+func (s *bucketStore) Set(ctx context.Context, tenantID string, setting *settingsv1.Setting, expectedVersion *int64) (*settingsv1.Setting, error) {
 	s.rw.Lock()
 	defer s.rw.Unlock()
 
@@ -75,6 +92,10 @@ func (s *bucketStore) Set(ctx context.Context, tenantID string, setting *setting
 	if err != nil {
 		return nil, err
 	}
+	history, err := s.loadHistory(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 
 	_, ok := s.store[tenantID]
 	if !ok {
@@ -85,16 +106,126 @@ func (s *bucketStore) Set(ctx context.Context, tenantID string, setting *setting
 	if ok && oldSetting.ModifiedAt > setting.ModifiedAt {
 		return nil, errors.Wrapf(oldSettingErr, "failed to update %s", setting.Name)
 	}
+	err = checkVersion(oldSetting, expectedVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update %s", setting.Name)
+	}
+
+	setting.Version = nextVersion(oldSetting, history, setting.Name)
 	s.store[tenantID][setting.Name] = setting
+	history = appendHistory(history, &settingsv1.SettingChange{
+		Name:       setting.Name,
+		Value:      setting.Value,
+		Version:    setting.Version,
+		ModifiedAt: setting.ModifiedAt,
+		ModifiedBy: setting.ModifiedBy,
+	})
 
 	err = s.unsafeFlush(ctx)
 	if err != nil {
 		return nil, err
 	}
+	err = s.uploadHistory(ctx, tenantID, history)
+	if err != nil {
+		return nil, err
+	}
 
 	return setting, nil
 }
 
+func (s *bucketStore) Delete(ctx context.Context, tenantID string, setting *settingsv1.Setting, expectedVersion *int64) error {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	err := s.unsafeLoad(ctx)
+	if err != nil {
+		return err
+	}
+	history, err := s.loadHistory(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	oldSetting, ok := s.store[tenantID][setting.Name]
+	if !ok {
+		return errors.Wrapf(settingNotFoundErr, "failed to delete %s", setting.Name)
+	}
+	if oldSetting.ModifiedAt > setting.ModifiedAt {
+		return errors.Wrapf(oldSettingErr, "failed to delete %s", setting.Name)
+	}
+	err = checkVersion(oldSetting, expectedVersion)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete %s", setting.Name)
+	}
+
+	delete(s.store[tenantID], setting.Name)
+	history = appendHistory(history, &settingsv1.SettingChange{
+		Name:       setting.Name,
+		Deleted:    true,
+		Version:    nextVersion(oldSetting, history, setting.Name),
+		ModifiedAt: setting.ModifiedAt,
+		ModifiedBy: setting.ModifiedBy,
+	})
+
+	err = s.unsafeFlush(ctx)
+	if err != nil {
+		return err
+	}
+	return s.uploadHistory(ctx, tenantID, history)
+}
+
+func (s *bucketStore) History(ctx context.Context, tenantID string) ([]*settingsv1.SettingChange, error) {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	return s.loadHistory(ctx, tenantID)
+}
+
+// checkVersion returns an error if the expected version is set and does not
+// match the version of the setting. A missing setting has version 0.
+func checkVersion(setting *settingsv1.Setting, expectedVersion *int64) error {
+	if expectedVersion == nil {
+		return nil
+	}
+	var version int64
+	if setting != nil {
+		version = setting.Version
+	}
+	if version != *expectedVersion {
+		return errors.Wrapf(versionMismatchErr, "expected version %d, current version %d", *expectedVersion, version)
+	}
+	return nil
+}
+
+// nextVersion returns the version of the next change of a setting.
+// Versions keep increasing after a setting is deleted, so a stale client
+// can't overwrite a setting which was deleted and created again.
+func nextVersion(setting *settingsv1.Setting, history []*settingsv1.SettingChange, name string) int64 {
+	var version int64
+	if setting != nil {
+		version = setting.Version
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Name == name {
+			if history[i].Version > version {
+				version = history[i].Version
+			}
+			break
+		}
+	}
+	return version + 1
+}
+
+// appendHistory records a change of a tenant's setting, dropping the oldest
+// changes if the history is too long.
+func appendHistory(history []*settingsv1.SettingChange, change *settingsv1.SettingChange) []*settingsv1.SettingChange {
+	history = append(history, change)
+	if len(history) > maxHistoryLength {
+		history = history[len(history)-maxHistoryLength:]
+	}
+	return history
+}
+
 func (s *bucketStore) Flush(ctx context.Context) error {
 	s.rw.Lock()
 	defer s.rw.Unlock()
@@ -122,25 +253,61 @@ func (s *bucketStore) unsafeFlush(ctx context.Context) error {
 }
 
 // unsafeLoad will read the store in object storage into memory, if it exists.
-// This is not thread-safe, the store's write mutex should be acquired first.
+// The settings in memory are replaced, so that changes made by other
+// processes, including deletions, are seen. This is not thread-safe, the
+// store's write mutex should be acquired first.
 func (s *bucketStore) unsafeLoad(ctx context.Context) error {
-	reader, err := s.bucket.Get(ctx, settingsFilename)
+	store := make(map[string]map[string]*settingsv1.Setting)
+	found, err := s.loadObject(ctx, settingsFilename, &store)
+	if err != nil || !found {
+		return err
+	}
+	s.store = store
+	return nil
+}
+
+// loadHistory reads the history of the changes of a tenant's settings.
+func (s *bucketStore) loadHistory(ctx context.Context, tenantID string) ([]*settingsv1.SettingChange, error) {
+	var history []*settingsv1.SettingChange
+	_, err := s.loadObject(ctx, historyPath(tenantID), &history)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (s *bucketStore) uploadHistory(ctx context.Context, tenantID string, history []*settingsv1.SettingChange) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return s.bucket.Upload(ctx, historyPath(tenantID), bytes.NewReader(data))
+}
+
+func historyPath(tenantID string) string {
+	return path.Join(tenantID, historyFilename)
+}
+
+// loadObject decodes the JSON object, and returns false if it doesn't exist.
+func (s *bucketStore) loadObject(ctx context.Context, name string, v any) (bool, error) {
+	reader, err := s.bucket.Get(ctx, name)
 	if err != nil {
 		if s.bucket.IsObjNotFoundErr(err) {
 			// It is OK if we don't find the file.
-			return nil
+			return false, nil
 		}
-		return err
+		return false, err
 	}
 
-	err = json.NewDecoder(reader).Decode(&s.store)
+	err = json.NewDecoder(reader).Decode(v)
 	if err != nil {
-		return err
+		_ = reader.Close()
+		return false, err
 	}
 
 	err = reader.Close()
 	if err != nil {
-		return err
+		return false, err
 	}
-	return nil
+	return true, nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/thanos-io/objstore"
 
 	settingsv1 "github.com/grafana/pyroscope/api/gen/proto/go/settings/v1"
 )
@@ -23,7 +24,7 @@ func TestMemoryBucket_Get(t *testing.T) {
 			{Name: "key2", Value: "val2"},
 		}
 		for _, s := range settings {
-			_, err = mem.Set(ctx, tenantID, s)
+			_, err = mem.Set(ctx, tenantID, s, nil)
 			assert.NoError(t, err)
 		}
 		got, err := mem.Get(ctx, tenantID)
@@ -45,7 +46,7 @@ func TestMemoryBucket_Get(t *testing.T) {
 			{Name: "t1_key2", Value: "val2"},
 		}
 		for _, s := range tenant1Settings {
-			_, err = mem.Set(ctx, tenantID, s)
+			_, err = mem.Set(ctx, tenantID, s, nil)
 			assert.NoError(t, err)
 		}
 
@@ -54,7 +55,7 @@ func TestMemoryBucket_Get(t *testing.T) {
 			{Name: "t2_key2", Value: "val2"},
 		}
 		for _, s := range tenant2Settings {
-			_, err = mem.Set(ctx, otherTenantID, s)
+			_, err = mem.Set(ctx, otherTenantID, s, nil)
 			assert.NoError(t, err)
 		}
 
@@ -76,7 +77,7 @@ func TestMemoryBucket_Set(t *testing.T) {
 			Name:  "key1",
 			Value: "val1",
 		}
-		got, err := mem.Set(ctx, tenantID, setting)
+		got, err := mem.Set(ctx, tenantID, setting, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, setting, got)
 	})
@@ -89,7 +90,7 @@ func TestMemoryBucket_Set(t *testing.T) {
 			Name:  "key1",
 			Value: "val1",
 		}
-		got, err := mem.Set(ctx, tenantID, setting)
+		got, err := mem.Set(ctx, tenantID, setting, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, setting, got)
 
@@ -97,7 +98,7 @@ func TestMemoryBucket_Set(t *testing.T) {
 			Name:  "key1",
 			Value: "val2",
 		}
-		got, err = mem.Set(ctx, tenantID, newSetting)
+		got, err = mem.Set(ctx, tenantID, newSetting, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, newSetting, got)
 	})
@@ -111,7 +112,7 @@ func TestMemoryBucket_Set(t *testing.T) {
 			Value:      "val1",
 			ModifiedAt: 10,
 		}
-		got, err := mem.Set(ctx, tenantID, setting)
+		got, err := mem.Set(ctx, tenantID, setting, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, setting, got)
 
@@ -120,7 +121,110 @@ func TestMemoryBucket_Set(t *testing.T) {
 			Value:      "val2",
 			ModifiedAt: 5,
 		}
-		_, err = mem.Set(ctx, tenantID, newSetting)
+		_, err = mem.Set(ctx, tenantID, newSetting, nil)
 		assert.EqualError(t, err, "failed to update key1: newer update already written")
 	})
 }
+
+func TestMemoryBucket_Versions(t *testing.T) {
+	ctx := context.Background()
+	tenantID := "[anonymous]"
+	version := func(v int64) *int64 { return &v }
+
+	mem, err := NewMemoryStore()
+	assert.NoError(t, err)
+
+	got, err := mem.Set(ctx, tenantID, &settingsv1.Setting{Name: "key1", Value: "val1"}, version(0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), got.Version)
+
+	_, err = mem.Set(ctx, tenantID, &settingsv1.Setting{Name: "key1", Value: "val2"}, version(0))
+	assert.EqualError(t, err, "failed to update key1: expected version 0, current version 1: setting was modified concurrently")
+
+	got, err = mem.Set(ctx, tenantID, &settingsv1.Setting{Name: "key1", Value: "val2", ModifiedBy: "alice"}, version(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got.Version)
+
+	err = mem.Delete(ctx, tenantID, &settingsv1.Setting{Name: "key1", ModifiedBy: "bob"}, version(1))
+	assert.EqualError(t, err, "failed to delete key1: expected version 1, current version 2: setting was modified concurrently")
+	err = mem.Delete(ctx, tenantID, &settingsv1.Setting{Name: "key1", ModifiedBy: "bob"}, version(2))
+	assert.NoError(t, err)
+	err = mem.Delete(ctx, tenantID, &settingsv1.Setting{Name: "key1"}, nil)
+	assert.EqualError(t, err, "failed to delete key1: setting not found")
+
+	// Versions keep increasing after a setting was deleted.
+	got, err = mem.Set(ctx, tenantID, &settingsv1.Setting{Name: "key1", Value: "val3"}, version(0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), got.Version)
+
+	history, err := mem.History(ctx, tenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*settingsv1.SettingChange{
+		{Name: "key1", Value: "val1", Version: 1},
+		{Name: "key1", Value: "val2", Version: 2, ModifiedBy: "alice"},
+		{Name: "key1", Deleted: true, Version: 3, ModifiedBy: "bob"},
+		{Name: "key1", Value: "val3", Version: 4},
+	}, history)
+
+	history, err = mem.History(ctx, "other")
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestBucketStore_Persistence(t *testing.T) {
+	ctx := context.Background()
+	bucket := objstore.NewInMemBucket()
+
+	store, err := NewBucketStore(bucket)
+	assert.NoError(t, err)
+	_, err = store.Set(ctx, "tenant", &settingsv1.Setting{Name: "key1", Value: "val1"}, nil)
+	assert.NoError(t, err)
+
+	store, err = NewBucketStore(bucket)
+	assert.NoError(t, err)
+	got, err := store.Get(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, []*settingsv1.Setting{{Name: "key1", Value: "val1", Version: 1}}, got)
+	history, err := store.History(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, []*settingsv1.SettingChange{{Name: "key1", Value: "val1", Version: 1}}, history)
+}
+
+func TestBucketStore_SharedBucket(t *testing.T) {
+	ctx := context.Background()
+	bucket := objstore.NewInMemBucket()
+
+	store1, err := NewBucketStore(bucket)
+	assert.NoError(t, err)
+	store2, err := NewBucketStore(bucket)
+	assert.NoError(t, err)
+
+	_, err = store1.Set(ctx, "tenant", &settingsv1.Setting{Name: "key1", Value: "val1"}, nil)
+	assert.NoError(t, err)
+	_, err = store1.Set(ctx, "other", &settingsv1.Setting{Name: "key1", Value: "val1"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, store2.Delete(ctx, "tenant", &settingsv1.Setting{Name: "key1"}, nil))
+
+	// The versions are checked against the stored settings, which no longer
+	// contain the setting deleted by the other store.
+	version := int64(1)
+	_, err = store1.Set(ctx, "tenant", &settingsv1.Setting{Name: "key1", Value: "val2"}, &version)
+	assert.EqualError(t, err, "failed to update key1: expected version 1, current version 0: setting was modified concurrently")
+	got, err := store1.Get(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	// The history of each tenant is kept in its own object.
+	exists, err := bucket.Exists(ctx, "tenant/settings_history.json")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	history, err := store1.History(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, []*settingsv1.SettingChange{
+		{Name: "key1", Value: "val1", Version: 1},
+		{Name: "key1", Deleted: true, Version: 2},
+	}, history)
+	history, err = store2.History(ctx, "other")
+	assert.NoError(t, err)
+	assert.Equal(t, []*settingsv1.SettingChange{{Name: "key1", Value: "val1", Version: 1}}, history)
+}
@@ -0,0 +1,129 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/exp/slices"
+
+	settingsv1 "github.com/grafana/pyroscope/api/gen/proto/go/settings/v1"
+)
+
+// Definition declares a tenant setting.
+type Definition struct {
+	Name        string
+	Description string
+	Type        settingsv1.SettingType
+	Default     string
+
+	// AllowedValues restricts the value of the setting, if not empty.
+	AllowedValues []string
+}
+
+// Validate returns an error if the value is not valid for the setting.
+func (d Definition) Validate(value string) error {
+	var err error
+	switch d.Type {
+	case settingsv1.SettingType_SETTING_TYPE_BOOL:
+		_, err = strconv.ParseBool(value)
+	case settingsv1.SettingType_SETTING_TYPE_INT:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case settingsv1.SettingType_SETTING_TYPE_DURATION:
+		_, err = model.ParseDuration(value)
+	case settingsv1.SettingType_SETTING_TYPE_JSON:
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("invalid JSON")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value for setting %s of type %s: %w", d.Name, d.Type, err)
+	}
+	if len(d.AllowedValues) > 0 && !slices.Contains(d.AllowedValues, value) {
+		return fmt.Errorf("invalid value for setting %s: must be one of %v", d.Name, d.AllowedValues)
+	}
+	return nil
+}
+
+func (d Definition) proto() *settingsv1.SettingDefinition {
+	return &settingsv1.SettingDefinition{
+		Name:          d.Name,
+		Description:   d.Description,
+		Type:          d.Type,
+		DefaultValue:  d.Default,
+		AllowedValues: d.AllowedValues,
+	}
+}
+
+// Schema is the list of the settings declared to tenants. Settings which
+// are not declared can be set too, e.g. by clients storing their own
+// settings, but their values are not validated.
+type Schema []Definition
+
+// DefaultSchema declares the tenant settings known to Pyroscope.
+var DefaultSchema = Schema{
+	{
+		Name:        "pluginSettings",
+		Description: "Settings of the Grafana Pyroscope app, as a JSON object.",
+		Type:        settingsv1.SettingType_SETTING_TYPE_JSON,
+		Default:     "{}",
+	},
+	{
+		Name:        "ui.maxNodes",
+		Description: "The maximum number of nodes shown in flame graphs.",
+		Type:        settingsv1.SettingType_SETTING_TYPE_INT,
+		Default:     "16384",
+	},
+	{
+		Name:          "ui.defaultTimeRange",
+		Description:   "The time range selected when opening the profile explorer.",
+		Type:          settingsv1.SettingType_SETTING_TYPE_DURATION,
+		Default:       "1h",
+		AllowedValues: []string{"5m", "15m", "30m", "1h", "3h", "6h", "12h", "24h", "7d"},
+	},
+}
+
+// Lookup returns the definition of the setting with the given name.
+func (s Schema) Lookup(name string) (Definition, bool) {
+	for _, d := range s {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Validate returns an error if the setting is declared and its value is not
+// valid.
+func (s Schema) Validate(setting *settingsv1.Setting) error {
+	d, ok := s.Lookup(setting.Name)
+	if !ok {
+		return nil
+	}
+	return d.Validate(setting.Value)
+}
+
+// WithDefaults returns the settings with the default value of the declared
+// settings which are not set, sorted by name.
+func (s Schema) WithDefaults(settings []*settingsv1.Setting) []*settingsv1.Setting {
+	for _, d := range s {
+		if d.Default == "" {
+			continue
+		}
+		set := slices.ContainsFunc(settings, func(setting *settingsv1.Setting) bool {
+			return setting.Name == d.Name
+		})
+		if !set {
+			settings = append(settings, &settingsv1.Setting{
+				Name:  d.Name,
+				Value: d.Default,
+			})
+		}
+	}
+	slices.SortFunc(settings, func(a, b *settingsv1.Setting) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return settings
+}
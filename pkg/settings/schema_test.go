@@ -0,0 +1,45 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	settingsv1 "github.com/grafana/pyroscope/api/gen/proto/go/settings/v1"
+)
+
+func TestDefinition_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		definition Definition
+		value      string
+		valid      bool
+	}{
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_STRING}, "anything", true},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_BOOL}, "true", true},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_BOOL}, "yes", false},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_INT}, "-42", true},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_INT}, "4.2", false},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_DURATION}, "7d", true},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_DURATION}, "7", false},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_JSON}, `{"maxNodes":10}`, true},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_JSON}, `{"maxNodes":`, false},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_STRING, AllowedValues: []string{"a", "b"}}, "b", true},
+		{Definition{Type: settingsv1.SettingType_SETTING_TYPE_STRING, AllowedValues: []string{"a", "b"}}, "c", false},
+	} {
+		err := tc.definition.Validate(tc.value)
+		if tc.valid {
+			require.NoError(t, err, tc.value)
+		} else {
+			require.Error(t, err, tc.value)
+		}
+	}
+}
+
+func TestDefaultSchema(t *testing.T) {
+	names := make(map[string]struct{})
+	for _, d := range DefaultSchema {
+		require.NotContains(t, names, d.Name)
+		names[d.Name] = struct{}{}
+		require.NoError(t, d.Validate(d.Default), d.Name)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -36,6 +37,36 @@ func TestTenantSettings_Get(t *testing.T) {
 		got, err := ts.Get(ctx, req)
 		require.NoError(t, err)
 
+		want := &settingsv1.GetSettingsResponse{
+			Settings: []*settingsv1.Setting{
+				wantSetting,
+				{Name: "limit", Value: "10"},
+			},
+		}
+		require.Equal(t, want, got.Msg)
+	})
+
+	t.Run("set settings override defaults", func(t *testing.T) {
+		const tenantID = "1234"
+		wantSetting := &settingsv1.Setting{
+			Name:       "limit",
+			Value:      "20",
+			ModifiedAt: 100,
+		}
+
+		ts, cleanup := newTestTenantSettings(t, map[string][]*settingsv1.Setting{
+			tenantID: {
+				wantSetting,
+			},
+		})
+		defer cleanup()
+
+		ctx := tenant.InjectTenantID(context.Background(), tenantID)
+		req := &connect.Request[settingsv1.GetSettingsRequest]{}
+
+		got, err := ts.Get(ctx, req)
+		require.NoError(t, err)
+
 		want := &settingsv1.GetSettingsResponse{
 			Settings: []*settingsv1.Setting{wantSetting},
 		}
@@ -235,12 +266,13 @@ func TestTenantSettings_Set(t *testing.T) {
 		wantErr := fmt.Errorf("settings store failed")
 
 		// Get method fails once.
-		store.On("Set", mock.Anything, mock.Anything, mock.Anything).
+		store.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, wantErr).
 			Once()
 
 		ts := &TenantSettings{
 			store:  store,
+			schema: testSchema,
 			logger: log.NewNopLogger(),
 		}
 
@@ -260,6 +292,11 @@ func TestTenantSettings_Set(t *testing.T) {
 	})
 }
 
+var testSchema = Schema{
+	{Name: "key1", Type: settingsv1.SettingType_SETTING_TYPE_STRING},
+	{Name: "limit", Type: settingsv1.SettingType_SETTING_TYPE_INT, Default: "10"},
+}
+
 func newTestTenantSettings(t *testing.T, initial map[string][]*settingsv1.Setting) (*TenantSettings, func()) {
 	t.Helper()
 
@@ -268,13 +305,14 @@ func newTestTenantSettings(t *testing.T, initial map[string][]*settingsv1.Settin
 
 	for tenant, settings := range initial {
 		for _, setting := range settings {
-			_, err = store.Set(context.Background(), tenant, setting)
+			_, err = store.Set(context.Background(), tenant, setting, nil)
 			require.NoError(t, err)
 		}
 	}
 
 	ts := &TenantSettings{
 		store:  store,
+		schema: testSchema,
 		logger: log.NewNopLogger(),
 	}
 
@@ -298,8 +336,8 @@ func (s *fakeStore) Get(ctx context.Context, tenantID string) ([]*settingsv1.Set
 	return args.Get(0).([]*settingsv1.Setting), args.Error(1)
 }
 
-func (s *fakeStore) Set(ctx context.Context, tenantID string, setting *settingsv1.Setting) (*settingsv1.Setting, error) {
-	args := s.Called(ctx, tenantID, setting)
+func (s *fakeStore) Set(ctx context.Context, tenantID string, setting *settingsv1.Setting, expectedVersion *int64) (*settingsv1.Setting, error) {
+	args := s.Called(ctx, tenantID, setting, expectedVersion)
 	if args.Get(0) == nil {
 		args[0] = &settingsv1.Setting{}
 	}
@@ -307,6 +345,20 @@ func (s *fakeStore) Set(ctx context.Context, tenantID string, setting *settingsv
 	return args.Get(0).(*settingsv1.Setting), args.Error(1)
 }
 
+func (s *fakeStore) Delete(ctx context.Context, tenantID string, setting *settingsv1.Setting, expectedVersion *int64) error {
+	args := s.Called(ctx, tenantID, setting, expectedVersion)
+	return args.Error(0)
+}
+
+func (s *fakeStore) History(ctx context.Context, tenantID string) ([]*settingsv1.SettingChange, error) {
+	args := s.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		args[0] = []*settingsv1.SettingChange{}
+	}
+
+	return args.Get(0).([]*settingsv1.SettingChange), args.Error(1)
+}
+
 func (s *fakeStore) Flush(ctx context.Context) error {
 	args := s.Called(ctx)
 	return args.Error(0)
@@ -316,3 +368,134 @@ func (s *fakeStore) Close() error {
 	args := s.Called()
 	return args.Error(0)
 }
+
+func TestTenantSettings_Validation(t *testing.T) {
+	const tenantID = "1234"
+	ts, cleanup := newTestTenantSettings(t, map[string][]*settingsv1.Setting{})
+	defer cleanup()
+	ctx := tenant.InjectTenantID(context.Background(), tenantID)
+
+	for _, tc := range []struct {
+		setting *settingsv1.Setting
+		wantErr string
+	}{
+		{
+			setting: &settingsv1.Setting{Name: "unknown", Value: "val1"},
+		},
+		{
+			setting: &settingsv1.Setting{Name: "limit", Value: "ten"},
+			wantErr: `invalid_argument: invalid value for setting limit of type SETTING_TYPE_INT: strconv.ParseInt: parsing "ten": invalid syntax`,
+		},
+		{
+			setting: &settingsv1.Setting{Name: "limit", Value: "20"},
+		},
+	} {
+		_, err := ts.Set(ctx, connect.NewRequest(&settingsv1.SetSettingsRequest{Setting: tc.setting}))
+		if tc.wantErr != "" {
+			require.EqualError(t, err, tc.wantErr)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	got, err := ts.GetSchema(ctx, connect.NewRequest(&settingsv1.GetSettingsSchemaRequest{}))
+	require.NoError(t, err)
+	require.Equal(t, []*settingsv1.SettingDefinition{
+		{Name: "key1", Type: settingsv1.SettingType_SETTING_TYPE_STRING},
+		{Name: "limit", Type: settingsv1.SettingType_SETTING_TYPE_INT, DefaultValue: "10"},
+	}, got.Msg.Definitions)
+}
+
+func TestTenantSettings_DefaultSchema(t *testing.T) {
+	store, err := NewMemoryStore()
+	require.NoError(t, err)
+	ts, err := New(store, log.NewNopLogger())
+	require.NoError(t, err)
+	defer ts.store.Close()
+	ctx := tenant.InjectTenantID(context.Background(), "1234")
+
+	got, err := ts.Get(ctx, connect.NewRequest(&settingsv1.GetSettingsRequest{}))
+	require.NoError(t, err)
+	require.Equal(t, []*settingsv1.Setting{
+		{Name: "pluginSettings", Value: "{}"},
+		{Name: "ui.defaultTimeRange", Value: "1h"},
+		{Name: "ui.maxNodes", Value: "16384"},
+	}, got.Msg.Settings)
+
+	for _, setting := range []*settingsv1.Setting{
+		{Name: "pluginSettings", Value: "{"},
+		{Name: "ui.maxNodes", Value: "many"},
+		{Name: "ui.defaultTimeRange", Value: "1"},
+		{Name: "ui.defaultTimeRange", Value: "2h"},
+	} {
+		_, err = ts.Set(ctx, connect.NewRequest(&settingsv1.SetSettingsRequest{Setting: setting}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), setting.Value)
+	}
+
+	_, err = ts.Set(ctx, connect.NewRequest(&settingsv1.SetSettingsRequest{
+		Setting: &settingsv1.Setting{Name: "ui.maxNodes", Value: "1024"},
+	}))
+	require.NoError(t, err)
+	got, err = ts.Get(ctx, connect.NewRequest(&settingsv1.GetSettingsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, got.Msg.Settings, 3)
+	require.Equal(t, "ui.maxNodes", got.Msg.Settings[2].Name)
+	require.Equal(t, "1024", got.Msg.Settings[2].Value)
+}
+
+func TestTenantSettings_DeleteAndHistory(t *testing.T) {
+	const tenantID = "1234"
+	ts, cleanup := newTestTenantSettings(t, map[string][]*settingsv1.Setting{})
+	defer cleanup()
+	ctx := tenant.InjectTenantID(context.Background(), tenantID)
+
+	set := func(name, value string, expectedVersion *int64) (*settingsv1.Setting, error) {
+		req := connect.NewRequest(&settingsv1.SetSettingsRequest{
+			Setting:         &settingsv1.Setting{Name: name, Value: value},
+			ExpectedVersion: expectedVersion,
+		})
+		req.Header().Set("X-Grafana-User", "mallory")
+		resp, err := ts.Set(user.InjectUserID(ctx, "alice"), req)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg.Setting, nil
+	}
+
+	setting, err := set("key1", "val1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "alice", setting.ModifiedBy)
+	require.Equal(t, int64(1), setting.Version)
+	_, err = set("limit", "20", nil)
+	require.NoError(t, err)
+
+	stale := int64(0)
+	_, err = set("key1", "val2", &stale)
+	require.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+
+	_, err = ts.Delete(ctx, connect.NewRequest(&settingsv1.DeleteSettingsRequest{Name: "key1", ExpectedVersion: &stale}))
+	require.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+	_, err = ts.Delete(ctx, connect.NewRequest(&settingsv1.DeleteSettingsRequest{Name: "key1"}))
+	require.NoError(t, err)
+	_, err = ts.Delete(ctx, connect.NewRequest(&settingsv1.DeleteSettingsRequest{Name: "key1"}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = ts.Delete(ctx, connect.NewRequest(&settingsv1.DeleteSettingsRequest{}))
+	require.EqualError(t, err, "invalid_argument: no setting name provided")
+
+	got, err := ts.Get(ctx, connect.NewRequest(&settingsv1.GetSettingsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, got.Msg.Settings, 1)
+	require.Equal(t, "limit", got.Msg.Settings[0].Name)
+
+	history, err := ts.GetHistory(ctx, connect.NewRequest(&settingsv1.GetSettingsHistoryRequest{Name: "key1"}))
+	require.NoError(t, err)
+	require.Len(t, history.Msg.Changes, 2)
+	require.Equal(t, "val1", history.Msg.Changes[0].Value)
+	require.Equal(t, "alice", history.Msg.Changes[0].ModifiedBy)
+	require.True(t, history.Msg.Changes[1].Deleted)
+	require.Equal(t, int64(2), history.Msg.Changes[1].Version)
+
+	history, err = ts.GetHistory(ctx, connect.NewRequest(&settingsv1.GetSettingsHistoryRequest{}))
+	require.NoError(t, err)
+	require.Len(t, history.Msg.Changes, 3)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/services"
 	"github.com/grafana/dskit/tenant"
+	"github.com/grafana/dskit/user"
 	"github.com/pkg/errors"
 
 	settingsv1 "github.com/grafana/pyroscope/api/gen/proto/go/settings/v1"
@@ -18,6 +19,7 @@ import (
 func New(store Store, logger log.Logger) (*TenantSettings, error) {
 	ts := &TenantSettings{
 		store:  store,
+		schema: DefaultSchema,
 		logger: logger,
 	}
 
@@ -30,6 +32,7 @@ type TenantSettings struct {
 	services.Service
 
 	store  Store
+	schema Schema
 	logger log.Logger
 }
 
@@ -88,7 +91,7 @@ func (ts *TenantSettings) Get(ctx context.Context, req *connect.Request[settings
 	}
 
 	return connect.NewResponse(&settingsv1.GetSettingsResponse{
-		Settings: settings,
+		Settings: ts.schema.WithDefaults(settings),
 	}), nil
 }
 
@@ -102,19 +105,103 @@ func (ts *TenantSettings) Set(ctx context.Context, req *connect.Request[settings
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no setting values provided"))
 	}
 
+	err = ts.schema.Validate(req.Msg.Setting)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	if req.Msg.Setting.ModifiedAt <= 0 {
 		req.Msg.Setting.ModifiedAt = time.Now().UnixMilli()
 	}
+	req.Msg.Setting.ModifiedBy = modifiedBy(ctx)
 
-	setting, err := ts.store.Set(ctx, tenantID, req.Msg.Setting)
+	setting, err := ts.store.Set(ctx, tenantID, req.Msg.Setting, req.Msg.ExpectedVersion)
 	if err != nil {
-		if errors.Is(err, oldSettingErr) {
-			return nil, connect.NewError(connect.CodeAlreadyExists, err)
-		}
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, storeError(err)
 	}
 
 	return connect.NewResponse(&settingsv1.SetSettingsResponse{
 		Setting: setting,
 	}), nil
 }
+
+func (ts *TenantSettings) Delete(ctx context.Context, req *connect.Request[settingsv1.DeleteSettingsRequest]) (*connect.Response[settingsv1.DeleteSettingsResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if req.Msg == nil || req.Msg.Name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no setting name provided"))
+	}
+
+	err = ts.store.Delete(ctx, tenantID, &settingsv1.Setting{
+		Name:       req.Msg.Name,
+		ModifiedAt: time.Now().UnixMilli(),
+		ModifiedBy: modifiedBy(ctx),
+	}, req.Msg.ExpectedVersion)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	return connect.NewResponse(&settingsv1.DeleteSettingsResponse{}), nil
+}
+
+func (ts *TenantSettings) GetSchema(ctx context.Context, req *connect.Request[settingsv1.GetSettingsSchemaRequest]) (*connect.Response[settingsv1.GetSettingsSchemaResponse], error) {
+	definitions := make([]*settingsv1.SettingDefinition, 0, len(ts.schema))
+	for _, d := range ts.schema {
+		definitions = append(definitions, d.proto())
+	}
+
+	return connect.NewResponse(&settingsv1.GetSettingsSchemaResponse{
+		Definitions: definitions,
+	}), nil
+}
+
+func (ts *TenantSettings) GetHistory(ctx context.Context, req *connect.Request[settingsv1.GetSettingsHistoryRequest]) (*connect.Response[settingsv1.GetSettingsHistoryResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	changes, err := ts.store.History(ctx, tenantID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if name := req.Msg.GetName(); name != "" {
+		filtered := changes[:0]
+		for _, c := range changes {
+			if c.Name == name {
+				filtered = append(filtered, c)
+			}
+		}
+		changes = filtered
+	}
+
+	return connect.NewResponse(&settingsv1.GetSettingsHistoryResponse{
+		Changes: changes,
+	}), nil
+}
+
+// modifiedBy returns the user the request was authenticated as, which is
+// forwarded with the tenant ID by the authenticating proxy. It is empty if
+// the proxy doesn't forward it, or multi-tenancy is disabled.
+func modifiedBy(ctx context.Context) string {
+	userID, _ := user.ExtractUserID(ctx)
+	return userID
+}
+
+// storeError maps an error of the store to a connect error.
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, oldSettingErr):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, versionMismatchErr):
+		return connect.NewError(connect.CodeAborted, err)
+	case errors.Is(err, settingNotFoundErr):
+		return connect.NewError(connect.CodeNotFound, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
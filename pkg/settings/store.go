@@ -10,8 +10,18 @@ type Store interface {
 	// Get settings for a tenant.
 	Get(ctx context.Context, tenantID string) ([]*settingsv1.Setting, error)
 
-	// Set a setting for a tenant.
-	Set(ctx context.Context, tenantID string, setting *settingsv1.Setting) (*settingsv1.Setting, error)
+	// Set a setting for a tenant. If expectedVersion is not nil, the setting
+	// is only updated if its current version matches.
+	Set(ctx context.Context, tenantID string, setting *settingsv1.Setting, expectedVersion *int64) (*settingsv1.Setting, error)
+
+	// Delete a setting for a tenant. Only the name, modifiedAt and modifiedBy
+	// of the setting are used. If expectedVersion is not nil, the setting is
+	// only deleted if its current version matches.
+	Delete(ctx context.Context, tenantID string, setting *settingsv1.Setting, expectedVersion *int64) error
+
+	// History returns the changes made to the settings of a tenant, oldest
+	// first.
+	History(ctx context.Context, tenantID string) ([]*settingsv1.SettingChange, error)
 
 	// Flush the store to disk.
 	Flush(ctx context.Context) error
@@ -19,6 +19,7 @@ import (
 	phlareobj "github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/phlaredb"
 	"github.com/grafana/pyroscope/pkg/phlaredb/block"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 )
 
 // TODO move this to a config.
@@ -34,6 +35,7 @@ type BucketStore struct {
 	fetcher block.MetadataFetcher
 
 	tenantID, syncDir string
+	symbolizer        symdb.Symbolizer
 
 	logger log.Logger
 
@@ -45,15 +47,16 @@ type BucketStore struct {
 	stats   BucketStoreStats
 }
 
-func NewBucketStore(bucket phlareobj.Bucket, fetcher block.MetadataFetcher, tenantID string, syncDir string, logger log.Logger, reg prometheus.Registerer) (*BucketStore, error) {
+func NewBucketStore(bucket phlareobj.Bucket, fetcher block.MetadataFetcher, tenantID string, syncDir string, symbolizer symdb.Symbolizer, logger log.Logger, reg prometheus.Registerer) (*BucketStore, error) {
 	s := &BucketStore{
-		fetcher:  fetcher,
-		bucket:   phlareobj.NewTenantBucketClient(tenantID, bucket, nil),
-		tenantID: tenantID,
-		syncDir:  syncDir,
-		logger:   log.With(logger, "tenant", tenantID),
-		blockSet: newBucketBlockSet(),
-		blocks:   map[ulid.ULID]*Block{},
+		fetcher:    fetcher,
+		bucket:     phlareobj.NewTenantBucketClient(tenantID, bucket, nil),
+		tenantID:   tenantID,
+		syncDir:    syncDir,
+		symbolizer: symbolizer,
+		logger:     log.With(logger, "tenant", tenantID),
+		blockSet:   newBucketBlockSet(),
+		blocks:     map[ulid.ULID]*Block{},
 		metrics: NewBucketStoreMetrics(prometheus.WrapRegistererWith(
 			prometheus.Labels{"tenant": tenantID},
 			reg,
@@ -181,6 +184,7 @@ func (bs *BucketStore) addBlock(ctx context.Context, meta *block.Meta) (err erro
 		bs.blocksMx.Lock()
 		defer bs.blocksMx.Unlock()
 		ctx = phlaredb.ContextWithBlockMetrics(ctx, bs.metrics.blockMetrics)
+		ctx = phlaredb.ContextWithSymbolizer(ctx, bs.symbolizer)
 		b, err := bs.createBlock(ctx, meta)
 		if err != nil {
 			return nil, errors.Wrap(err, "load block from disk")
@@ -19,6 +19,7 @@ import (
 	phlareobj "github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/phlaredb/block"
 	"github.com/grafana/pyroscope/pkg/phlaredb/bucket"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 	"github.com/grafana/pyroscope/pkg/util"
 )
 
@@ -107,6 +108,7 @@ type BucketStores struct {
 	syncBackoffConfig backoff.Config
 	shardingStrategy  ShardingStrategy
 	limits            Limits
+	symbolizer        symdb.Symbolizer
 	reg               prometheus.Registerer
 	// Keeps a bucket store for each tenant.
 	storesMu sync.RWMutex
@@ -120,7 +122,7 @@ type BucketStores struct {
 	blocksLoaded      prometheus.GaugeFunc
 }
 
-func NewBucketStores(cfg BucketStoreConfig, shardingStrategy ShardingStrategy, storageBucket phlareobj.Bucket, limits Limits, logger log.Logger, reg prometheus.Registerer) (*BucketStores, error) {
+func NewBucketStores(cfg BucketStoreConfig, shardingStrategy ShardingStrategy, storageBucket phlareobj.Bucket, limits Limits, symbolizer symdb.Symbolizer, logger log.Logger, reg prometheus.Registerer) (*BucketStores, error) {
 	bs := &BucketStores{
 		storageBucket: storageBucket,
 		logger:        logger,
@@ -134,6 +136,7 @@ func NewBucketStores(cfg BucketStoreConfig, shardingStrategy ShardingStrategy, s
 		shardingStrategy: shardingStrategy,
 		reg:              reg,
 		limits:           limits,
+		symbolizer:       symbolizer,
 	}
 	// Register metrics.
 	bs.syncTimes = promauto.With(reg).NewHistogram(prometheus.HistogramOpts{
@@ -341,6 +344,7 @@ func (bs *BucketStores) getOrCreateStore(userID string) (*BucketStore, error) {
 		fetcher,
 		userID,
 		bs.syncDirForUser(userID),
+		bs.symbolizer,
 		userLogger,
 		bs.reg,
 	)
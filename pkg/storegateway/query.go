@@ -3,6 +3,7 @@ package storegateway
 import (
 	"context"
 	"io"
+	"net/http"
 
 	"connectrpc.com/connect"
 	"github.com/pkg/errors"
@@ -11,12 +12,16 @@ import (
 	ingestv1 "github.com/grafana/pyroscope/api/gen/proto/go/ingester/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/phlaredb"
+	"github.com/grafana/pyroscope/pkg/phlaredb/query"
+	"github.com/grafana/pyroscope/pkg/querier/stats"
 	"github.com/grafana/pyroscope/pkg/tenant"
 )
 
 func (s *StoreGateway) MergeProfilesStacktraces(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeProfilesStacktracesRequest, ingestv1.MergeProfilesStacktracesResponse]) error {
 	found, err := s.forBucketStore(ctx, func(bs *BucketStore) error {
-		return bs.MergeProfilesStacktraces(ctx, stream)
+		return s.limitReads(ctx, bs, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return bs.MergeProfilesStacktraces(ctx, stream)
+		})
 	})
 	if err != nil || found {
 		return err
@@ -26,7 +31,9 @@ func (s *StoreGateway) MergeProfilesStacktraces(ctx context.Context, stream *con
 
 func (s *StoreGateway) MergeProfilesLabels(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeProfilesLabelsRequest, ingestv1.MergeProfilesLabelsResponse]) error {
 	found, err := s.forBucketStore(ctx, func(bs *BucketStore) error {
-		return bs.MergeProfilesLabels(ctx, stream)
+		return s.limitReads(ctx, bs, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return bs.MergeProfilesLabels(ctx, stream)
+		})
 	})
 	if err != nil || found {
 		return err
@@ -36,7 +43,9 @@ func (s *StoreGateway) MergeProfilesLabels(ctx context.Context, stream *connect.
 
 func (s *StoreGateway) MergeProfilesPprof(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeProfilesPprofRequest, ingestv1.MergeProfilesPprofResponse]) error {
 	found, err := s.forBucketStore(ctx, func(bs *BucketStore) error {
-		return bs.MergeProfilesPprof(ctx, stream)
+		return s.limitReads(ctx, bs, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return bs.MergeProfilesPprof(ctx, stream)
+		})
 	})
 	if err != nil || found {
 		return err
@@ -115,7 +124,9 @@ func (s *StoreGateway) Series(ctx context.Context, req *connect.Request[ingestv1
 
 func (s *StoreGateway) MergeSpanProfile(ctx context.Context, stream *connect.BidiStream[ingestv1.MergeSpanProfileRequest, ingestv1.MergeSpanProfileResponse]) error {
 	found, err := s.forBucketStore(ctx, func(bs *BucketStore) error {
-		return bs.MergeSpanProfile(ctx, stream)
+		return s.limitReads(ctx, bs, stream.ResponseTrailer(), func(ctx context.Context) error {
+			return bs.MergeSpanProfile(ctx, stream)
+		})
 	})
 	if err != nil || found {
 		return err
@@ -166,14 +177,27 @@ func (s *StoreGateway) forBucketStore(ctx context.Context, f func(*BucketStore)
 	return false, nil
 }
 
+// limitReads enforces the max_query_bytes limit of the tenant on the
+// query, and reports the data read and the stack traces resolved to the
+// querier in the response trailer.
+func (s *StoreGateway) limitReads(ctx context.Context, bs *BucketStore, trailer http.Header, fn func(context.Context) error) error {
+	return phlaredb.LimitReads(ctx, s.stores.limits, bs.tenantID, func(r *query.ReadStats) {
+		stats.SetReadHeaders(trailer, r.Bytes(), r.Rows(), r.Stacktraces())
+	}, fn)
+}
+
 func (s *BucketStore) openBlocksForReading(ctx context.Context, minT, maxT model.Time, hints *ingestv1.Hints) (phlaredb.Queriers, error) {
 	skipBlock := phlaredb.HintsToBlockSkipper(hints)
+	skipLabels := phlaredb.QueryLabelsBlockSkipper(ctx)
 	blks := s.blockSet.getFor(minT, maxT)
 	querier := make(phlaredb.Queriers, 0, len(blks))
 	for _, b := range blks {
 		if skipBlock(b.BlockID()) {
 			continue
 		}
+		if skipLabels(b.meta) {
+			continue
+		}
 		querier = append(querier, b)
 	}
 	if err := querier.Open(ctx); err != nil {
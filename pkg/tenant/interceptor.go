@@ -39,6 +39,7 @@ func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 			if tenantID != "" {
 				req.Header().Set("X-Scope-OrgID", tenantID)
 			}
+			injectUserIDIntoHeaders(ctx, req.Header())
 			return next(ctx, req)
 		}
 		// Server side if the interceptor is enabled, we extract the tenantID from the request header and inject it into the context
@@ -47,6 +48,7 @@ func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 			return next(InjectTenantID(ctx, DefaultTenantID), req)
 		}
 		_, ctx, _ = ExtractTenantIDFromHeaders(ctx, req.Header())
+		ctx = extractUserIDFromHeaders(ctx, req.Header())
 
 		resp, err := next(ctx, req)
 		if err != nil && errors.Is(err, ErrNoTenantID) {
@@ -63,6 +65,7 @@ func (i *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc)
 		if tenantID != "" {
 			conn.RequestHeader().Set("X-Scope-OrgID", tenantID)
 		}
+		injectUserIDIntoHeaders(ctx, conn.RequestHeader())
 		return conn
 	}
 }
@@ -73,6 +76,7 @@ func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc
 			return next(InjectTenantID(ctx, DefaultTenantID), conn)
 		}
 		_, ctx, _ = ExtractTenantIDFromHeaders(ctx, conn.RequestHeader())
+		ctx = extractUserIDFromHeaders(ctx, conn.RequestHeader())
 		if err := next(ctx, conn); err != nil {
 			if errors.Is(err, ErrNoTenantID) {
 				return connect.NewError(connect.CodeUnauthenticated, err)
@@ -101,6 +105,21 @@ func ExtractTenantIDFromHeaders(ctx context.Context, headers http.Header) (strin
 	return tenantID, ctx, nil
 }
 
+// extractUserIDFromHeaders injects the ID of the user the request was
+// authenticated as into the context, if the header is set.
+func extractUserIDFromHeaders(ctx context.Context, headers http.Header) context.Context {
+	if userID := headers.Get(user.UserIDHeaderName); userID != "" {
+		return user.InjectUserID(ctx, userID)
+	}
+	return ctx
+}
+
+func injectUserIDIntoHeaders(ctx context.Context, headers http.Header) {
+	if userID, _ := user.ExtractUserID(ctx); userID != "" {
+		headers.Set(user.UserIDHeaderName, userID)
+	}
+}
+
 // ExtractTenantIDFromContext extracts a single TenantID from the context.
 func ExtractTenantIDFromContext(ctx context.Context) (string, error) {
 	tenantID, err := defaultResolver.TenantID(ctx)
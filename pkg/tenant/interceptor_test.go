@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"connectrpc.com/connect"
+	"github.com/grafana/dskit/user"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,13 +60,40 @@ func Test_AuthInterceptor(t *testing.T) {
 			require.NoError(t, err)
 			require.Nil(t, resp)
 		},
+		"server: enable, forward user header": func(t *testing.T) {
+			i := NewAuthInterceptor(true)
+			req := newFakeReq(false)
+			req.Header().Set("X-Scope-OrgID", "foo")
+			req.Header().Set("X-Scope-UserID", "alice")
+			resp, err := i.WrapUnary(func(ctx context.Context, ar connect.AnyRequest) (connect.AnyResponse, error) {
+				userID, err := user.ExtractUserID(ctx)
+				require.NoError(t, err)
+				require.Equal(t, "alice", userID)
+				return nil, nil
+			})(context.Background(), req)
+			require.NoError(t, err)
+			require.Nil(t, resp)
+		},
+		"server: disable, no user": func(t *testing.T) {
+			i := NewAuthInterceptor(false)
+			req := newFakeReq(false)
+			req.Header().Set("X-Scope-UserID", "alice")
+			resp, err := i.WrapUnary(func(ctx context.Context, ar connect.AnyRequest) (connect.AnyResponse, error) {
+				_, err := user.ExtractUserID(ctx)
+				require.Equal(t, user.ErrNoUserID, err)
+				return nil, nil
+			})(context.Background(), req)
+			require.NoError(t, err)
+			require.Nil(t, resp)
+		},
 		"streaming client should forward from context": func(t *testing.T) {
 			i := NewAuthInterceptor(false)
 			inConn := newFakeClientStreamingConn()
 			outConn := i.WrapStreamingClient(func(ctx context.Context, s connect.Spec) connect.StreamingClientConn {
 				return inConn
-			})(InjectTenantID(context.Background(), "foo"), connect.Spec{})
+			})(user.InjectUserID(InjectTenantID(context.Background(), "foo"), "alice"), connect.Spec{})
 			require.Equal(t, "foo", outConn.RequestHeader().Get("X-Scope-OrgID"))
+			require.Equal(t, "alice", outConn.RequestHeader().Get("X-Scope-UserID"))
 		},
 		"streaming server should forward from header to context if enabled": func(t *testing.T) {
 			i := NewAuthInterceptor(true)
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/grafana/dskit/flagext"
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v3"
 
 	"github.com/grafana/pyroscope/pkg/phlaredb/block"
+	"github.com/grafana/pyroscope/pkg/pprof"
 )
 
 const (
@@ -40,6 +42,12 @@ type Limits struct {
 	MaxProfileStacktraceDepth        int `yaml:"max_profile_stacktrace_depth" json:"max_profile_stacktrace_depth"`
 	MaxProfileSymbolValueLength      int `yaml:"max_profile_symbol_value_length" json:"max_profile_symbol_value_length"`
 
+	// Sample labels kept at the sample level.
+	SampleLabels flagext.StringSliceCSV `yaml:"sample_labels" json:"sample_labels"`
+
+	// Stack trace normalization rules applied by the distributor.
+	IngestionNormalizationRules []*pprof.NormalizationRule `yaml:"ingestion_normalization_rules" json:"ingestion_normalization_rules" doc:"nocli|description=List of stack trace normalization rules applied to the ingested profiles in the given order. Each rule has an action: drop or keep frames of the functions with names matching regex, rename functions by replacing regex matches with replacement, collapse_recursion, or trim stack traces to max_depth frames."`
+
 	// Distributor aggregation.
 	DistributorAggregationWindow model.Duration `yaml:"distributor_aggregation_window" json:"distributor_aggregation_window"`
 	DistributorAggregationPeriod model.Duration `yaml:"distributor_aggregation_period" json:"distributor_aggregation_period"`
@@ -58,6 +66,8 @@ type Limits struct {
 	MaxQueryLookback    model.Duration `yaml:"max_query_lookback" json:"max_query_lookback"`
 	MaxQueryLength      model.Duration `yaml:"max_query_length" json:"max_query_length"`
 	MaxQueryParallelism int            `yaml:"max_query_parallelism" json:"max_query_parallelism"`
+	MaxQueryBytes       int            `yaml:"max_query_bytes" json:"max_query_bytes"`
+	MaxQueryProfiles    int            `yaml:"max_query_profiles" json:"max_query_profiles"`
 
 	// FlameGraph enforced limits.
 	MaxFlameGraphNodesDefault int `yaml:"max_flamegraph_nodes_default" json:"max_flamegraph_nodes_default"`
@@ -68,6 +78,7 @@ type Limits struct {
 
 	// Query frontend.
 	QuerySplitDuration model.Duration `yaml:"split_queries_by_interval" json:"split_queries_by_interval"`
+	QueryShards        int            `yaml:"query_sharding_total_shards" json:"query_sharding_total_shards"`
 
 	// Compactor.
 	CompactorBlocksRetentionPeriod     model.Duration `yaml:"compactor_blocks_retention_period" json:"compactor_blocks_retention_period"`
@@ -84,6 +95,11 @@ type Limits struct {
 	S3SSEKMSKeyID             string `yaml:"s3_sse_kms_key_id" json:"s3_sse_kms_key_id" doc:"nocli|description=S3 server-side encryption KMS Key ID. Ignored if the SSE type override is not set."`
 	S3SSEKMSEncryptionContext string `yaml:"s3_sse_kms_encryption_context" json:"s3_sse_kms_encryption_context" doc:"nocli|description=S3 server-side encryption KMS encryption context. If unset and the key ID override is set, the encryption context will not be provided to S3. Ignored if the SSE type override is not set."`
 
+	// Ad-hoc profiles.
+	AdHocProfilesMaxProfiles       int            `yaml:"adhoc_profiles_max_profiles" json:"adhoc_profiles_max_profiles"`
+	AdHocProfilesMaxTotalSizeBytes int            `yaml:"adhoc_profiles_max_total_size_bytes" json:"adhoc_profiles_max_total_size_bytes"`
+	AdHocProfilesRetentionPeriod   model.Duration `yaml:"adhoc_profiles_retention_period" json:"adhoc_profiles_retention_period"`
+
 	// Ensure profiles are dated within the IngestionWindow of the distributor.
 	RejectOlderThan model.Duration `yaml:"reject_older_than" json:"reject_older_than"`
 	RejectNewerThan model.Duration `yaml:"reject_newer_than" json:"reject_newer_than"`
@@ -122,7 +138,11 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	_ = l.QuerySplitDuration.Set("0s")
 	f.Var(&l.QuerySplitDuration, "querier.split-queries-by-interval", "Split queries by a time interval and execute in parallel. The value 0 disables splitting by time")
 
+	f.IntVar(&l.QueryShards, "querier.query-sharding-total-shards", 0, "The number of shards each split query is divided into by series fingerprint. Flame graph, pprof and span profile queries are sharded, as well as time series queries with the sum aggregation. The value 0 or 1 disables query sharding.")
+
 	f.IntVar(&l.MaxQueryParallelism, "querier.max-query-parallelism", 0, "Maximum number of queries that will be scheduled in parallel by the frontend.")
+	f.IntVar(&l.MaxQueryBytes, "querier.max-query-bytes", 0, "Maximum number of bytes of parquet pages a query can read in ingesters and store-gateways. Each ingester and store-gateway cancels its part of the query once it exceeds the limit, and the query fails once the total exceeds it. 0 to disable.")
+	f.IntVar(&l.MaxQueryProfiles, "querier.max-query-profiles", 0, "Maximum number of profiles a query can fetch from ingesters and store-gateways. The query is cancelled once the limit is exceeded. 0 to disable.")
 
 	f.IntVar(&l.MaxProfileSizeBytes, "validation.max-profile-size-bytes", 4*1024*1024, "Maximum size of a profile in bytes. This is based off the uncompressed size. 0 to disable.")
 	f.IntVar(&l.MaxProfileStacktraceSamples, "validation.max-profile-stacktrace-samples", 16000, "Maximum number of samples in a profile. 0 to disable.")
@@ -130,6 +150,8 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.MaxProfileStacktraceDepth, "validation.max-profile-stacktrace-depth", 1000, "Maximum depth of a profile stacktrace. Profiles are not rejected instead stacktraces are truncated. 0 to disable.")
 	f.IntVar(&l.MaxProfileSymbolValueLength, "validation.max-profile-symbol-value-length", 65535, "Maximum length of a profile symbol value (labels, function names and filenames, etc...). Profiles are not rejected instead symbol values are truncated. 0 to disable.")
 
+	f.Var(&l.SampleLabels, "distributor.sample-labels", "Comma-separated list of pprof sample label names that are stored at the sample level instead of being promoted to series labels. Such labels do not create new series and can be matched at query time with a sample label selector.")
+
 	f.IntVar(&l.MaxFlameGraphNodesDefault, "querier.max-flamegraph-nodes-default", 8<<10, "Maximum number of flamegraph nodes by default. 0 to disable.")
 	f.IntVar(&l.MaxFlameGraphNodesMax, "querier.max-flamegraph-nodes-max", 0, "Maximum number of flamegraph nodes allowed. 0 to disable.")
 
@@ -145,6 +167,10 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.Var(&l.CompactorPartialBlockDeletionDelay, "compactor.partial-block-deletion-delay", fmt.Sprintf("If a partial block (unfinished block without %s file) hasn't been modified for this time, it will be marked for deletion. The minimum accepted value is %s: a lower value will be ignored and the feature disabled. 0 to disable.", block.MetaFilename, MinCompactorPartialBlockDeletionDelay.String()))
 	f.BoolVar(&l.CompactorDownsamplerEnabled, "compactor.compactor-downsampler-enabled", true, "If enabled, the compactor will downsample profiles in blocks at compaction level 3 and above. The original profiles are also kept.")
 
+	f.IntVar(&l.AdHocProfilesMaxProfiles, "adhoc-profiles.max-profiles", 0, "Maximum number of ad-hoc profiles stored per tenant. Uploads are rejected once the limit is reached. 0 to disable.")
+	f.IntVar(&l.AdHocProfilesMaxTotalSizeBytes, "adhoc-profiles.max-total-size-bytes", 0, "Maximum total size in bytes of the ad-hoc profiles stored per tenant. Uploads are rejected once the limit is reached. 0 to disable.")
+	f.Var(&l.AdHocProfilesRetentionPeriod, "adhoc-profiles.retention-period", "Delete ad-hoc profiles uploaded longer ago than the specified retention period. 0 to disable.")
+
 	_ = l.RejectNewerThan.Set("10m")
 	f.Var(&l.RejectNewerThan, "validation.reject-newer-than", "This limits how far into the future profiling data can be ingested. This limit is enforced in the distributor. 0 to disable, defaults to 10m.")
 
@@ -281,6 +307,18 @@ func (o *Overrides) MaxSessionsPerSeries(tenantID string) int {
 	return o.getOverridesForTenant(tenantID).MaxSessionsPerSeries
 }
 
+// SampleLabels returns the names of the pprof sample labels that are stored
+// at the sample level instead of being promoted to series labels.
+func (o *Overrides) SampleLabels(tenantID string) []string {
+	return o.getOverridesForTenant(tenantID).SampleLabels
+}
+
+// IngestionNormalizationRules returns the stack trace normalization
+// rules the distributor applies to the tenant profiles.
+func (o *Overrides) IngestionNormalizationRules(tenantID string) []*pprof.NormalizationRule {
+	return o.getOverridesForTenant(tenantID).IngestionNormalizationRules
+}
+
 func (o *Overrides) DistributorAggregationWindow(tenantID string) model.Duration {
 	return o.getOverridesForTenant(tenantID).DistributorAggregationWindow
 }
@@ -312,6 +350,16 @@ func (o *Overrides) MaxQueryParallelism(tenantID string) int {
 	return o.getOverridesForTenant(tenantID).MaxQueryParallelism
 }
 
+// MaxQueryBytes returns the limit of bytes a query can read in ingesters and store-gateways.
+func (o *Overrides) MaxQueryBytes(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).MaxQueryBytes
+}
+
+// MaxQueryProfiles returns the limit of profiles a query can fetch.
+func (o *Overrides) MaxQueryProfiles(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).MaxQueryProfiles
+}
+
 // MaxQueryLookback returns the max lookback period of queries.
 func (o *Overrides) MaxQueryLookback(tenantID string) time.Duration {
 	return time.Duration(o.getOverridesForTenant(tenantID).MaxQueryLookback)
@@ -337,6 +385,27 @@ func (o *Overrides) QuerySplitDuration(tenantID string) time.Duration {
 	return time.Duration(o.getOverridesForTenant(tenantID).QuerySplitDuration)
 }
 
+// QueryShards returns the tenant specific number of shards each split query
+// is divided into by the query frontend.
+func (o *Overrides) QueryShards(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).QueryShards
+}
+
+// AdHocProfilesMaxProfiles returns the maximum number of ad-hoc profiles stored for a given tenant.
+func (o *Overrides) AdHocProfilesMaxProfiles(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).AdHocProfilesMaxProfiles
+}
+
+// AdHocProfilesMaxTotalSizeBytes returns the maximum total size of the ad-hoc profiles stored for a given tenant.
+func (o *Overrides) AdHocProfilesMaxTotalSizeBytes(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).AdHocProfilesMaxTotalSizeBytes
+}
+
+// AdHocProfilesRetentionPeriod returns the retention period of the ad-hoc profiles for a given tenant.
+func (o *Overrides) AdHocProfilesRetentionPeriod(tenantID string) time.Duration {
+	return time.Duration(o.getOverridesForTenant(tenantID).AdHocProfilesRetentionPeriod)
+}
+
 // CompactorTenantShardSize returns number of compactors that this user can use. 0 = all compactors.
 func (o *Overrides) CompactorTenantShardSize(userID string) int {
 	return o.getOverridesForTenant(userID).CompactorTenantShardSize
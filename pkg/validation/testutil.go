@@ -6,9 +6,12 @@ import (
 
 type MockLimits struct {
 	QuerySplitDurationValue     time.Duration
+	QueryShardsValue            int
 	MaxQueryParallelismValue    int
 	MaxQueryLengthValue         time.Duration
 	MaxQueryLookbackValue       time.Duration
+	MaxQueryBytesValue          int
+	MaxQueryProfilesValue       int
 	MaxLabelNameLengthValue     int
 	MaxLabelValueLengthValue    int
 	MaxLabelNamesPerSeriesValue int
@@ -27,12 +30,19 @@ type MockLimits struct {
 	MaxProfileStacktraceDepthValue        int
 	MaxProfileStacktraceSampleLabelsValue int
 	MaxProfileSymbolValueLengthValue      int
+
+	AdHocProfilesMaxProfilesValue       int
+	AdHocProfilesMaxTotalSizeBytesValue int
+	AdHocProfilesRetentionPeriodValue   time.Duration
 }
 
 func (m MockLimits) QuerySplitDuration(string) time.Duration        { return m.QuerySplitDurationValue }
+func (m MockLimits) QueryShards(string) int                         { return m.QueryShardsValue }
 func (m MockLimits) MaxQueryParallelism(string) int                 { return m.MaxQueryParallelismValue }
 func (m MockLimits) MaxQueryLength(tenantID string) time.Duration   { return m.MaxQueryLengthValue }
 func (m MockLimits) MaxQueryLookback(tenantID string) time.Duration { return m.MaxQueryLookbackValue }
+func (m MockLimits) MaxQueryBytes(string) int                       { return m.MaxQueryBytesValue }
+func (m MockLimits) MaxQueryProfiles(string) int                    { return m.MaxQueryProfilesValue }
 
 func (m MockLimits) MaxFlameGraphNodesDefault(string) int { return m.MaxFlameGraphNodesDefaultValue }
 func (m MockLimits) MaxFlameGraphNodesMax(string) int     { return m.MaxFlameGraphNodesMaxValue }
@@ -71,3 +81,15 @@ func (m MockLimits) RejectOlderThan(userID string) time.Duration {
 func (m MockLimits) RejectNewerThan(userID string) time.Duration {
 	return m.RejectNewerThanValue
 }
+
+func (m MockLimits) AdHocProfilesMaxProfiles(tenantID string) int {
+	return m.AdHocProfilesMaxProfilesValue
+}
+
+func (m MockLimits) AdHocProfilesMaxTotalSizeBytes(tenantID string) int {
+	return m.AdHocProfilesMaxTotalSizeBytesValue
+}
+
+func (m MockLimits) AdHocProfilesRetentionPeriod(tenantID string) time.Duration {
+	return m.AdHocProfilesRetentionPeriodValue
+}
@@ -63,6 +63,8 @@ const (
 	LabelValueTooLongErrorMsg           = "profile with labels '%s' has label value too long: '%s'"
 	DuplicateLabelNamesErrorMsg         = "profile with labels '%s' has duplicate label name: '%s'"
 	QueryTooLongErrorMsg                = "the query time range exceeds the limit (max_query_length, actual: %s, limit: %s)"
+	QueryTooManyBytesErrorMsg           = "the query exceeds the read bytes limit (max_query_bytes, actual: %d, limit: %d)"
+	QueryTooManyProfilesErrorMsg        = "the query exceeds the fetched profiles limit (max_query_profiles, actual: %d, limit: %d)"
 	ProfileTooBigErrorMsg               = "the profile with labels '%s' exceeds the size limit (max_profile_size_byte, actual: %d, limit: %d)"
 	ProfileTooManySamplesErrorMsg       = "the profile with labels '%s' exceeds the samples count limit (max_profile_stacktrace_samples, actual: %d, limit: %d)"
 	ProfileTooManySampleLabelsErrorMsg  = "the profile with labels '%s' exceeds the sample labels limit (max_profile_stacktrace_sample_labels, actual: %d, limit: %d)"
@@ -325,6 +327,23 @@ func ValidateRangeRequest(limits RangeRequestLimits, tenantIDs []string, req mod
 	return ValidatedRangeRequest{Interval: req}, nil
 }
 
+type QueryCostLimits interface {
+	MaxQueryBytes(tenantID string) int
+	MaxQueryProfiles(tenantID string) int
+}
+
+// ValidateQueryCost returns an error if the bytes read or the
+// profiles fetched by a query exceed the limits of the tenants.
+func ValidateQueryCost(limits QueryCostLimits, tenantIDs []string, bytes, profiles uint64) error {
+	if maxBytes := validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, limits.MaxQueryBytes); maxBytes > 0 && bytes > uint64(maxBytes) {
+		return NewErrorf(QueryLimit, QueryTooManyBytesErrorMsg, bytes, maxBytes)
+	}
+	if maxProfiles := validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, limits.MaxQueryProfiles); maxProfiles > 0 && profiles > uint64(maxProfiles) {
+		return NewErrorf(QueryLimit, QueryTooManyProfilesErrorMsg, profiles, maxProfiles)
+	}
+	return nil
+}
+
 type FlameGraphLimits interface {
 	MaxFlameGraphNodesDefault(string) int
 	MaxFlameGraphNodesMax(string) int
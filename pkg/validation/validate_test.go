@@ -400,3 +400,54 @@ func TestValidateFlamegraphMaxNodes(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateQueryCost(t *testing.T) {
+	type testCase struct {
+		name     string
+		bytes    uint64
+		profiles uint64
+		limits   QueryCostLimits
+		err      error
+	}
+
+	testCases := []testCase{
+		{
+			name:     "limits disabled",
+			bytes:    1 << 30,
+			profiles: 1 << 20,
+			limits:   MockLimits{},
+		},
+		{
+			name:     "within limits",
+			bytes:    1024,
+			profiles: 10,
+			limits: MockLimits{
+				MaxQueryBytesValue:    1024,
+				MaxQueryProfilesValue: 10,
+			},
+		},
+		{
+			name:  "bytes limit exceeded",
+			bytes: 1025,
+			limits: MockLimits{
+				MaxQueryBytesValue: 1024,
+			},
+			err: &Error{Reason: "query_limit", msg: "the query exceeds the read bytes limit (max_query_bytes, actual: 1025, limit: 1024)"},
+		},
+		{
+			name:     "profiles limit exceeded",
+			profiles: 11,
+			limits: MockLimits{
+				MaxQueryProfilesValue: 10,
+			},
+			err: &Error{Reason: "query_limit", msg: "the query exceeds the fetched profiles limit (max_query_profiles, actual: 11, limit: 10)"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, ValidateQueryCost(tc.limits, []string{"tenant"}, tc.bytes, tc.profiles))
+		})
+	}
+}
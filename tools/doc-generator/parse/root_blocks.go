@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/dskit/server"
 
 	"github.com/grafana/pyroscope/pkg/compactor"
+	"github.com/grafana/pyroscope/pkg/debuginfo"
 	"github.com/grafana/pyroscope/pkg/distributor"
 	"github.com/grafana/pyroscope/pkg/frontend"
 	"github.com/grafana/pyroscope/pkg/ingester"
@@ -73,6 +74,11 @@ var RootBlocks = []RootBlock{
 		StructType: reflect.TypeOf(compactor.Config{}),
 		Desc:       "The compactor block configures the compactor.",
 	},
+	{
+		Name:       "debuginfo",
+		StructType: reflect.TypeOf(debuginfo.Config{}),
+		Desc:       "The debuginfo block configures the debug information upload and symbolization.",
+	},
 	{
 		Name:       "grpc_client",
 		StructType: reflect.TypeOf(grpcclient.Config{}),